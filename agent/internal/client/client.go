@@ -3,12 +3,20 @@ package client
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 
 	"github.com/OinkiePie/calc_2/pkg/models"
 )
 
+var (
+	// ErrTaskCancelled - выражение задачи отменено, вычислять её больше не нужно.
+	ErrTaskCancelled = errors.New("task expression cancelled")
+	// ErrTaskLost - аренда задачи истекла или задача не найдена, результат не будет принят.
+	ErrTaskLost = errors.New("task lease expired or task not found")
+)
+
 // APIClient - структура для взаимодействия с API оркестратора.
 type APIClient struct {
 	// Адрес с которого получают и на который отравляют задачи.
@@ -117,3 +125,48 @@ func (c *APIClient) CompleteTask(completedTask models.TaskCompleted) error {
 
 	return nil
 }
+
+// Heartbeat - проверяет, нужно ли продолжать вычисление задачи.
+//
+//	Heartbeat отправляет POST запрос на URL оркестратора с суффиксом /heartbeat c JSON-представлением
+//	структуры models.TaskHeartbeat, добавляя заголовок Authorization.
+//
+// Args:
+//
+//	heartbeat : models.TaskHeartbeat - Структура TaskHeartbeat с ID задачи, её выражения и токеном аренды.
+//
+// Returns:
+//
+//	error - ErrTaskCancelled, если выражение отменено, ErrTaskLost, если аренда задачи истекла,
+//	 или ошибка, возникшая во время выполнения запроса.
+func (c *APIClient) Heartbeat(heartbeat models.TaskHeartbeat) error {
+	body, err := json.Marshal(heartbeat)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", c.url+"/heartbeat", bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", c.authToken)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusGone:
+		return ErrTaskCancelled
+	case http.StatusNotFound:
+		return ErrTaskLost
+	default:
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+}
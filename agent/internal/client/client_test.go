@@ -153,3 +153,54 @@ func TestCompleteTask(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestHeartbeat(t *testing.T) {
+	heartbeat := models.TaskHeartbeat{
+		ID:         "task-id-666",
+		Expression: "expr-id-666",
+		Lease:      "lease-666",
+	}
+
+	tests := []struct {
+		name     string
+		status   int
+		expected error
+	}{
+		{"Continue", http.StatusOK, nil},
+		{"Cancelled", http.StatusGone, client.ErrTaskCancelled},
+		{"Lease expired", http.StatusNotFound, client.ErrTaskLost},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.URL.Path != "/heartbeat" {
+					t.Errorf("Ожидался запрос POST /heartbeat, получено: %s %s", r.Method, r.URL.Path)
+				}
+				var received models.TaskHeartbeat
+				json.NewDecoder(r.Body).Decode(&received)
+				assert.Equal(t, heartbeat, received)
+
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			apiClient := client.NewAPIClient(server.URL, "test_token", &http.Client{})
+			err := apiClient.Heartbeat(heartbeat)
+
+			assert.ErrorIs(t, err, tt.expected)
+		})
+	}
+
+	t.Run("Server error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		apiClient := client.NewAPIClient(server.URL, "test_token", &http.Client{})
+		err := apiClient.Heartbeat(heartbeat)
+
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, client.ErrTaskCancelled)
+	})
+}
@@ -5,19 +5,24 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"math/cmplx"
 	"sync"
 	"time"
 
 	"github.com/OinkiePie/calc_2/agent/internal/client"
 	"github.com/OinkiePie/calc_2/config"
+	"github.com/OinkiePie/calc_2/pkg/evaluator"
 	"github.com/OinkiePie/calc_2/pkg/logger"
 	"github.com/OinkiePie/calc_2/pkg/models"
 	"github.com/OinkiePie/calc_2/pkg/operators"
+	"github.com/OinkiePie/calc_2/pkg/units"
 )
 
 var (
-	errDivisionByZero = errors.New("division by zero not allowed")
-	errFirstNil       = errors.New("first operator cannot be nil")
+	errFirstNil  = errors.New("first operator cannot be nil")
+	errSecondNil = errors.New("second operator cannot be nil")
+	errArgNil    = errors.New("operator cannot be nil")
+	errNotFinite = errors.New("result is not finite")
 )
 
 // Worker представляет собой рабочего, выполняющего задачи.
@@ -66,6 +71,7 @@ func NewWorker(workerID int, apiClient *client.APIClient, wg *sync.WaitGroup, er
 //   - Если нет задач для выполнения, воркер ждет 2 секунды и повторяет попытку.
 //   - Если не удается получить задачу, воркер ждет 5 секунд и повторяет попытку.
 //   - Если полученная задача невыполнима, в API отправляется сообщение об ошибке.
+//   - Если во время ожидания выражение задачи отменено или аренда задачи истекла, результат не отправляется.
 //   - Если во время вычисления происходит паника, она перехватывается, логируется и отправляется в канал ошибок.
 //   - Если при отправке результата возникает ошибка, воркер ждет 5 секунд и повторяет попытку.
 func (w *Worker) Start(ctx context.Context) {
@@ -111,6 +117,8 @@ func (w *Worker) Start(ctx context.Context) {
 
 			//  Запускаем вычисление в горутине
 			resultChan := make(chan float64, 1)
+			exactChan := make(chan string, 1)           // Точный результат, если задача вычисляется с точностью, отличной от float64
+			complexChan := make(chan models.Complex, 1) // Комплексный результат, если задача вычисляется в комплексных числах
 			errorChan := make(chan error, 1)
 
 			go func(t *models.TaskResponse) {
@@ -122,6 +130,33 @@ func (w *Worker) Start(ctx context.Context) {
 					}
 				}()
 
+				if t.Precision == evaluator.PrecisionComplex {
+					value, err := CalculateComplex(t)
+					if err != nil {
+						errorChan <- err
+						return
+					}
+					complexChan <- models.NewComplex(value)
+					resultChan <- real(value)
+					return
+				}
+
+				if t.Precision != "" && t.Precision != evaluator.PrecisionFloat64 {
+					exact, err := CalculateExact(t)
+					if err != nil {
+						errorChan <- err
+						return
+					}
+					// Приближенное значение пересчитывает оркестратор, вне диапазона float64 оно не передается
+					result, _ := evaluator.Approximate(exact)
+					if math.IsInf(result, 0) {
+						result = 0
+					}
+					exactChan <- exact
+					resultChan <- result
+					return
+				}
+
 				result, err := Calculate(t)
 				if err != nil {
 					errorChan <- err
@@ -132,9 +167,19 @@ func (w *Worker) Start(ctx context.Context) {
 
 			// Ожидаем результат и таймаут
 			var result float64
+			var exact string
+			var complexResult *models.Complex
 			select {
 			case result = <-resultChan:
-				<-taskCtx.Done()
+				select {
+				case exact = <-exactChan:
+				case value := <-complexChan:
+					complexResult = &value
+				default:
+				}
+				if !w.awaitOperation(taskCtx, task) {
+					continue
+				}
 				logger.Log.Debugf("Рабочий %d: Задача %s успешно выполнена", w.workerID, task.ID)
 			case err = <-errorChan:
 				logger.Log.Debugf("Рабочий %d: Задача %s невыполнима: %v", w.workerID, task.ID, err)
@@ -153,12 +198,20 @@ func (w *Worker) Start(ctx context.Context) {
 				task.Error = "result is -Inf"
 			}
 
+			if math.IsNaN(result) {
+				result = 0
+				task.Error = "result is NaN"
+			}
+
 			// Отправляем результат (даже если был таймаут)
 			completedTask := models.TaskCompleted{
-				Expression: task.Expression,
-				ID:         task.ID,
-				Result:     result,
-				Error:      task.Error,
+				Expression:    task.Expression,
+				ID:            task.ID,
+				Result:        result,
+				Error:         task.Error,
+				ExactResult:   exact,
+				ComplexResult: complexResult,
+				Lease:         task.Lease,
 			}
 
 			err = w.apiClient.CompleteTask(completedTask)
@@ -172,9 +225,52 @@ func (w *Worker) Start(ctx context.Context) {
 	}
 }
 
-// Calculate выполняет математическую операцию над двумя аргументами, указанными в задаче.
-// Поддерживаемые операции: сложение, вычитание, умножение, деление и возведение в степень.
-// Если второй аргумент равен nil, выполняется унарный минус для первого аргумента.
+// awaitOperation ожидает окончания времени операции задачи, каждые AGENT_HEARTBEAT_MS проверяя у оркестратора,
+// что вычисление нужно продолжать (см. client.APIClient.Heartbeat). Если оркестратор недоступен, ожидание продолжается.
+//
+// Args:
+//
+//	taskCtx: context.Context - Контекст с таймаутом, равным времени операции.
+//	task: *models.TaskResponse - Вычисляемая задача.
+//
+// Returns:
+//
+//	bool - true, если время операции истекло и результат нужно отправить, false, если выражение задачи отменено
+//	       или аренда задачи истекла.
+func (w *Worker) awaitOperation(taskCtx context.Context, task *models.TaskResponse) bool {
+	interval := config.Cfg.Server.Agent.AGENT_HEARTBEAT_MS
+	if interval <= 0 {
+		<-taskCtx.Done()
+		return true
+	}
+
+	ticker := time.NewTicker(time.Duration(interval) * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-taskCtx.Done():
+			return true
+		case <-ticker.C:
+			err := w.apiClient.Heartbeat(models.TaskHeartbeat{Expression: task.Expression, ID: task.ID, Lease: task.Lease})
+			if errors.Is(err, client.ErrTaskCancelled) || errors.Is(err, client.ErrTaskLost) {
+				logger.Log.Debugf("Рабочий %d: Вычисление задачи %s прервано: %v", w.workerID, task.ID, err)
+				return false
+			}
+			if err != nil {
+				logger.Log.Debugf("Рабочий %d: Не удалось проверить задачу %s: %v", w.workerID, task.ID, err)
+			}
+		}
+	}
+}
+
+// Calculate выполняет математическую операцию над аргументами, указанными в задаче.
+// Поддерживаемые операции: сложение, вычитание, умножение, деление, возведение в степень,
+// остаток от деления, целочисленное деление, факториал, унарный минус, процент (x%, a+b%, a-b%)
+// и математические функции (sin, cos, tan, sqrt, log, ln, abs, exp, max, min, round, floor, ceil, pmt, fv, median).
+// Унарные операции используют только первый аргумент, pmt и fv - пять аргументов, median - все аргументы, остальные - два. Вычисление выполняет evaluator.Evaluate.
+// Если задача содержит размерности аргументов (единицы измерения), сначала проверяется, что операция
+// над величинами таких размерностей допустима, значения аргументов заданы в основных единицах СИ.
 //
 // Args:
 //
@@ -183,43 +279,132 @@ func (w *Worker) Start(ctx context.Context) {
 // Returns:
 //
 //	float64 - Результат выполнения операции.
-//	error - Ошибка, если операция не может быть выполнена (например, деление на ноль или неизвестная операция).
+//	error - Ошибка, если операция не может быть выполнена (например, деление на ноль, неизвестная операция
+//	        или несовместимые размерности аргументов).
 func Calculate(task *models.TaskResponse) (float64, error) {
-	var arg1, arg2 float64
-
-	// Nil попадает в операндом только если оператором является унарный минус.
-	// При этом число которое необходимо обратить всегда первый операнд.
-	if task.Args[0] == nil {
-		// Перовый оператор никогода не может быть nil
-		return 0, errFirstNil
+	args, err := requiredArgs(task.Operation, task.Args)
+	if err != nil {
+		return 0, err
 	}
-	arg1 = *task.Args[0]
-	// Если 2й операнд - nil, то операнд всегда унарный минус
-	if task.Args[1] == nil {
-		return -*task.Args[0], nil
+	if err := checkUnits(task); err != nil {
+		return 0, err
 	}
-	arg2 = *task.Args[1]
-
-	switch task.Operation {
-
-	case operators.OpAdd:
-		return arg1 + arg2, nil
-
-	case operators.OpSubtract:
-		return arg1 - arg2, nil
+	return evaluator.Evaluate(task.Operation, args...)
+}
 
-	case operators.OpMultiply:
-		return arg1 * arg2, nil
+// requiredArgs проверяет, что задача содержит все аргументы, нужные операции (см. evaluator.ArgCount),
+// и возвращает их значения.
+//
+// Args:
+//
+//	operation: string - Операция задачи.
+//	args: []*T - Аргументы задачи.
+//
+// Returns:
+//
+//	[]T - Значения первых evaluator.ArgCount(operation) аргументов, для медианы - всех аргументов.
+//	error - Ошибка, если нужного аргумента нет или он nil.
+func requiredArgs[T any](operation string, args []*T) ([]T, error) {
+	count := evaluator.ArgCount(operation)
+	if count == operators.Variadic {
+		count = max(len(args), 1)
+	}
+	values := make([]T, count)
+	for i := range values {
+		if i >= len(args) || args[i] == nil {
+			switch i {
+			case 0:
+				// Перовый оператор никогода не может быть nil
+				return nil, errFirstNil
+			case 1:
+				return nil, errSecondNil
+			}
+			return nil, fmt.Errorf("%w: %d", errArgNil, i+1)
+		}
+		values[i] = *args[i]
+	}
+	return values, nil
+}
 
-	case operators.OpDivide:
-		if arg2 == 0 {
-			return 0, errDivisionByZero
+// checkUnits проверяет размерности аргументов задачи (см. units.Result).
+//
+// Args:
+//
+//	task: (*models.TaskResponse) - Задача с размерностями аргументов в Units.
+//
+// Returns:
+//
+//	error - Ошибка, если размерность не разбирается или недопустима для операции, nil - если размерностей нет.
+func checkUnits(task *models.TaskResponse) error {
+	if len(task.Units) == 0 {
+		return nil
+	}
+	dims := make([]units.Dimension, len(task.Units))
+	for i, text := range task.Units {
+		unit, err := units.Parse(text)
+		if err != nil {
+			return err
 		}
-		return arg1 / arg2, nil
+		dims[i] = unit.Dim
+	}
+	var exponent *float64
+	if len(task.Args) > 1 {
+		exponent = task.Args[1]
+	}
+	_, err := units.Result(task.Operation, dims, exponent)
+	return err
+}
 
-	case operators.OpPower:
-		return math.Pow(arg1, arg2), nil
+// CalculateExact выполняет операцию задачи над аргументами в точной строковой записи (ExactArgs)
+// с точностью, указанной в задаче (decimal, rational или bigfloat:N). Вычисление выполняет evaluator.EvaluateExact.
+//
+// Args:
+//
+//	task: (*models.TaskResponse) - Задача, содержащая точные аргументы, операцию и точность.
+//
+// Returns:
+//
+//	string - Точный результат в строковой записи (например, "1/3" для rational).
+//	error - Ошибка, если точность некорректна или операция не может быть выполнена.
+func CalculateExact(task *models.TaskResponse) (string, error) {
+	precision, err := evaluator.ParsePrecision(task.Precision)
+	if err != nil {
+		return "", err
+	}
+	args, err := requiredArgs(task.Operation, task.ExactArgs)
+	if err != nil {
+		return "", err
 	}
+	return evaluator.EvaluateExact(task.Operation, precision, args...)
+}
 
-	return 0, fmt.Errorf("unknown operator: %s", task.Operation)
+// CalculateComplex выполняет операцию задачи над комплексными аргументами (ComplexArgs).
+// Вычисление выполняет evaluator.EvaluateComplex.
+//
+// Args:
+//
+//	task: (*models.TaskResponse) - Задача, содержащая комплексные аргументы и операцию.
+//
+// Returns:
+//
+//	complex128 - Результат выполнения операции.
+//	error - Ошибка, если операция не может быть выполнена или результат - бесконечность или NaN.
+func CalculateComplex(task *models.TaskResponse) (complex128, error) {
+	values, err := requiredArgs(task.Operation, task.ComplexArgs)
+	if err != nil {
+		return 0, err
+	}
+	args := make([]complex128, len(values))
+	for i, value := range values {
+		args[i] = value.Value()
+	}
+
+	result, err := evaluator.EvaluateComplex(task.Operation, args...)
+	if err != nil {
+		return 0, err
+	}
+	if cmplx.IsInf(result) || cmplx.IsNaN(result) {
+		return 0, errNotFinite
+	}
+	return result, nil
 }
@@ -2,11 +2,19 @@ package worker_test
 
 import (
 	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/OinkiePie/calc_2/agent/internal/client"
 	"github.com/OinkiePie/calc_2/agent/internal/worker"
+	"github.com/OinkiePie/calc_2/config"
 	"github.com/OinkiePie/calc_2/pkg/logger"
 	"github.com/OinkiePie/calc_2/pkg/models"
 	"github.com/OinkiePie/calc_2/pkg/operators"
@@ -141,6 +149,306 @@ func TestCalculate_UnknownOperator(t *testing.T) {
 	assert.Contains(t, err.Error(), "unknown operator")
 }
 
+func TestCalculate_Functions(t *testing.T) {
+	tests := []struct {
+		name      string
+		operation string
+		args      []float64
+		expected  float64
+	}{
+		{"Sin", operators.FnSin, []float64{0}, 0},
+		{"Cos", operators.FnCos, []float64{0}, 1},
+		{"Tan", operators.FnTan, []float64{0}, 0},
+		{"Sqrt", operators.FnSqrt, []float64{16}, 4},
+		{"Log with base", operators.FnLog, []float64{8, 2}, 3},
+		{"Log decimal", operators.FnLog, []float64{1000, 10}, 3},
+		{"Ln", operators.FnLn, []float64{1}, 0},
+		{"Abs", operators.FnAbs, []float64{-7}, 7},
+		{"Exp", operators.FnExp, []float64{0}, 1},
+		{"Max", operators.FnMax, []float64{3, 9}, 9},
+		{"Min", operators.FnMin, []float64{3, 9}, 3},
+		{"Modulo", operators.OpModulo, []float64{7, 3}, 1},
+		{"Modulo negative dividend", operators.OpModulo, []float64{-7, 3}, 2},
+		{"Modulo negative divisor", operators.OpModulo, []float64{7, -3}, -2},
+		{"Modulo fractional", operators.OpModulo, []float64{5.5, 2}, 1.5},
+		{"Floor division", operators.OpFloorDivide, []float64{7, 2}, 3},
+		{"Floor division negative", operators.OpFloorDivide, []float64{-7, 2}, -4},
+		{"Factorial", operators.OpFactorial, []float64{5}, 120},
+		{"Factorial of zero", operators.OpFactorial, []float64{0}, 1},
+		{"Factorial of half", operators.OpFactorial, []float64{0.5}, math.Sqrt(math.Pi) / 2},
+		{"Less", operators.OpLess, []float64{1, 2}, 1},
+		{"Less equal", operators.OpLessEqual, []float64{3, 2}, 0},
+		{"Equal", operators.OpEqual, []float64{2, 2}, 1},
+		{"Not equal", operators.OpNotEqual, []float64{2, 2}, 0},
+		{"Greater", operators.OpGreater, []float64{3, 2}, 1},
+		{"Greater equal", operators.OpGreaterEqual, []float64{2, 3}, 0},
+		{"And", operators.OpAnd, []float64{2, 0}, 0},
+		{"Or", operators.OpOr, []float64{0, -3}, 1},
+		{"Not", operators.OpNot, []float64{0}, 1},
+		{"Not of nonzero", operators.OpNot, []float64{0.5}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := make([]*float64, len(tt.args))
+			for i := range tt.args {
+				args[i] = &tt.args[i]
+			}
+			task := &models.TaskResponse{Args: args, Operation: tt.operation}
+
+			result, err := worker.Calculate(task)
+
+			assert.NoError(t, err)
+			assert.InDelta(t, tt.expected, result, 1e-12)
+		})
+	}
+}
+
+func TestCalculate_FunctionDomainErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		operation string
+		args      []float64
+		err       string
+	}{
+		{"Sqrt of negative", operators.FnSqrt, []float64{-1}, "square root of negative number"},
+		{"Ln of zero", operators.FnLn, []float64{0}, "logarithm of non-positive number"},
+		{"Log of negative", operators.FnLog, []float64{-8, 2}, "logarithm of non-positive number"},
+		{"Log base one", operators.FnLog, []float64{8, 1}, "invalid logarithm base"},
+		{"Modulo by zero", operators.OpModulo, []float64{8, 0}, "modulo by zero not allowed"},
+		{"Floor division by zero", operators.OpFloorDivide, []float64{8, 0}, "division by zero not allowed"},
+		{"Factorial of negative integer", operators.OpFactorial, []float64{-3}, "factorial of negative integer"},
+		{"Fractional power of negative", operators.OpPower, []float64{-8, 1.0 / 3}, "result is not a real number, use complex precision"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := make([]*float64, len(tt.args))
+			for i := range tt.args {
+				args[i] = &tt.args[i]
+			}
+			task := &models.TaskResponse{Args: args, Operation: tt.operation}
+
+			_, err := worker.Calculate(task)
+
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+func TestCalculateExact(t *testing.T) {
+	tests := []struct {
+		name      string
+		precision string
+		operation string
+		args      []string
+		expected  string
+		err       string
+	}{
+		{"Decimal addition", "decimal", operators.OpAdd, []string{"0.1", "0.2"}, "0.3", ""},
+		{"Decimal division", "decimal", operators.OpDivide, []string{"2", "3"}, "0.6666666666666666666666666666666667", ""},
+		{"Rational division", "rational", operators.OpDivide, []string{"1", "3"}, "1/3", ""},
+		{"Rational addition", "rational", operators.OpAdd, []string{"1/3", "1/6"}, "1/2", ""},
+		{"Rational power", "rational", operators.OpPower, []string{"2/3", "-2"}, "9/4", ""},
+		{"Rational modulo", "rational", operators.OpModulo, []string{"-7/2", "2"}, "1/2", ""},
+		{"Rational factorial", "rational", operators.OpFactorial, []string{"25"}, "15511210043330985984000000", ""},
+		{"Rational comparison", "rational", operators.OpLess, []string{"1/3", "0.3333"}, "0", ""},
+		{"Bigfloat division", "bigfloat:8", operators.OpDivide, []string{"1", "3"}, "0.334", ""},
+		{"Rational irrational sqrt", "rational", operators.FnSqrt, []string{"2"}, "", "square root is not rational"},
+		{"Fractional exponent", "decimal", operators.OpPower, []string{"2", "0.5"}, "", "exponent must be an integer in exact precision"},
+		{"Unsupported function", "rational", operators.FnSin, []string{"1"}, "", "operation is not supported in exact precision: sin"},
+		{"Division by zero", "rational", operators.OpDivide, []string{"1", "0"}, "", "division by zero not allowed"},
+		{"Invalid precision", "bigfloat:0", operators.OpAdd, []string{"1", "2"}, "", `invalid precision: "bigfloat:0"`},
+		{"Rational large base power", "rational", operators.OpPower, []string{"1" + strings.Repeat("0", 10000), "10000"}, "",
+			"exponent is too large in exact precision"},
+		{"Rational large denominator power", "rational", operators.OpPower, []string{"1/" + strings.Repeat("9", 1000), "-100"}, "",
+			"exponent is too large in exact precision"},
+		{"Rational large factorial", "rational", operators.OpFactorial, []string{"10000"}, "", "exponent is too large in exact precision"},
+		{"Bigfloat large factorial", "bigfloat:64", operators.OpFactorial, []string{"10000"}, "", "exponent is too large in exact precision"},
+		{"Rational median", "rational", operators.FnMedian, []string{"1/2", "1/3", "5", "-1"}, "5/12", ""},
+		{"Bigfloat median", "bigfloat:64", operators.FnMedian, []string{"3", "1", "2"}, "2", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := make([]*string, len(tt.args))
+			for i := range tt.args {
+				args[i] = &tt.args[i]
+			}
+			task := &models.TaskResponse{ExactArgs: args, Operation: tt.operation, Precision: tt.precision}
+
+			result, err := worker.CalculateExact(task)
+
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestCalculateComplex(t *testing.T) {
+	tests := []struct {
+		name      string
+		operation string
+		args      []models.Complex
+		expected  complex128
+		err       string
+	}{
+		{"Addition", operators.OpAdd, []models.Complex{{Re: 3}, {Im: 4}}, 3 + 4i, ""},
+		{"Multiplication", operators.OpMultiply, []models.Complex{{Im: 1}, {Im: 1}}, -1, ""},
+		{"Division", operators.OpDivide, []models.Complex{{Re: 1}, {Re: 1, Im: 1}}, 0.5 - 0.5i, ""},
+		{"Sqrt of negative", operators.FnSqrt, []models.Complex{{Re: -1}}, 1i, ""},
+		{"Abs", operators.FnAbs, []models.Complex{{Re: 3, Im: 4}}, 5, ""},
+		{"Equal", operators.OpEqual, []models.Complex{{Re: 1, Im: 2}, {Re: 1, Im: 2}}, 1, ""},
+		{"Division by zero", operators.OpDivide, []models.Complex{{Re: 1}, {}}, 0, "division by zero not allowed"},
+		{"Ordering", operators.OpLess, []models.Complex{{Re: 1}, {Re: 2}}, 0, "operation is not defined for complex numbers: <"},
+		{"Second nil", operators.OpAdd, []models.Complex{{Re: 1}}, 0, "second operator cannot be nil"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := make([]*models.Complex, len(tt.args))
+			for i := range tt.args {
+				args[i] = &tt.args[i]
+			}
+			task := &models.TaskResponse{ComplexArgs: args, Operation: tt.operation, Precision: "complex"}
+
+			result, err := worker.CalculateComplex(task)
+
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.InDelta(t, real(tt.expected), real(result), 1e-12)
+			assert.InDelta(t, imag(tt.expected), imag(result), 1e-12)
+		})
+	}
+
+	// Главное значение кубического корня из -8
+	minusEight, third := models.Complex{Re: -8}, models.Complex{Re: 1.0 / 3}
+	result, err := worker.CalculateComplex(&models.TaskResponse{ComplexArgs: []*models.Complex{&minusEight, &third}, Operation: operators.OpPower})
+	assert.NoError(t, err)
+	assert.InDelta(t, 1, real(result), 1e-12)
+	assert.InDelta(t, math.Sqrt(3), imag(result), 1e-12)
+}
+
+func TestCalculate_Units(t *testing.T) {
+	tests := []struct {
+		name      string
+		operation string
+		args      []float64
+		units     []string
+		expected  float64
+		err       string
+	}{
+		{"Same dimensions", operators.OpAdd, []float64{3, 2}, []string{"m", "m"}, 5, ""},
+		{"Different dimensions", operators.OpAdd, []float64{3, 2}, []string{"m", "s"}, 0, "incompatible dimensions: m and s"},
+		{"Division", operators.OpDivide, []float64{10, 2}, []string{"m", "s"}, 5, ""},
+		{"Power", operators.OpPower, []float64{3, 2}, []string{"m", ""}, 9, ""},
+		{"Fractional power", operators.OpPower, []float64{2, 0.5}, []string{"m", ""}, 0, "exponent of a dimensional quantity must be a known number giving integer dimensions"},
+		{"Function", operators.FnSin, []float64{1}, []string{"kg*m/s^2"}, 0, "argument must be dimensionless: kg*m/s^2"},
+		{"Unknown unit", operators.OpAdd, []float64{3, 2}, []string{"m", "furlong"}, 0, "unknown unit: \"furlong\""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := make([]*float64, len(tt.args))
+			for i := range tt.args {
+				args[i] = &tt.args[i]
+			}
+			task := &models.TaskResponse{Args: args, Operation: tt.operation, Units: tt.units}
+
+			result, err := worker.Calculate(task)
+
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestCalculate_PercentAndFinancial(t *testing.T) {
+	tests := []struct {
+		name      string
+		operation string
+		args      []float64
+		expected  float64
+		err       string
+	}{
+		{"Percent", operators.OpPercent, []float64{15}, 0.15, ""},
+		{"Add percent", operators.OpAddPercent, []float64{200, 15}, 230, ""},
+		{"Subtract percent", operators.OpSubtractPercent, []float64{200, 15}, 170, ""},
+		{"Round half away from zero", operators.FnRound, []float64{1.005, 2}, 1.01, ""},
+		{"Round negative", operators.FnRound, []float64{-2.5, 0}, -3, ""},
+		{"Round to hundreds", operators.FnRound, []float64{1250, -2}, 1300, ""},
+		{"Round fractional digits", operators.FnRound, []float64{1.5, 0.5}, 0, "number of digits must be an integer"},
+		{"Floor", operators.FnFloor, []float64{-1.5}, -2, ""},
+		{"Ceil", operators.FnCeil, []float64{1.2}, 2, ""},
+		{"Payment", operators.FnPmt, []float64{0.01, 12, 1000, 0, 0}, -88.84878867834168, ""},
+		{"Payment zero rate", operators.FnPmt, []float64{0, 10, 1000, 0, 0}, -100, ""},
+		{"Future value", operators.FnFv, []float64{0.1, 2, -100, 0, 1}, 231, ""},
+		{"Zero periods", operators.FnPmt, []float64{0.01, 0, 1000, 0, 0}, 0, "number of periods must not be zero"},
+		{"Payment type", operators.FnFv, []float64{0.01, 12, -100, 0, 2}, 0, "payment type must be 0 (end of period) or 1 (beginning of period)"},
+		{"Missing argument", operators.FnPmt, []float64{0.01, 12, 1000}, 0, "operator cannot be nil: 4"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := make([]*float64, len(tt.args))
+			for i := range tt.args {
+				args[i] = &tt.args[i]
+			}
+			task := &models.TaskResponse{Args: args, Operation: tt.operation}
+
+			result, err := worker.Calculate(task)
+
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.InDelta(t, tt.expected, result, 1e-9)
+		})
+	}
+}
+
+func TestCalculate_Median(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []float64
+		expected float64
+	}{
+		{"Odd count", []float64{9, 1, 5, 3, 7}, 5},
+		{"Even count", []float64{4, 1, 3, 2}, 2.5},
+		{"One value", []float64{-2}, -2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := make([]*float64, len(tt.args))
+			for i := range tt.args {
+				args[i] = &tt.args[i]
+			}
+			task := &models.TaskResponse{Args: args, Operation: operators.FnMedian}
+
+			result, err := worker.Calculate(task)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+
+	_, err := worker.Calculate(&models.TaskResponse{Operation: operators.FnMedian})
+	assert.EqualError(t, err, "first operator cannot be nil")
+}
+
 func TestWorker_Start(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	apiClient := &client.APIClient{}
@@ -157,3 +465,56 @@ func TestWorker_Start(t *testing.T) {
 	cancel()
 	wg.Wait()
 }
+
+// TestWorker_Cancelled проверяет, что рабочий прекращает ожидание времени операции, когда выражение задачи отменено,
+// и не отправляет результат.
+func TestWorker_Cancelled(t *testing.T) {
+	config.InitConfig()
+	config.Cfg.Server.Agent.AGENT_HEARTBEAT_MS = 10
+	config.Cfg.Server.Agent.AGENT_REPEAT = 10
+
+	var polls atomic.Int32
+	var completed atomic.Bool
+	repolled := make(chan struct{}, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/heartbeat":
+			w.WriteHeader(http.StatusGone)
+		case r.Method == http.MethodPost:
+			completed.Store(true)
+		case polls.Add(1) == 1:
+			two := 2.0
+			json.NewEncoder(w).Encode(models.TaskResponse{
+				ID: "task", Expression: "expr", Operation: operators.OpAdd,
+				Args: []*float64{&two, &two}, Operation_time: 60000, Lease: "lease",
+			})
+		default:
+			select {
+			case repolled <- struct{}{}:
+			default:
+			}
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := &sync.WaitGroup{}
+	worker := worker.NewWorker(1, client.NewAPIClient(server.URL, "", &http.Client{}), wg, make(chan error, 1))
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		worker.Start(ctx)
+	}()
+
+	// Задача на 60 секунд прервана отменой, и рабочий запрашивает следующую
+	select {
+	case <-repolled:
+	case <-time.After(5 * time.Second):
+		t.Fatal("рабочий не прервал ожидание отмененной задачи")
+	}
+	cancel()
+	wg.Wait()
+	assert.False(t, completed.Load())
+}
@@ -15,6 +15,9 @@ import (
 type Config struct {
 	Server     ServicesConfig   `yaml:"server"`
 	Math       MathConfig       `yaml:"math"`
+	Optimizer  OptimizerConfig  `yaml:"optimizer"`
+	Storage    StorageConfig    `yaml:"storage"`
+	Retention  RetentionConfig  `yaml:"retention"`
 	Middleware MiddlewareConfig `yaml:"middleware"`
 	Logger     LoggerConfig     `yaml:"logger"`
 }
@@ -30,6 +33,10 @@ type ServicesConfig struct {
 type OrchestratorServiceConfig struct {
 	ADDR_ORCHESTRATOR string `yaml:"ADDR_ORCHESTRATOR"`
 	PORT_ORCHESTRATOR int    `yaml:"PORT_ORCHESTRATOR"`
+	// TASK_LEASE_GRACE_MS - запас к длительности операции в сроке аренды задачи агентом.
+	TASK_LEASE_GRACE_MS int `yaml:"TASK_LEASE_GRACE_MS"`
+	// TASK_REAPER_INTERVAL_MS - период проверки истекших аренд задач.
+	TASK_REAPER_INTERVAL_MS int `yaml:"TASK_REAPER_INTERVAL_MS"`
 }
 
 // AgentServiceConfig структура параметров агента
@@ -37,6 +44,8 @@ type AgentServiceConfig struct {
 	COMPUTING_POWER  int `yaml:"COMPUTING_POWER"`
 	AGENT_REPEAT     int `yaml:"AGENT_REPEAT"`
 	AGENT_REPEAT_ERR int `yaml:"AGENT_REPEAT_ERR"`
+	// AGENT_HEARTBEAT_MS - период, с которым агент во время вычисления задачи проверяет, не отменено ли её выражение.
+	AGENT_HEARTBEAT_MS int `yaml:"AGENT_HEARTBEAT_MS"`
 }
 
 // WebServiceConfig структура параметров веб сервиса
@@ -54,6 +63,66 @@ type MathConfig struct {
 	TIME_DIVISION_MS       int `yaml:"TIME_DIVISION_MS"`
 	TIME_UNARY_MINUS_MS    int `yaml:"TIME_UNARY_MINUS_MS"`
 	TIME_POWER_MS          int `yaml:"TIME_POWER_MS"`
+	TIME_MODULO_MS         int `yaml:"TIME_MODULO_MS"`
+	TIME_FLOOR_DIVISION_MS int `yaml:"TIME_FLOOR_DIVISION_MS"`
+	TIME_FACTORIAL_MS      int `yaml:"TIME_FACTORIAL_MS"`
+	TIME_SIN_MS            int `yaml:"TIME_SIN_MS"`
+	TIME_COS_MS            int `yaml:"TIME_COS_MS"`
+	TIME_TAN_MS            int `yaml:"TIME_TAN_MS"`
+	TIME_SQRT_MS           int `yaml:"TIME_SQRT_MS"`
+	TIME_LOG_MS            int `yaml:"TIME_LOG_MS"`
+	TIME_LN_MS             int `yaml:"TIME_LN_MS"`
+	TIME_ABS_MS            int `yaml:"TIME_ABS_MS"`
+	TIME_EXP_MS            int `yaml:"TIME_EXP_MS"`
+	TIME_MAX_MS            int `yaml:"TIME_MAX_MS"`
+	TIME_MIN_MS            int `yaml:"TIME_MIN_MS"`
+	TIME_COMPARISON_MS     int `yaml:"TIME_COMPARISON_MS"`
+	TIME_LOGICAL_MS        int `yaml:"TIME_LOGICAL_MS"`
+	TIME_PERCENT_MS        int `yaml:"TIME_PERCENT_MS"`
+	TIME_ROUND_MS          int `yaml:"TIME_ROUND_MS"`
+	TIME_FLOOR_MS          int `yaml:"TIME_FLOOR_MS"`
+	TIME_CEIL_MS           int `yaml:"TIME_CEIL_MS"`
+	TIME_PMT_MS            int `yaml:"TIME_PMT_MS"`
+	TIME_FV_MS             int `yaml:"TIME_FV_MS"`
+	TIME_MEDIAN_MS         int `yaml:"TIME_MEDIAN_MS"`
+	// Constants - Дополнительные именованные константы, доступные в выражениях.
+	Constants map[string]float64 `yaml:"constants"`
+}
+
+// OptimizerConfig представляет параметры оптимизации синтаксического дерева перед разбиением на задачи
+type OptimizerConfig struct {
+	// Rebalance - перестраивать цепочки сложений и умножений в сбалансированные деревья для параллельного вычисления.
+	// Меняет порядок операций, поэтому результат может отличаться от вычисления слева направо в последних знаках.
+	Rebalance bool `yaml:"rebalance"`
+	// FoldConstants - вычислять операции над одними числами в оркестраторе, не отправляя их агентам.
+	// Такие операции выполняются мгновенно, без длительностей TIME_*_MS.
+	FoldConstants bool `yaml:"fold_constants"`
+}
+
+// StorageConfig представляет параметры хранилища выражений оркестратора
+type StorageConfig struct {
+	// Type - тип хранилища: "memory" (выражения теряются при перезапуске) или "file" (журнал и снимки на диске).
+	Type string `yaml:"type"`
+	// Dir - директория файлов хранилища "file".
+	Dir string `yaml:"dir"`
+	// SnapshotInterval - количество записей журнала, после которого состояние сохраняется в снимок, а журнал очищается.
+	// 0 - снимок сохраняется только при остановке оркестратора.
+	SnapshotInterval int `yaml:"snapshot_interval"`
+	// Sync - сбрасывать журнал на диск после каждой записи. Медленнее, но записи не теряются при отключении питания.
+	Sync bool `yaml:"sync"`
+}
+
+// RetentionConfig представляет политику хранения завершенных (вычисленных, ошибочных и отмененных) выражений.
+// Выражения, которые ещё вычисляются, не удаляются.
+type RetentionConfig struct {
+	// TTL - время в секундах, через которое удаляется выражение, не запрашивавшееся после завершения
+	// или последнего запроса. 0 - без ограничения.
+	TTL int `yaml:"ttl"`
+	// MaxCount - максимальное количество завершенных выражений. Лишние выражения удаляются начиная с тех,
+	// которые дольше всего не запрашивались. 0 - без ограничения.
+	MaxCount int `yaml:"max_count"`
+	// JanitorInterval - период в секундах, с которым применяется политика хранения.
+	JanitorInterval int `yaml:"janitor_interval"`
 }
 
 // CORSConfig представляет параметры CORS
@@ -78,13 +147,16 @@ func defaultConfig() *Config {
 	return &Config{
 		Server: ServicesConfig{
 			Orchestrator: OrchestratorServiceConfig{
-				ADDR_ORCHESTRATOR: "127.0.0.1",
-				PORT_ORCHESTRATOR: 8080,
+				ADDR_ORCHESTRATOR:       "127.0.0.1",
+				PORT_ORCHESTRATOR:       8080,
+				TASK_LEASE_GRACE_MS:     10000,
+				TASK_REAPER_INTERVAL_MS: 1000,
 			},
 			Agent: AgentServiceConfig{
-				COMPUTING_POWER:  4,
-				AGENT_REPEAT:     5000,
-				AGENT_REPEAT_ERR: 2000,
+				COMPUTING_POWER:    4,
+				AGENT_REPEAT:       5000,
+				AGENT_REPEAT_ERR:   2000,
+				AGENT_HEARTBEAT_MS: 1000,
 			},
 			Web: WebServiceConfig{
 				ADDR_WEB:  "127.0.0.1",
@@ -99,6 +171,43 @@ func defaultConfig() *Config {
 			TIME_DIVISION_MS:       0,
 			TIME_UNARY_MINUS_MS:    0,
 			TIME_POWER_MS:          0,
+			TIME_MODULO_MS:         0,
+			TIME_FLOOR_DIVISION_MS: 0,
+			TIME_FACTORIAL_MS:      0,
+			TIME_SIN_MS:            0,
+			TIME_COS_MS:            0,
+			TIME_TAN_MS:            0,
+			TIME_SQRT_MS:           0,
+			TIME_LOG_MS:            0,
+			TIME_LN_MS:             0,
+			TIME_ABS_MS:            0,
+			TIME_EXP_MS:            0,
+			TIME_MAX_MS:            0,
+			TIME_MIN_MS:            0,
+			TIME_COMPARISON_MS:     0,
+			TIME_LOGICAL_MS:        0,
+			TIME_PERCENT_MS:        0,
+			TIME_ROUND_MS:          0,
+			TIME_FLOOR_MS:          0,
+			TIME_CEIL_MS:           0,
+			TIME_PMT_MS:            0,
+			TIME_FV_MS:             0,
+			TIME_MEDIAN_MS:         0,
+		},
+		Optimizer: OptimizerConfig{
+			Rebalance:     true,
+			FoldConstants: false,
+		},
+		Storage: StorageConfig{
+			Type:             "memory",
+			Dir:              "data",
+			SnapshotInterval: 1000,
+			Sync:             false,
+		},
+		Retention: RetentionConfig{
+			TTL:             3600,
+			MaxCount:        10000,
+			JanitorInterval: 60,
 		},
 		Middleware: MiddlewareConfig{
 			ApiKeyPrefix:  "",
@@ -265,10 +374,132 @@ func loadEnv() error {
 		Cfg.Math.TIME_POWER_MS = timePowerMS
 	}
 
+	// TASK_LEASE_GRACE_MS
+	if err := loadEnvInt("TASK_LEASE_GRACE_MS", &Cfg.Server.Orchestrator.TASK_LEASE_GRACE_MS); err != nil {
+		return err
+	}
+
+	// TASK_REAPER_INTERVAL_MS
+	if err := loadEnvInt("TASK_REAPER_INTERVAL_MS", &Cfg.Server.Orchestrator.TASK_REAPER_INTERVAL_MS); err != nil {
+		return err
+	}
+
+	// AGENT_HEARTBEAT_MS
+	if err := loadEnvInt("AGENT_HEARTBEAT_MS", &Cfg.Server.Agent.AGENT_HEARTBEAT_MS); err != nil {
+		return err
+	}
+
+	// Длительности остальных операций и математических функций
+	functionTimes := []struct {
+		name   string
+		target *int
+	}{
+		{"TIME_MODULO_MS", &Cfg.Math.TIME_MODULO_MS},
+		{"TIME_FLOOR_DIVISION_MS", &Cfg.Math.TIME_FLOOR_DIVISION_MS},
+		{"TIME_FACTORIAL_MS", &Cfg.Math.TIME_FACTORIAL_MS},
+		{"TIME_SIN_MS", &Cfg.Math.TIME_SIN_MS},
+		{"TIME_COS_MS", &Cfg.Math.TIME_COS_MS},
+		{"TIME_TAN_MS", &Cfg.Math.TIME_TAN_MS},
+		{"TIME_SQRT_MS", &Cfg.Math.TIME_SQRT_MS},
+		{"TIME_LOG_MS", &Cfg.Math.TIME_LOG_MS},
+		{"TIME_LN_MS", &Cfg.Math.TIME_LN_MS},
+		{"TIME_ABS_MS", &Cfg.Math.TIME_ABS_MS},
+		{"TIME_EXP_MS", &Cfg.Math.TIME_EXP_MS},
+		{"TIME_MAX_MS", &Cfg.Math.TIME_MAX_MS},
+		{"TIME_MIN_MS", &Cfg.Math.TIME_MIN_MS},
+		{"TIME_COMPARISON_MS", &Cfg.Math.TIME_COMPARISON_MS},
+		{"TIME_LOGICAL_MS", &Cfg.Math.TIME_LOGICAL_MS},
+		{"TIME_PERCENT_MS", &Cfg.Math.TIME_PERCENT_MS},
+		{"TIME_ROUND_MS", &Cfg.Math.TIME_ROUND_MS},
+		{"TIME_FLOOR_MS", &Cfg.Math.TIME_FLOOR_MS},
+		{"TIME_CEIL_MS", &Cfg.Math.TIME_CEIL_MS},
+		{"TIME_PMT_MS", &Cfg.Math.TIME_PMT_MS},
+		{"TIME_FV_MS", &Cfg.Math.TIME_FV_MS},
+		{"TIME_MEDIAN_MS", &Cfg.Math.TIME_MEDIAN_MS},
+	}
+	for _, ft := range functionTimes {
+		if err := loadEnvInt(ft.name, ft.target); err != nil {
+			return err
+		}
+	}
+
+	// OPTIMIZER_REBALANCE
+	if err := loadEnvBool("OPTIMIZER_REBALANCE", &Cfg.Optimizer.Rebalance); err != nil {
+		return err
+	}
+
+	// OPTIMIZER_FOLD_CONSTANTS
+	if err := loadEnvBool("OPTIMIZER_FOLD_CONSTANTS", &Cfg.Optimizer.FoldConstants); err != nil {
+		return err
+	}
+
+	// STORAGE_TYPE
+	if storageType := os.Getenv("STORAGE_TYPE"); storageType != "" {
+		Cfg.Storage.Type = storageType
+	}
+
+	// STORAGE_DIR
+	if storageDir := os.Getenv("STORAGE_DIR"); storageDir != "" {
+		Cfg.Storage.Dir = storageDir
+	}
+
+	// STORAGE_SNAPSHOT_INTERVAL
+	if err := loadEnvInt("STORAGE_SNAPSHOT_INTERVAL", &Cfg.Storage.SnapshotInterval); err != nil {
+		return err
+	}
+
+	// STORAGE_SYNC
+	if err := loadEnvBool("STORAGE_SYNC", &Cfg.Storage.Sync); err != nil {
+		return err
+	}
+
+	// RETENTION_TTL
+	if err := loadEnvInt("RETENTION_TTL", &Cfg.Retention.TTL); err != nil {
+		return err
+	}
+
+	// RETENTION_MAX_COUNT
+	if err := loadEnvInt("RETENTION_MAX_COUNT", &Cfg.Retention.MaxCount); err != nil {
+		return err
+	}
+
+	// RETENTION_JANITOR_INTERVAL
+	if err := loadEnvInt("RETENTION_JANITOR_INTERVAL", &Cfg.Retention.JanitorInterval); err != nil {
+		return err
+	}
+
 	return nil
 
 }
 
+// loadEnvInt записывает в target целочисленное значение переменной среды name, если она задана.
+func loadEnvInt(name string, target *int) error {
+	valueStr := os.Getenv(name)
+	if valueStr == "" {
+		return nil
+	}
+	value, err := strconv.Atoi(valueStr)
+	if err != nil {
+		return fmt.Errorf("ошибка преобразования %s в int: %w", name, err)
+	}
+	*target = value
+	return nil
+}
+
+// loadEnvBool записывает в target логическое значение переменной среды name, если она задана.
+func loadEnvBool(name string, target *bool) error {
+	valueStr := os.Getenv(name)
+	if valueStr == "" {
+		return nil
+	}
+	value, err := strconv.ParseBool(valueStr)
+	if err != nil {
+		return fmt.Errorf("ошибка преобразования %s в bool: %w", name, err)
+	}
+	*target = value
+	return nil
+}
+
 func InitConfig() error {
 	// Создаем конфиг по умолчанию
 	Cfg = defaultConfig()
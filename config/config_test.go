@@ -174,6 +174,12 @@ func TestConfig_Env(t *testing.T) {
 	assert.NoError(t, err)
 	err = os.Setenv("TIME_ADDITION_MS", "100")
 	assert.NoError(t, err)
+	err = os.Setenv("STORAGE_TYPE", "file")
+	assert.NoError(t, err)
+	err = os.Setenv("STORAGE_SNAPSHOT_INTERVAL", "10")
+	assert.NoError(t, err)
+	err = os.Setenv("RETENTION_TTL", "60")
+	assert.NoError(t, err)
 
 	// Отключаем конфиг
 	err = os.Setenv("APP_CFG", "CFG_FALSE")
@@ -184,6 +190,10 @@ func TestConfig_Env(t *testing.T) {
 
 	assert.Equal(t, "newadres", config.Cfg.Server.Orchestrator.ADDR_ORCHESTRATOR)
 	assert.Equal(t, 100, config.Cfg.Math.TIME_ADDITION_MS)
+	assert.Equal(t, "file", config.Cfg.Storage.Type)
+	assert.Equal(t, 10, config.Cfg.Storage.SnapshotInterval)
+	assert.Equal(t, 60, config.Cfg.Retention.TTL)
+	assert.Equal(t, 10000, config.Cfg.Retention.MaxCount)
 }
 
 func TestConfig_NoEnvCfg(t *testing.T) {
@@ -8,6 +8,8 @@ import (
 
 	"github.com/OinkiePie/calc_2/config"
 	"github.com/OinkiePie/calc_2/orchestrator/internal/router"
+	"github.com/OinkiePie/calc_2/orchestrator/internal/storage"
+	"github.com/OinkiePie/calc_2/orchestrator/internal/task_manager"
 	"github.com/OinkiePie/calc_2/pkg/initializer"
 	"github.com/OinkiePie/calc_2/pkg/logger"
 	"github.com/OinkiePie/calc_2/pkg/shutdown"
@@ -16,9 +18,11 @@ import (
 
 // Orchestrator представляет собой сервис оркестратора.
 type Orchestrator struct {
-	errChan chan error   // Канал для отправки ошибок, возникающих в сервисе.
-	server  *http.Server // Указатель на структуру http.Server, управляющую HTTP-сервером.
-	Addr    string       // Адрес, на котором прослушивает HTTP-сервер.
+	errChan        chan error                // Канал для отправки ошибок, возникающих в сервисе.
+	server         *http.Server              // Указатель на структуру http.Server, управляющую HTTP-сервером.
+	taskManager    *task_manager.TaskManager // Менеджер выражений, хранилище которого закрывается при остановке.
+	stopBackground context.CancelFunc        // Останавливает возврат в очередь задач с истекшей арендой и удаление устаревших выражений.
+	Addr           string                    // Адрес, на котором прослушивает HTTP-сервер.
 }
 
 // NewOrchestrator создает новый экземпляр сервиса оркестратора.
@@ -26,19 +30,20 @@ type Orchestrator struct {
 // Args:
 //
 //	errChan: chan error - Канал для отправки ошибок, возникающих при инициализации или работе сервиса.
+//	taskManager: *task_manager.TaskManager - Менеджер выражений и задач.
 //
 // Returns:
 //
 //	*Orchestrator - Указатель на новый экземпляр структуры Orchestrator.
-func NewOrchestrator(errChan chan error) *Orchestrator {
+func NewOrchestrator(errChan chan error, taskManager *task_manager.TaskManager) *Orchestrator {
 	addr := fmt.Sprintf("%s:%d", config.Cfg.Server.Orchestrator.ADDR_ORCHESTRATOR, config.Cfg.Server.Orchestrator.PORT_ORCHESTRATOR)
 
-	router := router.NewOrchestratorRouter()
+	router := router.NewOrchestratorRouter(taskManager)
 
 	c := cors.New(cors.Options{
 		AllowedOrigins:   config.Cfg.Middleware.AllowOrigin,
 		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Content-Type", "Authorization"},
+		AllowedHeaders:   []string{"Content-Type", "Authorization", "X-User-ID"},
 		AllowCredentials: true,
 	})
 	routerCORS := c.Handler(router)
@@ -48,12 +53,20 @@ func NewOrchestrator(errChan chan error) *Orchestrator {
 		Handler: routerCORS,
 	}
 
-	return &Orchestrator{errChan: errChan, server: srv, Addr: addr}
+	return &Orchestrator{errChan: errChan, server: srv, taskManager: taskManager, Addr: addr}
 }
 
 // Start запускает HTTP-сервер в отдельной горутине. Если во время запуска
-// возникает ошибка, она отправляется в канал ошибок.
+// возникает ошибка, она отправляется в канал ошибок. Вместе с сервером запускается
+// возврат в очередь задач, аренда которых истекла, и удаление устаревших выражений по политике хранения.
 func (o *Orchestrator) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	o.stopBackground = cancel
+	go o.taskManager.RunReaper(ctx, time.Duration(config.Cfg.Server.Orchestrator.TASK_REAPER_INTERVAL_MS)*time.Millisecond)
+	if config.Cfg.Retention.JanitorInterval > 0 {
+		go o.taskManager.RunJanitor(ctx, time.Duration(config.Cfg.Retention.JanitorInterval)*time.Second)
+	}
+
 	// Запускаем сервер в отдельной горутине, чтобы не блокировать основной поток выполнения.
 	go func() {
 		// Запускаем прослушивание входящих соединений на указанном адресе.
@@ -73,6 +86,13 @@ func (o *Orchestrator) Stop() {
 	if err != nil {
 		logger.Log.Errorf("Ошибка при остановке сервиса Оркестратор")
 	}
+	if o.stopBackground != nil {
+		o.stopBackground()
+	}
+	// Хранилище закрывается после сервера, когда обработчики уже не изменяют выражения
+	if err := o.taskManager.Close(); err != nil {
+		logger.Log.Errorf("Ошибка при закрытии хранилища выражений: %v", err)
+	}
 }
 
 // Запуск сервиса оркестратора
@@ -82,8 +102,18 @@ func main() {
 
 	errChan := make(chan error, 1)
 
+	// Восстановление выражений из хранилища
+	store, err := storage.Open(config.Cfg.Storage)
+	if err != nil {
+		logger.Log.Fatalf("Не удалось открыть хранилище выражений: %v", err)
+	}
+	taskManager, err := task_manager.NewTaskManagerWithStore(store)
+	if err != nil {
+		logger.Log.Fatalf("Не удалось восстановить выражения из хранилища: %v", err)
+	}
+
 	// Запуск сервиса агента в отдельной горутине чтобы можно было поймать завершение
-	orchestratorService := NewOrchestrator(errChan)
+	orchestratorService := NewOrchestrator(errChan, taskManager)
 	go func() {
 		logger.Log.Debugf("Запуск сервиса Оркестратор...")
 		orchestratorService.Start()
@@ -0,0 +1,177 @@
+package ast
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/OinkiePie/calc_2/pkg/operators"
+	"github.com/OinkiePie/calc_2/pkg/units"
+)
+
+// Node - узел синтаксического дерева математического выражения.
+type Node interface {
+	// Pos возвращает смещение в байтах от начала выражения до токена, с которого начинается узел
+	// (для операторов - до самого оператора).
+	Pos() int
+	// String возвращает запись узла с явной расстановкой скобок, например "(2 + (3 * 4))".
+	String() string
+}
+
+// Number - числовой литерал.
+type Number struct {
+	Value float64 // Значение литерала.
+	Text  string  // Точная десятичная запись литерала для вычислений с повышенной точностью.
+	Imag  float64 // Мнимая часть числа. Литералы действительные, мнимую часть получает только подставленная мнимая единица i.
+	// Unit - единица измерения, записанная после литерала, например "km/h". Пустая строка - число без единицы.
+	Unit string
+	// Dim - размерность значения. Заполняется при переводе литерала с единицей в основные единицы СИ.
+	Dim    units.Dimension
+	Offset int // Смещение литерала в выражении.
+}
+
+// Ident - имя переменной или константы.
+type Ident struct {
+	Name   string // Имя.
+	Offset int    // Смещение имени в выражении.
+}
+
+// Unary - унарная операция: префиксный унарный минус (operators.OpUnaryMinus), префиксное логическое НЕ (operators.OpNot),
+// постфиксный факториал (operators.OpFactorial) или постфиксный процент (operators.OpPercent).
+type Unary struct {
+	Op      string // Оператор.
+	Operand Node   // Операнд.
+	Offset  int    // Смещение оператора в выражении.
+}
+
+// Binary - бинарная операция.
+type Binary struct {
+	Op    string // Оператор.
+	Left  Node   // Левый операнд.
+	Right Node   // Правый операнд.
+	// Implicit - умножение не записано явно, например "2(3+4)" или "2x".
+	Implicit bool
+	Offset   int // Смещение оператора в выражении (для неявного умножения - правого операнда).
+}
+
+// Conditional - условное выражение "Cond ? Then : Else" или "if(Cond, Then, Else)".
+// Вычисляется только ветвь, выбранная условием.
+type Conditional struct {
+	Cond   Node // Условие, истинно любое ненулевое значение.
+	Then   Node // Значение, если условие истинно.
+	Else   Node // Значение, если условие ложно.
+	Offset int  // Смещение оператора "?" или имени функции if в выражении.
+}
+
+// Convert - перевод результата выражения в другую единицу измерения: "Value to Unit".
+// Стоит только в корне дерева.
+type Convert struct {
+	Value  Node   // Переводимое выражение.
+	Unit   string // Единица измерения, например "m/s".
+	Offset int    // Смещение ключевого слова "to" в выражении.
+}
+
+// Call - вызов функции.
+type Call struct {
+	// Func - Имя функции. Имя не из operators.Functions - вызов пользовательской функции или, если имя
+	// окажется переменной или константой, неявное умножение на единственный аргумент, например "x(3+4)".
+	Func   string
+	Args   []Node // Аргументы в порядке записи.
+	Offset int    // Смещение имени функции в выражении.
+}
+
+// Ref - ссылка на результат другого выражения: "$ref(id)".
+type Ref struct {
+	ID     string // ID выражения, результат которого подставляется на место ссылки.
+	Offset int    // Смещение ссылки в выражении.
+}
+
+// Definition - определение пользовательской функции, например "f(x, y) = x^2 + y^2".
+type Definition struct {
+	Name   string   // Имя функции.
+	Params []string // Имена параметров в порядке записи.
+	Body   Node     // Тело функции, смещения узлов считаются от начала определения.
+}
+
+// Pos возвращает смещение литерала.
+func (n *Number) Pos() int { return n.Offset }
+
+// Pos возвращает смещение имени.
+func (n *Ident) Pos() int { return n.Offset }
+
+// Pos возвращает смещение оператора.
+func (n *Unary) Pos() int { return n.Offset }
+
+// Pos возвращает смещение оператора.
+func (n *Binary) Pos() int { return n.Offset }
+
+// Pos возвращает смещение оператора "?" или имени функции if.
+func (n *Conditional) Pos() int { return n.Offset }
+
+// Pos возвращает смещение имени функции.
+func (n *Call) Pos() int { return n.Offset }
+
+// Pos возвращает смещение ключевого слова "to".
+func (n *Convert) Pos() int { return n.Offset }
+
+// Pos возвращает смещение ссылки.
+func (n *Ref) Pos() int { return n.Offset }
+
+// String возвращает значение литерала в кратчайшей точной записи, комплексное число - в виде "(3+4i)",
+// число с единицей измерения - в виде "(5 km/h)".
+func (n *Number) String() string {
+	if n.Imag != 0 {
+		return strconv.FormatComplex(complex(n.Value, n.Imag), 'g', -1, 128)
+	}
+	if n.Unit != "" {
+		return "(" + strconv.FormatFloat(n.Value, 'g', -1, 64) + " " + n.Unit + ")"
+	}
+	return strconv.FormatFloat(n.Value, 'g', -1, 64)
+}
+
+// String возвращает имя.
+func (n *Ident) String() string {
+	return n.Name
+}
+
+// String возвращает операцию в скобках: "(-x)", "(!x)", "(x!)" или "(x%)".
+func (n *Unary) String() string {
+	switch n.Op {
+	case operators.OpFactorial:
+		return "(" + n.Operand.String() + n.Op + ")"
+	case operators.OpPercent:
+		return "(" + n.Operand.String() + operators.OpModulo + ")"
+	case operators.OpNot:
+		return "(" + operators.OpFactorial + n.Operand.String() + ")"
+	default:
+		return "(" + operators.OpSubtract + n.Operand.String() + ")"
+	}
+}
+
+// String возвращает операцию в скобках: "(a + b)".
+func (n *Binary) String() string {
+	return "(" + n.Left.String() + " " + n.Op + " " + n.Right.String() + ")"
+}
+
+// String возвращает условное выражение в скобках: "(c ? a : b)".
+func (n *Conditional) String() string {
+	return "(" + n.Cond.String() + " " + operators.OpQuestion + " " + n.Then.String() + " " + operators.OpColon + " " + n.Else.String() + ")"
+}
+
+// String возвращает перевод в скобках: "(a to km/h)".
+func (n *Convert) String() string {
+	return "(" + n.Value.String() + " " + convertKeyword + " " + n.Unit + ")"
+}
+
+// String возвращает ссылку: "$ref(id)".
+func (n *Ref) String() string {
+	return operators.OpReference + operators.ParenLeft + n.ID + operators.ParenRight
+}
+
+// String возвращает вызов функции: "max(a, b)".
+func (n *Call) String() string {
+	args := make([]string, len(n.Args))
+	for i, arg := range n.Args {
+		args[i] = arg.String()
+	}
+	return n.Func + "(" + strings.Join(args, ", ") + ")"
+}
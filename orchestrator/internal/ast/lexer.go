@@ -0,0 +1,255 @@
+package ast
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/OinkiePie/calc_2/pkg/constants"
+	"github.com/OinkiePie/calc_2/pkg/operators"
+)
+
+// token - токен выражения вместе с его позицией в исходной строке.
+type token struct {
+	text string // Текст токена (число, имя, оператор, скобка или разделитель).
+	pos  int    // Смещение в байтах от начала выражения.
+}
+
+// compoundOperators - операторы из двух символов. Проверяются раньше односимвольных: "<=" - один оператор, а не "<" и "=".
+var compoundOperators = map[string]bool{
+	operators.OpFloorDivide:  true,
+	operators.OpLessEqual:    true,
+	operators.OpGreaterEqual: true,
+	operators.OpEqual:        true,
+	operators.OpNotEqual:     true,
+	operators.OpAnd:          true,
+	operators.OpOr:           true,
+}
+
+// isOperator проверяет, является ли токен строкой, представляющей математический оператор.
+//
+// Args:
+//
+//	token: string - Строка, которую необходимо проверить.
+//
+// Returns:
+//
+//	bool - true, если токен является одним из допустимых бинарных операторов (арифметических, сравнений или логических), иначе false.
+func isOperator(token string) bool {
+	_, ok := infixPowers[token]
+	return ok
+}
+
+// isUnaryMinus определяет, следует ли обрабатывать знак минус как унарный (например, "-5") или бинарный (например, "3 - 5").
+// Та же позиция определяет унарный плюс и отличает логическое НЕ ("!x") от факториала ("x!").
+//
+// Args:
+//
+//	tokens: []token - Токены выражения.
+//	i: int - Индекс текущего токена в срезе.
+//
+// Returns:
+//
+//	bool - true, если минус должен быть обработан как унарный, иначе false.
+func isUnaryMinus(tokens []token, i int) bool {
+	if i == 0 {
+		return true // Минус в начале выражения - унарный
+	}
+	prevToken := tokens[i-1].text
+	return prevToken == operators.ParenLeft || prevToken == operators.ListLeft || prevToken == operators.ArgSeparator ||
+		prevToken == operators.OpQuestion || prevToken == operators.OpColon || isOperator(prevToken)
+}
+
+// isName проверяет, является ли токен именем (функции или переменной).
+//
+// Args:
+//
+//	token: string - Строка, которую необходимо проверить.
+//
+// Returns:
+//
+//	bool - true, если токен начинается с буквы или подчеркивания.
+func isName(token string) bool {
+	for _, r := range token {
+		return unicode.IsLetter(r) || r == '_'
+	}
+	return false
+}
+
+// isReference проверяет, является ли токен ссылкой на результат другого выражения "$ref(id)".
+func isReference(token string) bool {
+	return strings.HasPrefix(token, operators.OpReference+operators.ParenLeft)
+}
+
+// referenceID возвращает ID выражения из токена ссылки "$ref(id)".
+func referenceID(token string) string {
+	return strings.TrimSuffix(strings.TrimPrefix(token, operators.OpReference+operators.ParenLeft), operators.ParenRight)
+}
+
+// scanReference считывает ссылку на результат другого выражения "$ref(id)", начинающуюся с позиции start.
+// ID выражения состоит из латинских букв, цифр, "-" и "_", пробелы внутри скобок не допускаются.
+//
+// Args:
+//
+//	runes: []rune - Символы выражения.
+//	start: int - Индекс символа "$".
+//
+// Returns:
+//
+//	string - Текст ссылки.
+//	int - Индекс символа, следующего за ссылкой.
+//	error - errReference, если после "$" нет ссылки вида "$ref(id)".
+func scanReference(runes []rune, start int) (string, int, error) {
+	prefix := []rune(operators.OpReference + operators.ParenLeft)
+	end := start + len(prefix)
+	if end > len(runes) || string(runes[start:end]) != string(prefix) {
+		return "", 0, errReference
+	}
+	for end < len(runes) && isReferenceChar(runes[end]) {
+		end++
+	}
+	if end == start+len(prefix) || end == len(runes) || string(runes[end]) != operators.ParenRight {
+		return "", 0, errReference
+	}
+	return string(runes[start : end+1]), end + 1, nil
+}
+
+// isReferenceChar проверяет, может ли символ входить в ID выражения в ссылке.
+func isReferenceChar(r rune) bool {
+	return ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z') || isDigit(r) || r == '-' || r == '_'
+}
+
+// isNumber проверяет, является ли переданный токен числом.
+//
+// Args:
+//
+//	token: string - Строка, которую необходимо проверить.
+//
+// Returns:
+//
+//	bool - true, если токен может быть преобразован в число, иначе false.
+func isNumber(token string) bool {
+	_, err := parseNumber(token)
+	return err == nil
+}
+
+// tokenize разбивает входную строку математического выражения на отдельные токены (числа, имена функций и переменных, операторы, скобки).
+// Токены используются для дальнейшей обработки выражения.
+//
+// Unicode-синонимы операторов (×, ÷, −, ·) заменяются на операторы, надстрочные цифры (², ³)
+// на возведение в степень, а символы констант (π) на их имена. Унарный плюс не влияет на значение и пропускается.
+// Числовые литералы считываются целиком (см. scanNumber) и сохраняются в исходной записи,
+// ссылка на другое выражение "$ref(id)" - одним токеном (см. scanReference).
+// Пробельные символы разделяют токены и в токены не попадают.
+//
+// Args:
+//
+//	expression: string - Строка, содержащая математическое выражение.
+//
+// Returns:
+//
+//	[]token - Токены выражения с их позициями в исходной строке.
+//	error - *ParseError, если выражение содержит некорректный числовой литерал или ссылку,
+//	        либо два числа подряд без оператора.
+func tokenize(expression string) ([]token, error) {
+	var tokens []token
+	var currentName token
+	superscript := false // Предыдущий символ - надстрочная цифра
+
+	// Байтовое смещение каждого символа в исходной строке
+	runes := make([]rune, 0, len(expression))
+	offsets := make([]int, 0, len(expression)+1)
+	for offset, r := range expression {
+		runes = append(runes, r)
+		offsets = append(offsets, offset)
+	}
+	offsets = append(offsets, len(expression))
+
+	// flush добавляет накопленное имя в токены
+	flush := func() {
+		if currentName.text != "" {
+			tokens = append(tokens, currentName)
+			currentName = token{}
+		}
+	}
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		pos := offsets[i]
+
+		// Надстрочные цифры образуют показатель степени
+		if digit, ok := operators.Superscripts[r]; ok {
+			if superscript {
+				tokens[len(tokens)-1].text += digit // Продолжение показателя, например "x¹²"
+			} else {
+				flush()
+				tokens = append(tokens, token{text: operators.OpPower, pos: pos}, token{text: digit, pos: pos})
+			}
+			superscript = true
+			continue
+		}
+		superscript = false
+
+		s := string(r)
+		if alias, ok := operators.Aliases[r]; ok {
+			s = alias
+		}
+
+		switch {
+		// Пробельные символы только разделяют токены
+		case unicode.IsSpace(r):
+			flush()
+		// Символ константы сразу становится её именем
+		case constants.Symbols[r] != "":
+			flush()
+			tokens = append(tokens, token{text: constants.Symbols[r], pos: pos})
+		// Имя продолжается буквами, цифрами и подчеркиванием
+		case currentName.text != "" && (unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'):
+			currentName.text += s
+		// Имя начинается с буквы или подчеркивания
+		case unicode.IsLetter(r) || r == '_':
+			flush()
+			currentName = token{text: s, pos: pos}
+		// Числовой литерал начинается с цифры или с точки перед цифрой
+		case isDigit(r) || (s == operators.Point && i+1 < len(runes) && isDigit(runes[i+1])):
+			flush()
+			literal, end, err := scanNumber(runes, i)
+			tok := token{text: literal, pos: pos}
+			if err != nil {
+				return nil, newParseError(err, tok)
+			}
+			// Два числа подряд возможны только через пробел, например "1 000"
+			if len(tokens) > 0 && isNumber(tokens[len(tokens)-1].text) {
+				return nil, newParseError(errInvalidSyntax, tok, expectedOperator)
+			}
+			tokens = append(tokens, tok)
+			i = end - 1
+		// Ссылка на результат другого выражения "$ref(id)" - один токен
+		case r == '$':
+			flush()
+			reference, end, err := scanReference(runes, i)
+			if err != nil {
+				return nil, newParseError(err, token{text: s, pos: pos})
+			}
+			tokens = append(tokens, token{text: reference, pos: pos})
+			i = end - 1
+		// Операторы из двух символов: "//", "<=", "==", "&&" и другие
+		case i+1 < len(runes) && compoundOperators[string(runes[i:i+2])]:
+			flush()
+			tokens = append(tokens, token{text: string(runes[i : i+2]), pos: pos})
+			i++
+		// Унарный плюс пропускается. После "%" плюс бинарный: в "200 + 15% + 5" знак "%" - процент
+		case s == operators.OpAdd && currentName.text == "" && isUnaryMinus(tokens, len(tokens)) &&
+			(len(tokens) == 0 || tokens[len(tokens)-1].text != operators.OpModulo):
+		default:
+			// Если накопилось имя, добавляем его в токены
+			flush()
+			// Добавляем текущий символ (оператор, скобку или разделитель) в токены
+			tokens = append(tokens, token{text: s, pos: pos})
+		}
+	}
+
+	// Если осталось имя, добавляем его в токены
+	flush()
+
+	return tokens, nil
+}
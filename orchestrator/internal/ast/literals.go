@@ -0,0 +1,257 @@
+package ast
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// basePrefixes - префиксы систем счисления числовых литералов ("0x", "0b", "0o").
+var basePrefixes = map[rune]int{
+	'x': 16,
+	'b': 2,
+	'o': 8,
+}
+
+// baseNames - названия систем счисления для сообщений об ошибках.
+var baseNames = map[int]string{
+	16: "шестнадцатеричном",
+	2:  "двоичном",
+	8:  "восьмеричном",
+}
+
+// isDigit проверяет, является ли символ десятичной цифрой ASCII.
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+// literalError создает ошибку некорректного числового литерала.
+//
+// Args:
+//
+//	literal: string - Текст литерала.
+//	reason: string - Причина ошибки.
+//
+// Returns:
+//
+//	error - Ошибка с текстом литерала и причиной.
+func literalError(literal, reason string) error {
+	return fmt.Errorf("некорректный числовой литерал %q: %s", literal, reason)
+}
+
+// scanNumber считывает числовой литерал, начинающийся с позиции start, и проверяет его корректность.
+//
+// Поддерживаемые формы: целые и дробные числа ("42", "3.14", ".5"), экспоненциальная запись ("1e-9", "6.02E23"),
+// шестнадцатеричные ("0xFF"), двоичные ("0b1010") и восьмеричные ("0o17") литералы,
+// а также разделитель разрядов "_" между цифрами ("1_000_000").
+//
+// Args:
+//
+//	runes: []rune - Символы выражения.
+//	start: int - Индекс первого символа литерала (цифра или точка).
+//
+// Returns:
+//
+//	string - Текст литерала.
+//	int - Индекс символа, следующего за литералом.
+//	error - Ошибка, если литерал некорректен.
+func scanNumber(runes []rune, start int) (string, int, error) {
+	i := start
+
+	// Литералы с префиксом системы счисления
+	if runes[i] == '0' && i+1 < len(runes) {
+		if base, ok := basePrefixes[unicode.ToLower(runes[i+1])]; ok {
+			i += 2
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_' || runes[i] == '.') {
+				i++
+			}
+			literal := string(runes[start:i])
+			return literal, i, validateBaseLiteral(literal, base)
+		}
+	}
+
+	// Целая и дробная часть
+	for i < len(runes) && (isDigit(runes[i]) || runes[i] == '_' || runes[i] == '.') {
+		i++
+	}
+
+	// Показатель степени. Буква после "e" означает начало имени, например "2exp(1)"
+	if i < len(runes) && (runes[i] == 'e' || runes[i] == 'E') {
+		next := i + 1
+		if next >= len(runes) || !(unicode.IsLetter(runes[next]) || runes[next] == '_') {
+			i = next
+			if i < len(runes) && (runes[i] == '+' || runes[i] == '-') {
+				i++
+			}
+			for i < len(runes) && (isDigit(runes[i]) || runes[i] == '_') {
+				i++
+			}
+		}
+	}
+
+	literal := string(runes[start:i])
+	return literal, i, validateDecimalLiteral(literal)
+}
+
+// validateSeparators проверяет, что каждый разделитель разрядов "_" стоит между двумя цифрами.
+//
+// Args:
+//
+//	literal: string - Текст литерала (для сообщения об ошибке).
+//	digits: string - Часть литерала, состоящая из цифр и разделителей.
+//	isValidDigit: func(rune) bool - Проверка допустимости цифры.
+//
+// Returns:
+//
+//	error - Ошибка, если разделитель стоит не между цифрами.
+func validateSeparators(literal, digits string, isValidDigit func(rune) bool) error {
+	runes := []rune(digits)
+	for i, r := range runes {
+		if r != '_' {
+			continue
+		}
+		if i == 0 || i == len(runes)-1 || !isValidDigit(runes[i-1]) || !isValidDigit(runes[i+1]) {
+			return literalError(literal, "разделитель разрядов \"_\" должен стоять между цифрами")
+		}
+	}
+	return nil
+}
+
+// validateDecimalLiteral проверяет десятичный литерал, в том числе в экспоненциальной записи.
+//
+// Args:
+//
+//	literal: string - Текст литерала.
+//
+// Returns:
+//
+//	error - Ошибка, если литерал некорректен.
+func validateDecimalLiteral(literal string) error {
+	mantissa, exponent, hasExponent := strings.Cut(strings.ToLower(literal), "e")
+
+	if strings.Count(mantissa, ".") > 1 {
+		return literalError(literal, "лишняя десятичная точка")
+	}
+	if !strings.ContainsFunc(mantissa, isDigit) {
+		return literalError(literal, "отсутствуют цифры")
+	}
+	intPart, fracPart, _ := strings.Cut(mantissa, ".")
+	if err := validateSeparators(literal, intPart, isDigit); err != nil {
+		return err
+	}
+	if err := validateSeparators(literal, fracPart, isDigit); err != nil {
+		return err
+	}
+
+	if hasExponent {
+		exponent = strings.TrimLeft(exponent, "+-")
+		if !strings.ContainsFunc(exponent, isDigit) {
+			return literalError(literal, "отсутствует показатель степени")
+		}
+		if err := validateSeparators(literal, exponent, isDigit); err != nil {
+			return err
+		}
+	}
+
+	_, err := parseNumber(literal)
+	return err
+}
+
+// validateBaseLiteral проверяет литерал с префиксом системы счисления ("0x", "0b", "0o").
+//
+// Args:
+//
+//	literal: string - Текст литерала.
+//	base: int - Основание системы счисления.
+//
+// Returns:
+//
+//	error - Ошибка, если литерал некорректен.
+func validateBaseLiteral(literal string, base int) error {
+	digits := []rune(literal)[2:]
+	isValidDigit := func(r rune) bool {
+		value, err := strconv.ParseUint(string(r), base, 8)
+		return err == nil && int(value) < base
+	}
+
+	for _, r := range digits {
+		if r != '_' && !isValidDigit(r) {
+			return literalError(literal, fmt.Sprintf("недопустимая цифра %q в %s литерале", r, baseNames[base]))
+		}
+	}
+	if !strings.ContainsFunc(string(digits), isValidDigit) {
+		return literalError(literal, fmt.Sprintf("отсутствуют цифры в %s литерале", baseNames[base]))
+	}
+	return validateSeparators(literal, string(digits), isValidDigit)
+}
+
+// parseNumber преобразует числовой литерал или число, подставленное вместо переменной, в float64.
+//
+// Args:
+//
+//	literal: string - Текст литерала.
+//
+// Returns:
+//
+//	float64 - Значение литерала.
+//	error - Ошибка, если строка не является числом или значение вне диапазона float64.
+func parseNumber(literal string) (float64, error) {
+	clean := strings.ReplaceAll(literal, "_", "")
+	digits := strings.TrimLeft(clean, "+-")
+	if digits == "" || !(isDigit(rune(digits[0])) || digits[0] == '.') {
+		// Отсекаем "inf", "nan" и прочие формы, которые принимает strconv.ParseFloat
+		return 0, literalError(literal, "не является числом")
+	}
+
+	if len(clean) > 2 && clean[0] == '0' {
+		if _, ok := basePrefixes[unicode.ToLower(rune(clean[1]))]; ok {
+			n, ok := new(big.Int).SetString(clean, 0)
+			if !ok {
+				return 0, literalError(literal, "не является числом")
+			}
+			value, _ := new(big.Float).SetInt(n).Float64()
+			if math.IsInf(value, 0) {
+				return 0, literalError(literal, "значение вне допустимого диапазона")
+			}
+			return value, nil
+		}
+	}
+
+	value, err := strconv.ParseFloat(clean, 64)
+	if err != nil {
+		if errors.Is(err, strconv.ErrRange) && math.IsInf(value, 0) {
+			return 0, literalError(literal, "значение вне допустимого диапазона")
+		}
+		if !errors.Is(err, strconv.ErrRange) {
+			return 0, literalError(literal, "не является числом")
+		}
+	}
+	return value, nil
+}
+
+// exactNumber возвращает точную десятичную запись проверенного числового литерала без потери цифр,
+// которую теряет преобразование в float64: разделители разрядов удаляются,
+// а литералы в других системах счисления переводятся в десятичные целые.
+//
+// Args:
+//
+//	literal: string - Текст литерала.
+//
+// Returns:
+//
+//	string - Десятичная запись литерала, например "1000000" для "1_000_000" или "255" для "0xFF".
+func exactNumber(literal string) string {
+	clean := strings.ReplaceAll(literal, "_", "")
+	if len(clean) > 2 && clean[0] == '0' {
+		if _, ok := basePrefixes[unicode.ToLower(rune(clean[1]))]; ok {
+			if n, ok := new(big.Int).SetString(clean, 0); ok {
+				return n.String()
+			}
+		}
+	}
+	return clean
+}
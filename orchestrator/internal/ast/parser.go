@@ -0,0 +1,778 @@
+package ast
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/OinkiePie/calc_2/pkg/operators"
+	"github.com/OinkiePie/calc_2/pkg/units"
+)
+
+var (
+	errUnopenedParen     = errors.New("неоткрытая скобка")
+	errUnclosedParen     = errors.New("незакрытая скобка")
+	errInvalidSyntax     = errors.New("неверный синтаксис")
+	errNotEnoughOperands = errors.New("недостаточно операндов")
+	errUnaryMinus        = errors.New("недостаточно операндов для унарного минуса")
+	errFunctionCall      = errors.New("после имени функции ожидается открывающая скобка")
+	errArgSeparator      = errors.New("разделитель аргументов вне вызова функции")
+	errEmptyArgument     = errors.New("пустой аргумент функции")
+	errLogicalNot        = errors.New("недостаточно операндов для логического отрицания")
+	errConditional       = errors.New("в условном выражении после ветви \"то\" ожидается \":\"")
+	errColon             = errors.New("\":\" вне условного выражения")
+	errUnknownUnit       = errors.New("неизвестная единица измерения")
+	errList              = errors.New("список допустим только как аргумент функции sum, avg, min, max, median или stddev")
+	errUnclosedList      = errors.New("незакрытый список")
+	errUnopenedList      = errors.New("неоткрытый список")
+	errEmptyList         = errors.New("пустой список")
+	errEmptyListItem     = errors.New("пустой элемент списка")
+	errFunctionHead      = errors.New("ожидается заголовок функции вида f(x, y)")
+	errFunctionEquals    = errors.New("после заголовка функции ожидается \"=\"")
+	errDuplicateParam    = errors.New("повторяющийся параметр функции")
+	errReference         = errors.New("ожидается ссылка на выражение вида $ref(id)")
+)
+
+// ParseError - синтаксическая ошибка в выражении с указанием места, где она обнаружена.
+type ParseError struct {
+	// Err - Причина ошибки.
+	Err error
+	// Pos - Смещение в байтах от начала выражения до ошибочного токена (длина выражения, если выражение оборвалось).
+	Pos int
+	// Token - Ошибочный токен в исходной записи, пустая строка - конец выражения.
+	Token string
+	// Expected - Что ожидалось на месте ошибочного токена (может быть пустым).
+	Expected []string
+}
+
+// Error возвращает описание причины ошибки.
+func (e *ParseError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap возвращает причину ошибки для errors.Is и errors.As.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Caret возвращает выражение и строку под ним с символом "^" под местом ошибки, например:
+//
+//	2 + * 3
+//	    ^
+//
+// Args:
+//
+//	expression: string - Выражение, в котором обнаружена ошибка.
+//
+// Returns:
+//
+//	string - Две строки: выражение и указатель на место ошибки.
+func (e *ParseError) Caret(expression string) string {
+	pos := min(max(e.Pos, 0), len(expression))
+	column := utf8.RuneCountInString(expression[:pos])
+	return expression + "\n" + strings.Repeat(" ", column) + "^"
+}
+
+// expectedOperand - токены, которые могут стоять на месте операнда.
+var expectedOperand = []string{"число", "имя", operators.ParenLeft, operators.OpSubtract}
+
+// expectedOperator - токен, который должен стоять между двумя операндами.
+const expectedOperator = "оператор"
+
+// expectedUnit - токен, который должен стоять после ключевого слова перевода единиц.
+const expectedUnit = "единица измерения"
+
+// convertKeyword - ключевое слово перевода результата в другую единицу измерения: "5 km/h to m/s".
+const convertKeyword = "to"
+
+// definitionSign - знак между заголовком и телом определения пользовательской функции.
+const definitionSign = "="
+
+// newParseError создает *ParseError для ошибки, обнаруженной на токене.
+//
+// Args:
+//
+//	err: error - Причина ошибки.
+//	tok: token - Ошибочный токен.
+//	expected: ...string - Что ожидалось на месте токена.
+//
+// Returns:
+//
+//	*ParseError - Ошибка с позицией и текстом токена.
+func newParseError(err error, tok token, expected ...string) *ParseError {
+	return &ParseError{Err: err, Pos: tok.pos, Token: tok.text, Expected: expected}
+}
+
+// Грамматика выражений (от низшего приоритета к высшему):
+//
+//	convert     = conditional [ "to" unit ]                  перевод результата всего выражения в другую единицу
+//	conditional = or [ "?" conditional ":" conditional ]     правая ассоциативность: a ? b : c ? d : e = a ? b : (c ? d : e)
+//	or          = and { "||" and }
+//	and         = equality { "&&" equality }
+//	equality    = relation { ("==" | "!=") relation }
+//	relation    = expression { ("<" | "<=" | ">" | ">=") expression }
+//	expression  = term { ("+" | "-") term }                  левая ассоциативность
+//	term        = unary { ("*" | "/" | "%" | "//") unary }    левая ассоциативность, неявное умножение: 2(3+4), 2x
+//	unary       = ("-" | "!") power | power                  унарные операторы слабее степени: -2^2 = -(2^2)
+//	power       = postfix [ "^" unary ]                      правая ассоциативность: 2^3^2 = 2^(3^2)
+//	postfix     = primary { "!" | "%" }                     "%" без правого операнда - процент: 15% = 0.15
+//	primary     = number [ unit ] | name | name "(" [ argument { "," argument } ] ")" | reference | "(" conditional ")"
+//	argument    = conditional | "[" conditional { "," conditional } "]"   список - только у вариативных функций
+//	unit        = factor { ("*" | "/") factor }              обозначения из пакета units: 5 km/h, 9.8 m/s^2
+//	factor      = unit-name [ "^" [ "-" ] integer ]
+//	reference   = "$ref(" id ")"                              результат другого выражения, id - его ID
+//
+// Показатель степени может начинаться с унарного минуса: 2^-1 = 2^(-1).
+// Два унарных минуса подряд ("--5") запрещены, отрицание отрицания записывается со скобками: -(-5).
+// Символ "!" перед операндом - логическое НЕ, после операнда - факториал. Вызов if(c, a, b) разбирается как c ? a : b.
+// Символ "%", за которым не следует операнд, - процент, иначе - остаток от деления: "7 % 3", но "15% + 1".
+// Процент справа от "+" или "-" берется от левого операнда: "a + b%" разбирается как a +% b (operators.OpAddPercent).
+// Поэтому остаток от деления на отрицательное число записывается со скобками: "7 % (-3)".
+// Единица измерения записывается сразу после числа и забирает множители, пока за "*" или "/" стоит обозначение единицы:
+// в "5 km / 2 h" единица числа 5 - только "km". Обозначение, за которым следует "(", - вызов функции: "5 min(1, 2)".
+// Элементы списка становятся аргументами функции: sum([1, 2], 3) разбирается как sum(1, 2, 3).
+//
+// Разбор выполняется методом Пратта: каждому оператору соответствует сила связывания,
+// и оператор забирает правый операнд, пока следующий оператор связывает слабее.
+
+// bindingPower - сила связывания бинарного оператора с операндами.
+type bindingPower struct {
+	left  int // Сила связывания с левым операндом (приоритет оператора).
+	right int // Минимальная сила операторов внутри правого операнда.
+}
+
+// infixPowers - силы связывания бинарных операторов.
+// Для левоассоциативных операторов right равна left, для правоассоциативных - меньше left.
+var infixPowers = map[string]bindingPower{
+	operators.OpAdd:          {left: 10, right: 10},
+	operators.OpSubtract:     {left: 10, right: 10},
+	operators.OpMultiply:     {left: 20, right: 20},
+	operators.OpDivide:       {left: 20, right: 20},
+	operators.OpModulo:       {left: 20, right: 20},
+	operators.OpFloorDivide:  {left: 20, right: 20},
+	operators.OpPower:        {left: 40, right: 39},
+	operators.OpOr:           {left: 4, right: 4},
+	operators.OpAnd:          {left: 6, right: 6},
+	operators.OpEqual:        {left: 7, right: 7},
+	operators.OpNotEqual:     {left: 7, right: 7},
+	operators.OpLess:         {left: 8, right: 8},
+	operators.OpLessEqual:    {left: 8, right: 8},
+	operators.OpGreater:      {left: 8, right: 8},
+	operators.OpGreaterEqual: {left: 8, right: 8},
+}
+
+// percentOperators - операции с процентом от левого операнда для сложения и вычитания.
+var percentOperators = map[string]string{
+	operators.OpAdd:      operators.OpAddPercent,
+	operators.OpSubtract: operators.OpSubtractPercent,
+}
+
+const (
+	// conditionalPower - сила связывания оператора "?", ниже любого бинарного оператора.
+	conditionalPower = 2
+	// unaryMinusPower - минимальная сила операторов внутри операнда унарного минуса и логического НЕ:
+	// степень (40) входит в операнд, умножение (20) - нет.
+	unaryMinusPower = 30
+	// postfixPower - сила связывания постфиксного факториала, выше любого бинарного оператора.
+	postfixPower = 50
+)
+
+// parser - состояние разбора выражения.
+type parser struct {
+	expression string  // Исходное выражение.
+	tokens     []token // Токены выражения.
+	current    int     // Индекс следующего непрочитанного токена.
+}
+
+// Parse разбирает математическое выражение в синтаксическое дерево.
+// Имена переменных и констант остаются в дереве узлами *Ident, их значения подставляются вызывающей стороной.
+// Имя со скобкой, не являющееся встроенной функцией, остается узлом *Call: это вызов пользовательской функции
+// или неявное умножение, например "x(3+4)".
+//
+// Args:
+//
+//	expression: string - Математическое выражение.
+//
+// Returns:
+//
+//	Node - Корень синтаксического дерева.
+//	error - *ParseError с позицией ошибочного токена, если выражение синтаксически неверно.
+func Parse(expression string) (Node, error) {
+	tokens, err := tokenize(expression)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{expression: expression, tokens: tokens}
+
+	node, err := p.parseExpression(0)
+	if err != nil {
+		return nil, err
+	}
+
+	// Перевод результата в другую единицу измерения
+	if p.isConversion() {
+		to, _ := p.next()
+		unitToken := p.peekOrEnd()
+		unit := p.parseUnit()
+		if unit == "" {
+			return nil, newParseError(errUnknownUnit, unitToken, expectedUnit)
+		}
+		node = &Convert{Value: node, Unit: unit, Offset: to.pos}
+	}
+
+	if err := p.finish(); err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+// ParseFunction разбирает определение пользовательской функции "имя(параметры) = тело", например
+// "f(x, y) = x^2 + y^2". Тело разбирается как выражение (см. Parse), но без перевода единиц "to".
+//
+// Args:
+//
+//	definition: string - Определение функции.
+//
+// Returns:
+//
+//	*Definition - Имя, параметры и синтаксическое дерево тела функции.
+//	error - *ParseError с позицией ошибочного токена, если заголовок или тело записаны неверно.
+func ParseFunction(definition string) (*Definition, error) {
+	tokens, err := tokenize(definition)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{expression: definition, tokens: tokens}
+
+	name, ok := p.next()
+	if !ok || !isName(name.text) {
+		return nil, newParseError(errFunctionHead, name, "имя")
+	}
+	if open, ok := p.next(); !ok || open.text != operators.ParenLeft {
+		return nil, newParseError(errFunctionHead, p.lastOrEnd(ok, open), operators.ParenLeft)
+	}
+
+	fn := &Definition{Name: name.text}
+	if next, ok := p.peek(); ok && next.text == operators.ParenRight {
+		p.next() // Функция без параметров, например "answer() = 42"
+	} else {
+		for {
+			param, ok := p.next()
+			if !ok || !isName(param.text) {
+				return nil, newParseError(errFunctionHead, p.lastOrEnd(ok, param), "имя")
+			}
+			if slices.Contains(fn.Params, param.text) {
+				return nil, newParseError(errDuplicateParam, param)
+			}
+			fn.Params = append(fn.Params, param.text)
+
+			tok, ok := p.next()
+			if ok && tok.text == operators.ParenRight {
+				break
+			}
+			if !ok || tok.text != operators.ArgSeparator {
+				return nil, newParseError(errFunctionHead, p.lastOrEnd(ok, tok), operators.ArgSeparator, operators.ParenRight)
+			}
+		}
+	}
+	if equals, ok := p.next(); !ok || equals.text != definitionSign {
+		return nil, newParseError(errFunctionEquals, p.lastOrEnd(ok, equals), definitionSign)
+	}
+
+	if fn.Body, err = p.parseExpression(0); err != nil {
+		return nil, err
+	}
+	if err := p.finish(); err != nil {
+		return nil, err
+	}
+	return fn, nil
+}
+
+// finish проверяет, что выражение разобрано до конца.
+//
+// Returns:
+//
+//	error - *ParseError для первого оставшегося токена, nil - если токенов не осталось.
+func (p *parser) finish() error {
+	tok, ok := p.peek()
+	if !ok {
+		return nil
+	}
+	switch tok.text {
+	case operators.ParenRight:
+		return newParseError(errUnopenedParen, tok)
+	case operators.ArgSeparator:
+		return newParseError(errArgSeparator, tok)
+	case operators.OpColon:
+		return newParseError(errColon, tok)
+	case operators.ListRight:
+		return newParseError(errUnopenedList, tok)
+	default:
+		return newParseError(errInvalidSyntax, tok)
+	}
+}
+
+// lastOrEnd возвращает последний прочитанный токен или пустой токен конца выражения, если токены закончились.
+func (p *parser) lastOrEnd(ok bool, tok token) token {
+	if ok {
+		return tok
+	}
+	return token{pos: len(p.expression)}
+}
+
+// peek возвращает следующий токен, не продвигаясь дальше.
+func (p *parser) peek() (token, bool) {
+	if p.current >= len(p.tokens) {
+		return token{}, false
+	}
+	return p.tokens[p.current], true
+}
+
+// next возвращает следующий токен и продвигается дальше.
+func (p *parser) next() (token, bool) {
+	tok, ok := p.peek()
+	if ok {
+		p.current++
+	}
+	return tok, ok
+}
+
+// peekOrEnd возвращает следующий токен или пустой токен конца выражения.
+func (p *parser) peekOrEnd() token {
+	if tok, ok := p.peek(); ok {
+		return tok
+	}
+	return token{pos: len(p.expression)}
+}
+
+// previous возвращает текст токена, стоящего перед последним прочитанным, или пустую строку.
+func (p *parser) previous() string {
+	if p.current < 2 {
+		return ""
+	}
+	return p.tokens[p.current-2].text
+}
+
+// startsOperand проверяет, может ли токен начинать операнд (число, имя, ссылка или открывающая скобка).
+// Такой токен сразу после операнда означает неявное умножение.
+func startsOperand(tok token) bool {
+	return isNumber(tok.text) || isName(tok.text) || isReference(tok.text) || tok.text == operators.ParenLeft
+}
+
+// parseExpression разбирает выражение, в которое входят только операторы сильнее minPower.
+//
+// Args:
+//
+//	minPower: int - Сила связывания оператора, правый операнд которого разбирается (0 - всё выражение).
+//
+// Returns:
+//
+//	Node - Разобранное выражение.
+//	error - *ParseError, если выражение синтаксически неверно.
+func (p *parser) parseExpression(minPower int) (Node, error) {
+	left, err := p.parsePrefix()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		tok, ok := p.peek()
+		if !ok {
+			return left, nil
+		}
+
+		switch power, isInfix := infixPowers[tok.text]; {
+		case tok.text == operators.OpFactorial: // Постфиксный оператор сразу применяется к операнду слева
+			if postfixPower <= minPower {
+				return left, nil
+			}
+			p.next()
+			left = &Unary{Op: operators.OpFactorial, Operand: left, Offset: tok.pos}
+		case tok.text == operators.OpModulo && p.isPercent(): // Процент - постфиксный оператор, как факториал
+			if postfixPower <= minPower {
+				return left, nil
+			}
+			p.next()
+			left = &Unary{Op: operators.OpPercent, Operand: left, Offset: tok.pos}
+		case tok.text == operators.OpQuestion:
+			if conditionalPower <= minPower {
+				return left, nil
+			}
+			p.next()
+			if left, err = p.parseConditional(left, tok); err != nil {
+				return nil, err
+			}
+		case isInfix:
+			if power.left <= minPower {
+				return left, nil
+			}
+			p.next()
+			right, err := p.parseExpression(power.right)
+			if err != nil {
+				return nil, err
+			}
+			op := tok.text
+			// Процент справа от сложения или вычитания берется от левого операнда
+			if percent, ok := right.(*Unary); ok && percent.Op == operators.OpPercent && percentOperators[op] != "" {
+				op, right = percentOperators[op], percent.Operand
+			}
+			left = &Binary{Op: op, Left: left, Right: right, Offset: tok.pos}
+		case p.isConversion(): // Перевод в единицу измерения завершает всё выражение
+			return left, nil
+		case startsOperand(tok): // Операнд сразу после операнда - неявное умножение
+			power := infixPowers[operators.OpMultiply]
+			if power.left <= minPower {
+				return left, nil
+			}
+			right, err := p.parseExpression(power.right)
+			if err != nil {
+				return nil, err
+			}
+			left = &Binary{Op: operators.OpMultiply, Left: left, Right: right, Implicit: true, Offset: tok.pos}
+		default:
+			// Закрывающая скобка, разделитель или посторонний символ завершают операнд
+			return left, nil
+		}
+	}
+}
+
+// parsePrefix разбирает операнд: число, имя, вызов функции, выражение в скобках или унарный минус с операндом.
+//
+// Returns:
+//
+//	Node - Разобранный операнд.
+//	error - *ParseError, если на месте операнда стоит другой токен.
+func (p *parser) parsePrefix() (Node, error) {
+	tok, ok := p.next()
+	if !ok {
+		return nil, newParseError(errNotEnoughOperands, p.peekOrEnd(), expectedOperand...)
+	}
+
+	if value, err := parseNumber(tok.text); err == nil {
+		return &Number{Value: value, Text: exactNumber(tok.text), Unit: p.parseUnit(), Offset: tok.pos}, nil
+	}
+
+	switch {
+	case tok.text == operators.FnIf:
+		call, err := p.parseCall(tok)
+		if err != nil {
+			return nil, err
+		}
+		args := call.(*Call).Args
+		return &Conditional{Cond: args[0], Then: args[1], Else: args[2], Offset: tok.pos}, nil
+	case operators.IsFunction(tok.text):
+		return p.parseCall(tok)
+	case isName(tok.text):
+		if next, ok := p.peek(); ok && next.text == operators.ParenLeft {
+			return p.parseCall(tok) // Пользовательская функция или неявное умножение, это решает вызывающая сторона
+		}
+		return &Ident{Name: tok.text, Offset: tok.pos}, nil
+	case isReference(tok.text):
+		return &Ref{ID: referenceID(tok.text), Offset: tok.pos}, nil
+	case tok.text == operators.ParenLeft:
+		return p.parseGroup(tok)
+	case tok.text == operators.ListLeft:
+		return nil, newParseError(errList, tok)
+	case tok.text == operators.OpSubtract:
+		next, ok := p.peek()
+		if !ok {
+			return nil, newParseError(errUnaryMinus, p.peekOrEnd(), expectedOperand...)
+		}
+		if next.text == operators.OpSubtract {
+			return nil, newParseError(errUnaryMinus, next) // Два унарных минуса подряд
+		}
+		operand, err := p.parseExpression(unaryMinusPower)
+		if err != nil {
+			return nil, err
+		}
+		return &Unary{Op: operators.OpUnaryMinus, Operand: operand, Offset: tok.pos}, nil
+	case tok.text == operators.OpFactorial: // "!" на месте операнда - логическое НЕ
+		if _, ok := p.peek(); !ok {
+			return nil, newParseError(errLogicalNot, p.peekOrEnd(), expectedOperand...)
+		}
+		if next := p.peekOrEnd(); next.text == operators.ParenRight || next.text == operators.ArgSeparator ||
+			(isOperator(next.text) && next.text != operators.OpSubtract) {
+			return nil, newParseError(errLogicalNot, next, expectedOperand...)
+		}
+		operand, err := p.parseExpression(unaryMinusPower)
+		if err != nil {
+			return nil, err
+		}
+		return &Unary{Op: operators.OpNot, Operand: operand, Offset: tok.pos}, nil
+	case tok.text == operators.ArgSeparator:
+		if prev := p.previous(); prev == operators.ParenLeft || prev == operators.ArgSeparator {
+			return nil, newParseError(errEmptyArgument, tok, expectedOperand...)
+		}
+		return nil, newParseError(errInvalidSyntax, tok, expectedOperand...)
+	case tok.text == operators.ParenRight:
+		if p.previous() == operators.ArgSeparator {
+			return nil, newParseError(errEmptyArgument, tok, expectedOperand...)
+		}
+		return nil, newParseError(errInvalidSyntax, tok, expectedOperand...)
+	case isOperator(tok.text):
+		return nil, newParseError(errInvalidSyntax, tok, expectedOperand...)
+	default:
+		return nil, newParseError(errInvalidSyntax, tok)
+	}
+}
+
+// isPercent проверяет, является ли следующий токен "%" процентом: за ним нет операнда.
+func (p *parser) isPercent() bool {
+	if p.current+1 >= len(p.tokens) {
+		return true
+	}
+	next := p.tokens[p.current+1]
+	return !startsOperand(next) && next.text != operators.OpFactorial
+}
+
+// isConversion проверяет, начинается ли со следующего токена перевод в единицу измерения: "to" и имя после него.
+func (p *parser) isConversion() bool {
+	tok, ok := p.peek()
+	return ok && tok.text == convertKeyword && p.current+1 < len(p.tokens) && isName(p.tokens[p.current+1].text)
+}
+
+// parseUnit разбирает единицу измерения, начинающуюся со следующего токена, например "km/h" или "m/s^2".
+// Разбор останавливается перед "*" или "/", за которыми не следует обозначение единицы.
+//
+// Returns:
+//
+//	string - Запись единицы без пробелов или пустая строка, если следующий токен не начинает единицу.
+func (p *parser) parseUnit() string {
+	end := p.unitFactor(p.current)
+	if end < 0 {
+		return ""
+	}
+	for end < len(p.tokens) {
+		if op := p.tokens[end].text; op != operators.OpMultiply && op != operators.OpDivide {
+			break
+		}
+		next := p.unitFactor(end + 1)
+		if next < 0 {
+			break
+		}
+		end = next
+	}
+
+	var unit strings.Builder
+	for ; p.current < end; p.current++ {
+		unit.WriteString(p.tokens[p.current].text)
+	}
+	return unit.String()
+}
+
+// unitFactor проверяет, начинается ли с токена i множитель единицы измерения: обозначение единицы
+// без открывающей скобки после него и необязательный целый показатель степени "^n" или "^-n".
+//
+// Args:
+//
+//	i: int - Индекс первого токена множителя.
+//
+// Returns:
+//
+//	int - Индекс токена после множителя или -1, если множителя нет.
+func (p *parser) unitFactor(i int) int {
+	if i >= len(p.tokens) || !units.IsUnit(p.tokens[i].text) {
+		return -1
+	}
+	i++
+	if i < len(p.tokens) && p.tokens[i].text == operators.ParenLeft {
+		return -1
+	}
+	if i < len(p.tokens) && p.tokens[i].text == operators.OpPower {
+		exponent := i + 1
+		if exponent < len(p.tokens) && p.tokens[exponent].text == operators.OpSubtract {
+			exponent++
+		}
+		if exponent < len(p.tokens) {
+			if _, err := strconv.Atoi(p.tokens[exponent].text); err == nil {
+				return exponent + 1
+			}
+		}
+	}
+	return i
+}
+
+// parseConditional разбирает ветви условного выражения после оператора "?".
+//
+// Args:
+//
+//	cond: Node - Условие, разобранное перед "?".
+//	question: token - Оператор "?".
+//
+// Returns:
+//
+//	Node - Узел *Conditional.
+//	error - *ParseError, если ветви записаны неверно или отсутствует ":".
+func (p *parser) parseConditional(cond Node, question token) (Node, error) {
+	then, err := p.parseExpression(0)
+	if err != nil {
+		return nil, err
+	}
+	colon, ok := p.next()
+	if !ok {
+		return nil, newParseError(errConditional, p.peekOrEnd(), operators.OpColon)
+	}
+	if colon.text != operators.OpColon {
+		return nil, newParseError(errConditional, colon, operators.OpColon)
+	}
+	// Ветвь "иначе" может содержать следующее условное выражение
+	otherwise, err := p.parseExpression(conditionalPower - 1)
+	if err != nil {
+		return nil, err
+	}
+	return &Conditional{Cond: cond, Then: then, Else: otherwise, Offset: question.pos}, nil
+}
+
+// parseGroup разбирает выражение в скобках после открывающей скобки.
+//
+// Args:
+//
+//	open: token - Открывающая скобка.
+//
+// Returns:
+//
+//	Node - Выражение внутри скобок.
+//	error - *ParseError, если скобка не закрыта или внутри стоит разделитель аргументов.
+func (p *parser) parseGroup(open token) (Node, error) {
+	inner, err := p.parseExpression(0)
+	if err != nil {
+		return nil, err
+	}
+	tok, ok := p.next()
+	switch {
+	case !ok:
+		return nil, newParseError(errUnclosedParen, open, operators.ParenRight)
+	case tok.text == operators.ArgSeparator:
+		return nil, newParseError(errArgSeparator, tok)
+	case tok.text != operators.ParenRight:
+		return nil, newParseError(errInvalidSyntax, tok, operators.ParenRight)
+	}
+	return inner, nil
+}
+
+// parseCall разбирает вызов функции после её имени и проверяет количество аргументов встроенной функции.
+//
+// Args:
+//
+//	fn: token - Имя функции.
+//
+// Returns:
+//
+//	Node - Узел *Call.
+//	error - *ParseError, если вызов записан неверно или количество аргументов недопустимо.
+func (p *parser) parseCall(fn token) (Node, error) {
+	open, ok := p.peek()
+	if !ok || open.text != operators.ParenLeft {
+		return nil, newParseError(errFunctionCall, p.peekOrEnd(), operators.ParenLeft)
+	}
+	p.next()
+
+	call := &Call{Func: fn.text, Offset: fn.pos}
+	if next, ok := p.peek(); ok && next.text == operators.ParenRight {
+		p.next() // Вызов без аргументов, например "sin()"
+	} else {
+		for {
+			// Список - аргументы вариативной функции, записанные одним аргументом
+			if next, ok := p.peek(); ok && next.text == operators.ListLeft && operators.Functions[call.Func].Max == operators.Variadic {
+				p.next()
+				items, err := p.parseList(next)
+				if err != nil {
+					return nil, err
+				}
+				call.Args = append(call.Args, items...)
+			} else {
+				arg, err := p.parseExpression(0)
+				if err != nil {
+					return nil, err
+				}
+				call.Args = append(call.Args, arg)
+			}
+
+			tok, ok := p.next()
+			if !ok {
+				return nil, newParseError(errUnclosedParen, open, operators.ParenRight)
+			}
+			if tok.text == operators.ParenRight {
+				break
+			}
+			if tok.text != operators.ArgSeparator {
+				return nil, newParseError(errInvalidSyntax, tok, operators.ArgSeparator, operators.ParenRight)
+			}
+		}
+	}
+
+	// Количество аргументов пользовательской функции известно только вызывающей стороне
+	if !operators.IsFunction(call.Func) {
+		return call, nil
+	}
+	if err := checkArity(call.Func, len(call.Args)); err != nil {
+		return nil, newParseError(err, fn)
+	}
+	return call, nil
+}
+
+// parseList разбирает элементы списка после открывающей квадратной скобки: "[1, 2, 3]".
+//
+// Args:
+//
+//	open: token - Открывающая квадратная скобка.
+//
+// Returns:
+//
+//	[]Node - Элементы списка.
+//	error - *ParseError, если список пуст, не закрыт или содержит пустой элемент.
+func (p *parser) parseList(open token) ([]Node, error) {
+	var items []Node
+	for {
+		if next, ok := p.peek(); ok && (next.text == operators.ListRight || next.text == operators.ArgSeparator) {
+			if len(items) == 0 && next.text == operators.ListRight {
+				return nil, newParseError(errEmptyList, next, expectedOperand...)
+			}
+			return nil, newParseError(errEmptyListItem, next, expectedOperand...)
+		}
+		item, err := p.parseExpression(0)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+
+		tok, ok := p.next()
+		if !ok {
+			return nil, newParseError(errUnclosedList, open, operators.ListRight)
+		}
+		if tok.text == operators.ListRight {
+			return items, nil
+		}
+		if tok.text != operators.ArgSeparator {
+			return nil, newParseError(errInvalidSyntax, tok, operators.ArgSeparator, operators.ListRight)
+		}
+	}
+}
+
+// checkArity проверяет, что функция вызвана с допустимым количеством аргументов.
+//
+// Args:
+//
+//	name: string - Имя функции.
+//	argCount: int - Количество переданных аргументов.
+//
+// Returns:
+//
+//	error - Ошибка, если количество аргументов недопустимо.
+func checkArity(name string, argCount int) error {
+	arity := operators.Functions[name]
+	if argCount < arity.Min || (arity.Max != operators.Variadic && argCount > arity.Max) {
+		switch {
+		case arity.Max == operators.Variadic:
+			return fmt.Errorf("функция %s принимает не менее %d аргументов, передано %d", name, arity.Min, argCount)
+		case arity.Min == arity.Max:
+			return fmt.Errorf("функция %s принимает %d аргументов, передано %d", name, arity.Min, argCount)
+		default:
+			return fmt.Errorf("функция %s принимает от %d до %d аргументов, передано %d", name, arity.Min, arity.Max, argCount)
+		}
+	}
+	return nil
+}
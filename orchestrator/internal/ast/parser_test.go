@@ -0,0 +1,294 @@
+package ast
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestTokenize проверяет разбиение на токены, включая Unicode-синонимы операторов и числовые литералы.
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		expected   []string
+	}{
+		{"Simple", "2+3", []string{"2", "+", "3"}},
+		{"Spaces separate tokens", " 2 +\t3 ", []string{"2", "+", "3"}},
+		{"Unary plus skipped", "+7-(+3)", []string{"7", "-", "(", "3", ")"}},
+		{"Multiplication sign", "3×4", []string{"3", "*", "4"}},
+		{"Division sign", "8÷2", []string{"8", "/", "2"}},
+		{"Middle dot", "2·3", []string{"2", "*", "3"}},
+		{"Unicode minus", "5−2", []string{"5", "-", "2"}},
+		{"Unicode unary minus", "−5", []string{"-", "5"}},
+		{"Superscript square", "3²", []string{"3", "^", "2"}},
+		{"Superscript cube after paren", "(1+2)³", []string{"(", "1", "+", "2", ")", "^", "3"}},
+		{"Multi-digit superscript", "2¹⁰", []string{"2", "^", "10"}},
+		{"Pi symbol", "2·π", []string{"2", "*", "pi"}},
+		{"Function call", "sin(1)", []string{"sin", "(", "1", ")"}},
+		{"Name with digits", "x1+y_2", []string{"x1", "+", "y_2"}},
+		{"Floor division", "7//2", []string{"7", "//", "2"}},
+		{"Reference", "2$ref(1b4e-28ba_9)+1", []string{"2", "$ref(1b4e-28ba_9)", "+", "1"}},
+		{"Scientific notation", "1e-9+6.02E23", []string{"1e-9", "+", "6.02E23"}},
+		{"Hex and binary", "0xFF-0b1010", []string{"0xFF", "-", "0b1010"}},
+		{"Digit separators", "1_000_000*2", []string{"1_000_000", "*", "2"}},
+		{"Leading point", ".5+1", []string{".5", "+", "1"}},
+		{"Number before e function", "2exp(1)", []string{"2", "exp", "(", "1", ")"}},
+		{"Comparisons", "a<=b>=c==d!=e<f>g", []string{"a", "<=", "b", ">=", "c", "==", "d", "!=", "e", "<", "f", ">", "g"}},
+		{"Logical operators", "!a&&b||c", []string{"!", "a", "&&", "b", "||", "c"}},
+		{"Conditional", "a?-1:+2", []string{"a", "?", "-", "1", ":", "2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens, err := tokenize(tt.expression)
+			assert.NoError(t, err)
+			var texts []string
+			for _, tok := range tokens {
+				texts = append(texts, tok.text)
+			}
+			assert.Equal(t, tt.expected, texts)
+		})
+	}
+}
+
+// TestParse проверяет структуру дерева: приоритет, ассоциативность, неявное умножение и вызовы функций.
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		expected   string
+	}{
+		{"Precedence", "2 + 3 * 4", "(2 + (3 * 4))"},
+		{"Left associativity", "10 - 4 - 3", "((10 - 4) - 3)"},
+		{"Power is right-associative", "2^3^2", "(2 ^ (3 ^ 2))"},
+		{"Unary minus weaker than power", "-2^2", "(-(2 ^ 2))"},
+		{"Unary minus stronger than multiplication", "-2*3", "((-2) * 3)"},
+		{"Negative exponent", "2^-3^2", "(2 ^ (-(3 ^ 2)))"},
+		{"Factorial before power", "2^3!", "(2 ^ (3!))"},
+		{"Factorial before unary minus", "-3!", "(-(3!))"},
+		{"Modulo and floor division", "7 % 3 // 2", "((7 % 3) // 2)"},
+		{"Parentheses", "(1 + 2) * 3", "((1 + 2) * 3)"},
+		{"Implicit: number and paren", "2(3+4)", "(2 * (3 + 4))"},
+		{"Implicit: parens", "(1+2)(3+4)", "((1 + 2) * (3 + 4))"},
+		{"Implicit: paren and number", "(1+2)3", "((1 + 2) * 3)"},
+		{"Implicit: number and function", "2sqrt(4)", "(2 * sqrt(4))"},
+		{"Implicit: number and name", "2x^2", "(2 * (x ^ 2))"},
+		{"Implicit: after unary minus", "-2(3)", "((-2) * 3)"},
+		{"Name and paren is a call", "x(3+4)", "x((3 + 4))"}, // Неявное умножение определяется при подстановке значений
+		{"Reference", "$ref(a-1) * 2", "($ref(a-1) * 2)"},
+		{"Implicit: number and reference", "2$ref(a)", "(2 * $ref(a))"},
+		{"Function arguments", "max(1, 2 + 3, -4)", "max(1, (2 + 3), (-4))"},
+		{"Function without arguments", "sin() + 1", ""},
+		{"Nested calls", "log(sqrt(16), 2)", "log(sqrt(16), 2)"},
+		{"Hex literal value", "0xFF + 1", "(255 + 1)"},
+		{"Comparison weaker than arithmetic", "1 + 2 < 3 * 4", "((1 + 2) < (3 * 4))"},
+		{"Equality weaker than relation", "a < b == c >= d", "((a < b) == (c >= d))"},
+		{"And stronger than or", "a || b && c", "(a || (b && c))"},
+		{"Logical not", "!a && b", "((!a) && b)"},
+		{"Logical not before power", "!x^2", "(!(x ^ 2))"},
+		{"Logical not of negative", "!-5", "(!(-5))"},
+		{"Double logical not", "!!x", "(!(!x))"},
+		{"Factorial and logical not", "!3!", "(!(3!))"},
+		{"Not equal after operand", "5!=5", "(5 != 5)"},
+		{"Conditional", "x > 0 ? x : -x", "((x > 0) ? x : (-x))"},
+		{"Conditional is right-associative", "a ? b : c ? d : e", "(a ? b : (c ? d : e))"},
+		{"Nested conditional in then", "a ? b ? 1 : 2 : 3", "(a ? (b ? 1 : 2) : 3)"},
+		{"Conditional weaker than or", "a || b ? 1 : 2", "((a || b) ? 1 : 2)"},
+		{"Conditional in arguments", "max(a ? 1 : 2, 3)", "max((a ? 1 : 2), 3)"},
+		{"If function", "if(x < 1, 2, 3) + 1", "(((x < 1) ? 2 : 3) + 1)"},
+		{"Unary minus in branch", "a ? -1 : -2", "(a ? (-1) : (-2))"},
+		{"If with two arguments", "if(x, 1)", ""},
+		{"Percent", "15% * 2", "((15%) * 2)"},
+		{"Percent of left operand", "200 + 15%", "(200 +% 15)"},
+		{"Percent subtracted", "200 - 15% + 5", "((200 -% 15) + 5)"},
+		{"Percent before division", "5%/12", "((5%) / 12)"},
+		{"Percent in arguments", "pmt(5%, 10, -x)", "pmt((5%), 10, (-x))"},
+		{"Percent binds to operand only", "200 + 15% * 2", "(200 + ((15%) * 2))"},
+		{"Modulo is not percent", "7 % 3", "(7 % 3)"},
+		{"Modulo by negative in parens", "7 % (-3)", "(7 % (-3))"},
+		{"Percent of exponent", "2^10%", "(2 ^ (10%))"},
+		{"Round with digits", "round(x, 2)", "round(x, 2)"},
+		{"Unit", "3 m + 2 s", "((3 m) + (2 s))"},
+		{"Compound unit", "5 km/h * 2 h", "((5 km/h) * (2 h))"},
+		{"Unit exponent", "9.8 m/s² * 2m^-1", "((9.8 m/s^2) * (2 m^-1))"},
+		{"Unit stops before number", "5 km / 2 h", "((5 km) / (2 h))"},
+		{"Unit before call is a function", "5 min(1, 2)", "(5 * min(1, 2))"},
+		{"Unit exponent must be integer", "2 m^x", "((2 m) ^ x)"},
+		{"Conversion", "5 km/h to m/s", "((5 km/h) to m/s)"},
+		{"Conversion of whole expression", "1 h + 30 min to min", "(((1 h) + (30 min)) to min)"},
+		{"List", "sum([1, 2, 3])", "sum(1, 2, 3)"},
+		{"List and arguments", "max([1, -2], x, [3 m])", "max(1, (-2), x, (3 m))"},
+		{"Single item list", "median([x])", "median(x)"},
+		{"List items are expressions", "avg([a + 1, 15%, sqrt(4)])", "avg((a + 1), (15%), sqrt(4))"},
+		{"List counts for arity", "max([1])", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node, err := Parse(tt.expression)
+			if tt.expected == "" {
+				assert.Error(t, err) // Неверное количество аргументов
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, node.String())
+		})
+	}
+}
+
+// TestParseIdent проверяет, что имя перед скобкой разбирается как вызов функции, а без скобки - как имя.
+func TestParseIdent(t *testing.T) {
+	node, err := Parse("foo(2, x)")
+	assert.NoError(t, err)
+	call, ok := node.(*Call)
+	if assert.True(t, ok) {
+		assert.Equal(t, "foo", call.Func)
+		assert.Len(t, call.Args, 2)
+	}
+
+	node, err = Parse("foo + 2")
+	assert.NoError(t, err)
+	assert.Equal(t, &Ident{Name: "foo", Offset: 0}, node.(*Binary).Left)
+}
+
+// TestParseFunction проверяет разбор определений пользовательских функций.
+func TestParseFunction(t *testing.T) {
+	fn, err := ParseFunction("hyp(x, y) = sqrt(x^2 + y^2)")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "hyp", fn.Name)
+		assert.Equal(t, []string{"x", "y"}, fn.Params)
+		assert.Equal(t, "sqrt(((x ^ 2) + (y ^ 2)))", fn.Body.String())
+	}
+
+	fn, err = ParseFunction("answer() = 42")
+	if assert.NoError(t, err) {
+		assert.Empty(t, fn.Params)
+	}
+
+	tests := []struct {
+		name       string
+		definition string
+		err        error
+		pos        int
+		token      string
+	}{
+		{"Missing name", "(x) = x", errFunctionHead, 0, "("},
+		{"Missing parens", "f = 1", errFunctionHead, 2, "="},
+		{"Number parameter", "f(1) = 1", errFunctionHead, 2, "1"},
+		{"Unclosed parameters", "f(x", errFunctionHead, 3, ""},
+		{"Duplicate parameter", "f(x, x) = x", errDuplicateParam, 5, "x"},
+		{"Missing equals", "f(x) x", errFunctionEquals, 5, "x"},
+		{"Comparison instead of equals", "f(x) == x", errFunctionEquals, 5, "=="},
+		{"Empty body", "f(x) =", errNotEnoughOperands, 6, ""},
+		{"Conversion in body", "f(x) = x m to km", errInvalidSyntax, 11, "to"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseFunction(tt.definition)
+			assert.ErrorIs(t, err, tt.err)
+			var parseErr *ParseError
+			if assert.ErrorAs(t, err, &parseErr) {
+				assert.Equal(t, tt.pos, parseErr.Pos)
+				assert.Equal(t, tt.token, parseErr.Token)
+			}
+		})
+	}
+}
+
+// TestParseNumberText проверяет точную запись числовых литералов.
+func TestParseNumberText(t *testing.T) {
+	tests := map[string]string{
+		"0.1":                     "0.1",
+		"1_000_000":               "1000000",
+		"0xFF":                    "255",
+		"0b1010":                  "10",
+		"6.02E23":                 "6.02E23",
+		"12345678901234567890123": "12345678901234567890123",
+	}
+	for literal, expected := range tests {
+		node, err := Parse(literal)
+		if assert.NoError(t, err, literal) {
+			assert.Equal(t, expected, node.(*Number).Text, literal)
+		}
+	}
+}
+
+// TestParseErrors проверяет позицию, токен и ожидаемые токены синтаксических ошибок.
+func TestParseErrors(t *testing.T) {
+	operand := []string{"число", "имя", "(", "-"}
+	tests := []struct {
+		name       string
+		expression string
+		err        error
+		pos        int
+		token      string
+		expected   []string
+	}{
+		{"Operator instead of operand", "2 + * 3", errInvalidSyntax, 4, "*", operand},
+		{"Unclosed paren points to open paren", "2 + (3 * 4", errUnclosedParen, 4, "(", []string{")"}},
+		{"Unclosed call", "sin(1", errUnclosedParen, 3, "(", []string{")"}},
+		{"Unopened paren", "2 + 3) * 4", errUnopenedParen, 5, ")", nil},
+		{"Empty parens", "()", errInvalidSyntax, 1, ")", operand},
+		{"Unexpected end", "2 +", errNotEnoughOperands, 3, "", operand},
+		{"Empty expression", "", errNotEnoughOperands, 0, "", operand},
+		{"Lone unary minus", "-", errUnaryMinus, 1, "", operand},
+		{"Double unary minus", "--5", errUnaryMinus, 1, "-", nil},
+		{"Function without paren", "sqrt + 4", errFunctionCall, 5, "+", []string{"("}},
+		{"Empty argument", "max(1,,2)", errEmptyArgument, 6, ",", operand},
+		{"Trailing separator", "max(1,2,)", errEmptyArgument, 8, ")", operand},
+		{"Separator outside call", "(1, 2) + 3", errArgSeparator, 2, ",", nil},
+		{"Separator at top level", "1, 2", errArgSeparator, 1, ",", nil},
+		{"Unknown character", "2 & 3", errInvalidSyntax, 2, "&", nil},
+		{"Numbers separated by space", "1 000", errInvalidSyntax, 2, "000", []string{"оператор"}},
+		{"Logical not without operand", "(!)", errLogicalNot, 2, ")", operand},
+		{"Logical not at end", "1 + !", errLogicalNot, 5, "", operand},
+		{"Conditional without colon", "x ? 1", errConditional, 5, "", []string{":"}},
+		{"Conditional with wrong separator", "x ? 1 , 2", errConditional, 6, ",", []string{":"}},
+		{"Colon without question", "1 : 2", errColon, 2, ":", nil},
+		{"Single equals sign", "x = 1", errInvalidSyntax, 2, "=", nil},
+		{"Byte offset after multibyte runes", "π × (2 + )", errInvalidSyntax, 11, ")", operand},
+		{"Unknown conversion unit", "5 km to furlong", errUnknownUnit, 8, "furlong", []string{"единица измерения"}},
+		{"Conversion inside parens", "(5 km to m) + 1", errInvalidSyntax, 6, "to", []string{")"}},
+		{"List outside call", "[1, 2] + 3", errList, 0, "[", nil},
+		{"List of fixed arity function", "sin([1])", errList, 4, "[", nil},
+		{"Nested list", "sum([[1], 2])", errList, 5, "[", nil},
+		{"Unclosed list", "sum([1, 2)", errInvalidSyntax, 9, ")", []string{",", "]"}},
+		{"Unclosed list at end", "sum([1, 2", errUnclosedList, 4, "[", []string{"]"}},
+		{"Unopened list", "1 + 2]", errUnopenedList, 5, "]", nil},
+		{"Empty list", "sum([])", errEmptyList, 5, "]", operand},
+		{"Empty list item", "sum([1, , 2])", errEmptyListItem, 8, ",", operand},
+		{"Dollar without reference", "1 + $x", errReference, 4, "$", nil},
+		{"Empty reference", "$ref()", errReference, 0, "$", nil},
+		{"Unclosed reference", "$ref(abc", errReference, 0, "$", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.expression)
+			assert.ErrorIs(t, err, tt.err)
+			var parseErr *ParseError
+			if assert.ErrorAs(t, err, &parseErr) {
+				assert.Equal(t, tt.pos, parseErr.Pos)
+				assert.Equal(t, tt.token, parseErr.Token)
+				assert.Equal(t, tt.expected, parseErr.Expected)
+			}
+		})
+	}
+}
+
+// TestParseErrorCaret проверяет указатель на место ошибки с учетом многобайтовых символов.
+func TestParseErrorCaret(t *testing.T) {
+	expression := "π × (2 + )"
+	_, err := Parse(expression)
+	var parseErr *ParseError
+	if assert.ErrorAs(t, err, &parseErr) {
+		assert.Equal(t, "π × (2 + )\n         ^", parseErr.Caret(expression))
+	}
+
+	expression = "2 +"
+	_, err = Parse(expression)
+	if assert.ErrorAs(t, err, &parseErr) {
+		assert.Equal(t, "2 +\n   ^", parseErr.Caret(expression))
+	}
+}
@@ -0,0 +1,507 @@
+package functions
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/OinkiePie/calc_2/orchestrator/internal/ast"
+	"github.com/OinkiePie/calc_2/pkg/constants"
+	"github.com/OinkiePie/calc_2/pkg/evaluator"
+	"github.com/OinkiePie/calc_2/pkg/operators"
+)
+
+var (
+	errBuiltinName   = errors.New("имя занято встроенной функцией")
+	errConstantName  = errors.New("имя занято константой")
+	errUnknownName   = errors.New("неизвестное имя в теле функции")
+	errReference     = errors.New("ссылка на выражение недоступна в теле функции")
+	errRecursion     = errors.New("рекурсивное определение функции")
+	errRecursiveCall = errors.New("рекурсивный вызов функции")
+	errArity         = errors.New("неверное количество аргументов функции")
+	errTooManyCalls  = errors.New("слишком много вызовов пользовательских функций")
+)
+
+// maxExpansions - наибольшее количество подстановок пользовательских функций в одно выражение.
+const maxExpansions = 10000
+
+// maxExpandedNodes - наибольшее количество узлов, создаваемых подстановкой функций в одно выражение.
+// Параметр, который встречается в теле несколько раз, копирует аргумент, поэтому вложенные вызовы
+// (например, f(f(f(y))) при f(x) = x + x) удваивают размер дерева на каждом уровне даже при малом числе подстановок.
+const maxExpandedNodes = 10000
+
+// imaginaryUnit - имя мнимой единицы, которое в комплексном режиме подставляет разбиватель на задачи.
+const imaginaryUnit = "i"
+
+// Function - версия пользовательской функции. После создания не изменяется,
+// поэтому версии можно передавать в разбиватель на задачи без копирования.
+type Function struct {
+	// Name - Имя функции.
+	Name string
+	// Params - Имена параметров в порядке записи.
+	Params []string
+	// Body - Тело функции, в котором константы заменены их значениями, а имена остались только у параметров.
+	// Смещения узлов считаются от начала определения.
+	Body ast.Node
+	// Definition - Исходное определение, например "f(x, y) = x^2 + y^2".
+	Definition string
+	// Version - Номер версии, начиная с 1. Каждое новое определение функции с тем же именем - следующая версия.
+	Version int
+	// CreatedAt - Время регистрации версии.
+	CreatedAt time.Time
+}
+
+// Registry - хранилище пользовательских функций. Функции каждого пользователя хранятся отдельно,
+// все версии функции сохраняются. Выражения разворачивают последние версии функций в момент добавления,
+// поэтому новая версия функции не меняет уже добавленные выражения.
+type Registry struct {
+	// functions - Версии функций: пользователь -> имя функции -> версии по возрастанию номера.
+	functions map[string]map[string][]*Function
+	// versions - Последний выданный номер версии: пользователь -> имя функции -> номер. Не сбрасывается при удалении
+	// функции, поэтому номер версии однозначно определяет определение функции и после её повторного создания.
+	versions map[string]map[string]int
+	// mu - Mutex для защиты map от конкурентного доступа.
+	mu sync.RWMutex
+}
+
+// NewRegistry - конструктор для Registry. Создает пустое хранилище функций.
+//
+// Returns:
+//
+//	*Registry - Указатель на новый экземпляр Registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		functions: make(map[string]map[string][]*Function),
+		versions:  make(map[string]map[string]int),
+	}
+}
+
+// Define регистрирует функцию пользователя или новую версию функции с тем же именем.
+// В теле функции допустимы параметры, константы, встроенные функции и другие пользовательские функции,
+// в том числе ещё не зарегистрированные. Функция не может вызывать саму себя, прямо или через другие функции.
+//
+// Args:
+//
+//	user: string - Идентификатор пользователя, пустая строка - общее пространство имен.
+//	definition: string - Определение функции, например "f(x, y) = x^2 + y^2".
+//
+// Returns:
+//
+//	*Function - Зарегистрированная версия функции.
+//	error - *ast.ParseError с позицией в определении, если определение записано неверно, имя функции занято
+//	        встроенной функцией или константой, в теле есть неизвестное имя или определение рекурсивно.
+func (r *Registry) Define(user, definition string) (*Function, error) {
+	parsed, err := ast.ParseFunction(definition)
+	if err != nil {
+		return nil, err
+	}
+	if operators.IsFunction(parsed.Name) {
+		return nil, &ast.ParseError{Err: fmt.Errorf("%w: %s", errBuiltinName, parsed.Name), Pos: 0, Token: parsed.Name}
+	}
+	if _, ok := constants.Lookup(parsed.Name); ok {
+		return nil, &ast.ParseError{Err: fmt.Errorf("%w: %s", errConstantName, parsed.Name), Pos: 0, Token: parsed.Name}
+	}
+	body, err := resolve(parsed.Body, parsed.Params)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	functions := r.functions[user]
+	if functions == nil {
+		functions = make(map[string][]*Function)
+		r.functions[user] = functions
+	}
+	fn := &Function{
+		Name:       parsed.Name,
+		Params:     parsed.Params,
+		Body:       body,
+		Definition: definition,
+		Version:    r.versions[user][parsed.Name] + 1,
+		CreatedAt:  time.Now(),
+	}
+
+	latest := latestVersions(functions)
+	latest[fn.Name] = fn
+	if cycle := findCycle(fn, latest, []string{fn.Name}); cycle != nil {
+		err := fmt.Errorf("%w: %s", errRecursion, strings.Join(cycle, " -> "))
+		return nil, &ast.ParseError{Err: err, Pos: 0, Token: fn.Name}
+	}
+
+	if r.versions[user] == nil {
+		r.versions[user] = make(map[string]int)
+	}
+	r.versions[user][fn.Name] = fn.Version
+	functions[fn.Name] = append(functions[fn.Name], fn)
+	return fn, nil
+}
+
+// Functions возвращает последние версии функций пользователя.
+//
+// Args:
+//
+//	user: string - Идентификатор пользователя.
+//
+// Returns:
+//
+//	map[string]*Function - Последние версии функций по именам. Изменение map не влияет на хранилище.
+func (r *Registry) Functions(user string) map[string]*Function {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return latestVersions(r.functions[user])
+}
+
+// List возвращает последние версии функций пользователя, упорядоченные по имени.
+//
+// Args:
+//
+//	user: string - Идентификатор пользователя.
+//
+// Returns:
+//
+//	[]*Function - Последние версии функций.
+func (r *Registry) List(user string) []*Function {
+	latest := r.Functions(user)
+	list := make([]*Function, 0, len(latest))
+	for _, fn := range latest {
+		list = append(list, fn)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+// Versions возвращает все версии функции пользователя.
+//
+// Args:
+//
+//	user: string - Идентификатор пользователя.
+//	name: string - Имя функции.
+//
+// Returns:
+//
+//	[]*Function - Версии функции по возрастанию номера.
+//	bool - true, если функция найдена, иначе false.
+func (r *Registry) Versions(user, name string) ([]*Function, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	versions, ok := r.functions[user][name]
+	return slices.Clone(versions), ok
+}
+
+// Delete удаляет функцию пользователя со всеми версиями. Уже добавленные выражения, использующие функцию,
+// вычисляются как обычно, а новые выражения с её вызовом получают ошибку неизвестной функции.
+// Нумерация версий функции с тем же именем, созданной после удаления, продолжается.
+//
+// Args:
+//
+//	user: string - Идентификатор пользователя.
+//	name: string - Имя функции.
+//
+// Returns:
+//
+//	bool - true, если функция была удалена, false - если её не было.
+func (r *Registry) Delete(user, name string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.functions[user][name]; !ok {
+		return false
+	}
+	delete(r.functions[user], name)
+	return true
+}
+
+// latestVersions возвращает последние версии функций. Вызывается под блокировкой mu.
+func latestVersions(functions map[string][]*Function) map[string]*Function {
+	latest := make(map[string]*Function, len(functions))
+	for name, versions := range functions {
+		latest[name] = versions[len(versions)-1]
+	}
+	return latest
+}
+
+// Expand подставляет пользовательские функции вместо их вызовов: параметры тела заменяются аргументами вызова.
+// Вызовы встроенных функций и вызовы имен, которых нет среди функций (неявное умножение "x(3+4)"
+// или неизвестная функция), остаются в дереве. Подставленные узлы тела получают смещение вызова,
+// поэтому ошибки в них указывают на вызов функции в выражении.
+//
+// Args:
+//
+//	node: ast.Node - Синтаксическое дерево выражения.
+//	functions: map[string]*Function - Функции пользователя (может быть nil).
+//
+// Returns:
+//
+//	ast.Node - Дерево без вызовов пользовательских функций.
+//	map[string]int - Версии подставленных функций по именам, nil - если функции не вызывались.
+//	error - *ast.ParseError с позицией вызова, если количество аргументов неверно, вызов рекурсивен
+//	        или подстановок слишком много.
+func Expand(node ast.Node, functions map[string]*Function) (ast.Node, map[string]int, error) {
+	if len(functions) == 0 {
+		return node, nil, nil
+	}
+	e := &expander{functions: functions}
+	expanded, err := e.expand(node)
+	if err != nil {
+		return nil, nil, err
+	}
+	return expanded, e.used, nil
+}
+
+// expander хранит состояние подстановки функций в одно выражение.
+type expander struct {
+	functions  map[string]*Function // Функции пользователя.
+	used       map[string]int       // Версии подставленных функций.
+	stack      []string             // Функции, тела которых подставляются сейчас, для обнаружения рекурсии.
+	call       *ast.Call            // Вызов из исходного выражения, тело которого подставляется сейчас.
+	expansions int                  // Количество выполненных подстановок.
+	nodes      int                  // Количество узлов, созданных подстановками.
+}
+
+// expand подставляет функции в дерево, начиная с аргументов вызовов.
+func (e *expander) expand(node ast.Node) (ast.Node, error) {
+	return rewrite(node, func(node ast.Node) (ast.Node, error) {
+		// Узлы внутри подставленного тела - копии тела функции и аргументов вызова
+		if len(e.stack) > 0 {
+			if e.nodes++; e.nodes > maxExpandedNodes {
+				err := fmt.Errorf("%w: подстановка создает больше %d узлов", errTooManyCalls, maxExpandedNodes)
+				return nil, &ast.ParseError{Err: err, Pos: e.call.Offset, Token: e.call.Func}
+			}
+		}
+		call, ok := node.(*ast.Call)
+		if !ok {
+			return node, nil
+		}
+		fn, ok := e.functions[call.Func]
+		if !ok || operators.IsFunction(call.Func) {
+			return node, nil
+		}
+
+		if slices.Contains(e.stack, fn.Name) {
+			cycle := strings.Join(append(e.stack, fn.Name), " -> ")
+			return nil, &ast.ParseError{Err: fmt.Errorf("%w: %s", errRecursiveCall, cycle), Pos: call.Offset, Token: call.Func}
+		}
+		if len(call.Args) != len(fn.Params) {
+			err := fmt.Errorf("%w: %s принимает %d, передано %d", errArity, fn.Name, len(fn.Params), len(call.Args))
+			return nil, &ast.ParseError{Err: err, Pos: call.Offset, Token: call.Func}
+		}
+		if e.expansions++; e.expansions > maxExpansions {
+			err := fmt.Errorf("%w: больше %d", errTooManyCalls, maxExpansions)
+			return nil, &ast.ParseError{Err: err, Pos: call.Offset, Token: call.Func}
+		}
+		if e.used == nil {
+			e.used = make(map[string]int)
+		}
+		e.used[fn.Name] = fn.Version
+
+		body, err := substitute(fn, call)
+		if err != nil {
+			return nil, err
+		}
+		// Тело может вызывать другие функции
+		if len(e.stack) == 0 {
+			e.call = call
+		}
+		e.stack = append(e.stack, fn.Name)
+		defer func() { e.stack = e.stack[:len(e.stack)-1] }()
+		return e.expand(body)
+	})
+}
+
+// substitute возвращает тело функции, в котором параметры заменены аргументами вызова,
+// а остальные узлы получили смещение вызова.
+func substitute(fn *Function, call *ast.Call) (ast.Node, error) {
+	return rewrite(fn.Body, func(node ast.Node) (ast.Node, error) {
+		if ident, ok := node.(*ast.Ident); ok {
+			if i := slices.Index(fn.Params, ident.Name); i >= 0 {
+				return call.Args[i], nil
+			}
+		}
+		setOffset(node, call.Offset)
+		return node, nil
+	})
+}
+
+// resolve проверяет имена в теле функции и заменяет константы их значениями. Параметры и мнимая единица i
+// остаются именами, а имя параметра или константы со скобкой или после числа - неявным умножением: "f(a) = a(a+1) + 2a".
+//
+// Args:
+//
+//	body: ast.Node - Тело функции.
+//	params: []string - Имена параметров.
+//
+// Returns:
+//
+//	ast.Node - Тело функции с подставленными константами.
+//	error - *ast.ParseError, если в теле есть имя, не являющееся параметром или константой, или ссылка на выражение.
+func resolve(body ast.Node, params []string) (ast.Node, error) {
+	return rewrite(body, func(node ast.Node) (ast.Node, error) {
+		switch n := node.(type) {
+		case *ast.Number:
+			if !slices.Contains(params, n.Unit) {
+				return n, nil
+			}
+			number := *n
+			number.Unit = ""
+			name := &ast.Ident{Name: n.Unit, Offset: n.Offset}
+			return &ast.Binary{Op: operators.OpMultiply, Left: &number, Right: name, Implicit: true, Offset: n.Offset}, nil
+		case *ast.Ident:
+			if slices.Contains(params, n.Name) || n.Name == imaginaryUnit {
+				return n, nil
+			}
+			if value, ok := constants.Lookup(n.Name); ok {
+				return &ast.Number{Value: value, Text: evaluator.FormatFloat(value), Offset: n.Offset}, nil
+			}
+			return nil, &ast.ParseError{Err: fmt.Errorf("%w: %s", errUnknownName, n.Name), Pos: n.Offset, Token: n.Name}
+		case *ast.Ref:
+			return nil, &ast.ParseError{Err: errReference, Pos: n.Offset, Token: n.String()}
+		case *ast.Call:
+			if operators.IsFunction(n.Func) || len(n.Args) != 1 {
+				return n, nil
+			}
+			var factor ast.Node
+			if slices.Contains(params, n.Func) {
+				factor = &ast.Ident{Name: n.Func, Offset: n.Offset}
+			} else if value, ok := constants.Lookup(n.Func); ok {
+				factor = &ast.Number{Value: value, Text: evaluator.FormatFloat(value), Offset: n.Offset}
+			} else {
+				return n, nil // Вызов пользовательской функции
+			}
+			return &ast.Binary{Op: operators.OpMultiply, Left: factor, Right: n.Args[0], Implicit: true, Offset: n.Args[0].Pos()}, nil
+		}
+		return node, nil
+	})
+}
+
+// findCycle ищет цепочку вызовов пользовательских функций, которая начинается в теле функции
+// и возвращается к первой функции пути.
+//
+// Args:
+//
+//	fn: *Function - Функция, вызовы которой проверяются.
+//	functions: map[string]*Function - Последние версии функций пользователя.
+//	path: []string - Цепочка вызовов от проверяемой функции до fn.
+//
+// Returns:
+//
+//	[]string - Цепочка вызовов, замыкающаяся на первой функции пути, или nil, если рекурсии нет.
+func findCycle(fn *Function, functions map[string]*Function, path []string) []string {
+	var cycle []string
+	_, _ = rewrite(fn.Body, func(node ast.Node) (ast.Node, error) {
+		call, ok := node.(*ast.Call)
+		if !ok || cycle != nil {
+			return node, nil
+		}
+		if call.Func == path[0] {
+			cycle = append(slices.Clone(path), call.Func)
+			return node, nil
+		}
+		callee, ok := functions[call.Func]
+		if ok && !operators.IsFunction(call.Func) && !slices.Contains(path, call.Func) {
+			cycle = findCycle(callee, functions, append(path, call.Func))
+		}
+		return node, nil
+	})
+	return cycle
+}
+
+// rewrite обходит дерево снизу вверх и заменяет каждый узел результатом visit.
+// Узлы копируются перед вызовом visit, поэтому visit может изменять полученный узел, не затрагивая исходное дерево.
+//
+// Args:
+//
+//	node: ast.Node - Корень дерева.
+//	visit: func(ast.Node) (ast.Node, error) - Замена узла, дочерние узлы которого уже заменены.
+//
+// Returns:
+//
+//	ast.Node - Новое дерево.
+//	error - Первая ошибка visit.
+func rewrite(node ast.Node, visit func(ast.Node) (ast.Node, error)) (ast.Node, error) {
+	var err error
+	switch n := node.(type) {
+	case *ast.Number:
+		copied := *n
+		return visit(&copied)
+	case *ast.Ident:
+		copied := *n
+		return visit(&copied)
+	case *ast.Unary:
+		copied := *n
+		if copied.Operand, err = rewrite(n.Operand, visit); err != nil {
+			return nil, err
+		}
+		return visit(&copied)
+	case *ast.Binary:
+		copied := *n
+		if copied.Left, err = rewrite(n.Left, visit); err != nil {
+			return nil, err
+		}
+		if copied.Right, err = rewrite(n.Right, visit); err != nil {
+			return nil, err
+		}
+		return visit(&copied)
+	case *ast.Conditional:
+		copied := *n
+		if copied.Cond, err = rewrite(n.Cond, visit); err != nil {
+			return nil, err
+		}
+		if copied.Then, err = rewrite(n.Then, visit); err != nil {
+			return nil, err
+		}
+		if copied.Else, err = rewrite(n.Else, visit); err != nil {
+			return nil, err
+		}
+		return visit(&copied)
+	case *ast.Call:
+		copied := *n
+		copied.Args = make([]ast.Node, len(n.Args))
+		for i, arg := range n.Args {
+			if copied.Args[i], err = rewrite(arg, visit); err != nil {
+				return nil, err
+			}
+		}
+		return visit(&copied)
+	case *ast.Convert:
+		copied := *n
+		if copied.Value, err = rewrite(n.Value, visit); err != nil {
+			return nil, err
+		}
+		return visit(&copied)
+	case *ast.Ref:
+		copied := *n
+		return visit(&copied)
+	default:
+		return visit(node)
+	}
+}
+
+// setOffset устанавливает смещение узла.
+func setOffset(node ast.Node, offset int) {
+	switch n := node.(type) {
+	case *ast.Number:
+		n.Offset = offset
+	case *ast.Ident:
+		n.Offset = offset
+	case *ast.Unary:
+		n.Offset = offset
+	case *ast.Binary:
+		n.Offset = offset
+	case *ast.Conditional:
+		n.Offset = offset
+	case *ast.Call:
+		n.Offset = offset
+	case *ast.Convert:
+		n.Offset = offset
+	case *ast.Ref:
+		n.Offset = offset
+	}
+}
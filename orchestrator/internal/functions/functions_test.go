@@ -0,0 +1,137 @@
+package functions_test
+
+import (
+	"errors"
+	"io"
+	"log"
+	"testing"
+
+	"github.com/OinkiePie/calc_2/config"
+	"github.com/OinkiePie/calc_2/orchestrator/internal/ast"
+	"github.com/OinkiePie/calc_2/orchestrator/internal/functions"
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	// Отключаем выводы и инициализируем конфиг
+	log.SetOutput(io.Discard)
+	config.InitConfig()
+}
+
+func TestDefine(t *testing.T) {
+	tests := []struct {
+		name       string
+		definition string
+		body       string
+		err        string
+	}{
+		{"Two parameters", "f(x, y) = x^2 + y^2", "((x ^ 2) + (y ^ 2))", ""},
+		{"Constant is resolved", "area(r) = pi*r^2", "(3.141592653589793 * (r ^ 2))", ""},
+		{"Parameter with paren is multiplication", "f(a) = a(a+1)", "(a * (a + 1))", ""},
+		{"Call of other function", "f(x) = g(x) + 1", "(g(x) + 1)", ""},
+		{"Builtin name", "sin(x) = x", "", "имя занято встроенной функцией: sin"},
+		{"Constant name", "pi(x) = x", "", "имя занято константой: pi"},
+		{"Unknown name", "f(x) = x + y", "", "неизвестное имя в теле функции: y"},
+		{"Direct recursion", "f(x) = f(x - 1)", "", "рекурсивное определение функции: f -> f"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fn, err := functions.NewRegistry().Define("", tt.definition)
+			if tt.err != "" {
+				var parseErr *ast.ParseError
+				assert.True(t, errors.As(err, &parseErr))
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.body, fn.Body.String())
+			assert.Equal(t, 1, fn.Version)
+		})
+	}
+}
+
+func TestRegistryVersions(t *testing.T) {
+	registry := functions.NewRegistry()
+
+	_, err := registry.Define("alice", "f(x) = x + 1")
+	assert.NoError(t, err)
+	second, err := registry.Define("alice", "f(x) = x + 2")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, second.Version)
+
+	// Функции разных пользователей не пересекаются
+	assert.Empty(t, registry.Functions("bob"))
+	assert.Same(t, second, registry.Functions("alice")["f"])
+
+	versions, ok := registry.Versions("alice", "f")
+	assert.True(t, ok)
+	assert.Len(t, versions, 2)
+
+	// Рекурсия через другую функцию обнаруживается по последним версиям
+	_, err = registry.Define("alice", "g(x) = f(x) * 2")
+	assert.NoError(t, err)
+	_, err = registry.Define("alice", "f(x) = g(x)")
+	assert.EqualError(t, err, "рекурсивное определение функции: f -> g -> f")
+
+	assert.True(t, registry.Delete("alice", "f"))
+	assert.False(t, registry.Delete("alice", "f"))
+	_, ok = registry.Versions("alice", "f")
+	assert.False(t, ok)
+
+	// После удаления нумерация версий продолжается
+	_, err = registry.Define("alice", "h(x) = x")
+	assert.NoError(t, err)
+	assert.True(t, registry.Delete("alice", "h"))
+	redefined, err := registry.Define("alice", "h(x) = x * 2")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, redefined.Version)
+	versions, _ = registry.Versions("alice", "h")
+	assert.Len(t, versions, 1)
+}
+
+func TestExpand(t *testing.T) {
+	registry := functions.NewRegistry()
+	for _, definition := range []string{"f(x, y) = x^2 + y^2", "g(x) = f(x, 1) * 2", "h(x) = k(x)", "d(x) = x + x"} {
+		_, err := registry.Define("", definition)
+		assert.NoError(t, err)
+	}
+	fns := registry.Functions("")
+
+	tests := []struct {
+		name       string
+		expression string
+		expected   string
+		used       map[string]int
+		err        string
+	}{
+		{"Arguments are substituted", "f(a+1, 2)", "(((a + 1) ^ 2) + (2 ^ 2))", map[string]int{"f": 1}, ""},
+		{"Nested functions", "g(3) - 1", "((((3 ^ 2) + (1 ^ 2)) * 2) - 1)", map[string]int{"f": 1, "g": 1}, ""},
+		{"Function in argument", "f(f(1, 2), 0)", "((((1 ^ 2) + (2 ^ 2)) ^ 2) + (0 ^ 2))", map[string]int{"f": 1}, ""},
+		{"Builtins are kept", "sin(x) + x(2)", "(sin(x) + x(2))", nil, ""},
+		{"Wrong arity", "1 + f(2)", "", nil, "неверное количество аргументов функции: f принимает 2, передано 1"},
+		{"Undefined callee is kept", "h(2)", "k(2)", map[string]int{"h": 1}, ""},
+		{"Doubling nested calls", "1 + d(d(d(d(d(d(d(d(d(d(d(d(y))))))))))))", "", nil,
+			"слишком много вызовов пользовательских функций: подстановка создает больше 10000 узлов"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tree, err := ast.Parse(tt.expression)
+			assert.NoError(t, err)
+
+			expanded, used, err := functions.Expand(tree, fns)
+			if tt.err != "" {
+				var parseErr *ast.ParseError
+				if assert.True(t, errors.As(err, &parseErr)) {
+					assert.Equal(t, 4, parseErr.Pos)
+				}
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, expanded.String())
+			assert.Equal(t, tt.used, used)
+		})
+	}
+}
@@ -2,19 +2,34 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"io"
+	"math"
 	"net/http"
 	"strings"
+	"unicode"
 
+	"github.com/OinkiePie/calc_2/orchestrator/internal/ast"
+	"github.com/OinkiePie/calc_2/orchestrator/internal/functions"
 	"github.com/OinkiePie/calc_2/orchestrator/internal/task_manager"
+	"github.com/OinkiePie/calc_2/orchestrator/internal/task_splitter"
+	"github.com/OinkiePie/calc_2/orchestrator/internal/workbooks"
+	"github.com/OinkiePie/calc_2/pkg/constants"
 	"github.com/OinkiePie/calc_2/pkg/logger"
 	"github.com/OinkiePie/calc_2/pkg/models"
 	"github.com/gorilla/mux"
 )
 
-// Handlers - структура для обработчиков запросов, зависит от TaskManager
+// userHeader - заголовок запроса с идентификатором пользователя, которому принадлежат пользовательские функции.
+// Запросы без заголовка используют общее пространство имен.
+const userHeader = "X-User-ID"
+
+// Handlers - структура для обработчиков запросов, зависит от TaskManager, хранилища пользовательских функций
+// и рабочих книг
 type Handlers struct {
 	taskManager *task_manager.TaskManager
+	functions   *functions.Registry
+	workbooks   *workbooks.Workbooks
 }
 
 // NewOrchestratorHandlers - конструктор для структуры Handlers.
@@ -23,18 +38,22 @@ type Handlers struct {
 //
 //	tm: *task_manager.TaskManager - Указатель на экземпляр TaskManager.
 //	    Необходимо передать уже инициализированный экземпляр TaskManager.
+//	registry: *functions.Registry - Хранилище пользовательских функций.
+//	books: *workbooks.Workbooks - Хранилище рабочих книг, формулы которых вычисляет tm.
 //
 // Returns:
 //
 //	*Handlers - Указатель на новый экземпляр структуры Handlers.
-func NewOrchestratorHandlers(tm *task_manager.TaskManager) *Handlers {
-	return &Handlers{taskManager: tm}
+func NewOrchestratorHandlers(tm *task_manager.TaskManager, registry *functions.Registry, books *workbooks.Workbooks) *Handlers {
+	return &Handlers{taskManager: tm, functions: registry, workbooks: books}
 }
 
 // AddExpressionHandler обрабатывает POST-запросы на эндпоинт /api/v1/calculate.
 //
-// Функция принимает JSON-запрос, содержащий математическое выражение в строковом формате,
-// передает выражение в TaskManager для обработки и сохранения, и возвращает ID созданного выражения.
+// Функция принимает JSON-запрос, содержащий математическое выражение в строковом формате
+// и (необязательно) значения переменных, передает выражение в TaskManager для обработки
+// и сохранения, и возвращает ID созданного выражения. В выражении доступны пользовательские функции
+// пользователя из заголовка X-User-ID (см. AddFunctionHandler).
 //
 // Args:
 //
@@ -44,7 +63,9 @@ func NewOrchestratorHandlers(tm *task_manager.TaskManager) *Handlers {
 // Request body (JSON):
 //
 //	{
-//		"expression": "строка с математическим выражением"
+//		"expression": "строка с математическим выражением",
+//		"variables": {"имя": число, ...}, // может отсутствовать
+//		"precision": "float64, decimal, rational, bigfloat:N или complex" // может отсутствовать, по умолчанию float64
 //	}
 //
 // Responses:
@@ -77,6 +98,22 @@ func NewOrchestratorHandlers(tm *task_manager.TaskManager) *Handlers {
 //		"error": "Содержание ошибки при добавлении выражения в TaskManager"
 //	}
 //
+//	{
+//		"error": "выражение по ссылке не найдено: id" // выражение ссылается на несуществующее выражение через $ref(id)
+//	}
+//
+//	{
+//		"error": "не заданы значения переменных: a, b",
+//		"unbound": ["a", "b"]
+//	}
+//
+//	{
+//		"error": "неверный синтаксис",
+//		"position": 4, // смещение ошибочного токена в байтах от начала expression
+//		"token": "*", // пустая строка - выражение оборвалось
+//		"expected": ["число", "имя", "(", "-"] // может отсутствовать
+//	}
+//
 //	500 Internal Server Error:
 //	{
 //		"error": "не удалось прочитать запрос"
@@ -117,8 +154,20 @@ func (h *Handlers) AddExpressionHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	id, err := h.taskManager.AddExpression(trimmedBody)
+	userFunctions := h.functions.Functions(r.Header.Get(userHeader))
+	id, err := h.taskManager.AddExpression(trimmedBody, requestBody.Variables, requestBody.Precision, userFunctions)
 	if err != nil {
+		var unboundErr *task_splitter.UnboundVariablesError
+		if errors.As(err, &unboundErr) {
+			h.writeResponse(w, http.StatusUnprocessableEntity, UnboundVariablesResponse{Error: err.Error(), Unbound: unboundErr.Names}) //422
+			return
+		}
+		var parseErr *ast.ParseError
+		if errors.As(err, &parseErr) {
+			logger.Log.Debugf("Синтаксическая ошибка в выражении:\n%s", parseErr.Caret(trimmedBody))
+			h.writeResponse(w, http.StatusUnprocessableEntity, parseErrorResponse(parseErr, requestBody.Expression)) //422
+			return
+		}
 		h.writeErrorResponse(w, http.StatusUnprocessableEntity, err.Error()) //422
 		return
 	}
@@ -183,10 +232,14 @@ func (h *Handlers) GetExpressionsHandler(w http.ResponseWriter, r *http.Request)
 	// Проходим по map и преобразуем Expression в ExpressionResponse
 	for _, expression := range expressionsMap {
 		expressionResponse := models.ExpressionResponse{
-			ID:     expression.ID,
-			Status: expression.Status,
-			Result: expression.Result,
-			Error:  expression.Error,
+			ID:            expression.ID,
+			Status:        expression.Status,
+			Result:        expressionResult(expression),
+			Error:         expression.Error,
+			ExactResult:   expression.ExactResult,
+			ComplexResult: expression.ComplexResult,
+			Unit:          expression.Unit,
+			Functions:     expression.Functions,
 		}
 		expressionResponses = append(expressionResponses, expressionResponse)
 	}
@@ -226,7 +279,17 @@ func (h *Handlers) GetExpressionsHandler(w http.ResponseWriter, r *http.Request)
 //			"id": "уникальный ID выражения",
 //			"status": "статус выражения (pending, processing, completed, error)",
 //			"result": "результат выражения (может отсутствовать, если вычисления не завершены)",
-//			"error": "ошибка при вычислении (может отсутствовать, если ошибки нет)"
+//			"error": "ошибка при вычислении (может отсутствовать, если ошибки нет)",
+//			"exact_result": "точный результат, например \"1/3\" (только при точности, отличной от float64)",
+//			"complex_result": {"re": 3, "im": 4}, // только при точности complex
+//			"unit": "единица измерения результата, например \"m/s\" (только если в выражении есть единицы измерения)",
+//			"functions": {"f": 2}, // версии использованных пользовательских функций (только если они вызывались)
+//			"stats": {
+//				"tasks_before": "количество задач без оптимизаций",
+//				"tasks_after": "количество задач, отправляемых агентам",
+//				"folded": "количество операций, вычисленных оркестратором",
+//				"deduplicated": "количество повторных подвыражений"
+//			}
 //		}
 //	}
 //
@@ -261,10 +324,15 @@ func (h *Handlers) GetExpressionHandler(w http.ResponseWriter, r *http.Request)
 	}
 
 	expressionResponse := models.ExpressionResponse{
-		ID:     expression.ID,
-		Status: expression.Status,
-		Result: expression.Result,
-		Error:  expression.Error,
+		ID:            expression.ID,
+		Status:        expression.Status,
+		Result:        expressionResult(expression),
+		Error:         expression.Error,
+		Stats:         &expression.Stats,
+		ExactResult:   expression.ExactResult,
+		ComplexResult: expression.ComplexResult,
+		Unit:          expression.Unit,
+		Functions:     expression.Functions,
 	}
 
 	response := map[string]models.ExpressionResponse{"expression": expressionResponse}
@@ -280,96 +348,83 @@ func (h *Handlers) GetExpressionHandler(w http.ResponseWriter, r *http.Request)
 	logger.Log.Debugf("Выражение %s успешно отправлен", id)
 }
 
-// GetTaskHandler обрабатывает GET-запросы на эндпоинт /internal/task.
+// CancelExpressionHandler обрабатывает DELETE-запросы на эндпоинт /api/v1/expressions/{id}
+// и POST-запросы на эндпоинт /api/v1/expressions/{id}/cancel.
 //
-// Функция получает задачу для выполнения из TaskManager и возвращает JSON-ответ с информацией о задаче.
-// Этот эндпоинт предназначен для внутреннего использования агентом.
+// Функция отменяет выражение: его невыполненные задачи больше не выдаются агентам, а агенты, вычисляющие
+// его задачи, прекращают вычисление. Выражение остается доступным со статусом "cancelled".
 //
 // Args:
 //
 //	w: http.ResponseWriter - интерфейс для записи HTTP-ответа.
 //	r: *http.Request - указатель на структуру, представляющую HTTP-запрос.
 //
+// Path parameters:
+//
+//	id: ID выражения.
+//
 // Responses:
 //
-//	200 OK:
-//	{
-//		"id": "уникальный ID задачи",
-//		"operation": "операция, которую нужно выполнить (+, -, *, /, ^, u-)",
-//		"args": [], // 2 числа
-//		"operation_time": "время выполнения задачи",
-//		"expression": "ID выражения, составной частью которого является задача"
-//	}
+//	204 No Content:
+//	(пустой ответ) - Выражение отменено.
 //
 //	404 Not Found:
-//		(пустой ответ) - Если нет доступных задач для выполнения
+//	{
+//		"error": "выражение не найдено"
+//	}
 //
 //	405 Method Not Allowed:
 //	{
 //		"error": "метод не поддерживается"
 //	}
-func (h *Handlers) GetTaskHandler(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodGet {
+//
+//	409 Conflict:
+//	{
+//		"error": "выражение уже завершено"
+//	}
+func (h *Handlers) CancelExpressionHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete && r.Method != http.MethodPost {
 		h.writeErrorResponse(w, http.StatusMethodNotAllowed, "метод не поддерживается")
 		return
 	}
 
-	task, _, ok := h.taskManager.GetTask()
-	if !ok {
-		w.WriteHeader(http.StatusNotFound) // 404
+	id := mux.Vars(r)["id"]
+	err := h.taskManager.CancelExpression(id)
+	if errors.Is(err, task_manager.ErrExpressionNotFound) {
+		h.writeErrorResponse(w, http.StatusNotFound, err.Error()) // 404
 		return
 	}
-
-	response := models.TaskResponse{
-		ID:             task.ID,
-		Args:           task.Args,
-		Operation:      task.Operation,
-		Operation_time: task.Operation_time,
-		Expression:     task.Expression,
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusConflict, err.Error()) // 409
+		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(response)
+	w.WriteHeader(http.StatusNoContent) // 204
 
-	logger.Log.Debugf("Задача %s успешно отправлена", task.ID)
+	logger.Log.Debugf("Выражение %s успешно отменено", id)
 }
 
-// GetTaskIDHandler обрабатывает GET-запросы на эндпоинт /internal/task/{id}.
+// GetConstantsHandler обрабатывает GET-запросы на эндпоинт /api/v1/constants.
 //
-// Функция получает список задач, связанных с определенным идентификатором, из TaskManager и возвращает
-// их в формате JSON. Этот эндпоинт предназначен для отладки и проверки.
+// Функция возвращает список математических констант, доступных в выражениях
+// (встроенные и заданные в конфигурации), например для автодополнения в веб интерфейсе.
 //
 // Args:
 //
 //	w: http.ResponseWriter - интерфейс для записи HTTP-ответа.
 //	r: *http.Request - указатель на структуру, представляющую HTTP-запрос.
 //
-// Path parameters:
-//
-//	id: ID, с которым связаны задачи (например, ID выражения).
-//
 // Responses:
 //
 //	200 OK:
 //	{
-//	  "tasks": [
+//	  "constants": [
 //	    {
-//			"id": "уникальный ID задачи",
-//			"operation": "операция, которую нужно выполнить (+, -, *, /, ^, u-)",
-//			"args": "[] (2 числа или nil'ы, если зависит от иногй задачи)",
-//			"operation_time": "время выполнения задачи",
-//			"dependencies": "id задач от которых она зависит",
-//			"status": "статус задачи "pending", "processing", "completed", "error")",
-//			"result": "результат вычисления задачи (nil если еще не выполнена)",
-//			"expression" "ID выражения, составной частью которого является задача"
-//			}
-//			...
-//		]
-//	}
-//
-//	404 OK:
-//	{
-//	 	"error": "выражение не найдено"
+//	      "name": "имя константы",
+//	      "value": "значение константы (число)"
+//	    },
+//	    ...
+//	  ]
 //	}
 //
 //	405 Method Not Allowed:
@@ -381,38 +436,29 @@ func (h *Handlers) GetTaskHandler(w http.ResponseWriter, r *http.Request) {
 //	{
 //		"error": "ошибка при кодировании ответа в JSON"
 //	}
-func (h *Handlers) GetTaskIDHandler(w http.ResponseWriter, r *http.Request) {
+func (h *Handlers) GetConstantsHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		h.writeErrorResponse(w, http.StatusMethodNotAllowed, "метод не поддерживается")
 		return
 	}
 
-	vars := mux.Vars(r)
-	id := vars["id"]
-
-	taskMap := h.taskManager.GetTasks(id)
-
-	if len(taskMap) == 0 {
-		h.writeErrorResponse(w, http.StatusNotFound, "выражение не найдено") // 404
-		return
-	}
-
-	response := map[string][]models.Task{"tasks": taskMap}
+	response := map[string][]constants.Constant{"constants": constants.List()}
 
 	w.Header().Set("Content-Type", "application/json")
-	err := json.NewEncoder(w).Encode(response)
+	err := json.NewEncoder(w).Encode(response) // 200
 	if err != nil {
 		h.writeErrorResponse(w, http.StatusInternalServerError, "ошибка при кодировании ответа в JSON") // 500
 		return
 	}
 
-	logger.Log.Debugf("Задача %s успешно отправлена", id)
+	logger.Log.Debugf("Список констант успешно отправлен")
 }
 
-// CompleteTaskHandler обрабатывает POST-запросы на эндпоинт /internal/task.
+// AddFunctionHandler обрабатывает POST-запросы на эндпоинт /api/v1/functions.
 //
-// Функция принимает JSON-запрос с ID выполненной задачи и результатом ее выполнения,
-// обновляет информацию о задаче в TaskManager. Этот эндпоинт предназначен для внутреннего использования агентами.
+// Функция регистрирует пользовательскую функцию пользователя из заголовка X-User-ID (без заголовка - в общем
+// пространстве имен). Повторное определение функции с тем же именем создает её новую версию: новые выражения
+// используют последнюю версию, а уже добавленные вычисляются с той версией, что была при их добавлении.
 //
 // Args:
 //
@@ -422,26 +468,29 @@ func (h *Handlers) GetTaskIDHandler(w http.ResponseWriter, r *http.Request) {
 // Request body (JSON):
 //
 //	{
-//		"expression": "ID выражения, частью которого являетя задача"
-//		"id": "ID выполненной задачи",
-//		"result": "результат выполнения задачи (число)",
-//		"error": "ошибка, возикшая при выполнении задачи" (может отсутсвовать)
+//		"definition": "f(x, y) = x^2 + y^2"
 //	}
 //
 // Responses:
 //
-//	200 OK:
-//	(пустой ответ) - В случае успешного завершения.
-//
+//	201 Created:
+//	{
+//		"function": {
+//			"name": "f",
+//			"params": ["x", "y"],
+//			"definition": "f(x, y) = x^2 + y^2",
+//			"version": 1,
+//			"created_at": "время регистрации версии"
+//		}
+//	}
 //
 //	400 Bad Request:
 //	{
-//		"error": "пустое тело запроса"
+//		"error": "определение функции обязательно"
 //	}
 //
-//	404 Not Found:
 //	{
-//		"error": "задача не найдена"
+//		"error": "пустое тело запроса"
 //	}
 //
 //	405 Method Not Allowed:
@@ -454,12 +503,18 @@ func (h *Handlers) GetTaskIDHandler(w http.ResponseWriter, r *http.Request) {
 //		"error": "не удалось декодировать JSON"
 //	}
 //
+//	{
+//		"error": "рекурсивное определение функции: f -> g -> f",
+//		"position": 0, // смещение ошибочного токена в байтах от начала definition
+//		"token": "f",
+//		"expected": ["="] // может отсутствовать
+//	}
+//
 //	500 Internal Server Error:
 //	{
-//		"error": "не удалось прочитать тело запроса"
+//		"error": "не удалось прочитать запрос"
 //	}
-func (h *Handlers) CompleteTaskHandler(w http.ResponseWriter, r *http.Request) {
-
+func (h *Handlers) AddFunctionHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		h.writeErrorResponse(w, http.StatusMethodNotAllowed, "метод не поддерживается") // 405
 		return
@@ -472,38 +527,914 @@ func (h *Handlers) CompleteTaskHandler(w http.ResponseWriter, r *http.Request) {
 
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
-		h.writeErrorResponse(w, http.StatusInternalServerError, "не удалось прочитать тело запроса") // 500
+		h.writeErrorResponse(w, http.StatusInternalServerError, "не удалось прочитать запрос") //500
 		return
 	}
 
-	var requestBody models.TaskCompleted
+	var requestBody models.FunctionAdd
 
 	err = json.Unmarshal(body, &requestBody)
 	if err != nil {
-		h.writeErrorResponse(w, http.StatusUnprocessableEntity, "не удалось декодировать JSON") // 422
+		h.writeErrorResponse(w, http.StatusUnprocessableEntity, "не удалось декодировать JSON") //422
 		return
 	}
 
-	success := h.taskManager.CompleteTask(requestBody.Expression, requestBody.ID, requestBody.Error, requestBody.Result)
-	if !success {
-		h.writeErrorResponse(w, http.StatusNotFound, "задача не найдена") // 404
+	definition := strings.TrimSpace(requestBody.Definition)
+	if definition == "" {
+		h.writeErrorResponse(w, http.StatusBadRequest, "определение функции обязательно") //400
 		return
 	}
 
-	w.WriteHeader(http.StatusOK) // 200
+	fn, err := h.functions.Define(r.Header.Get(userHeader), definition)
+	if err != nil {
+		var parseErr *ast.ParseError
+		if errors.As(err, &parseErr) {
+			logger.Log.Debugf("Ошибка в определении функции:\n%s", parseErr.Caret(definition))
+			h.writeResponse(w, http.StatusUnprocessableEntity, parseErrorResponse(parseErr, requestBody.Definition)) //422
+			return
+		}
+		h.writeErrorResponse(w, http.StatusUnprocessableEntity, err.Error()) //422
+		return
+	}
 
-	logger.Log.Debugf("Задача %s успешно выполнена", requestBody.ID)
+	h.writeResponse(w, http.StatusCreated, map[string]models.Function{"function": functionResponse(fn)}) // 201
+
+	logger.Log.Debugf("Функция %s версии %d успешно создана", fn.Name, fn.Version)
 }
 
-type ErrorResponse struct {
-	Error string `json:"error"`
+// GetFunctionsHandler обрабатывает GET-запросы на эндпоинт /api/v1/functions.
+//
+// Функция возвращает последние версии пользовательских функций пользователя из заголовка X-User-ID,
+// упорядоченные по имени.
+//
+// Args:
+//
+//	w: http.ResponseWriter - интерфейс для записи HTTP-ответа.
+//	r: *http.Request - указатель на структуру, представляющую HTTP-запрос.
+//
+// Responses:
+//
+//	200 OK:
+//	{
+//	  "functions": [
+//	    {
+//	      "name": "f",
+//	      "params": ["x", "y"],
+//	      "definition": "f(x, y) = x^2 + y^2",
+//	      "version": 2,
+//	      "created_at": "время регистрации версии"
+//	    },
+//	    ...
+//	  ]
+//	}
+//
+//	405 Method Not Allowed:
+//	{
+//		"error": "метод не поддерживается"
+//	}
+func (h *Handlers) GetFunctionsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.writeErrorResponse(w, http.StatusMethodNotAllowed, "метод не поддерживается")
+		return
+	}
+
+	list := h.functions.List(r.Header.Get(userHeader))
+	functionResponses := make([]models.Function, 0, len(list))
+	for _, fn := range list {
+		functionResponses = append(functionResponses, functionResponse(fn))
+	}
+
+	h.writeResponse(w, http.StatusOK, map[string][]models.Function{"functions": functionResponses}) // 200
+
+	logger.Log.Debugf("Список функций успешно отправлен")
 }
 
-func (h *Handlers) writeErrorResponse(w http.ResponseWriter, statusCode int, err string) {
+// GetFunctionHandler обрабатывает GET-запросы на эндпоинт /api/v1/functions/{name}.
+//
+// Функция возвращает все версии пользовательской функции пользователя из заголовка X-User-ID.
+//
+// Args:
+//
+//	w: http.ResponseWriter - интерфейс для записи HTTP-ответа.
+//	r: *http.Request - указатель на структуру, представляющую HTTP-запрос.
+//
+// Path parameters:
+//
+//	name: Имя функции.
+//
+// Responses:
+//
+//	200 OK:
+//	{
+//	  "versions": [
+//	    {
+//	      "name": "f",
+//	      "params": ["x"],
+//	      "definition": "f(x) = x^2",
+//	      "version": 1,
+//	      "created_at": "время регистрации версии"
+//	    },
+//	    ...
+//	  ]
+//	}
+//
+//	404 Not Found:
+//	{
+//	  "error": "функция не найдена"
+//	}
+//
+//	405 Method Not Allowed:
+//	{
+//		"error": "метод не поддерживается"
+//	}
+func (h *Handlers) GetFunctionHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.writeErrorResponse(w, http.StatusMethodNotAllowed, "метод не поддерживается")
+		return
+	}
+
+	name := mux.Vars(r)["name"]
+	versions, ok := h.functions.Versions(r.Header.Get(userHeader), name)
+	if !ok {
+		h.writeErrorResponse(w, http.StatusNotFound, "функция не найдена") // 404
+		return
+	}
+
+	versionResponses := make([]models.Function, 0, len(versions))
+	for _, fn := range versions {
+		versionResponses = append(versionResponses, functionResponse(fn))
+	}
+
+	h.writeResponse(w, http.StatusOK, map[string][]models.Function{"versions": versionResponses}) // 200
+
+	logger.Log.Debugf("Версии функции %s успешно отправлены", name)
+}
+
+// DeleteFunctionHandler обрабатывает DELETE-запросы на эндпоинт /api/v1/functions/{name}.
+//
+// Функция удаляет пользовательскую функцию пользователя из заголовка X-User-ID со всеми версиями.
+// Уже добавленные выражения, использующие функцию, вычисляются как обычно.
+//
+// Args:
+//
+//	w: http.ResponseWriter - интерфейс для записи HTTP-ответа.
+//	r: *http.Request - указатель на структуру, представляющую HTTP-запрос.
+//
+// Path parameters:
+//
+//	name: Имя функции.
+//
+// Responses:
+//
+//	204 No Content:
+//	(пустой ответ) - Функция удалена.
+//
+//	404 Not Found:
+//	{
+//	  "error": "функция не найдена"
+//	}
+//
+//	405 Method Not Allowed:
+//	{
+//		"error": "метод не поддерживается"
+//	}
+func (h *Handlers) DeleteFunctionHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		h.writeErrorResponse(w, http.StatusMethodNotAllowed, "метод не поддерживается")
+		return
+	}
+
+	name := mux.Vars(r)["name"]
+	if !h.functions.Delete(r.Header.Get(userHeader), name) {
+		h.writeErrorResponse(w, http.StatusNotFound, "функция не найдена") // 404
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent) // 204
+
+	logger.Log.Debugf("Функция %s успешно удалена", name)
+}
+
+// SetCellHandler обрабатывает PUT-запросы на эндпоинт /api/v1/workbooks/{id}/cells/{name}.
+//
+// Функция задает формулу ячейки рабочей книги (книга создается при первой записи) и отправляет на вычисление
+// ячейку и все ячейки, которые от неё зависят. Имена других ячеек книги в формуле заменяются их значениями,
+// ячейки вычисляются агентами в порядке зависимостей. В формуле доступны пользовательские функции пользователя
+// из заголовка X-User-ID.
+//
+// Args:
+//
+//	w: http.ResponseWriter - интерфейс для записи HTTP-ответа.
+//	r: *http.Request - указатель на структуру, представляющую HTTP-запрос.
+//
+// Path parameters:
+//
+//	id: ID рабочей книги.
+//	name: Имя ячейки, например A1.
+//
+// Request body (JSON):
+//
+//	{
+//		"formula": "A1 * (1 + rate)",
+//		"precision": "float64, decimal, rational, bigfloat:N или complex" // может отсутствовать
+//	}
+//
+// Responses:
+//
+//	200 OK:
+//	{
+//		"cell": {
+//			"name": "B1",
+//			"formula": "A1 * (1 + rate)",
+//			"dependencies": ["A1", "rate"],
+//			"expression": "ID выражения, вычисляющего ячейку",
+//			"status": "pending"
+//		},
+//		"recalculated": ["B1", "C1"] // пересчитанные ячейки, зависимости раньше зависимых
+//	}
+//
+//	400 Bad Request:
+//	{
+//		"error": "формула обязательна"
+//	}
+//
+//	405 Method Not Allowed:
+//	{
+//		"error": "метод не поддерживается"
+//	}
+//
+//	422 Unprocessable Entity:
+//	{
+//		"error": "циклическая ссылка между ячейками: A1 -> B1 -> A1"
+//	}
+//
+//	{
+//		"error": "не заданы значения переменных: X1", // имя не является ячейкой книги или константой
+//		"unbound": ["X1"]
+//	}
+//
+//	{
+//		"error": "неверный синтаксис",
+//		"position": 4, // смещение ошибочного токена в байтах от начала formula
+//		"token": "*"
+//	}
+//
+//	500 Internal Server Error:
+//	{
+//		"error": "не удалось прочитать запрос"
+//	}
+func (h *Handlers) SetCellHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		h.writeErrorResponse(w, http.StatusMethodNotAllowed, "метод не поддерживается") // 405
+		return
+	}
+
+	if r.Body == nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "пустое тело запроса") // 400
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusInternalServerError, "не удалось прочитать запрос") //500
+		return
+	}
+
+	var requestBody models.CellSet
+
+	err = json.Unmarshal(body, &requestBody)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusUnprocessableEntity, "не удалось декодировать JSON") //422
+		return
+	}
+
+	formula := strings.TrimSpace(requestBody.Formula)
+	if formula == "" {
+		h.writeErrorResponse(w, http.StatusBadRequest, "формула обязательна") //400
+		return
+	}
+
+	vars := mux.Vars(r)
+	userFunctions := h.functions.Functions(r.Header.Get(userHeader))
+	cell, recalculated, err := h.workbooks.SetCell(vars["id"], vars["name"], formula, requestBody.Precision, userFunctions)
+	if err != nil {
+		var unboundErr *task_splitter.UnboundVariablesError
+		if errors.As(err, &unboundErr) {
+			h.writeResponse(w, http.StatusUnprocessableEntity, UnboundVariablesResponse{Error: err.Error(), Unbound: unboundErr.Names}) //422
+			return
+		}
+		var parseErr *ast.ParseError
+		if errors.As(err, &parseErr) {
+			h.writeResponse(w, http.StatusUnprocessableEntity, parseErrorResponse(parseErr, requestBody.Formula)) //422
+			return
+		}
+		h.writeErrorResponse(w, http.StatusUnprocessableEntity, err.Error()) //422
+		return
+	}
+
+	h.writeResponse(w, http.StatusOK, CellSetResponse{Cell: h.cellResponse(cell), Recalculated: recalculated}) // 200
+
+	logger.Log.Debugf("Ячейка %s книги %s изменена, пересчитано ячеек: %d", cell.Name, vars["id"], len(recalculated))
+}
+
+// GetWorkbookHandler обрабатывает GET-запросы на эндпоинт /api/v1/workbooks/{id}.
+//
+// Функция возвращает все ячейки рабочей книги с их текущими значениями.
+//
+// Args:
+//
+//	w: http.ResponseWriter - интерфейс для записи HTTP-ответа.
+//	r: *http.Request - указатель на структуру, представляющую HTTP-запрос.
+//
+// Path parameters:
+//
+//	id: ID рабочей книги.
+//
+// Responses:
+//
+//	200 OK:
+//	{
+//		"workbook": {
+//			"id": "ID книги",
+//			"cells": [
+//				{
+//					"name": "A1",
+//					"formula": "100",
+//					"dependencies": [],
+//					"expression": "ID выражения",
+//					"status": "completed",
+//					"result": 100
+//				},
+//				...
+//			]
+//		}
+//	}
+//
+//	404 Not Found:
+//	{
+//		"error": "книга не найдена"
+//	}
+//
+//	405 Method Not Allowed:
+//	{
+//		"error": "метод не поддерживается"
+//	}
+func (h *Handlers) GetWorkbookHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.writeErrorResponse(w, http.StatusMethodNotAllowed, "метод не поддерживается")
+		return
+	}
+
+	id := mux.Vars(r)["id"]
+	cells, ok := h.workbooks.Cells(id)
+	if !ok {
+		h.writeErrorResponse(w, http.StatusNotFound, "книга не найдена") // 404
+		return
+	}
+
+	workbook := models.WorkbookResponse{ID: id, Cells: make([]models.CellResponse, 0, len(cells))}
+	for _, cell := range cells {
+		workbook.Cells = append(workbook.Cells, h.cellResponse(cell))
+	}
+
+	h.writeResponse(w, http.StatusOK, map[string]models.WorkbookResponse{"workbook": workbook}) // 200
+
+	logger.Log.Debugf("Книга %s успешно отправлена", id)
+}
+
+// GetCellHandler обрабатывает GET-запросы на эндпоинт /api/v1/workbooks/{id}/cells/{name}.
+//
+// Функция возвращает ячейку рабочей книги с её текущим значением.
+//
+// Args:
+//
+//	w: http.ResponseWriter - интерфейс для записи HTTP-ответа.
+//	r: *http.Request - указатель на структуру, представляющую HTTP-запрос.
+//
+// Path parameters:
+//
+//	id: ID рабочей книги.
+//	name: Имя ячейки.
+//
+// Responses:
+//
+//	200 OK:
+//	{
+//		"cell": {
+//			"name": "B1",
+//			"formula": "A1 * 2",
+//			"dependencies": ["A1"],
+//			"expression": "ID выражения",
+//			"status": "completed",
+//			"result": 200
+//		}
+//	}
+//
+//	404 Not Found:
+//	{
+//		"error": "ячейка не найдена"
+//	}
+//
+//	405 Method Not Allowed:
+//	{
+//		"error": "метод не поддерживается"
+//	}
+func (h *Handlers) GetCellHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.writeErrorResponse(w, http.StatusMethodNotAllowed, "метод не поддерживается")
+		return
+	}
+
+	vars := mux.Vars(r)
+	cell, ok := h.workbooks.Cell(vars["id"], vars["name"])
+	if !ok {
+		h.writeErrorResponse(w, http.StatusNotFound, "ячейка не найдена") // 404
+		return
+	}
+
+	h.writeResponse(w, http.StatusOK, map[string]models.CellResponse{"cell": h.cellResponse(cell)}) // 200
+
+	logger.Log.Debugf("Ячейка %s книги %s успешно отправлена", vars["name"], vars["id"])
+}
+
+// DeleteCellHandler обрабатывает DELETE-запросы на эндпоинт /api/v1/workbooks/{id}/cells/{name}.
+//
+// Функция удаляет ячейку рабочей книги. Ячейку, на которую ссылаются другие ячейки, удалить нельзя.
+//
+// Args:
+//
+//	w: http.ResponseWriter - интерфейс для записи HTTP-ответа.
+//	r: *http.Request - указатель на структуру, представляющую HTTP-запрос.
+//
+// Path parameters:
+//
+//	id: ID рабочей книги.
+//	name: Имя ячейки.
+//
+// Responses:
+//
+//	204 No Content:
+//	(пустой ответ) - Ячейка удалена.
+//
+//	404 Not Found:
+//	{
+//		"error": "ячейка не найдена"
+//	}
+//
+//	405 Method Not Allowed:
+//	{
+//		"error": "метод не поддерживается"
+//	}
+//
+//	409 Conflict:
+//	{
+//		"error": "на ячейку ссылаются другие ячейки: B1, C1"
+//	}
+func (h *Handlers) DeleteCellHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		h.writeErrorResponse(w, http.StatusMethodNotAllowed, "метод не поддерживается")
+		return
+	}
+
+	vars := mux.Vars(r)
+	err := h.workbooks.DeleteCell(vars["id"], vars["name"])
+	if errors.Is(err, workbooks.ErrCellNotFound) {
+		h.writeErrorResponse(w, http.StatusNotFound, err.Error()) // 404
+		return
+	}
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusConflict, err.Error()) // 409
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent) // 204
+
+	logger.Log.Debugf("Ячейка %s книги %s успешно удалена", vars["name"], vars["id"])
+}
+
+// GetTaskHandler обрабатывает GET-запросы на эндпоинт /internal/task.
+//
+// Функция получает задачу для выполнения из TaskManager и возвращает JSON-ответ с информацией о задаче.
+// Этот эндпоинт предназначен для внутреннего использования агентом.
+//
+// Args:
+//
+//	w: http.ResponseWriter - интерфейс для записи HTTP-ответа.
+//	r: *http.Request - указатель на структуру, представляющую HTTP-запрос.
+//
+// Responses:
+//
+//	200 OK:
+//	{
+//		"id": "уникальный ID задачи",
+//		"operation": "операция, которую нужно выполнить (+, -, *, /, ^, u-, sin, cos, tan, sqrt, log, ln, abs, exp, max, min, u%, +%, -%, round, floor, ceil, pmt, fv, median)",
+//		"args": [], // числа, по одному на каждый аргумент операции (у pmt и fv их пять, у median - сколько значений)
+//		"operation_time": "время выполнения задачи",
+//		"expression": "ID выражения, составной частью которого является задача",
+//		"lease": "токен аренды задачи: результат принимается только с ним до истечения operation_time и TASK_LEASE_GRACE_MS"
+//	}
+//
+//	404 Not Found:
+//		(пустой ответ) - Если нет доступных задач для выполнения
+//
+//	405 Method Not Allowed:
+//	{
+//		"error": "метод не поддерживается"
+//	}
+func (h *Handlers) GetTaskHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.writeErrorResponse(w, http.StatusMethodNotAllowed, "метод не поддерживается")
+		return
+	}
+
+	task, _, ok := h.taskManager.GetTask()
+	if !ok {
+		w.WriteHeader(http.StatusNotFound) // 404
+		return
+	}
+
+	response := models.TaskResponse{
+		ID:             task.ID,
+		Args:           task.Args,
+		Operation:      task.Operation,
+		Operation_time: task.Operation_time,
+		Expression:     task.Expression,
+		Precision:      task.Precision,
+		ExactArgs:      task.ExactArgs,
+		ComplexArgs:    task.ComplexArgs,
+		Units:          task.Units,
+		Lease:          task.Lease,
+	}
+	if task.ComplexArgs != nil {
+		// Действительные части не передаются: агент без поддержки комплексных чисел должен сообщить об ошибке,
+		// а не вычислить задачу по ним
+		response.Args = make([]*float64, len(task.Args))
+	} else if task.Precision != "" {
+		// Агент вычисляет задачу по точным аргументам, приближенные значения вне диапазона float64 не передаются
+		response.Args = make([]*float64, len(task.Args))
+		for i, arg := range task.Args {
+			response.Args[i] = finite(arg)
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+
+	logger.Log.Debugf("Задача %s успешно отправлена", task.ID)
+}
+
+// GetTaskIDHandler обрабатывает GET-запросы на эндпоинт /internal/task/{id}.
+//
+// Функция получает список задач, связанных с определенным идентификатором, из TaskManager и возвращает
+// их в формате JSON. Этот эндпоинт предназначен для отладки и проверки.
+//
+// Args:
+//
+//	w: http.ResponseWriter - интерфейс для записи HTTP-ответа.
+//	r: *http.Request - указатель на структуру, представляющую HTTP-запрос.
+//
+// Path parameters:
+//
+//	id: ID, с которым связаны задачи (например, ID выражения).
+//
+// Responses:
+//
+//	200 OK:
+//	{
+//	  "tasks": [
+//	    {
+//			"id": "уникальный ID задачи",
+//			"operation": "операция, которую нужно выполнить (+, -, *, /, ^, u-, sin, cos, tan, sqrt, log, ln, abs, exp, max, min, u%, +%, -%, round, floor, ceil, pmt, fv, median)",
+//			"args": "[] (числа или nil'ы, если зависит от иногй задачи)",
+//			"operation_time": "время выполнения задачи",
+//			"dependencies": "id задач от которых она зависит",
+//			"status": "статус задачи "pending", "processing", "completed", "error")",
+//			"result": "результат вычисления задачи (nil если еще не выполнена)",
+//			"expression" "ID выражения, составной частью которого является задача"
+//			}
+//			...
+//		]
+//	}
+//
+//	404 OK:
+//	{
+//	 	"error": "выражение не найдено"
+//	}
+//
+//	405 Method Not Allowed:
+//	{
+//		"error": "метод не поддерживается"
+//	}
+//
+//	500 Internal Server Error:
+//	{
+//		"error": "ошибка при кодировании ответа в JSON"
+//	}
+func (h *Handlers) GetTaskIDHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.writeErrorResponse(w, http.StatusMethodNotAllowed, "метод не поддерживается")
+		return
+	}
+
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	taskMap := h.taskManager.GetTasks(id)
+
+	if len(taskMap) == 0 {
+		h.writeErrorResponse(w, http.StatusNotFound, "выражение не найдено") // 404
+		return
+	}
+
+	response := map[string][]models.Task{"tasks": taskMap}
+
+	w.Header().Set("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(response)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusInternalServerError, "ошибка при кодировании ответа в JSON") // 500
+		return
+	}
+
+	logger.Log.Debugf("Задача %s успешно отправлена", id)
+}
+
+// CompleteTaskHandler обрабатывает POST-запросы на эндпоинт /internal/task.
+//
+// Функция принимает JSON-запрос с ID выполненной задачи и результатом ее выполнения,
+// обновляет информацию о задаче в TaskManager. Этот эндпоинт предназначен для внутреннего использования агентами.
+//
+// Args:
+//
+//	w: http.ResponseWriter - интерфейс для записи HTTP-ответа.
+//	r: *http.Request - указатель на структуру, представляющую HTTP-запрос.
+//
+// Request body (JSON):
+//
+//	{
+//		"expression": "ID выражения, частью которого являетя задача"
+//		"id": "ID выполненной задачи",
+//		"result": "результат выполнения задачи (число)",
+//		"error": "ошибка, возикшая при выполнении задачи" (может отсутсвовать),
+//		"lease": "токен аренды, полученный вместе с задачей"
+//	}
+//
+// Responses:
+//
+//	200 OK:
+//	(пустой ответ) - В случае успешного завершения.
+//
+//
+//	400 Bad Request:
+//	{
+//		"error": "пустое тело запроса"
+//	}
+//
+//	404 Not Found:
+//	{
+//		"error": "задача не найдена или её аренда истекла" // результат пришел после истечения аренды, задача выдана повторно
+//	}
+//
+//	405 Method Not Allowed:
+//	{
+//		"error": "метод не поддерживается"
+//	}
+//
+//	422 Unprocessable Entity:
+//	{
+//		"error": "не удалось декодировать JSON"
+//	}
+//
+//	500 Internal Server Error:
+//	{
+//		"error": "не удалось прочитать тело запроса"
+//	}
+func (h *Handlers) CompleteTaskHandler(w http.ResponseWriter, r *http.Request) {
+
+	if r.Method != http.MethodPost {
+		h.writeErrorResponse(w, http.StatusMethodNotAllowed, "метод не поддерживается") // 405
+		return
+	}
+
+	if r.Body == nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "пустое тело запроса") // 400
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusInternalServerError, "не удалось прочитать тело запроса") // 500
+		return
+	}
+
+	var requestBody models.TaskCompleted
+
+	err = json.Unmarshal(body, &requestBody)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusUnprocessableEntity, "не удалось декодировать JSON") // 422
+		return
+	}
+
+	var success bool
+	if requestBody.ExactResult != "" && requestBody.Error == "" {
+		success = h.taskManager.CompleteExactTask(requestBody.Expression, requestBody.ID, requestBody.Lease, requestBody.ExactResult)
+	} else if requestBody.ComplexResult != nil && requestBody.Error == "" {
+		success = h.taskManager.CompleteComplexTask(requestBody.Expression, requestBody.ID, requestBody.Lease, *requestBody.ComplexResult)
+	} else {
+		success = h.taskManager.CompleteTask(requestBody.Expression, requestBody.ID, requestBody.Lease, requestBody.Error, requestBody.Result)
+	}
+	if !success {
+		h.writeErrorResponse(w, http.StatusNotFound, "задача не найдена или её аренда истекла") // 404
+		return
+	}
+
+	w.WriteHeader(http.StatusOK) // 200
+
+	logger.Log.Debugf("Задача %s успешно выполнена", requestBody.ID)
+}
+
+// TaskHeartbeatHandler обрабатывает POST-запросы на эндпоинт /internal/task/heartbeat.
+//
+// Агент периодически отправляет запрос во время вычисления задачи, чтобы узнать, нужно ли продолжать:
+// если выражение отменено или аренда задачи истекла, агент прекращает вычисление и не отправляет результат.
+// Этот эндпоинт предназначен для внутреннего использования агентами.
+//
+// Args:
+//
+//	w: http.ResponseWriter - интерфейс для записи HTTP-ответа.
+//	r: *http.Request - указатель на структуру, представляющую HTTP-запрос.
+//
+// Request body (JSON):
+//
+//	{
+//		"expression": "ID выражения, частью которого являетя задача",
+//		"id": "ID вычисляемой задачи",
+//		"lease": "токен аренды, полученный вместе с задачей"
+//	}
+//
+// Responses:
+//
+//	200 OK:
+//	(пустой ответ) - Вычисление задачи нужно продолжать.
+//
+//	400 Bad Request:
+//	{
+//		"error": "пустое тело запроса"
+//	}
+//
+//	404 Not Found:
+//	{
+//		"error": "задача не найдена или её аренда истекла"
+//	}
+//
+//	405 Method Not Allowed:
+//	{
+//		"error": "метод не поддерживается"
+//	}
+//
+//	410 Gone:
+//	{
+//		"error": "выражение отменено"
+//	}
+//
+//	422 Unprocessable Entity:
+//	{
+//		"error": "не удалось декодировать JSON"
+//	}
+func (h *Handlers) TaskHeartbeatHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.writeErrorResponse(w, http.StatusMethodNotAllowed, "метод не поддерживается") // 405
+		return
+	}
+
+	if r.Body == nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "пустое тело запроса") // 400
+		return
+	}
+
+	var requestBody models.TaskHeartbeat
+	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
+		h.writeErrorResponse(w, http.StatusUnprocessableEntity, "не удалось декодировать JSON") // 422
+		return
+	}
+
+	err := h.taskManager.Heartbeat(requestBody.Expression, requestBody.ID, requestBody.Lease)
+	if errors.Is(err, task_manager.ErrExpressionCancelled) {
+		h.writeErrorResponse(w, http.StatusGone, err.Error()) // 410
+		return
+	}
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusNotFound, err.Error()) // 404
+		return
+	}
+
+	w.WriteHeader(http.StatusOK) // 200
+}
+
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// UnboundVariablesResponse - ответ об ошибке, содержащий список переменных без значений.
+type UnboundVariablesResponse struct {
+	Error   string   `json:"error"`
+	Unbound []string `json:"unbound"`
+}
+
+// CellSetResponse - ответ на изменение ячейки рабочей книги.
+type CellSetResponse struct {
+	Cell         models.CellResponse `json:"cell"`
+	Recalculated []string            `json:"recalculated"`
+}
+
+// ParseErrorResponse - ответ о синтаксической ошибке с указанием места ошибки в выражении.
+type ParseErrorResponse struct {
+	Error    string   `json:"error"`
+	Position int      `json:"position"`
+	Token    string   `json:"token"`
+	Expected []string `json:"expected,omitempty"`
+}
+
+// parseErrorResponse возвращает ответ о синтаксической ошибке. Позиция ошибки считается от начала
+// обрезанной строки, в ответе она переводится в позицию в исходной строке запроса.
+func parseErrorResponse(parseErr *ast.ParseError, source string) ParseErrorResponse {
+	leading := len(source) - len(strings.TrimLeftFunc(source, unicode.IsSpace))
+	return ParseErrorResponse{
+		Error:    parseErr.Error(),
+		Position: parseErr.Pos + leading,
+		Token:    parseErr.Token,
+		Expected: parseErr.Expected,
+	}
+}
+
+// cellResponse преобразует ячейку рабочей книги в формат HTTP-ответа со статусом и значением её выражения.
+func (h *Handlers) cellResponse(cell workbooks.Cell) models.CellResponse {
+	response := models.CellResponse{
+		Name:         cell.Name,
+		Formula:      cell.Formula,
+		Dependencies: cell.Dependencies,
+		Expression:   cell.ExpressionID,
+		Status:       "error",
+		Error:        cell.Error,
+	}
+	if response.Dependencies == nil {
+		response.Dependencies = []string{}
+	}
+	if cell.ExpressionID == "" {
+		return response
+	}
+
+	expression, ok := h.taskManager.LookupExpression(cell.ExpressionID)
+	if !ok {
+		response.Error = "выражение ячейки удалено, ячейка будет пересчитана при следующем изменении книги"
+		return response
+	}
+	response.Status = expression.Status
+	response.Result = expressionResult(expression)
+	response.ExactResult = expression.ExactResult
+	response.ComplexResult = expression.ComplexResult
+	response.Unit = expression.Unit
+	response.Error = expression.Error
+	return response
+}
+
+// functionResponse преобразует версию пользовательской функции в формат HTTP-ответа.
+func functionResponse(fn *functions.Function) models.Function {
+	return models.Function{
+		Name:       fn.Name,
+		Params:     fn.Params,
+		Definition: fn.Definition,
+		Version:    fn.Version,
+		CreatedAt:  fn.CreatedAt,
+	}
+}
+
+// expressionResult возвращает действительный результат выражения для ответа: nil, если результат
+// не может быть закодирован в JSON или является комплексным числом с ненулевой мнимой частью.
+func expressionResult(expression models.Expression) *float64 {
+	if expression.ComplexResult != nil && expression.ComplexResult.Im != 0 {
+		return nil
+	}
+	return finite(expression.Result)
+}
+
+// finite возвращает значение, если оно может быть закодировано в JSON, иначе nil.
+// Приближение точного результата (см. models.ExpressionResponse.ExactResult) может быть вне диапазона float64.
+func finite(value *float64) *float64 {
+	if value == nil || math.IsInf(*value, 0) || math.IsNaN(*value) {
+		return nil
+	}
+	return value
+}
+
+func (h *Handlers) writeErrorResponse(w http.ResponseWriter, statusCode int, err string) {
+	h.writeResponse(w, statusCode, ErrorResponse{Error: err})
+}
+
+// writeResponse записывает JSON-ответ с указанным статусом.
+func (h *Handlers) writeResponse(w http.ResponseWriter, statusCode int, response any) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(statusCode)
-	errResponse := ErrorResponse{Error: err}
-	if encodeErr := json.NewEncoder(w).Encode(errResponse); encodeErr != nil {
+	if encodeErr := json.NewEncoder(w).Encode(response); encodeErr != nil {
 		http.Error(w, "Internal server error", http.StatusInternalServerError) // Крайний случай
 	}
 }
@@ -5,15 +5,20 @@ import (
 	"encoding/json"
 	"io"
 	"log"
+	"math"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/OinkiePie/calc_2/config"
+	"github.com/OinkiePie/calc_2/orchestrator/internal/functions"
 	"github.com/OinkiePie/calc_2/orchestrator/internal/handlers"
 	"github.com/OinkiePie/calc_2/orchestrator/internal/task_manager"
+	"github.com/OinkiePie/calc_2/orchestrator/internal/workbooks"
+	"github.com/OinkiePie/calc_2/pkg/constants"
 	"github.com/OinkiePie/calc_2/pkg/logger"
 	"github.com/OinkiePie/calc_2/pkg/models"
+	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -26,7 +31,8 @@ func init() {
 
 func TestAddExpressionHandler(t *testing.T) {
 	// Создаем мок для TaskManager
-	h := handlers.NewOrchestratorHandlers(task_manager.NewTaskManager())
+	tm := task_manager.NewTaskManager()
+	h := handlers.NewOrchestratorHandlers(tm, functions.NewRegistry(), workbooks.NewWorkbooks(tm))
 
 	t.Run("Successful", func(t *testing.T) {
 		requestBody := map[string]string{"expression": "42 + 55"}
@@ -74,6 +80,122 @@ func TestAddExpressionHandler(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, rr.Code)
 	})
 
+	t.Run("Variables", func(t *testing.T) {
+		requestBody := map[string]any{
+			"expression": "a*x^2 + b",
+			"variables":  map[string]float64{"a": 1, "x": 3, "b": 2},
+		}
+		jsonBody, _ := json.Marshal(requestBody)
+		req, err := http.NewRequest("POST", "/api/v1/calculate", bytes.NewBuffer(jsonBody))
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		h.AddExpressionHandler(rr, req)
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+	})
+
+	t.Run("Unbound variables", func(t *testing.T) {
+		requestBody := map[string]any{
+			"expression": "a*x^2 + b",
+			"variables":  map[string]float64{"x": 3},
+		}
+		jsonBody, _ := json.Marshal(requestBody)
+		req, err := http.NewRequest("POST", "/api/v1/calculate", bytes.NewBuffer(jsonBody))
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		h.AddExpressionHandler(rr, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+
+		var response handlers.UnboundVariablesResponse
+		err = json.Unmarshal(rr.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a", "b"}, response.Unbound)
+	})
+
+	t.Run("Parse error position", func(t *testing.T) {
+		requestBody := map[string]string{"expression": "  2 + * 3"}
+		jsonBody, _ := json.Marshal(requestBody)
+		req, err := http.NewRequest("POST", "/api/v1/calculate", bytes.NewBuffer(jsonBody))
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		h.AddExpressionHandler(rr, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+
+		var response handlers.ParseErrorResponse
+		err = json.Unmarshal(rr.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, "неверный синтаксис", response.Error)
+		assert.Equal(t, 6, response.Position) // Позиция в исходной строке, с учетом обрезанных пробелов
+		assert.Equal(t, "*", response.Token)
+		assert.NotEmpty(t, response.Expected)
+	})
+
+	t.Run("Precision", func(t *testing.T) {
+		requestBody := map[string]string{"expression": "1/3 + 1/6", "precision": "rational"}
+		jsonBody, _ := json.Marshal(requestBody)
+		req, err := http.NewRequest("POST", "/api/v1/calculate", bytes.NewBuffer(jsonBody))
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		h.AddExpressionHandler(rr, req)
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+	})
+
+	t.Run("Invalid precision", func(t *testing.T) {
+		requestBody := map[string]string{"expression": "1/3", "precision": "bigfloat:abc"}
+		jsonBody, _ := json.Marshal(requestBody)
+		req, err := http.NewRequest("POST", "/api/v1/calculate", bytes.NewBuffer(jsonBody))
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		h.AddExpressionHandler(rr, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	})
+
+	t.Run("Function unavailable with precision", func(t *testing.T) {
+		requestBody := map[string]string{"expression": "2 * ln(3)", "precision": "decimal"}
+		jsonBody, _ := json.Marshal(requestBody)
+		req, err := http.NewRequest("POST", "/api/v1/calculate", bytes.NewBuffer(jsonBody))
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		h.AddExpressionHandler(rr, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+
+		var response handlers.ParseErrorResponse
+		err = json.Unmarshal(rr.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, 4, response.Position)
+		assert.Equal(t, "ln", response.Token)
+	})
+
+	t.Run("Incompatible units", func(t *testing.T) {
+		requestBody := map[string]string{"expression": "3 m + 2 s"}
+		jsonBody, _ := json.Marshal(requestBody)
+		req, err := http.NewRequest("POST", "/api/v1/calculate", bytes.NewBuffer(jsonBody))
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		h.AddExpressionHandler(rr, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+
+		var response handlers.ParseErrorResponse
+		err = json.Unmarshal(rr.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, "несовместимые размерности m и s в операции +", response.Error)
+		assert.Equal(t, 4, response.Position)
+		assert.Equal(t, "+", response.Token)
+	})
+
 	t.Run("When adding", func(t *testing.T) {
 		requestBody := map[string]string{"expression": "+52+"}
 		jsonBody, _ := json.Marshal(requestBody)
@@ -89,7 +211,8 @@ func TestAddExpressionHandler(t *testing.T) {
 
 func TestGetExpressionsHandler(t *testing.T) {
 	// Создаем мок для TaskManager
-	h := handlers.NewOrchestratorHandlers(task_manager.NewTaskManager())
+	tm := task_manager.NewTaskManager()
+	h := handlers.NewOrchestratorHandlers(tm, functions.NewRegistry(), workbooks.NewWorkbooks(tm))
 
 	t.Run("Successful", func(t *testing.T) {
 		req, err := http.NewRequest("GET", "/api/v1/expressions", nil)
@@ -114,10 +237,31 @@ func TestGetExpressionsHandler(t *testing.T) {
 
 func TestGetExpressionHandler(t *testing.T) {
 	// Создаем мок для TaskManager
-	h := handlers.NewOrchestratorHandlers(task_manager.NewTaskManager())
+	tm := task_manager.NewTaskManager()
+	h := handlers.NewOrchestratorHandlers(tm, functions.NewRegistry(), workbooks.NewWorkbooks(tm))
 
-	// Успешный запрос невозможно проверить т.к. он получает ID
-	// из ссылки благодаря gorilla/mux.
+	t.Run("Repeated reads", func(t *testing.T) {
+		id, err := tm.AddExpression("2 + 3", nil, "", nil)
+		assert.NoError(t, err)
+		task, _, _ := tm.GetTask()
+		tm.CompleteTask(id, task.ID, task.Lease, "", 5)
+
+		// Вычисленное выражение не удаляется после первого запроса
+		var bodies []string
+		for i := 0; i < 2; i++ {
+			req, err := http.NewRequest("GET", "/api/v1/expressions/"+id, nil)
+			assert.NoError(t, err)
+			req = mux.SetURLVars(req, map[string]string{"id": id})
+
+			rr := httptest.NewRecorder()
+			h.GetExpressionHandler(rr, req)
+
+			assert.Equal(t, http.StatusOK, rr.Code)
+			bodies = append(bodies, rr.Body.String())
+		}
+		assert.Contains(t, bodies[0], `"result":5`)
+		assert.Equal(t, bodies[0], bodies[1])
+	})
 
 	t.Run("Not Found", func(t *testing.T) {
 		req, err := http.NewRequest("GET", "/api/v1/expressions/id42bratuha", nil)
@@ -140,10 +284,306 @@ func TestGetExpressionHandler(t *testing.T) {
 	})
 }
 
+func TestGetConstantsHandler(t *testing.T) {
+	tm := task_manager.NewTaskManager()
+	h := handlers.NewOrchestratorHandlers(tm, functions.NewRegistry(), workbooks.NewWorkbooks(tm))
+
+	t.Run("Successful", func(t *testing.T) {
+		req, err := http.NewRequest("GET", "/api/v1/constants", nil)
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		h.GetConstantsHandler(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		var response map[string][]constants.Constant
+		err = json.Unmarshal(rr.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Contains(t, response["constants"], constants.Constant{Name: "pi", Value: math.Pi})
+	})
+
+	t.Run("Method Not Allowed", func(t *testing.T) {
+		req, err := http.NewRequest("POST", "/api/v1/constants", nil)
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		h.GetConstantsHandler(rr, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+	})
+}
+
+func TestFunctionHandlers(t *testing.T) {
+	tm := task_manager.NewTaskManager()
+	h := handlers.NewOrchestratorHandlers(tm, functions.NewRegistry(), workbooks.NewWorkbooks(tm))
+
+	addFunction := func(user, definition string) *httptest.ResponseRecorder {
+		jsonBody, _ := json.Marshal(models.FunctionAdd{Definition: definition})
+		req, err := http.NewRequest("POST", "/api/v1/functions", bytes.NewBuffer(jsonBody))
+		assert.NoError(t, err)
+		req.Header.Set("X-User-ID", user)
+
+		rr := httptest.NewRecorder()
+		h.AddFunctionHandler(rr, req)
+		return rr
+	}
+
+	t.Run("Add", func(t *testing.T) {
+		rr := addFunction("alice", "f(x, y) = x^2 + y^2")
+		assert.Equal(t, http.StatusCreated, rr.Code)
+
+		var response map[string]models.Function
+		err := json.Unmarshal(rr.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, "f", response["function"].Name)
+		assert.Equal(t, []string{"x", "y"}, response["function"].Params)
+		assert.Equal(t, 1, response["function"].Version)
+	})
+
+	t.Run("Invalid definitions", func(t *testing.T) {
+		rr := addFunction("alice", " ")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+		rr = addFunction("alice", "  f(x) = x +")
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+		var parseResponse handlers.ParseErrorResponse
+		err := json.Unmarshal(rr.Body.Bytes(), &parseResponse)
+		assert.NoError(t, err)
+		assert.Equal(t, 12, parseResponse.Position) // Смещение в исходной строке с ведущими пробелами
+
+		addFunction("alice", "g(x) = h(x) + 1")
+		rr = addFunction("alice", "h(x) = g(x) * 2")
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+		err = json.Unmarshal(rr.Body.Bytes(), &parseResponse)
+		assert.NoError(t, err)
+		assert.Equal(t, "рекурсивное определение функции: h -> g -> h", parseResponse.Error)
+	})
+
+	t.Run("Expression uses user functions", func(t *testing.T) {
+		jsonBody, _ := json.Marshal(models.ExpressionAdd{Expression: "f(3, 4) + 1"})
+
+		req, _ := http.NewRequest("POST", "/api/v1/calculate", bytes.NewBuffer(jsonBody))
+		req.Header.Set("X-User-ID", "alice")
+		rr := httptest.NewRecorder()
+		h.AddExpressionHandler(rr, req)
+		assert.Equal(t, http.StatusCreated, rr.Code)
+
+		// У другого пользователя функции f нет
+		req, _ = http.NewRequest("POST", "/api/v1/calculate", bytes.NewBuffer(jsonBody))
+		req.Header.Set("X-User-ID", "bob")
+		rr = httptest.NewRecorder()
+		h.AddExpressionHandler(rr, req)
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+		assert.Contains(t, rr.Body.String(), "неизвестная функция: f")
+	})
+
+	t.Run("List and versions", func(t *testing.T) {
+		assert.Equal(t, http.StatusCreated, addFunction("alice", "f(x) = 2x").Code)
+
+		req, _ := http.NewRequest("GET", "/api/v1/functions", nil)
+		req.Header.Set("X-User-ID", "alice")
+		rr := httptest.NewRecorder()
+		h.GetFunctionsHandler(rr, req)
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		var list map[string][]models.Function
+		err := json.Unmarshal(rr.Body.Bytes(), &list)
+		assert.NoError(t, err)
+		if assert.Len(t, list["functions"], 2) {
+			assert.Equal(t, "f", list["functions"][0].Name)
+			assert.Equal(t, 2, list["functions"][0].Version)
+			assert.Equal(t, "g", list["functions"][1].Name)
+		}
+
+		req, _ = http.NewRequest("GET", "/api/v1/functions/f", nil)
+		req.Header.Set("X-User-ID", "alice")
+		req = mux.SetURLVars(req, map[string]string{"name": "f"})
+		rr = httptest.NewRecorder()
+		h.GetFunctionHandler(rr, req)
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		var versions map[string][]models.Function
+		err = json.Unmarshal(rr.Body.Bytes(), &versions)
+		assert.NoError(t, err)
+		if assert.Len(t, versions["versions"], 2) {
+			assert.Equal(t, "f(x, y) = x^2 + y^2", versions["versions"][0].Definition)
+			assert.Equal(t, "f(x) = 2x", versions["versions"][1].Definition)
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		req, _ := http.NewRequest("DELETE", "/api/v1/functions/f", nil)
+		req.Header.Set("X-User-ID", "alice")
+		req = mux.SetURLVars(req, map[string]string{"name": "f"})
+		rr := httptest.NewRecorder()
+		h.DeleteFunctionHandler(rr, req)
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+
+		rr = httptest.NewRecorder()
+		h.DeleteFunctionHandler(rr, req)
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+
+		req, _ = http.NewRequest("GET", "/api/v1/functions/f", nil)
+		req = mux.SetURLVars(req, map[string]string{"name": "f"})
+		rr = httptest.NewRecorder()
+		h.GetFunctionHandler(rr, req)
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("Method Not Allowed", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/api/v1/functions", nil)
+		rr := httptest.NewRecorder()
+		h.AddFunctionHandler(rr, req)
+		assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+
+		rr = httptest.NewRecorder()
+		h.DeleteFunctionHandler(rr, req)
+		assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+	})
+}
+
+// TestWorkbookHandlers проверяет обработчики рабочих книг.
+func TestWorkbookHandlers(t *testing.T) {
+	tm := task_manager.NewTaskManager()
+	h := handlers.NewOrchestratorHandlers(tm, functions.NewRegistry(), workbooks.NewWorkbooks(tm))
+
+	setCell := func(name, formula string) *httptest.ResponseRecorder {
+		jsonBody, _ := json.Marshal(models.CellSet{Formula: formula})
+		req, err := http.NewRequest("PUT", "/api/v1/workbooks/book/cells/"+name, bytes.NewBuffer(jsonBody))
+		assert.NoError(t, err)
+		req.Header.Set("X-User-ID", "alice")
+		req = mux.SetURLVars(req, map[string]string{"id": "book", "name": name})
+
+		rr := httptest.NewRecorder()
+		h.SetCellHandler(rr, req)
+		return rr
+	}
+
+	// completeNext выдает агенту следующую готовую задачу и завершает её с результатом result
+	completeNext := func(result float64) {
+		task, exprID, found := tm.GetTask()
+		if assert.True(t, found) {
+			tm.CompleteTask(exprID, task.ID, task.Lease, "", result)
+		}
+	}
+
+	t.Run("Set", func(t *testing.T) {
+		rr := setCell("A1", "2 + 3")
+		assert.Equal(t, http.StatusOK, rr.Code)
+		completeNext(5)
+
+		rr = setCell("B1", "A1 * 2")
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		var response handlers.CellSetResponse
+		err := json.Unmarshal(rr.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, "B1", response.Cell.Name)
+		assert.Equal(t, []string{"A1"}, response.Cell.Dependencies)
+		assert.Equal(t, "pending", response.Cell.Status)
+		assert.Equal(t, []string{"B1"}, response.Recalculated)
+		completeNext(10)
+
+		rr = setCell("A1", "10")
+		assert.Equal(t, http.StatusOK, rr.Code)
+		err = json.Unmarshal(rr.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"A1", "B1"}, response.Recalculated)
+	})
+
+	t.Run("Invalid formulas", func(t *testing.T) {
+		rr := setCell("C1", " ")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+		rr = setCell("A1", "B1 + 1")
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+		assert.Contains(t, rr.Body.String(), "циклическая ссылка")
+
+		rr = setCell("C1", "X1 + 1")
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+		var unboundResponse handlers.UnboundVariablesResponse
+		err := json.Unmarshal(rr.Body.Bytes(), &unboundResponse)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"X1"}, unboundResponse.Unbound)
+
+		rr = setCell("C1", "A1 + * 2")
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+		var parseResponse handlers.ParseErrorResponse
+		err = json.Unmarshal(rr.Body.Bytes(), &parseResponse)
+		assert.NoError(t, err)
+		assert.Equal(t, 5, parseResponse.Position)
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		// Ячейка-константа A1 вычислена без задач, агент вычисляет пересчитанную B1
+		completeNext(20)
+
+		req, _ := http.NewRequest("GET", "/api/v1/workbooks/book/cells/A1", nil)
+		req = mux.SetURLVars(req, map[string]string{"id": "book", "name": "A1"})
+		rr := httptest.NewRecorder()
+		h.GetCellHandler(rr, req)
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		var cell map[string]models.CellResponse
+		err := json.Unmarshal(rr.Body.Bytes(), &cell)
+		assert.NoError(t, err)
+		assert.Equal(t, "completed", cell["cell"].Status)
+		if assert.NotNil(t, cell["cell"].Result) {
+			assert.Equal(t, 10.0, *cell["cell"].Result)
+		}
+
+		req, _ = http.NewRequest("GET", "/api/v1/workbooks/book", nil)
+		req = mux.SetURLVars(req, map[string]string{"id": "book"})
+		rr = httptest.NewRecorder()
+		h.GetWorkbookHandler(rr, req)
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		var workbook map[string]models.WorkbookResponse
+		err = json.Unmarshal(rr.Body.Bytes(), &workbook)
+		assert.NoError(t, err)
+		if assert.Len(t, workbook["workbook"].Cells, 2) {
+			assert.Equal(t, "A1", workbook["workbook"].Cells[0].Name)
+			assert.Equal(t, "completed", workbook["workbook"].Cells[1].Status)
+		}
+
+		req = mux.SetURLVars(req, map[string]string{"id": "missing"})
+		rr = httptest.NewRecorder()
+		h.GetWorkbookHandler(rr, req)
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		deleteCell := func(name string) *httptest.ResponseRecorder {
+			req, _ := http.NewRequest("DELETE", "/api/v1/workbooks/book/cells/"+name, nil)
+			req = mux.SetURLVars(req, map[string]string{"id": "book", "name": name})
+			rr := httptest.NewRecorder()
+			h.DeleteCellHandler(rr, req)
+			return rr
+		}
+
+		assert.Equal(t, http.StatusConflict, deleteCell("A1").Code)
+		assert.Equal(t, http.StatusNoContent, deleteCell("B1").Code)
+		assert.Equal(t, http.StatusNotFound, deleteCell("B1").Code)
+	})
+
+	t.Run("Method Not Allowed", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/api/v1/workbooks/book/cells/A1", nil)
+		rr := httptest.NewRecorder()
+		h.SetCellHandler(rr, req)
+		assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+
+		rr = httptest.NewRecorder()
+		h.DeleteCellHandler(rr, req)
+		assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+	})
+}
+
 func TestGetTaskHandler(t *testing.T) {
 
 	// Создаем мок для TaskManager
-	h := handlers.NewOrchestratorHandlers(task_manager.NewTaskManager())
+	tm := task_manager.NewTaskManager()
+	h := handlers.NewOrchestratorHandlers(tm, functions.NewRegistry(), workbooks.NewWorkbooks(tm))
 
 	t.Run("Succesful", func(t *testing.T) {
 		// Добавляем выражение чтобы потом получать его задачу
@@ -189,7 +629,8 @@ func TestGetTaskHandler(t *testing.T) {
 
 func TestGetTaskIDHandler(t *testing.T) {
 	// Создаем мок для TaskManager
-	h := handlers.NewOrchestratorHandlers(task_manager.NewTaskManager())
+	tm := task_manager.NewTaskManager()
+	h := handlers.NewOrchestratorHandlers(tm, functions.NewRegistry(), workbooks.NewWorkbooks(tm))
 
 	// Успешный запрос невозможно проверить т.к. он получает ID
 	// из ссылки благодаря gorilla/mux.
@@ -217,7 +658,8 @@ func TestGetTaskIDHandler(t *testing.T) {
 
 func TestCompleteTaskHandler(t *testing.T) {
 	// Создаем мок для TaskManager
-	h := handlers.NewOrchestratorHandlers(task_manager.NewTaskManager())
+	tm := task_manager.NewTaskManager()
+	h := handlers.NewOrchestratorHandlers(tm, functions.NewRegistry(), workbooks.NewWorkbooks(tm))
 
 	t.Run("Successful", func(t *testing.T) {
 		// Добавляем выражение в список выражений чтобы получить реальный ID и таск
@@ -263,7 +705,19 @@ func TestCompleteTaskHandler(t *testing.T) {
 			Expression: expressionID,
 			ID:         taskID,
 			Result:     97.0, // Посчитали сами
+			Lease:      "чужая аренда",
 		}
+		// Результат без токена аренды, выданного с задачей, отклоняется
+		jsonBody, _ = json.Marshal(taskCompleted)
+		req, err = http.NewRequest("POST", "/internal/task", bytes.NewBuffer(jsonBody))
+		assert.NoError(t, err)
+
+		rr = httptest.NewRecorder()
+		h.CompleteTaskHandler(rr, req)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+
+		taskCompleted.Lease = taskBody.Lease
 		jsonBody, _ = json.Marshal(taskCompleted)
 		req, err = http.NewRequest("POST", "/internal/task", bytes.NewBuffer(jsonBody))
 		assert.NoError(t, err)
@@ -312,3 +766,43 @@ func TestCompleteTaskHandler(t *testing.T) {
 		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
 	})
 }
+
+// TestCancelExpressionHandler проверяет отмену выражения и проверку агентом, что вычисление нужно продолжать.
+func TestCancelExpressionHandler(t *testing.T) {
+	tm := task_manager.NewTaskManager()
+	h := handlers.NewOrchestratorHandlers(tm, functions.NewRegistry(), workbooks.NewWorkbooks(tm))
+
+	id, err := tm.AddExpression("2 + 3", nil, "", nil)
+	assert.NoError(t, err)
+	task, _, _ := tm.GetTask()
+
+	heartbeat := func() int {
+		jsonBody, _ := json.Marshal(models.TaskHeartbeat{Expression: id, ID: task.ID, Lease: task.Lease})
+		req, err := http.NewRequest("POST", "/internal/task/heartbeat", bytes.NewBuffer(jsonBody))
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		h.TaskHeartbeatHandler(rr, req)
+		return rr.Code
+	}
+	cancel := func(method, id string) int {
+		req, err := http.NewRequest(method, "/api/v1/expressions/"+id, nil)
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"id": id})
+
+		rr := httptest.NewRecorder()
+		h.CancelExpressionHandler(rr, req)
+		return rr.Code
+	}
+
+	assert.Equal(t, http.StatusOK, heartbeat())
+
+	assert.Equal(t, http.StatusNoContent, cancel("DELETE", id))
+	assert.Equal(t, http.StatusGone, heartbeat())
+	assert.Equal(t, http.StatusConflict, cancel("POST", id))
+	assert.Equal(t, http.StatusNotFound, cancel("DELETE", "missing"))
+	assert.Equal(t, http.StatusMethodNotAllowed, cancel("GET", id))
+
+	expr, _ := tm.GetExpression(id)
+	assert.Equal(t, "cancelled", expr.Status)
+}
@@ -140,7 +140,7 @@ func TestEnableCORS(t *testing.T) {
 		assert.Equal(t, http.StatusOK, rr.Code)
 		assert.Equal(t, "https://example.com", rr.Header().Get("Access-Control-Allow-Origin"))
 		assert.Equal(t, "GET, POST, OPTIONS, PUT, DELETE", rr.Header().Get("Access-Control-Allow-Methods"))
-		assert.Equal(t, "Accept, Content-Type, Content-Length, Authorization", rr.Header().Get("Access-Control-Allow-Headers"))
+		assert.Equal(t, "Accept, Content-Type, Content-Length, Authorization, X-User-ID", rr.Header().Get("Access-Control-Allow-Headers"))
 	})
 
 	t.Run("Запрос с запрещённым Origin", func(t *testing.T) {
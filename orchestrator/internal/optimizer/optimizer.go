@@ -0,0 +1,150 @@
+package optimizer
+
+import (
+	"github.com/OinkiePie/calc_2/orchestrator/internal/ast"
+	"github.com/OinkiePie/calc_2/pkg/operators"
+)
+
+// associative - операторы, цепочки которых можно перегруппировать без изменения результата
+// (с точностью до округления чисел с плавающей точкой).
+var associative = map[string]bool{
+	operators.OpAdd:      true,
+	operators.OpMultiply: true,
+}
+
+// Rebalance перестраивает цепочки ассоциативных операторов (+, *) в сбалансированные деревья.
+// Например, "1+2+3+4" разбирается как "((1+2)+3)+4" - три задачи, выполняемые строго по очереди,
+// а после перестройки превращается в "(1+2)+(3+4)", где первые две задачи выполняются параллельно.
+// Глубина цепочки из n операндов уменьшается с n-1 до ⌈log₂ n⌉, порядок операндов сохраняется.
+//
+// Args:
+//
+//	node: ast.Node - Корень синтаксического дерева.
+//
+// Returns:
+//
+//	ast.Node - Дерево с перестроенными цепочками. Исходное дерево не изменяется.
+func Rebalance(node ast.Node) ast.Node {
+	switch n := node.(type) {
+	case *ast.Unary:
+		rebalanced := *n
+		rebalanced.Operand = Rebalance(n.Operand)
+		return &rebalanced
+	case *ast.Binary:
+		if !associative[n.Op] {
+			rebalanced := *n
+			rebalanced.Left = Rebalance(n.Left)
+			rebalanced.Right = Rebalance(n.Right)
+			return &rebalanced
+		}
+		var operands []ast.Node
+		var ops []*ast.Binary
+		flattenChain(n, n.Op, &operands, &ops)
+		// Операнды перестраиваются до балансировки, чтобы учитывать их итоговую глубину
+		for i, operand := range operands {
+			operands[i] = Rebalance(operand)
+		}
+		return balance(operands, ops)
+	case *ast.Conditional:
+		rebalanced := *n
+		rebalanced.Cond = Rebalance(n.Cond)
+		rebalanced.Then = Rebalance(n.Then)
+		rebalanced.Else = Rebalance(n.Else)
+		return &rebalanced
+	case *ast.Call:
+		rebalanced := *n
+		rebalanced.Args = make([]ast.Node, len(n.Args))
+		for i, arg := range n.Args {
+			rebalanced.Args[i] = Rebalance(arg)
+		}
+		return &rebalanced
+	default:
+		return node
+	}
+}
+
+// flattenChain собирает операнды цепочки одинаковых операторов слева направо.
+//
+// Args:
+//
+//	node: ast.Node - Узел цепочки.
+//	op: string - Оператор цепочки.
+//	operands: *[]ast.Node - Срез, в который добавляются операнды цепочки.
+//	ops: *[]*ast.Binary - Срез, в который добавляются узлы операторов; ops[i] стоит между operands[i] и operands[i+1].
+func flattenChain(node ast.Node, op string, operands *[]ast.Node, ops *[]*ast.Binary) {
+	binary, ok := node.(*ast.Binary)
+	if !ok || binary.Op != op {
+		*operands = append(*operands, node)
+		return
+	}
+	flattenChain(binary.Left, op, operands, ops)
+	*ops = append(*ops, binary)
+	flattenChain(binary.Right, op, operands, ops)
+}
+
+// balance строит из операндов цепочки дерево минимальной глубины с сохранением порядка операндов.
+// На каждом шаге объединяются два соседних операнда с наименьшей глубиной большего из них
+// (при равенстве - самые левые), поэтому неглубокие операнды группируются раньше глубоких.
+// Каждый новый узел наследует позицию и признак неявного умножения оператора, стоявшего между объединяемыми операндами.
+//
+// Args:
+//
+//	operands: []ast.Node - Операнды цепочки, не менее одного.
+//	ops: []*ast.Binary - Операторы между операндами (на один меньше, чем операндов).
+//
+// Returns:
+//
+//	ast.Node - Корень построенного дерева.
+func balance(operands []ast.Node, ops []*ast.Binary) ast.Node {
+	depths := make([]int, len(operands))
+	for i, operand := range operands {
+		depths[i] = Depth(operand)
+	}
+
+	for len(operands) > 1 {
+		best := 0
+		for i := 1; i < len(operands)-1; i++ {
+			if max(depths[i], depths[i+1]) < max(depths[best], depths[best+1]) {
+				best = i
+			}
+		}
+
+		merged := *ops[best]
+		merged.Left, merged.Right = operands[best], operands[best+1]
+
+		operands = append(operands[:best], append([]ast.Node{&merged}, operands[best+2:]...)...)
+		depths = append(depths[:best], append([]int{max(depths[best], depths[best+1]) + 1}, depths[best+2:]...)...)
+		ops = append(ops[:best], ops[best+1:]...)
+	}
+	return operands[0]
+}
+
+// Depth возвращает глубину дерева - количество операций на самом длинном пути от листа до корня.
+// Равна длине критического пути задач выражения, если вариативные функции считать одной операцией.
+//
+// Args:
+//
+//	node: ast.Node - Корень дерева.
+//
+// Returns:
+//
+//	int - Глубина дерева, 0 для числа или имени.
+func Depth(node ast.Node) int {
+	switch n := node.(type) {
+	case *ast.Unary:
+		return Depth(n.Operand) + 1
+	case *ast.Binary:
+		return max(Depth(n.Left), Depth(n.Right)) + 1
+	case *ast.Conditional:
+		// Ветви начинают вычисляться только после условия, а выбор ветви - ещё одна операция
+		return Depth(n.Cond) + max(Depth(n.Then), Depth(n.Else)) + 1
+	case *ast.Call:
+		depth := 0
+		for _, arg := range n.Args {
+			depth = max(depth, Depth(arg))
+		}
+		return depth + 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,63 @@
+package optimizer_test
+
+import (
+	"testing"
+
+	"github.com/OinkiePie/calc_2/orchestrator/internal/ast"
+	"github.com/OinkiePie/calc_2/orchestrator/internal/optimizer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRebalance(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		expected   string
+		depth      int
+	}{
+		{"Addition chain", "1+2+3+4+5+6+7+8", "(((1 + 2) + (3 + 4)) + ((5 + 6) + (7 + 8)))", 3},
+		{"Odd chain", "1+2+3+4+5", "(((1 + 2) + (3 + 4)) + 5)", 3},
+		{"Multiplication chain", "1*2*3*4", "((1 * 2) * (3 * 4))", 2},
+		{"Right-nested chain", "1+(2+(3+4))", "((1 + 2) + (3 + 4))", 2},
+		{"Chain stops at other operator", "1+2+3*4*5*6", "((1 + 2) + ((3 * 4) * (5 * 6)))", 3},
+		{"Subtraction is not rebalanced", "1-2-3-4", "(((1 - 2) - 3) - 4)", 3},
+		{"Chain inside function", "sqrt(1+2+3+4)", "sqrt(((1 + 2) + (3 + 4)))", 3},
+		{"Chain inside unary minus", "-(1*2*3*4)", "(-((1 * 2) * (3 * 4)))", 3},
+		{"Chains on both sides of power", "(1+2+3+4)^(5+6+7+8)", "(((1 + 2) + (3 + 4)) ^ ((5 + 6) + (7 + 8)))", 3},
+		{"Single operation", "1+2", "(1 + 2)", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tree, err := ast.Parse(tt.expression)
+			assert.NoError(t, err)
+
+			rebalanced := optimizer.Rebalance(tree)
+
+			assert.Equal(t, tt.expected, rebalanced.String())
+			assert.Equal(t, tt.depth, optimizer.Depth(rebalanced))
+		})
+	}
+}
+
+func TestRebalanceKeepsOriginal(t *testing.T) {
+	tree, err := ast.Parse("1+2+3+4")
+	assert.NoError(t, err)
+
+	optimizer.Rebalance(tree)
+
+	assert.Equal(t, "(((1 + 2) + 3) + 4)", tree.String())
+}
+
+func TestRebalanceKeepsOperatorMetadata(t *testing.T) {
+	tree, err := ast.Parse("2x*y*z")
+	assert.NoError(t, err)
+
+	root := optimizer.Rebalance(tree).(*ast.Binary)
+
+	// Корень объединяет "2x" и "y*z", то есть соответствует явному умножению на позиции 2
+	assert.Equal(t, "((2 * x) * (y * z))", root.String())
+	assert.Equal(t, 2, root.Offset)
+	assert.False(t, root.Implicit)
+	assert.True(t, root.Left.(*ast.Binary).Implicit)
+}
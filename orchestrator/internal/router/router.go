@@ -2,9 +2,11 @@ package router
 
 import (
 	"github.com/OinkiePie/calc_2/config"
+	"github.com/OinkiePie/calc_2/orchestrator/internal/functions"
 	"github.com/OinkiePie/calc_2/orchestrator/internal/handlers"
 	"github.com/OinkiePie/calc_2/orchestrator/internal/middlewares"
 	"github.com/OinkiePie/calc_2/orchestrator/internal/task_manager"
+	"github.com/OinkiePie/calc_2/orchestrator/internal/workbooks"
 	"github.com/gorilla/mux"
 )
 
@@ -13,14 +15,13 @@ import (
 //
 // Args:
 //
-//	(None): Функция не принимает аргументов.
+//	taskManager: *task_manager.TaskManager - Менеджер выражений и задач, с которым работают обработчики.
 //
 // Returns:
 //
 //	*mux.Router: Указатель на созданный и настроенный роутер.
-func NewOrchestratorRouter() *mux.Router {
-	taskManager := task_manager.NewTaskManager()
-	handler := handlers.NewOrchestratorHandlers(taskManager)
+func NewOrchestratorRouter(taskManager *task_manager.TaskManager) *mux.Router {
+	handler := handlers.NewOrchestratorHandlers(taskManager, functions.NewRegistry(), workbooks.NewWorkbooks(taskManager))
 
 	middleware := middlewares.NewOrchestratorMiddlewares(config.Cfg.Middleware.ApiKeyPrefix, config.Cfg.Middleware.Authorization, config.Cfg.Middleware.AllowOrigin)
 
@@ -31,6 +32,17 @@ func NewOrchestratorRouter() *mux.Router {
 	router.HandleFunc("/api/v1/calculate", handler.AddExpressionHandler).Methods("POST")
 	router.HandleFunc("/api/v1/expressions", handler.GetExpressionsHandler).Methods("GET")
 	router.HandleFunc("/api/v1/expressions/{id}", handler.GetExpressionHandler).Methods("GET")
+	router.HandleFunc("/api/v1/expressions/{id}", handler.CancelExpressionHandler).Methods("DELETE")
+	router.HandleFunc("/api/v1/expressions/{id}/cancel", handler.CancelExpressionHandler).Methods("POST")
+	router.HandleFunc("/api/v1/constants", handler.GetConstantsHandler).Methods("GET")
+	router.HandleFunc("/api/v1/functions", handler.AddFunctionHandler).Methods("POST")
+	router.HandleFunc("/api/v1/functions", handler.GetFunctionsHandler).Methods("GET")
+	router.HandleFunc("/api/v1/functions/{name}", handler.GetFunctionHandler).Methods("GET")
+	router.HandleFunc("/api/v1/functions/{name}", handler.DeleteFunctionHandler).Methods("DELETE")
+	router.HandleFunc("/api/v1/workbooks/{id}", handler.GetWorkbookHandler).Methods("GET")
+	router.HandleFunc("/api/v1/workbooks/{id}/cells/{name}", handler.SetCellHandler).Methods("PUT")
+	router.HandleFunc("/api/v1/workbooks/{id}/cells/{name}", handler.GetCellHandler).Methods("GET")
+	router.HandleFunc("/api/v1/workbooks/{id}/cells/{name}", handler.DeleteCellHandler).Methods("DELETE")
 
 	// Internal endpoints (внутренние конечные точки, используемые агентом)
 	// Подмаршрутизатор для Internal endpoints
@@ -39,6 +51,7 @@ func NewOrchestratorRouter() *mux.Router {
 
 	internalRouter.HandleFunc("/task", handler.GetTaskHandler).Methods("GET")
 	internalRouter.HandleFunc("/task", handler.CompleteTaskHandler).Methods("POST")
+	internalRouter.HandleFunc("/task/heartbeat", handler.TaskHeartbeatHandler).Methods("POST")
 
 	// Debug endpoints (конечные точки, используемые только для отладки)
 	internalRouter.HandleFunc("/task/{id}", handler.GetTaskIDHandler).Methods("GET")
@@ -9,6 +9,7 @@ import (
 
 	"github.com/OinkiePie/calc_2/config"
 	"github.com/OinkiePie/calc_2/orchestrator/internal/router"
+	"github.com/OinkiePie/calc_2/orchestrator/internal/task_manager"
 	"github.com/OinkiePie/calc_2/pkg/logger"
 	"github.com/stretchr/testify/assert"
 )
@@ -25,7 +26,7 @@ func TestNewOrchestratorRouterUnauthorized(t *testing.T) {
 	config.Cfg.Middleware.ApiKeyPrefix = "Bearer "
 	config.Cfg.Middleware.Authorization = "Skibidi"
 
-	router := router.NewOrchestratorRouter()
+	router := router.NewOrchestratorRouter(task_manager.NewTaskManager())
 
 	testsGet := []struct {
 		method       string
@@ -35,9 +36,14 @@ func TestNewOrchestratorRouterUnauthorized(t *testing.T) {
 		{"POST", "/api/v1/calculate", http.StatusBadRequest}, // Пустое тело запроса
 		{"GET", "/api/v1/expressions", http.StatusOK},
 		{"GET", "/api/v1/expressions/1", http.StatusNotFound}, // Нет выражения с таким ID
+		{"DELETE", "/api/v1/expressions/1", http.StatusNotFound},
+		{"POST", "/api/v1/expressions/1/cancel", http.StatusNotFound},
+		{"GET", "/api/v1/constants", http.StatusOK},
+		{"GET", "/api/v1/workbooks/1", http.StatusNotFound}, // Нет книги с таким ID
 		{"GET", "/internal/task", http.StatusUnauthorized},
 		{"GET", "/internal/task/1", http.StatusUnauthorized},
 		{"POST", "/internal/task", http.StatusUnauthorized},
+		{"POST", "/internal/task/heartbeat", http.StatusUnauthorized},
 	}
 
 	for _, tt := range testsGet {
@@ -56,7 +62,7 @@ func TestNewOrchestratorRouterAuthorized(t *testing.T) {
 	config.Cfg.Middleware.ApiKeyPrefix = "Bearer "
 	config.Cfg.Middleware.Authorization = "2KluchaAvtorizaciiMneIli2Drugomu"
 
-	router := router.NewOrchestratorRouter()
+	router := router.NewOrchestratorRouter(task_manager.NewTaskManager())
 
 	authHeader := config.Cfg.Middleware.ApiKeyPrefix + config.Cfg.Middleware.Authorization
 	tests := []struct {
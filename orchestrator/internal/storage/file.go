@@ -0,0 +1,284 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/OinkiePie/calc_2/pkg/logger"
+	"github.com/OinkiePie/calc_2/pkg/models"
+)
+
+// Файлы хранилища в директории FileStore.
+const (
+	snapshotFile = "snapshot.json"
+	journalFile  = "journal.jsonl"
+)
+
+// Операции записей журнала.
+const (
+	opPut    = "put"    // Добавление или замена выражения.
+	opTask   = "task"   // Замена задачи выражения.
+	opDelete = "delete" // Удаление выражения.
+)
+
+var errJournal = errors.New("журнал хранилища поврежден")
+
+// record - запись журнала FileStore, одна строка JSON.
+type record struct {
+	Op         string             `json:"op"`
+	Expression *models.Expression `json:"expression,omitempty"`
+	ID         string             `json:"id,omitempty"` // ID выражения для opTask и opDelete.
+	Task       *models.Task       `json:"task,omitempty"`
+}
+
+// FileStore - хранилище выражений на диске. Выражения хранятся в памяти, а каждое изменение дописывается
+// в журнал (journal.jsonl). Каждые snapshotInterval записей состояние целиком сохраняется в снимок (snapshot.json),
+// после чего журнал очищается. При открытии хранилища снимок загружается, а журнал применяется к нему.
+type FileStore struct {
+	memory           *MemoryStore
+	dir              string
+	journal          *os.File
+	records          int  // Записей в журнале после последнего снимка.
+	snapshotInterval int  // 0 - снимок только при открытии и закрытии хранилища.
+	sync             bool // Сбрасывать журнал на диск после каждой записи.
+	mu               sync.Mutex
+}
+
+// NewFileStore открывает хранилище в директории dir и восстанавливает сохраненные выражения.
+// Недописанная последняя запись журнала (например, после аварийной остановки) пропускается.
+//
+// Args:
+//
+//	dir: string - Директория файлов хранилища, создается при необходимости.
+//	snapshotInterval: int - Количество записей журнала между снимками, 0 - без промежуточных снимков.
+//	sync: bool - Сбрасывать журнал на диск после каждой записи.
+//
+// Returns:
+//
+//	*FileStore - Указатель на открытое хранилище.
+//	error - Ошибка чтения или записи файлов хранилища, либо поврежденная запись в середине журнала.
+func NewFileStore(dir string, snapshotInterval int, sync bool) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("не удалось создать директорию хранилища: %w", err)
+	}
+	s := &FileStore{memory: NewMemoryStore(), dir: dir, snapshotInterval: snapshotInterval, sync: sync}
+	if err := s.loadSnapshot(); err != nil {
+		return nil, err
+	}
+	if err := s.replay(); err != nil {
+		return nil, err
+	}
+
+	journal, err := os.OpenFile(filepath.Join(dir, journalFile), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось открыть журнал хранилища: %w", err)
+	}
+	s.journal = journal
+	// Восстановленное состояние сразу сохраняется в снимок: журнал начинается заново, без недописанных записей
+	if err := s.snapshot(); err != nil {
+		journal.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// loadSnapshot загружает выражения из снимка, если он есть.
+func (s *FileStore) loadSnapshot() error {
+	data, err := os.ReadFile(filepath.Join(s.dir, snapshotFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("не удалось прочитать снимок хранилища: %w", err)
+	}
+
+	var expressions []models.Expression
+	if err := json.Unmarshal(data, &expressions); err != nil {
+		return fmt.Errorf("снимок хранилища поврежден: %w", err)
+	}
+	for _, expression := range expressions {
+		s.memory.PutExpression(expression)
+	}
+	return nil
+}
+
+// replay применяет записи журнала к загруженному снимку.
+func (s *FileStore) replay() error {
+	data, err := os.ReadFile(filepath.Join(s.dir, journalFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("не удалось прочитать журнал хранилища: %w", err)
+	}
+
+	lines := bytes.Split(data, []byte("\n"))
+	for i, line := range lines {
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		var rec record
+		err := json.Unmarshal(line, &rec)
+		if err != nil && i == len(lines)-1 {
+			// Последняя строка без перевода строки - запись, прерванная остановкой оркестратора
+			logger.Log.Warnf("Пропущена недописанная запись журнала хранилища: %v", err)
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("%w: строка %d: %v", errJournal, i+1, err)
+		}
+		if err := s.apply(rec); err != nil {
+			return fmt.Errorf("%w: строка %d: %v", errJournal, i+1, err)
+		}
+	}
+	return nil
+}
+
+// apply применяет запись журнала к выражениям в памяти.
+func (s *FileStore) apply(rec record) error {
+	switch rec.Op {
+	case opPut:
+		if rec.Expression == nil {
+			return fmt.Errorf("запись %s без выражения", rec.Op)
+		}
+		return s.memory.PutExpression(*rec.Expression)
+	case opTask:
+		if rec.Task == nil {
+			return fmt.Errorf("запись %s без задачи", rec.Op)
+		}
+		return s.memory.UpdateTask(rec.ID, *rec.Task)
+	case opDelete:
+		return s.memory.DeleteExpression(rec.ID)
+	default:
+		return fmt.Errorf("неизвестная операция %q", rec.Op)
+	}
+}
+
+// write применяет запись к выражениям в памяти и дописывает её в журнал. Вызывается под блокировкой mu.
+// Если запись не удалось дописать, изменение остается в памяти, но будет потеряно при перезапуске.
+func (s *FileStore) write(rec record) error {
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("не удалось сериализовать запись журнала: %w", err)
+	}
+	if err := s.apply(rec); err != nil {
+		return err
+	}
+
+	if _, err := s.journal.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("не удалось дописать журнал хранилища: %w", err)
+	}
+	if s.sync {
+		if err := s.journal.Sync(); err != nil {
+			return fmt.Errorf("не удалось сбросить журнал хранилища на диск: %w", err)
+		}
+	}
+	s.records++
+	if s.snapshotInterval > 0 && s.records >= s.snapshotInterval {
+		return s.snapshot()
+	}
+	return nil
+}
+
+// snapshot сохраняет все выражения в снимок и очищает журнал. Вызывается под блокировкой mu.
+// Снимок записывается во временный файл и заменяет прежний переименованием, поэтому прерванная запись
+// снимка не повреждает сохраненное состояние.
+func (s *FileStore) snapshot() error {
+	data, err := json.Marshal(s.memory.ListExpressions())
+	if err != nil {
+		return fmt.Errorf("не удалось сериализовать снимок хранилища: %w", err)
+	}
+
+	path := filepath.Join(s.dir, snapshotFile)
+	tmp, err := os.CreateTemp(s.dir, snapshotFile+".*")
+	if err != nil {
+		return fmt.Errorf("не удалось создать снимок хранилища: %w", err)
+	}
+	defer os.Remove(tmp.Name()) // После переименования файла уже нет, ошибка игнорируется
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("не удалось записать снимок хранилища: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("не удалось записать снимок хранилища: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("не удалось записать снимок хранилища: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("не удалось заменить снимок хранилища: %w", err)
+	}
+
+	// Записи журнала вошли в снимок
+	if err := s.journal.Truncate(0); err != nil {
+		return fmt.Errorf("не удалось очистить журнал хранилища: %w", err)
+	}
+	s.records = 0
+	return nil
+}
+
+// PutExpression добавляет выражение или заменяет выражение с тем же ID.
+func (s *FileStore) PutExpression(expression models.Expression) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.write(record{Op: opPut, Expression: &expression})
+}
+
+// GetExpression возвращает выражение по ID.
+func (s *FileStore) GetExpression(id string) (models.Expression, bool) {
+	return s.memory.GetExpression(id)
+}
+
+// ListExpressions возвращает все выражения в произвольном порядке.
+func (s *FileStore) ListExpressions() []models.Expression {
+	return s.memory.ListExpressions()
+}
+
+// UpdateExpression заменяет сохраненное выражение.
+func (s *FileStore) UpdateExpression(expression models.Expression) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.memory.GetExpression(expression.ID); !ok {
+		return fmt.Errorf("%w: %s", ErrNotFound, expression.ID)
+	}
+	return s.write(record{Op: opPut, Expression: &expression})
+}
+
+// UpdateTask заменяет задачу выражения.
+func (s *FileStore) UpdateTask(expressionID string, task models.Task) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.write(record{Op: opTask, ID: expressionID, Task: &task})
+}
+
+// DeleteExpression удаляет выражение.
+func (s *FileStore) DeleteExpression(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.memory.GetExpression(id); !ok {
+		return nil
+	}
+	return s.write(record{Op: opDelete, ID: id})
+}
+
+// Close сохраняет снимок и закрывает журнал.
+func (s *FileStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	err := s.snapshot()
+	if closeErr := s.journal.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
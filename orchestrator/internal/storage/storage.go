@@ -0,0 +1,155 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/OinkiePie/calc_2/config"
+	"github.com/OinkiePie/calc_2/pkg/models"
+)
+
+// Типы хранилищ (см. config.StorageConfig.Type).
+const (
+	TypeMemory = "memory"
+	TypeFile   = "file"
+)
+
+var (
+	ErrNotFound = errors.New("выражение не найдено в хранилище")
+	errType     = errors.New("неизвестный тип хранилища")
+)
+
+// Store - хранилище выражений и их задач, с которым работает TaskManager.
+// Реализации безопасны для конкурентного использования. Возвращаемые выражения разделяют срезы задач
+// с сохраненными: изменения нужно записывать через UpdateExpression или UpdateTask.
+type Store interface {
+	// PutExpression добавляет выражение или заменяет выражение с тем же ID.
+	PutExpression(expression models.Expression) error
+	// GetExpression возвращает выражение по ID и true, если оно найдено.
+	GetExpression(id string) (models.Expression, bool)
+	// ListExpressions возвращает все выражения.
+	ListExpressions() []models.Expression
+	// UpdateExpression заменяет сохраненное выражение. ErrNotFound, если выражения нет.
+	UpdateExpression(expression models.Expression) error
+	// UpdateTask заменяет задачу выражения с тем же ID. ErrNotFound, если нет выражения или задачи.
+	UpdateTask(expressionID string, task models.Task) error
+	// DeleteExpression удаляет выражение. Удаление отсутствующего выражения не является ошибкой.
+	DeleteExpression(id string) error
+	// Close сохраняет несохраненное состояние и освобождает ресурсы хранилища.
+	Close() error
+}
+
+// Open создает хранилище по параметрам конфигурации.
+//
+// Args:
+//
+//	cfg: config.StorageConfig - Параметры хранилища.
+//
+// Returns:
+//
+//	Store - Хранилище: MemoryStore для типа "memory" (и пустого типа), FileStore для типа "file".
+//	error - Ошибка, если тип хранилища неизвестен или состояние FileStore не удалось восстановить.
+func Open(cfg config.StorageConfig) (Store, error) {
+	switch cfg.Type {
+	case "", TypeMemory:
+		return NewMemoryStore(), nil
+	case TypeFile:
+		return NewFileStore(cfg.Dir, cfg.SnapshotInterval, cfg.Sync)
+	default:
+		return nil, fmt.Errorf("%w: %s", errType, cfg.Type)
+	}
+}
+
+// MemoryStore - хранилище выражений в памяти. Выражения теряются при перезапуске оркестратора.
+type MemoryStore struct {
+	expressions map[string]models.Expression
+	mu          sync.RWMutex
+}
+
+// NewMemoryStore - конструктор для MemoryStore.
+//
+// Args:
+//
+//	(None) - Функция не принимает аргументов.
+//
+// Returns:
+//
+//	*MemoryStore - Указатель на новое пустое хранилище.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{expressions: make(map[string]models.Expression)}
+}
+
+// PutExpression добавляет выражение или заменяет выражение с тем же ID.
+func (s *MemoryStore) PutExpression(expression models.Expression) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.expressions[expression.ID] = expression
+	return nil
+}
+
+// GetExpression возвращает выражение по ID.
+func (s *MemoryStore) GetExpression(id string) (models.Expression, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	expression, ok := s.expressions[id]
+	return expression, ok
+}
+
+// ListExpressions возвращает все выражения в произвольном порядке.
+func (s *MemoryStore) ListExpressions() []models.Expression {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	list := make([]models.Expression, 0, len(s.expressions))
+	for _, expression := range s.expressions {
+		list = append(list, expression)
+	}
+	return list
+}
+
+// UpdateExpression заменяет сохраненное выражение.
+func (s *MemoryStore) UpdateExpression(expression models.Expression) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.expressions[expression.ID]; !ok {
+		return fmt.Errorf("%w: %s", ErrNotFound, expression.ID)
+	}
+	s.expressions[expression.ID] = expression
+	return nil
+}
+
+// UpdateTask заменяет задачу выражения.
+func (s *MemoryStore) UpdateTask(expressionID string, task models.Task) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	expression, ok := s.expressions[expressionID]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrNotFound, expressionID)
+	}
+	for i := range expression.Tasks {
+		if expression.Tasks[i].ID == task.ID {
+			expression.Tasks[i] = task
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: задача %s выражения %s", ErrNotFound, task.ID, expressionID)
+}
+
+// DeleteExpression удаляет выражение.
+func (s *MemoryStore) DeleteExpression(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.expressions, id)
+	return nil
+}
+
+// Close ничего не делает: хранилищу в памяти нечего сохранять.
+func (s *MemoryStore) Close() error {
+	return nil
+}
@@ -0,0 +1,158 @@
+package storage_test
+
+import (
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/OinkiePie/calc_2/config"
+	"github.com/OinkiePie/calc_2/orchestrator/internal/storage"
+	"github.com/OinkiePie/calc_2/pkg/logger"
+	"github.com/OinkiePie/calc_2/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	// Отключаем выводы и инициализируем конфиг
+	log.SetOutput(io.Discard)
+	config.InitConfig()
+	logger.InitLogger(logger.Options{Level: 6})
+}
+
+// newExpression создает выражение с двумя задачами для тестов.
+func newExpression(id string) models.Expression {
+	a, b := 2.0, 3.0
+	return models.Expression{
+		ID:               id,
+		Status:           "pending",
+		ExpressionString: "2 + 3 * 3",
+		Tasks: []models.Task{
+			{ID: id + "-1", Args: []*float64{&b, &b}, Operation: "*", Dependencies: []string{"", ""}, Status: "pending", Expression: id},
+			{ID: id + "-2", Args: []*float64{&a, nil}, Operation: "+", Dependencies: []string{"", id + "-1"}, Status: "pending", Expression: id},
+		},
+	}
+}
+
+// testStore проверяет операции, общие для всех реализаций хранилища.
+func testStore(t *testing.T, store storage.Store) {
+	assert.NoError(t, store.PutExpression(newExpression("e1")))
+	assert.NoError(t, store.PutExpression(newExpression("e2")))
+	assert.Len(t, store.ListExpressions(), 2)
+
+	expr, ok := store.GetExpression("e1")
+	assert.True(t, ok)
+	assert.Equal(t, "2 + 3 * 3", expr.ExpressionString)
+
+	task := expr.Tasks[0]
+	task.Status = "processing"
+	assert.NoError(t, store.UpdateTask("e1", task))
+	expr, _ = store.GetExpression("e1")
+	assert.Equal(t, "processing", expr.Tasks[0].Status)
+
+	expr.Status = "error"
+	assert.NoError(t, store.UpdateExpression(expr))
+	expr, _ = store.GetExpression("e1")
+	assert.Equal(t, "error", expr.Status)
+
+	assert.ErrorIs(t, store.UpdateExpression(newExpression("missing")), storage.ErrNotFound)
+	assert.ErrorIs(t, store.UpdateTask("missing", task), storage.ErrNotFound)
+	assert.ErrorIs(t, store.UpdateTask("e2", models.Task{ID: "missing"}), storage.ErrNotFound)
+
+	assert.NoError(t, store.DeleteExpression("e2"))
+	assert.NoError(t, store.DeleteExpression("e2"))
+	_, ok = store.GetExpression("e2")
+	assert.False(t, ok)
+}
+
+// TestMemoryStore проверяет хранилище в памяти.
+func TestMemoryStore(t *testing.T) {
+	testStore(t, storage.NewMemoryStore())
+}
+
+// TestFileStore проверяет хранилище на диске и восстановление выражений после повторного открытия.
+func TestFileStore(t *testing.T) {
+	dir := t.TempDir()
+	store, err := storage.NewFileStore(dir, 0, true)
+	assert.NoError(t, err)
+	testStore(t, store)
+
+	// Без Close: состояние восстанавливается из журнала, как после аварийной остановки
+	reopened, err := storage.NewFileStore(dir, 0, false)
+	assert.NoError(t, err)
+	assert.Len(t, reopened.ListExpressions(), 1)
+	expr, ok := reopened.GetExpression("e1")
+	assert.True(t, ok)
+	assert.Equal(t, "error", expr.Status)
+	assert.Equal(t, "processing", expr.Tasks[0].Status)
+	if assert.NotNil(t, expr.Tasks[1].Args[0]) {
+		assert.Equal(t, 2.0, *expr.Tasks[1].Args[0])
+	}
+	assert.Nil(t, expr.Tasks[1].Args[1])
+	assert.NoError(t, reopened.Close())
+}
+
+// TestFileStoreSnapshot проверяет сохранение снимка и очистку журнала.
+func TestFileStoreSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	store, err := storage.NewFileStore(dir, 3, false)
+	assert.NoError(t, err)
+
+	for _, id := range []string{"e1", "e2", "e3"} {
+		assert.NoError(t, store.PutExpression(newExpression(id)))
+	}
+	// Третья запись сохранила снимок и очистила журнал
+	journal, err := os.ReadFile(filepath.Join(dir, "journal.jsonl"))
+	assert.NoError(t, err)
+	assert.Empty(t, journal)
+
+	assert.NoError(t, store.DeleteExpression("e1"))
+	journal, _ = os.ReadFile(filepath.Join(dir, "journal.jsonl"))
+	assert.NotEmpty(t, journal)
+
+	reopened, err := storage.NewFileStore(dir, 3, false)
+	assert.NoError(t, err)
+	assert.Len(t, reopened.ListExpressions(), 2)
+}
+
+// TestFileStoreJournalErrors проверяет восстановление из поврежденного журнала.
+func TestFileStoreJournalErrors(t *testing.T) {
+	dir := t.TempDir()
+	store, err := storage.NewFileStore(dir, 0, false)
+	assert.NoError(t, err)
+	assert.NoError(t, store.PutExpression(newExpression("e1")))
+
+	// Недописанная последняя запись пропускается
+	journal, err := os.OpenFile(filepath.Join(dir, "journal.jsonl"), os.O_WRONLY|os.O_APPEND, 0o644)
+	assert.NoError(t, err)
+	_, err = journal.WriteString(`{"op":"put","expression":{"ID":"e2"`)
+	assert.NoError(t, err)
+	journal.Close()
+
+	reopened, err := storage.NewFileStore(dir, 0, false)
+	assert.NoError(t, err)
+	assert.Len(t, reopened.ListExpressions(), 1)
+
+	// Поврежденная запись в середине журнала - ошибка
+	assert.NoError(t, reopened.PutExpression(newExpression("e2")))
+	err = os.WriteFile(filepath.Join(dir, "journal.jsonl"), []byte("{\"op\":\n{\"op\":\"delete\",\"id\":\"e1\"}\n"), 0o644)
+	assert.NoError(t, err)
+	_, err = storage.NewFileStore(dir, 0, false)
+	assert.ErrorContains(t, err, "журнал хранилища поврежден: строка 1")
+}
+
+// TestOpen проверяет выбор хранилища по конфигурации.
+func TestOpen(t *testing.T) {
+	store, err := storage.Open(config.StorageConfig{Type: storage.TypeMemory})
+	assert.NoError(t, err)
+	assert.IsType(t, &storage.MemoryStore{}, store)
+
+	store, err = storage.Open(config.StorageConfig{Type: storage.TypeFile, Dir: t.TempDir()})
+	assert.NoError(t, err)
+	assert.IsType(t, &storage.FileStore{}, store)
+	assert.NoError(t, store.Close())
+
+	_, err = storage.Open(config.StorageConfig{Type: "redis"})
+	assert.ErrorContains(t, err, "redis")
+}
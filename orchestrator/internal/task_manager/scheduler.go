@@ -0,0 +1,163 @@
+package task_manager
+
+import (
+	"slices"
+
+	"github.com/OinkiePie/calc_2/pkg/models"
+	"github.com/OinkiePie/calc_2/pkg/operators"
+)
+
+// taskRef - ссылка на задачу выражения в очереди готовых задач.
+type taskRef struct {
+	expressionID string
+	taskID       string
+}
+
+// scheduler - очередь задач, готовых к выдаче агентам, и индекс ожидающих задач.
+// Задача попадает в очередь, когда вычислены все её зависимости и условия ветвей, поэтому GetTask
+// не просматривает выражения, а берет задачу из начала очереди. Методы вызываются под блокировкой expressionsMu.
+type scheduler struct {
+	// ready - Очередь готовых задач. Задачи, ставшие неактуальными (выражение удалено или завершилось ошибкой,
+	// задача пропущена), не удаляются из очереди, а пропускаются при выдаче (см. TaskManager.GetTask).
+	ready []taskRef
+	// unmet - Количество невычисленных зависимостей и условий ветвей ожидающей задачи: ID задачи -> количество.
+	unmet map[string]int
+	// waiting - Обратный индекс зависимостей: ID задачи -> ID задач того же выражения, ожидающих её результата.
+	waiting map[string][]string
+	// leased - Задачи, выданные агентам: ID задачи -> ID выражения. По нему ReapExpired находит истекшие аренды.
+	leased map[string]string
+}
+
+// newScheduler создает пустую очередь задач.
+func newScheduler() *scheduler {
+	return &scheduler{
+		unmet:   make(map[string]int),
+		waiting: make(map[string][]string),
+		leased:  make(map[string]string),
+	}
+}
+
+// prerequisites возвращает ID задач, которые должны быть вычислены до выдачи задачи: зависимости и условия ветвей.
+//
+// Args:
+//
+//	task: *models.Task - Задача.
+//
+// Returns:
+//
+//	[]string - ID задач без повторов.
+func prerequisites(task *models.Task) []string {
+	var ids []string
+	for _, id := range task.Dependencies {
+		if id != "" && !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	for _, guard := range task.Guards {
+		if !slices.Contains(ids, guard.Condition) {
+			ids = append(ids, guard.Condition)
+		}
+	}
+	return ids
+}
+
+// add индексирует ожидающие задачи выражения: готовые ставятся в очередь, остальные ждут своих зависимостей.
+//
+// Args:
+//
+//	expr: models.Expression - Добавленное или восстановленное выражение.
+func (s *scheduler) add(expr models.Expression) {
+	completed := make(map[string]bool, len(expr.Tasks))
+	for _, task := range expr.Tasks {
+		completed[task.ID] = task.Status == "completed"
+	}
+
+	for i := range expr.Tasks {
+		task := &expr.Tasks[i]
+		if task.Status != "pending" {
+			continue
+		}
+		unmet := 0
+		for _, id := range prerequisites(task) {
+			if !completed[id] {
+				unmet++
+				s.waiting[id] = append(s.waiting[id], task.ID)
+			}
+		}
+		if unmet > 0 {
+			s.unmet[task.ID] = unmet
+			continue
+		}
+		s.push(expr, task)
+	}
+}
+
+// push ставит задачу в очередь, если её может выполнить агент. Выбор ветви и ссылки на другие выражения
+// выполняет оркестратор, а задачи невыбранных ветвей не выполняются.
+//
+// Args:
+//
+//	expr: models.Expression - Выражение задачи.
+//	task: *models.Task - Задача, все зависимости и условия которой вычислены.
+func (s *scheduler) push(expr models.Expression, task *models.Task) {
+	if task.Status != "pending" || task.Operation == operators.OpSelect || task.Operation == operators.OpReference ||
+		guardState(expr.Tasks, task) != guardPassed {
+		return
+	}
+	s.ready = append(s.ready, taskRef{expressionID: expr.ID, taskID: task.ID})
+}
+
+// pop возвращает задачу из начала очереди.
+//
+// Returns:
+//
+//	taskRef - Ссылка на задачу.
+//	bool - false, если очередь пуста.
+func (s *scheduler) pop() (taskRef, bool) {
+	if len(s.ready) == 0 {
+		return taskRef{}, false
+	}
+	ref := s.ready[0]
+	s.ready[0] = taskRef{}
+	s.ready = s.ready[1:]
+	if len(s.ready) == 0 {
+		s.ready = nil // Освобождаем массив, который больше не используется
+	}
+	return ref, true
+}
+
+// complete сообщает задачам, ожидающим задачу taskID, что она вычислена. Задачи, у которых не осталось
+// невычисленных зависимостей, ставятся в очередь.
+//
+// Args:
+//
+//	expr: models.Expression - Выражение после завершения задачи и выбора ветвей.
+//	taskID: string - ID вычисленной задачи.
+func (s *scheduler) complete(expr models.Expression, taskID string) {
+	delete(s.leased, taskID)
+	for _, dependentID := range s.waiting[taskID] {
+		s.unmet[dependentID]--
+		if s.unmet[dependentID] > 0 {
+			continue
+		}
+		delete(s.unmet, dependentID)
+		if task := findTask(expr.Tasks, dependentID); task != nil {
+			s.push(expr, task)
+		}
+	}
+	delete(s.waiting, taskID)
+}
+
+// remove удаляет задачи выражения из индекса, когда выражение вычислено или завершилось ошибкой.
+// Ссылки в очереди остаются и пропускаются при выдаче.
+//
+// Args:
+//
+//	expr: models.Expression - Завершенное выражение.
+func (s *scheduler) remove(expr models.Expression) {
+	for _, task := range expr.Tasks {
+		delete(s.unmet, task.ID)
+		delete(s.waiting, task.ID)
+		delete(s.leased, task.ID)
+	}
+}
@@ -1,23 +1,58 @@
 package task_manager
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
 	"sync"
+	"time"
 
+	"github.com/OinkiePie/calc_2/config"
+	"github.com/OinkiePie/calc_2/orchestrator/internal/functions"
+	"github.com/OinkiePie/calc_2/orchestrator/internal/storage"
 	"github.com/OinkiePie/calc_2/orchestrator/internal/task_splitter"
+	"github.com/OinkiePie/calc_2/pkg/evaluator"
 	"github.com/OinkiePie/calc_2/pkg/logger"
 	"github.com/OinkiePie/calc_2/pkg/models"
+	"github.com/OinkiePie/calc_2/pkg/operators"
 	"github.com/google/uuid"
 )
 
+var errReferenceNotFound = errors.New("выражение по ссылке не найдено")
+
+var (
+	// ErrExpressionNotFound - выражение с указанным ID не найдено.
+	ErrExpressionNotFound = errors.New("выражение не найдено")
+	// ErrExpressionFinished - выражение уже вычислено, завершилось ошибкой или отменено.
+	ErrExpressionFinished = errors.New("выражение уже завершено")
+	// ErrExpressionCancelled - выражение, которому принадлежит задача, отменено.
+	ErrExpressionCancelled = errors.New("выражение отменено")
+	// ErrLeaseInvalid - задача не выдана агенту с этой арендой или аренда истекла.
+	ErrLeaseInvalid = errors.New("задача не найдена или её аренда истекла")
+)
+
 // TaskManager - структура, управляющая списком выражений и задачами.
 type TaskManager struct {
-	// expressions - Хранилище выражений, где ключ - ID выражения, значение - структура Expression.
-	expressions map[string]models.Expression
-	// expressionsMu - Mutex для защиты map от конкурентного доступа (чтения и записи).
+	// store - Хранилище выражений (в памяти или на диске, см. storage.Open).
+	store storage.Store
+	// dependents - Выражения, ожидающие результата другого выражения: ID выражения -> ID выражений со ссылками на него.
+	dependents map[string][]string
+	// scheduler - Очередь задач, готовых к выдаче агентам (см. GetTask).
+	scheduler *scheduler
+	// used - Время завершения или последнего запроса завершенных выражений: ID выражения -> время.
+	// По нему политика хранения находит устаревшие выражения (см. Evict).
+	used map[string]time.Time
+	// pinned - Выражения, которые не удаляются политикой хранения, пока их используют подсистемы оркестратора
+	// (например, выражения ячеек рабочих книг, см. Pin).
+	pinned map[string]bool
+	// expressionsMu - Mutex, делающий изменения выражения и его задач в хранилище атомарными.
 	expressionsMu sync.RWMutex
 }
 
-// NewTaskManager - конструктор для TaskManager. Создает и возвращает новый экземпляр TaskManager.
+// NewTaskManager - конструктор для TaskManager. Создает и возвращает новый экземпляр TaskManager
+// с хранилищем выражений в памяти.
 //
 // Args:
 //
@@ -27,49 +62,252 @@ type TaskManager struct {
 //
 //	*TaskManager - Указатель на новый экземпляр TaskManager.
 func NewTaskManager() *TaskManager {
-	// Инициализируем map для хранения выражений.
-	return &TaskManager{
-		expressions: make(map[string]models.Expression),
+	// Пустое хранилище в памяти восстанавливается без ошибок
+	tm, _ := NewTaskManagerWithStore(storage.NewMemoryStore())
+	return tm
+}
+
+// NewTaskManagerWithStore - конструктор для TaskManager, работающего с заданным хранилищем.
+// Задачи, выданные агентам до перезапуска оркестратора (статус "processing"), возвращаются в очередь
+// и теряют аренду: результат агента, вычислявшего их, уже не будет принят. Ссылки "$ref(id)" невычисленных выражений
+// снова ожидают выражения, на которые ссылаются.
+//
+// Args:
+//
+//	store: storage.Store - Хранилище выражений, возможно с выражениями, сохраненными до перезапуска.
+//
+// Returns:
+//
+//	*TaskManager - Указатель на новый экземпляр TaskManager.
+//	error - Ошибка, если не удалось сохранить задачи, возвращенные в очередь.
+func NewTaskManagerWithStore(store storage.Store) (*TaskManager, error) {
+	tm := &TaskManager{
+		store:      store,
+		dependents: make(map[string][]string),
+		scheduler:  newScheduler(),
+		used:       make(map[string]time.Time),
+		pinned:     make(map[string]bool),
+	}
+
+	type reference struct{ expressionID, taskID, referenced string }
+	var references []reference
+	expressions := store.ListExpressions()
+	requeued := 0
+	now := time.Now()
+	for _, expr := range expressions {
+		// Время запросов до перезапуска не сохраняется, срок хранения завершенных выражений отсчитывается заново
+		if finished(expr.Status) {
+			tm.used[expr.ID] = now
+		}
+		if expr.Status != "pending" && expr.Status != "processing" {
+			continue
+		}
+		for _, task := range expr.Tasks {
+			if task.Status == "processing" {
+				releaseLease(&task)
+				if err := store.UpdateTask(expr.ID, task); err != nil {
+					return nil, err
+				}
+				requeued++
+			}
+			if task.Operation == operators.OpReference && task.Status == "pending" {
+				references = append(references, reference{expr.ID, task.ID, task.Reference})
+			}
+		}
+		expr, _ = store.GetExpression(expr.ID)
+		tm.scheduler.add(expr)
+	}
+
+	for _, ref := range references {
+		referenced, ok := store.GetExpression(ref.referenced)
+		switch {
+		case !ok:
+			tm.completeTask(ref.expressionID, ref.taskID, fmt.Sprintf("%s: %s", errReferenceNotFound, ref.referenced), models.Task{})
+		case finished(referenced.Status):
+			tm.resolveReference(ref.expressionID, ref.taskID, referenced)
+		case !slices.Contains(tm.dependents[ref.referenced], ref.expressionID):
+			tm.dependents[ref.referenced] = append(tm.dependents[ref.referenced], ref.expressionID)
+		}
+	}
+
+	if len(expressions) > 0 {
+		logger.Log.Infof("Восстановлено выражений: %d, задач возвращено в очередь: %d", len(expressions), requeued)
+	}
+	return tm, nil
+}
+
+// Close закрывает хранилище выражений, сохраняя его состояние.
+//
+// Returns:
+//
+//	error - Ошибка сохранения состояния хранилища.
+func (tm *TaskManager) Close() error {
+	tm.expressionsMu.Lock()
+	defer tm.expressionsMu.Unlock()
+
+	return tm.store.Close()
+}
+
+// save записывает измененное выражение в хранилище. Ошибка хранилища не прерывает вычисление:
+// выражение продолжает вычисляться, но изменение может быть потеряно при перезапуске.
+// Вызывается под блокировкой expressionsMu.
+func (tm *TaskManager) save(expr models.Expression) {
+	if err := tm.store.UpdateExpression(expr); err != nil {
+		logger.Log.Errorf("Не удалось сохранить выражение %s: %v", expr.ID, err)
 	}
 }
 
 // AddExpression - добавляет новое выражение в TaskManager.
+// Ссылки "$ref(id)" на уже вычисленные выражения получают их результат сразу, остальные - когда выражение
+// будет вычислено (см. notifyDependents). Ошибка выражения по ссылке становится ошибкой нового выражения.
 //
 // Args:
 //
 //	expressionString: string - Строка, представляющая арифметическое выражение.
+//	variables: map[string]float64 - Значения переменных, используемых в выражении (может быть nil).
+//	precision: string - Точность вычислений (см. task_splitter.SplitExpression), пустая строка - float64.
+//	userFunctions: map[string]*functions.Function - Пользовательские функции, доступные выражению (может быть nil).
 //
 // Returns:
 //
 //	string - ID добавленного выражения.
-//	error - Ошибка, если не удалось добавить выражение.
-func (tm *TaskManager) AddExpression(expressionString string) (string, error) {
+//	error - Ошибка, если не удалось добавить выражение или выражение по ссылке не найдено.
+func (tm *TaskManager) AddExpression(expressionString string, variables map[string]float64, precision string,
+	userFunctions map[string]*functions.Function) (string, error) {
 	tm.expressionsMu.Lock()
 	defer tm.expressionsMu.Unlock()
 
 	// Генерируем уникальный ID для выражения.
 	id := uuid.New().String()
 
-	// Разбираем выражение на задачи с помощью task_splitter.ParseExpression.
-	tasks, err := task_splitter.ParseExpression(id, expressionString)
+	// Разбираем выражение на задачи с помощью task_splitter.SplitExpression.
+	plan, err := task_splitter.SplitExpression(id, expressionString, variables, precision, userFunctions)
 	if err != nil {
 		return "", err
 	}
+	for _, task := range plan.Tasks {
+		if _, ok := tm.store.GetExpression(task.Reference); task.Operation == operators.OpReference && !ok {
+			return "", fmt.Errorf("%w: %s", errReferenceNotFound, task.Reference)
+		}
+	}
 	// Создаем структуру Expression.
 	expression := models.Expression{
 		ID:               id,
 		Status:           "pending",
 		Result:           nil,
-		Tasks:            tasks,
+		Tasks:            plan.Tasks,
 		ExpressionString: expressionString,
+		Variables:        variables,
+		Stats:            plan.Stats,
+		Precision:        precision,
+		Unit:             plan.Unit,
+		Functions:        plan.Functions,
+		CreatedAt:        time.Now(),
+	}
+	if precision == "" {
+		expression.Precision = evaluator.PrecisionFloat64
+	}
+	// Полностью свернутое выражение уже вычислено и не требует задач
+	if plan.Result != nil {
+		expression.Status = "completed"
+		expression.Result = plan.Result
+		expression.ExactResult = plan.ExactResult
+		expression.ComplexResult = plan.ComplexResult
+	}
+
+	// Добавляем выражение в хранилище.
+	if err := tm.store.PutExpression(expression); err != nil {
+		return "", fmt.Errorf("не удалось сохранить выражение: %w", err)
+	}
+	if expression.Status == "pending" {
+		tm.scheduler.add(expression)
+	} else {
+		tm.used[id] = time.Now()
 	}
 
-	// Добавляем выражение в map выражений.
-	tm.expressions[id] = expression
+	for _, task := range plan.Tasks {
+		if task.Operation != operators.OpReference {
+			continue
+		}
+		referenced, _ := tm.store.GetExpression(task.Reference)
+		if finished(referenced.Status) {
+			tm.resolveReference(id, task.ID, referenced)
+		} else if !slices.Contains(tm.dependents[task.Reference], id) {
+			tm.dependents[task.Reference] = append(tm.dependents[task.Reference], id)
+		}
+	}
 
 	return id, nil
 }
 
+// notifyDependents передает результат или ошибку вычисленного выражения задачам ссылок выражений,
+// которые его ожидают. Завершение задачи ссылки может завершить зависимое выражение и, в свою очередь,
+// передать его результат дальше. Вызывается под блокировкой expressionsMu.
+//
+// Args:
+//
+//	id: string - ID выражения, получившего статус "completed", "error" или "cancelled".
+func (tm *TaskManager) notifyDependents(id string) {
+	dependents := tm.dependents[id]
+	delete(tm.dependents, id)
+
+	referenced, _ := tm.store.GetExpression(id)
+	for _, dependentID := range dependents {
+		expr, ok := tm.store.GetExpression(dependentID)
+		if !ok {
+			continue
+		}
+		for _, task := range expr.Tasks {
+			if task.Operation == operators.OpReference && task.Reference == id {
+				tm.resolveReference(dependentID, task.ID, referenced)
+			}
+		}
+	}
+}
+
+// resolveReference завершает задачу ссылки результатом выражения, на которое она ссылается.
+// Пропущенная задача невыбранной ветви условного выражения результата не получает.
+// Вызывается под блокировкой expressionsMu.
+//
+// Args:
+//
+//	expressionID: string - ID выражения, которому принадлежит задача ссылки.
+//	taskID: string - ID задачи ссылки.
+//	referenced: models.Expression - Вычисленное выражение, на которое ссылается задача.
+func (tm *TaskManager) resolveReference(expressionID, taskID string, referenced models.Expression) {
+	expr, _ := tm.store.GetExpression(expressionID)
+	task := findTask(expr.Tasks, taskID)
+	if expr.Status == "error" || task == nil || task.Status != "pending" {
+		return
+	}
+
+	if referenced.Status == "error" {
+		tm.completeTask(expressionID, taskID, fmt.Sprintf("ошибка в выражении %s: %s", referenced.ID, referenced.Error), models.Task{})
+		return
+	}
+	if referenced.Status == "cancelled" {
+		tm.completeTask(expressionID, taskID, fmt.Sprintf("выражение %s отменено", referenced.ID), models.Task{})
+		return
+	}
+	if referenced.Result == nil {
+		tm.completeTask(expressionID, taskID, fmt.Sprintf("выражение %s не имеет действительного результата", referenced.ID), models.Task{})
+		return
+	}
+
+	mode, _ := evaluator.ParsePrecision(expr.Precision)
+	value := models.Task{Result: referenced.Result}
+	if mode.IsExact() {
+		value.ExactResult = referenced.ExactResult
+	}
+	if mode.IsComplex() {
+		value.ComplexResult = referenced.ComplexResult
+	} else if referenced.ComplexResult != nil && referenced.ComplexResult.Im != 0 {
+		tm.completeTask(expressionID, taskID, fmt.Sprintf("результат выражения %s - комплексное число", referenced.ID), models.Task{})
+		return
+	}
+	tm.completeTask(expressionID, taskID, "", value)
+}
+
 // GetExpressions - возвращает список всех выражений, хранящихся в TaskManager.
 //
 // Args:
@@ -83,14 +321,15 @@ func (tm *TaskManager) GetExpressions() []models.Expression {
 	tm.expressionsMu.RLock()
 	defer tm.expressionsMu.RUnlock()
 
-	// Создаем срез для хранения выражений.
-	expressionsList := make([]models.Expression, 0, len(tm.expressions))
-	// Копируем все выражения из map в срез.
-	for _, expression := range tm.expressions {
-		expressionsList = append(expressionsList, expression)
-	}
-
-	return expressionsList
+	// Хранилище возвращает выражения в произвольном порядке
+	expressions := tm.store.ListExpressions()
+	sort.Slice(expressions, func(i, j int) bool {
+		if !expressions[i].CreatedAt.Equal(expressions[j].CreatedAt) {
+			return expressions[i].CreatedAt.Before(expressions[j].CreatedAt)
+		}
+		return expressions[i].ID < expressions[j].ID
+	})
+	return expressions
 }
 
 // GetExpression - возвращает выражение из TaskManager по его ID.
@@ -107,23 +346,39 @@ func (tm *TaskManager) GetExpression(id string) (models.Expression, bool) {
 	tm.expressionsMu.Lock()
 	defer tm.expressionsMu.Unlock()
 
-	// Получаем выражение из map.
-	expression, ok := tm.expressions[id]
+	// Получаем выражение из хранилища.
+	expression, ok := tm.store.GetExpression(id)
 
 	if !ok {
 		return models.Expression{}, false
 	}
 
-	//Проверяем выполнена ли задача
-	if expression.Status != "completed" {
-		return expression, true
+	// Запрос продлевает хранение завершенного выражения (см. Evict)
+	if finished(expression.Status) {
+		tm.used[id] = time.Now()
 	}
-	// Если выражение забирается пользователем удаляем из списка ожидающих
-	delete(tm.expressions, id)
 
 	return expression, true
 }
 
+// LookupExpression - возвращает выражение по его ID. В отличие от GetExpression, не считается запросом выражения
+// и не продлевает его хранение. Используется подсистемами оркестратора, которые следят за результатами своих выражений.
+//
+// Args:
+//
+//	id: string - ID выражения.
+//
+// Returns:
+//
+//	models.Expression: Выражение с указанным ID.
+//	bool: true, если выражение найдено, иначе false.
+func (tm *TaskManager) LookupExpression(id string) (models.Expression, bool) {
+	tm.expressionsMu.RLock()
+	defer tm.expressionsMu.RUnlock()
+
+	return tm.store.GetExpression(id)
+}
+
 // GetTasks - возвращает список всех задач для заданного выражения.
 //
 // Args:
@@ -137,23 +392,17 @@ func (tm *TaskManager) GetTasks(id string) []models.Task {
 	tm.expressionsMu.RLock()
 	defer tm.expressionsMu.RUnlock()
 
-	expression := models.Expression{}
-	// Ищем выражение по ID в map.
-	for exprID, expr := range tm.expressions {
-		if exprID == id {
-			expression = expr
-		}
-	}
-
+	expression, ok := tm.store.GetExpression(id)
 	// Если выражение не найдено, возвращаем пустой срез.
-	if expression.ID == "" {
+	if !ok {
 		return []models.Task{}
 	}
 
 	return expression.Tasks
 }
 
-// GetTask - возвращает первую задачу со статусом "pending".
+// GetTask - возвращает задачу из начала очереди готовых задач. Задачи попадают в очередь, когда вычислены
+// их зависимости (см. scheduler), поэтому время выдачи не зависит от количества выражений.
 //
 // Args:
 //
@@ -161,163 +410,455 @@ func (tm *TaskManager) GetTasks(id string) []models.Task {
 //
 // Returns:
 //
-//	models.Task - Первая задача со статусом "pending" (в момент отправки присвоится "processing"). Если таких задач нет, возвращается пустая задача.
+//	models.Task - Готовая к выполнению задача (в момент отправки присвоится "processing"). Если таких задач нет, возвращается пустая задача.
 //	string - ID выражения, которому принадлежит найденная задача. Если задача не найдена, возвращается пустая строка.
 //	bool - true, если задача найдена, иначе false.
 func (tm *TaskManager) GetTask() (models.Task, string, bool) {
-	// Устанавливаем блокировку для чтения, чтобы разрешить параллельное чтение выражений.
+	// Устанавливаем блокировку на запись: найденная задача получает статус "processing".
 	tm.expressionsMu.Lock()
 	defer tm.expressionsMu.Unlock()
 
-	// Объявляем переменные для хранения результатов и синхронизации.
-	var (
-		foundTask   models.Task          // Найденная задача.
-		foundExprID string               // ID выражения, которому принадлежит задача.
-		found       bool                 // Флаг, указывающий, найдена ли задача.
-		wg          sync.WaitGroup       // WaitGroup для ожидания завершения всех горутин.
-		taskChan    = make(chan struct { // Канал для передачи результатов из горутин.
-			task   models.Task
-			exprID string
-			found  bool
-		}, len(tm.expressions)) // Буферизованный канал, размер которого равен количеству выражений. Это предотвращает блокировку горутин при отправке результатов.
-	)
-
-	// Итерируемся по всем выражениям.
-	for exprID, expr := range tm.expressions {
-		if expr.Status == "pending" || expr.Status == "processing" {
-			wg.Add(1)
-			// Запускаем горутину для обработки текущего выражения.
-			go func(exprID string, expr models.Expression) {
-				defer wg.Done()
-
-				// Итерируемся по задачам в выражении.
-				for i := range expr.Tasks {
-					// Получаем указатель на текущую задачу
-					task := &expr.Tasks[i]
-					if task.Status == "pending" {
-						// Проверяем наличие зависимостей.
-						if task.Dependencies[0] == "" && task.Dependencies[1] == "" {
-							// Задача без зависимостей готова к выполнению.
-							task.Status = "processing"                   // Устанавливаем статус "processing"
-							if entry, ok := tm.expressions[exprID]; ok { // Устанавливаем для задачи над таском которой работаем статус "processing"
-								entry.Status = "processing"
-								tm.expressions[exprID] = entry
-							}
-							task := *task        // Обновляем задачу в выражении
-							taskChan <- struct { // Отправляем результат в канал
-								task   models.Task
-								exprID string
-								found  bool
-							}{task: task, exprID: exprID, found: true}
-							return // Завершаем горутину, так как задача найдена.
-						}
-
-						// Проверяем, выполнены ли все зависимости.
-						if tm.AreDependenciesCompleted(expr.Tasks, task.Dependencies) {
-							// Все зависимости выполнены, задача готова к выполнению
-							task.Status = "processing"      // Устанавливаем статус "processing"
-							for i, arg := range task.Args { // Если значение nil, то оно находится в зависимостях
-								if arg == nil {
-									for _, dependency := range tm.expressions[exprID].Tasks {
-										if dependency.ID == task.Dependencies[i] {
-											task.Args[i] = dependency.Result
-										}
-									}
-								}
-							}
-
-							// Устанавливаем для задачи над таском которой работаем статус "processing"
-							if entry, ok := tm.expressions[exprID]; ok {
-								entry.Status = "processing"
-								tm.expressions[exprID] = entry
-							}
-							task := *task        // Обновляем задачу в выражении
-							taskChan <- struct { // Отправляем результат в канал
-								task   models.Task
-								exprID string
-								found  bool
-							}{task: task, exprID: exprID, found: true}
-							return // Завершаем горутину, так как задача найдена.
-						}
+	for {
+		ref, ok := tm.scheduler.pop()
+		if !ok {
+			return models.Task{}, "", false
+		}
+		// Задача могла стать неактуальной, пока ждала в очереди: выражение удалено или завершилось ошибкой
+		expr, ok := tm.store.GetExpression(ref.expressionID)
+		if !ok || (expr.Status != "pending" && expr.Status != "processing") {
+			continue
+		}
+		task := findTask(expr.Tasks, ref.taskID)
+		if task == nil || task.Status != "pending" {
+			continue
+		}
+		return tm.leaseTask(expr, task), expr.ID, true
+	}
+}
+
+// leaseTask выдает задачу агенту: присваивает ей статус "processing" и аренду, подставляет результаты зависимостей
+// в аргументы и сохраняет выражение. Вызывается под блокировкой expressionsMu.
+//
+// Args:
+//
+//	expr: models.Expression - Выражение задачи.
+//	task: *models.Task - Готовая задача в срезе expr.Tasks.
+//
+// Returns:
+//
+//	models.Task - Выданная задача.
+func (tm *TaskManager) leaseTask(expr models.Expression, task *models.Task) models.Task {
+	task.Status = "processing" // Устанавливаем статус "processing"
+	// Выдаем аренду: результат принимается только с этим токеном и только до истечения срока
+	task.Lease = uuid.New().String()
+	task.LeaseDeadline = time.Now().Add(leaseDuration(task))
+	for i, arg := range task.Args { // Если значение nil, то оно находится в зависимостях
+		if arg == nil {
+			for _, dependency := range expr.Tasks {
+				if dependency.ID == task.Dependencies[i] {
+					task.Args[i] = dependency.Result
+					if task.ExactArgs != nil {
+						task.ExactArgs[i] = exactValue(&dependency)
+					}
+					if task.ComplexArgs != nil {
+						task.ComplexArgs[i] = complexValue(&dependency)
 					}
 				}
-			}(exprID, expr) // Передаем значения в горутину.
+			}
+		}
+	}
+
+	// Сохраняем задачу, а выражение, задачи которого ещё не выдавались, получает статус "processing"
+	if expr.Status == "processing" {
+		if err := tm.store.UpdateTask(expr.ID, *task); err != nil {
+			logger.Log.Errorf("Не удалось сохранить задачу %s: %v", task.ID, err)
+		}
+	} else {
+		expr.Status = "processing"
+		tm.save(expr)
+	}
+	tm.scheduler.leased[task.ID] = expr.ID
+
+	return *task
+}
+
+// leaseDuration возвращает срок аренды задачи: длительность операции и запас TASK_LEASE_GRACE_MS.
+func leaseDuration(task *models.Task) time.Duration {
+	return time.Duration(task.Operation_time+config.Cfg.Server.Orchestrator.TASK_LEASE_GRACE_MS) * time.Millisecond
+}
+
+// releaseLease возвращает выданную задачу в очередь, аннулируя её аренду.
+func releaseLease(task *models.Task) {
+	task.Status = "pending"
+	task.Lease = ""
+	task.LeaseDeadline = time.Time{}
+}
+
+// ReapExpired возвращает в очередь задачи, аренда которых истекла к моменту now: агент, получивший задачу,
+// не вернул результат вовремя (например, завершился аварийно). Задача будет выдана снова с новым токеном,
+// а поздний результат по прежней аренде будет отклонен.
+//
+// Args:
+//
+//	now: time.Time - Момент, с которым сравниваются сроки аренды.
+//
+// Returns:
+//
+//	int - Количество задач, возвращенных в очередь.
+func (tm *TaskManager) ReapExpired(now time.Time) int {
+	tm.expressionsMu.Lock()
+	defer tm.expressionsMu.Unlock()
+
+	reaped := 0
+	for taskID, expressionID := range tm.scheduler.leased {
+		expr, ok := tm.store.GetExpression(expressionID)
+		if !ok || expr.Status != "processing" {
+			delete(tm.scheduler.leased, taskID)
+			continue
+		}
+		task := findTask(expr.Tasks, taskID)
+		if task == nil || task.Status != "processing" {
+			delete(tm.scheduler.leased, taskID)
+			continue
+		}
+		if task.LeaseDeadline.IsZero() || !now.After(task.LeaseDeadline) {
+			continue
 		}
+		releaseLease(task)
+		if err := tm.store.UpdateTask(expr.ID, *task); err != nil {
+			logger.Log.Errorf("Не удалось вернуть в очередь задачу %s: %v", task.ID, err)
+			continue
+		}
+		delete(tm.scheduler.leased, taskID)
+		tm.scheduler.push(expr, task)
+		logger.Log.Warnf("Аренда задачи %s выражения %s истекла, задача возвращена в очередь", task.ID, expr.ID)
+		reaped++
 	}
+	return reaped
+}
+
+// RunReaper периодически возвращает в очередь задачи с истекшей арендой (см. ReapExpired), пока не отменен ctx.
+//
+// Args:
+//
+//	ctx: context.Context - Контекст, отмена которого останавливает проверку.
+//	interval: time.Duration - Период проверки.
+func (tm *TaskManager) RunReaper(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
 
-	// Ожидаем завершения всех горутин.
-	wg.Wait()
-	// Закрываем канал, чтобы сообщить получателям, что больше не будет данных.
-	close(taskChan)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			tm.ReapExpired(now)
+		}
+	}
+}
+
+// CancelExpression отменяет выражение: оно получает статус "cancelled", а его невыполненные задачи - статус "cancelled"
+// и больше не выдаются агентам. Агенты, вычисляющие задачи выражения, узнают об отмене из Heartbeat,
+// а их результаты не принимаются. Выражения со ссылками на отмененное выражение завершаются ошибкой.
+//
+// Args:
+//
+//	id: string - ID выражения.
+//
+// Returns:
+//
+//	error - ErrExpressionNotFound, если выражение не найдено, ErrExpressionFinished, если оно уже завершено.
+func (tm *TaskManager) CancelExpression(id string) error {
+	tm.expressionsMu.Lock()
+	defer tm.expressionsMu.Unlock()
+
+	expr, ok := tm.store.GetExpression(id)
+	if !ok {
+		return ErrExpressionNotFound
+	}
+	if finished(expr.Status) {
+		return ErrExpressionFinished
+	}
 
-	// Получаем результат из канала.
-	for result := range taskChan {
-		// Если задача найдена, сохраняем результат и выходим из цикла.
-		if result.found {
-			foundTask = result.task
-			foundExprID = result.exprID
-			found = true
-			break // Прекращаем поиск после нахождения первой задачи.
+	for i := range expr.Tasks {
+		task := &expr.Tasks[i]
+		if task.Status == "pending" || task.Status == "processing" {
+			task.Status = "cancelled"
+			task.Lease = ""
+			task.LeaseDeadline = time.Time{}
 		}
 	}
+	expr.Status = "cancelled"
+	tm.save(expr)
+	tm.scheduler.remove(expr)
+	tm.used[id] = time.Now()
+	logger.Log.Debugf("Выражение %s отменено", id)
+	tm.notifyDependents(id)
+	return nil
+}
 
-	// Возвращаем найденную задачу, ID выражения и флаг, указывающий, была ли задача найдена.
-	return foundTask, foundExprID, found
+// Heartbeat проверяет, что агент, вычисляющий задачу, может продолжать: выражение не отменено,
+// а аренда задачи действительна.
+//
+// Args:
+//
+//	expressionID: string - ID выражения, которому принадлежит задача.
+//	taskID: string - ID задачи.
+//	lease: string - Токен аренды, выданный вместе с задачей.
+//
+// Returns:
+//
+//	error - ErrExpressionCancelled, если выражение отменено, ErrLeaseInvalid, если задача не выдана агенту
+//	        с этой арендой или аренда истекла.
+func (tm *TaskManager) Heartbeat(expressionID, taskID, lease string) error {
+	tm.expressionsMu.RLock()
+	defer tm.expressionsMu.RUnlock()
+
+	if expr, ok := tm.store.GetExpression(expressionID); ok && expr.Status == "cancelled" {
+		return ErrExpressionCancelled
+	}
+	if !tm.checkLease(expressionID, taskID, lease) {
+		return ErrLeaseInvalid
+	}
+	return nil
 }
 
-// areDependenciesCompleted проверяет, выполнены ли все зависимости задачи.
+// Evict удаляет завершенные выражения по политике хранения (config.Cfg.Retention): выражения, которые не запрашивались
+// дольше TTL после завершения или последнего запроса, а затем, если завершенных выражений больше MaxCount,
+// выражения, которые дольше всего не запрашивались (LRU). Выражения, которые ещё вычисляются, и закрепленные
+// выражения (см. Pin) не удаляются и не учитываются в MaxCount.
 //
 // Args:
 //
-//	tasks: []models.Task - Список задач в выражении.
-//	dependencies: []string - Список ID задач, от которых зависит текущая задача.
+//	now: time.Time - Момент, с которым сравнивается время последнего запроса.
 //
 // Returns:
 //
-//	bool - true, если все зависимости выполнены, false в противном случае.
-func (tm *TaskManager) AreDependenciesCompleted(tasks []models.Task, dependencies []string) bool {
-	for _, dependencyID := range dependencies {
-		if dependencyID == "" {
-			break
-		}
-		found := false
-		for _, task := range tasks {
-			// Проверяем, совпадает ли ID задачи с ID зависимости.
-			if task.ID == dependencyID {
-				found = true // Зависимость найдена.
-				// Если зависимость не выполнена, возвращаем false.
-				if task.Status != "completed" {
-					return false
-				}
-				break // Зависимость найдена и проверена, переходим к следующей.
-			}
+//	int - Количество удаленных выражений.
+func (tm *TaskManager) Evict(now time.Time) int {
+	tm.expressionsMu.Lock()
+	defer tm.expressionsMu.Unlock()
+
+	policy := config.Cfg.Retention
+	ttl := time.Duration(policy.TTL) * time.Second
+
+	var kept []string
+	evicted := 0
+	for id, used := range tm.used {
+		if tm.pinned[id] {
+			continue
 		}
-		if !found {
-			return false // Зависимость не найдена в списке задач
+		if policy.TTL > 0 && now.Sub(used) > ttl {
+			evicted += tm.evict(id)
+			continue
 		}
+		kept = append(kept, id)
 	}
-	// Все зависимости выполнены.
-	return true
+
+	if policy.MaxCount > 0 && len(kept) > policy.MaxCount {
+		sort.Slice(kept, func(i, j int) bool {
+			return tm.used[kept[i]].Before(tm.used[kept[j]])
+		})
+		for _, id := range kept[:len(kept)-policy.MaxCount] {
+			evicted += tm.evict(id)
+		}
+	}
+
+	if evicted > 0 {
+		logger.Log.Debugf("Удалено устаревших выражений: %d", evicted)
+	}
+	return evicted
+}
+
+// evict удаляет завершенное выражение из хранилища. Вызывается под блокировкой expressionsMu.
+//
+// Returns:
+//
+//	int - 1, если выражение удалено, 0, если не удалось удалить его из хранилища.
+func (tm *TaskManager) evict(id string) int {
+	if err := tm.store.DeleteExpression(id); err != nil {
+		logger.Log.Errorf("Не удалось удалить выражение %s: %v", id, err)
+		return 0
+	}
+	delete(tm.used, id)
+	return 1
+}
+
+// Pin закрепляет выражение: политика хранения не удаляет его, пока оно не будет откреплено (см. Unpin).
+// Используется подсистемами оркестратора, которые читают результат выражения без запросов клиента,
+// например рабочими книгами для выражений ячеек.
+//
+// Args:
+//
+//	id: string - ID выражения.
+func (tm *TaskManager) Pin(id string) {
+	tm.expressionsMu.Lock()
+	defer tm.expressionsMu.Unlock()
+
+	tm.pinned[id] = true
+}
+
+// Unpin открепляет выражение, закрепленное Pin. Срок его хранения отсчитывается от последнего запроса,
+// поэтому выражение, которое долго не запрашивалось, удаляется при следующем применении политики хранения.
+//
+// Args:
+//
+//	id: string - ID выражения.
+func (tm *TaskManager) Unpin(id string) {
+	tm.expressionsMu.Lock()
+	defer tm.expressionsMu.Unlock()
+
+	delete(tm.pinned, id)
+}
+
+// RunJanitor периодически удаляет устаревшие выражения (см. Evict), пока не отменен ctx.
+//
+// Args:
+//
+//	ctx: context.Context - Контекст, отмена которого останавливает удаление.
+//	interval: time.Duration - Период применения политики хранения.
+func (tm *TaskManager) RunJanitor(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			tm.Evict(now)
+		}
+	}
+}
+
+// finished проверяет, что выражение со статусом status больше не вычисляется.
+func finished(status string) bool {
+	return status == "completed" || status == "error" || status == "cancelled"
 }
 
 // CompleteTask - обновляет статус и результат задачи. Если все задачи
-// выполняются присваивает выражению статус completed.
+// выполнены или пропущены присваивает выражению статус completed.
 //
 // Args:
 //
 //	expressionID: string - ID выражения, которому принадлежит задача.
 //	taskID: string - ID задачи, которую необходимо завершить.
+//	lease: string - Токен аренды, выданный вместе с задачей (см. GetTask).
+//	taskErr: string - Ошибка выполнения задачи, пустая строка - задача выполнена.
 //	result: float64 - Результат выполнения задачи.
 //
 // Returns:
 //
-//	bool - true, если задача успешно завершена и обновлена, false в противном случае.
-func (tm *TaskManager) CompleteTask(expressionID, taskID, taskErr string, result float64) bool {
+//	bool - true, если задача успешно завершена и обновлена, false, если задача не найдена,
+//	       не выдана агенту или её аренда истекла либо принадлежит другому агенту.
+func (tm *TaskManager) CompleteTask(expressionID, taskID, lease, taskErr string, result float64) bool {
+	tm.expressionsMu.Lock()
+	defer tm.expressionsMu.Unlock()
+
+	if !tm.checkLease(expressionID, taskID, lease) {
+		return false
+	}
+	return tm.completeTask(expressionID, taskID, taskErr, models.Task{Result: &result})
+}
+
+// CompleteExactTask - сохраняет точный результат задачи, вычисленной с точностью, отличной от float64.
+// Результатом задачи становится ближайшее значение float64, а точная запись используется
+// как аргумент зависимых задач и как точный результат выражения.
+//
+// Args:
+//
+//	expressionID: string - ID выражения, которому принадлежит задача.
+//	taskID: string - ID задачи, которую необходимо завершить.
+//	lease: string - Токен аренды, выданный вместе с задачей.
+//	exactResult: string - Точный результат в строковой записи (например, "1/3").
+//
+// Returns:
+//
+//	bool - true, если задача найдена и обновлена (или выражение помечено ошибочным из-за некорректной записи),
+//	       false, если задача не найдена или аренда недействительна (см. CompleteTask).
+func (tm *TaskManager) CompleteExactTask(expressionID, taskID, lease, exactResult string) bool {
+	tm.expressionsMu.Lock()
+	defer tm.expressionsMu.Unlock()
+
+	if !tm.checkLease(expressionID, taskID, lease) {
+		return false
+	}
+	result, err := evaluator.Approximate(exactResult)
+	if err != nil {
+		return tm.completeTask(expressionID, taskID, fmt.Sprintf("некорректный точный результат задачи: %s", err), models.Task{})
+	}
+	return tm.completeTask(expressionID, taskID, "", models.Task{Result: &result, ExactResult: &exactResult})
+}
+
+// CompleteComplexTask - сохраняет результат задачи выражения, вычисляемого в комплексных числах.
+// Результатом задачи (Result) становится действительная часть, а комплексное значение используется
+// как аргумент зависимых задач и как комплексный результат выражения.
+//
+// Args:
+//
+//	expressionID: string - ID выражения, которому принадлежит задача.
+//	taskID: string - ID задачи, которую необходимо завершить.
+//	lease: string - Токен аренды, выданный вместе с задачей.
+//	complexResult: models.Complex - Комплексный результат задачи.
+//
+// Returns:
+//
+//	bool - true, если задача успешно завершена и обновлена, false, если задача не найдена или аренда недействительна.
+func (tm *TaskManager) CompleteComplexTask(expressionID, taskID, lease string, complexResult models.Complex) bool {
 	tm.expressionsMu.Lock()
 	defer tm.expressionsMu.Unlock()
 
+	if !tm.checkLease(expressionID, taskID, lease) {
+		return false
+	}
+	result := complexResult.Re
+	return tm.completeTask(expressionID, taskID, "", models.Task{Result: &result, ComplexResult: &complexResult})
+}
+
+// checkLease проверяет, что задача выдана агенту с арендой lease и аренда не истекла.
+// Задача с истекшей арендой, которую ещё не вернул в очередь ReapExpired, тоже не принимается.
+// Вызывается под блокировкой expressionsMu.
+//
+// Args:
+//
+//	expressionID: string - ID выражения, которому принадлежит задача.
+//	taskID: string - ID задачи.
+//	lease: string - Токен аренды из результата агента.
+//
+// Returns:
+//
+//	bool - true, если результат задачи можно принять.
+func (tm *TaskManager) checkLease(expressionID, taskID, lease string) bool {
+	expr, ok := tm.store.GetExpression(expressionID)
+	if !ok || expr.Status != "processing" {
+		return false
+	}
+	task := findTask(expr.Tasks, taskID)
+	if task == nil || task.Status != "processing" {
+		return false
+	}
+	if task.Lease != lease || time.Now().After(task.LeaseDeadline) {
+		logger.Log.Warnf("Отклонен результат задачи %s выражения %s: аренда истекла или выдана другому агенту", taskID, expressionID)
+		return false
+	}
+	return true
+}
+
+// completeTask обновляет статус и результат задачи (см. CompleteTask). Вызывается под блокировкой expressionsMu.
+//
+// Args:
+//
+//	expressionID: string - ID выражения, которому принадлежит задача.
+//	taskID: string - ID задачи, которую необходимо завершить.
+//	taskErr: string - Ошибка выполнения задачи, пустая строка - задача выполнена.
+//	result: models.Task - Результаты выполнения задачи: Result и, в зависимости от точности, ExactResult или ComplexResult.
+//
+// Returns:
+//
+//	bool - true, если задача успешно завершена и обновлена, false в противном случае.
+func (tm *TaskManager) completeTask(expressionID, taskID, taskErr string, result models.Task) bool {
 	// Пытаемся получить выражение по ID.
-	expr, ok := tm.expressions[expressionID]
+	expr, ok := tm.store.GetExpression(expressionID)
 	if !ok {
 		return false
 	}
@@ -332,14 +873,28 @@ func (tm *TaskManager) CompleteTask(expressionID, taskID, taskErr string, result
 	for i, task := range expr.Tasks {
 		// Ищем задачу с соответствующим ID.
 		if task.ID == taskID {
+			// Запоминаем выполненные задачи, чтобы найти задачи, выполненные выбором ветвей
+			completed := make(map[string]bool, len(expr.Tasks))
+			for _, task := range expr.Tasks {
+				completed[task.ID] = task.Status == "completed"
+			}
 			// Обновляем результат и статус задачи.
-			res := result // Создаем копию результата, чтобы взять указатель на неё.
-			expr.Tasks[i].Result = &res
+			setResult(&expr.Tasks[i], result)
 			expr.Tasks[i].Status = "completed"
+			expr.Tasks[i].Lease = ""
+			expr.Tasks[i].LeaseDeadline = time.Time{}
+			// Выбираем ветви условных выражений, условия которых вычислены
+			resolveConditionals(expr.Tasks)
+			// Ставим в очередь задачи, дождавшиеся результатов
+			for _, task := range expr.Tasks {
+				if task.Status == "completed" && !completed[task.ID] {
+					tm.scheduler.complete(expr, task.ID)
+				}
+			}
 			// Проверяем все ли задачи выполнены.
 			allCompleted := true
 			for _, task := range expr.Tasks {
-				if task.Status != "completed" {
+				if task.Status != "completed" && task.Status != "skipped" {
 					allCompleted = false
 					break // Нашли незавершенную задачу, дальше проверять нет смысла.
 				}
@@ -347,13 +902,20 @@ func (tm *TaskManager) CompleteTask(expressionID, taskID, taskErr string, result
 			// Присваиваем статус completed.
 			if allCompleted {
 				expr.Status = "completed"
+				tm.scheduler.remove(expr)
+				tm.used[expressionID] = time.Now()
 				//Меняем статус выражение на "completed"
 				// Сплиттер разделяет задачи так, что в конце будет находиться последня операция.
 				// Если задача имеет зависимости, она будет корневым элементом
 				expr.Result = expr.Tasks[len(expr.Tasks)-1].Result
+				expr.ExactResult = expr.Tasks[len(expr.Tasks)-1].ExactResult
+				expr.ComplexResult = expr.Tasks[len(expr.Tasks)-1].ComplexResult
 			}
 
-			tm.expressions[expressionID] = expr // Обновляем выражение в map.
+			tm.save(expr) // Обновляем выражение в хранилище.
+			if allCompleted {
+				tm.notifyDependents(expressionID)
+			}
 			return true
 		}
 	}
@@ -370,9 +932,176 @@ func (tm *TaskManager) CompleteTask(expressionID, taskID, taskErr string, result
 //	expressionID: string - ID выражения, которое невозможно выполнить.
 //	taskID: string - ID задачи, ставшее ошибкой.
 func (tm *TaskManager) impossibleTask(expressionID, taskErr string) {
-	expr := tm.expressions[expressionID]
+	expr, _ := tm.store.GetExpression(expressionID)
 	expr.Status = "error"
 	expr.Error = taskErr
-	tm.expressions[expressionID] = expr
+	tm.save(expr)
+	tm.scheduler.remove(expr)
+	tm.used[expressionID] = time.Now()
 	logger.Log.Debugf("Выражение %s невозможно выполнить: %s", expressionID, taskErr)
+	tm.notifyDependents(expressionID)
+}
+
+// Состояния условий выполнения задачи (см. guardState).
+const (
+	guardWaiting = iota // Не все условия вычислены.
+	guardPassed         // Все условия вычислены и задача находится в выбранных ветвях.
+	guardFailed         // Задача находится в невыбранной ветви.
+)
+
+// findTask возвращает задачу выражения по ID.
+//
+// Args:
+//
+//	tasks: []models.Task - Задачи выражения.
+//	id: string - ID задачи.
+//
+// Returns:
+//
+//	*models.Task - Указатель на задачу в срезе или nil, если задача не найдена.
+func findTask(tasks []models.Task, id string) *models.Task {
+	for i := range tasks {
+		if tasks[i].ID == id {
+			return &tasks[i]
+		}
+	}
+	return nil
+}
+
+// guardState проверяет условия выполнения задачи из ветвей условных выражений.
+//
+// Args:
+//
+//	tasks: []models.Task - Задачи выражения.
+//	task: *models.Task - Проверяемая задача.
+//
+// Returns:
+//
+//	int - guardPassed, если задачу можно выполнять (в том числе если условий нет),
+//	      guardFailed, если хотя бы одно условие выбрало другую ветвь, иначе guardWaiting.
+func guardState(tasks []models.Task, task *models.Task) int {
+	state := guardPassed
+	for _, guard := range task.Guards {
+		condition := findTask(tasks, guard.Condition)
+		if condition == nil || condition.Status != "completed" {
+			state = guardWaiting
+			continue
+		}
+		if isTrue(condition) != guard.Branch {
+			return guardFailed
+		}
+	}
+	return state
+}
+
+// resolveConditionals пропускает задачи невыбранных ветвей и выполняет задачи выбора ветви (operators.OpSelect),
+// у которых вычислены условие и значение выбранной ветви. Повторяется, пока что-то меняется:
+// результат выбора может быть условием или значением другого условного выражения.
+//
+// Args:
+//
+//	tasks: []models.Task - Задачи выражения, изменяются на месте.
+func resolveConditionals(tasks []models.Task) {
+	for changed := true; changed; {
+		changed = false
+		for i := range tasks {
+			task := &tasks[i]
+			if task.Status != "pending" {
+				continue
+			}
+			switch guardState(tasks, task) {
+			case guardFailed:
+				task.Status = "skipped"
+				changed = true
+			case guardPassed:
+				if task.Operation != operators.OpSelect {
+					continue
+				}
+				if value, ok := selectedValue(tasks, task); ok {
+					setResult(task, value)
+					task.Status = "completed"
+					changed = true
+				}
+			}
+		}
+	}
+}
+
+// selectedValue возвращает значение ветви, выбранной задачей выбора.
+// Операнды задачи выбора: условие, значение ветви "то", значение ветви "иначе".
+//
+// Args:
+//
+//	tasks: []models.Task - Задачи выражения.
+//	task: *models.Task - Задача выбора ветви.
+//
+// Returns:
+//
+//	models.Task - Значение выбранной ветви в полях результата (Result, ExactResult, ComplexResult).
+//	bool - false, если условие или значение выбранной ветви ещё не вычислены.
+func selectedValue(tasks []models.Task, task *models.Task) (models.Task, bool) {
+	condition := findTask(tasks, task.Dependencies[0])
+	if condition == nil || condition.Status != "completed" {
+		return models.Task{}, false
+	}
+
+	branch := 2 // Ветвь "иначе"
+	if isTrue(condition) {
+		branch = 1 // Ветвь "то"
+	}
+	if task.Args[branch] != nil {
+		// Значение ветви задано числом
+		value := models.Task{Result: task.Args[branch]}
+		if task.ExactArgs != nil {
+			value.ExactResult = task.ExactArgs[branch]
+		}
+		if task.ComplexArgs != nil {
+			value.ComplexResult = task.ComplexArgs[branch]
+		}
+		return value, true
+	}
+	value := findTask(tasks, task.Dependencies[branch])
+	if value == nil || value.Status != "completed" {
+		return models.Task{}, false
+	}
+	return *value, true
+}
+
+// setResult копирует результаты выполнения из value в задачу task.
+func setResult(task *models.Task, value models.Task) {
+	task.Result = value.Result
+	task.ExactResult = value.ExactResult
+	task.ComplexResult = value.ComplexResult
+}
+
+// isTrue проверяет истинность результата выполненной задачи. Точный результат проверяется точно:
+// очень малое по модулю число истинно, даже если его приближение float64 равно нулю.
+// Комплексное число истинно, если не равна нулю хотя бы одна из частей.
+func isTrue(task *models.Task) bool {
+	if task.ComplexResult != nil {
+		return task.ComplexResult.Value() != 0
+	}
+	if task.ExactResult != nil {
+		return evaluator.IsTrue(*task.ExactResult)
+	}
+	return *task.Result != operators.False
+}
+
+// exactValue возвращает точную запись результата выполненной задачи.
+// Если задача вычислялась с точностью float64, возвращается кратчайшая запись её результата.
+func exactValue(task *models.Task) *string {
+	if task.ExactResult != nil {
+		return task.ExactResult
+	}
+	text := evaluator.FormatFloat(*task.Result)
+	return &text
+}
+
+// complexValue возвращает комплексный результат выполненной задачи.
+// Если задача вычислялась в действительных числах, мнимая часть равна нулю.
+func complexValue(task *models.Task) *models.Complex {
+	if task.ComplexResult != nil {
+		return task.ComplexResult
+	}
+	return &models.Complex{Re: *task.Result}
 }
@@ -4,8 +4,10 @@ import (
 	"io"
 	"log"
 	"testing"
+	"time"
 
 	"github.com/OinkiePie/calc_2/config"
+	"github.com/OinkiePie/calc_2/orchestrator/internal/storage"
 	"github.com/OinkiePie/calc_2/orchestrator/internal/task_manager"
 	"github.com/OinkiePie/calc_2/pkg/logger"
 	"github.com/OinkiePie/calc_2/pkg/models"
@@ -30,7 +32,7 @@ func TestNewTaskManager(t *testing.T) {
 func TestAddExpression(t *testing.T) {
 	tm := task_manager.NewTaskManager()
 
-	id, err := tm.AddExpression("2 + 2")
+	id, err := tm.AddExpression("2 + 2", nil, "", nil)
 	assert.NoError(t, err)
 	assert.NotEmpty(t, id)
 
@@ -41,7 +43,7 @@ func TestAddExpression(t *testing.T) {
 	assert.Equal(t, "pending", expressions[0].Status)
 
 	// Добавляем некорректное выражение
-	_, err = tm.AddExpression("2 + ")
+	_, err = tm.AddExpression("2 + ", nil, "", nil)
 	assert.Error(t, err)
 }
 
@@ -49,15 +51,15 @@ func TestAddExpression(t *testing.T) {
 func TestGetExpressions(t *testing.T) {
 	tm := task_manager.NewTaskManager()
 
-	id, err := tm.AddExpression("4 + 2")
+	id, err := tm.AddExpression("4 + 2", nil, "", nil)
 	assert.NoError(t, err)
 	assert.NotEmpty(t, id)
 
-	id, err = tm.AddExpression("5 + 5")
+	id, err = tm.AddExpression("5 + 5", nil, "", nil)
 	assert.NoError(t, err)
 	assert.NotEmpty(t, id)
 
-	id, err = tm.AddExpression("777+-")
+	id, err = tm.AddExpression("777+-", nil, "", nil)
 	assert.Error(t, err)
 	assert.Empty(t, id)
 
@@ -71,7 +73,7 @@ func TestGetExpression(t *testing.T) {
 	tm := task_manager.NewTaskManager()
 
 	// Добавляем выражение
-	id, err := tm.AddExpression("2 + 2")
+	id, err := tm.AddExpression("2 + 2", nil, "", nil)
 	assert.NoError(t, err)
 
 	// Получаем выражение по ID
@@ -85,12 +87,34 @@ func TestGetExpression(t *testing.T) {
 	assert.False(t, found)
 }
 
+// TestAddExpressionFolded проверяет, что полностью свернутое выражение сразу вычислено и не выдает задач агентам.
+func TestAddExpressionFolded(t *testing.T) {
+	config.Cfg.Optimizer.FoldConstants = true
+	defer func() { config.Cfg.Optimizer.FoldConstants = false }()
+
+	tm := task_manager.NewTaskManager()
+
+	id, err := tm.AddExpression("(2 + 3) * (2 + 3)", nil, "", nil)
+	assert.NoError(t, err)
+
+	_, _, found := tm.GetTask()
+	assert.False(t, found)
+
+	expr, found := tm.GetExpression(id)
+	assert.True(t, found)
+	assert.Equal(t, "completed", expr.Status)
+	if assert.NotNil(t, expr.Result) {
+		assert.Equal(t, 25.0, *expr.Result)
+	}
+	assert.Equal(t, models.ExpressionStats{TasksBefore: 3, TasksAfter: 0, Folded: 3}, expr.Stats)
+}
+
 // TestGetTasks проверяет получение задач для выражения.
 func TestGetTasks(t *testing.T) {
 	tm := task_manager.NewTaskManager()
 
 	// Добавляем выражение
-	id, err := tm.AddExpression("2 + 2 * 2")
+	id, err := tm.AddExpression("2 + 2 * 2", nil, "", nil)
 	assert.NoError(t, err)
 
 	// Получаем задачи для выражения
@@ -108,7 +132,7 @@ func TestGetTask(t *testing.T) {
 	tm := task_manager.NewTaskManager()
 
 	// Добавляем выражение
-	id, err := tm.AddExpression("2 + 2")
+	id, err := tm.AddExpression("2 + 2", nil, "", nil)
 	assert.NoError(t, err)
 
 	// Получаем задачу
@@ -118,19 +142,206 @@ func TestGetTask(t *testing.T) {
 	assert.Equal(t, "processing", task.Status)
 
 	// Завершаем задачу
-	tm.CompleteTask(id, task.ID, "", 4.0)
+	tm.CompleteTask(id, task.ID, task.Lease, "", 4.0)
 
 	// Пытаемся получить задачу снова (все задачи завершены)
 	_, _, found = tm.GetTask()
 	assert.False(t, found)
 }
 
+// TestGetTaskSecondDependency проверяет, что задача, зависящая от второго аргумента,
+// не выдается до выполнения зависимости.
+func TestGetTaskSecondDependency(t *testing.T) {
+	tm := task_manager.NewTaskManager()
+
+	id, err := tm.AddExpression("2 + sqrt(9)", nil, "", nil)
+	assert.NoError(t, err)
+
+	// Первой выдается задача без зависимостей
+	task, _, found := tm.GetTask()
+	assert.True(t, found)
+	assert.Equal(t, "sqrt", task.Operation)
+
+	// Сложение ждет результат корня
+	_, _, found = tm.GetTask()
+	assert.False(t, found)
+
+	tm.CompleteTask(id, task.ID, task.Lease, "", 3.0)
+
+	task, _, found = tm.GetTask()
+	assert.True(t, found)
+	assert.Equal(t, "+", task.Operation)
+	assert.Equal(t, 2.0, *task.Args[0])
+	assert.Equal(t, 3.0, *task.Args[1])
+}
+
+// TestConditionalBranches проверяет, что задачи ветвей выдаются только после вычисления условия,
+// а задачи невыбранной ветви пропускаются.
+func TestConditionalBranches(t *testing.T) {
+	tm := task_manager.NewTaskManager()
+
+	id, err := tm.AddExpression("x != 0 ? 1/x : x - 1", map[string]float64{"x": 0}, "", nil)
+	assert.NoError(t, err)
+
+	// Первым выдается только условие
+	cond, _, found := tm.GetTask()
+	assert.True(t, found)
+	assert.Equal(t, "!=", cond.Operation)
+
+	_, _, found = tm.GetTask()
+	assert.False(t, found)
+
+	// Условие ложно: деление пропускается, выдается ветвь "иначе"
+	tm.CompleteTask(id, cond.ID, cond.Lease, "", 0)
+
+	task, _, found := tm.GetTask()
+	assert.True(t, found)
+	assert.Equal(t, "-", task.Operation)
+
+	_, _, found = tm.GetTask()
+	assert.False(t, found)
+
+	statuses := map[string]string{}
+	for _, task := range tm.GetTasks(id) {
+		statuses[task.Operation] = task.Status
+	}
+	assert.Equal(t, "skipped", statuses["/"])
+
+	// Выбор ветви выполняет оркестратор, поэтому выражение завершается вместе с ветвью
+	tm.CompleteTask(id, task.ID, task.Lease, "", -1)
+
+	expr, found := tm.GetExpression(id)
+	assert.True(t, found)
+	assert.Equal(t, "completed", expr.Status)
+	if assert.NotNil(t, expr.Result) {
+		assert.Equal(t, -1.0, *expr.Result)
+	}
+}
+
+// TestNestedConditionalSkipped проверяет пропуск вложенного условного выражения из невыбранной ветви.
+func TestNestedConditionalSkipped(t *testing.T) {
+	tm := task_manager.NewTaskManager()
+
+	id, err := tm.AddExpression("x > 0 ? (x > 10 ? x*2 : x*3) : 5", map[string]float64{"x": -1}, "", nil)
+	assert.NoError(t, err)
+
+	cond, _, found := tm.GetTask()
+	assert.True(t, found)
+	tm.CompleteTask(id, cond.ID, cond.Lease, "", 0)
+
+	// Ветвь "иначе" - число, поэтому агентам больше нечего выполнять
+	_, _, found = tm.GetTask()
+	assert.False(t, found)
+
+	expr, found := tm.GetExpression(id)
+	assert.True(t, found)
+	assert.Equal(t, "completed", expr.Status)
+	if assert.NotNil(t, expr.Result) {
+		assert.Equal(t, 5.0, *expr.Result)
+	}
+	for _, task := range expr.Tasks[1 : len(expr.Tasks)-1] {
+		assert.Equal(t, "skipped", task.Status, task.Operation)
+	}
+}
+
+// TestExactPrecision проверяет передачу точных результатов между задачами выражения с повышенной точностью.
+func TestExactPrecision(t *testing.T) {
+	tm := task_manager.NewTaskManager()
+
+	id, err := tm.AddExpression("x / 3 * 3", map[string]float64{"x": 1}, "rational", nil)
+	assert.NoError(t, err)
+
+	task, _, found := tm.GetTask()
+	assert.True(t, found)
+	assert.Equal(t, "/", task.Operation)
+	assert.Equal(t, "rational", task.Precision)
+	assert.Equal(t, "1", *task.ExactArgs[0])
+	assert.True(t, tm.CompleteExactTask(id, task.ID, task.Lease, "1/3"))
+
+	// Зависимая задача получает точный результат, а не его приближение
+	task, _, found = tm.GetTask()
+	assert.True(t, found)
+	assert.Equal(t, "1/3", *task.ExactArgs[0])
+	assert.InDelta(t, 1.0/3, *task.Args[0], 1e-15)
+	assert.True(t, tm.CompleteExactTask(id, task.ID, task.Lease, "1"))
+
+	expr, found := tm.GetExpression(id)
+	assert.True(t, found)
+	assert.Equal(t, "completed", expr.Status)
+	if assert.NotNil(t, expr.ExactResult) {
+		assert.Equal(t, "1", *expr.ExactResult)
+	}
+	assert.Equal(t, 1.0, *expr.Result)
+
+	// Некорректный точный результат делает выражение ошибочным
+	id, err = tm.AddExpression("x + 1", map[string]float64{"x": 1}, "decimal", nil)
+	assert.NoError(t, err)
+	task, _, _ = tm.GetTask()
+	assert.True(t, tm.CompleteExactTask(id, task.ID, task.Lease, "two"))
+	expr, _ = tm.GetExpression(id)
+	assert.Equal(t, "error", expr.Status)
+}
+
+// TestComplexPrecision проверяет передачу комплексных результатов между задачами выражения.
+func TestComplexPrecision(t *testing.T) {
+	tm := task_manager.NewTaskManager()
+
+	id, err := tm.AddExpression("sqrt(x) + 1", map[string]float64{"x": -4}, "complex", nil)
+	assert.NoError(t, err)
+
+	task, _, found := tm.GetTask()
+	assert.True(t, found)
+	assert.Equal(t, "sqrt", task.Operation)
+	assert.Equal(t, &models.Complex{Re: -4}, task.ComplexArgs[0])
+	assert.True(t, tm.CompleteComplexTask(id, task.ID, task.Lease, models.Complex{Im: 2}))
+
+	task, _, found = tm.GetTask()
+	assert.True(t, found)
+	assert.Equal(t, &models.Complex{Im: 2}, task.ComplexArgs[0])
+	assert.True(t, tm.CompleteComplexTask(id, task.ID, task.Lease, models.Complex{Re: 1, Im: 2}))
+
+	expr, found := tm.GetExpression(id)
+	assert.True(t, found)
+	assert.Equal(t, "completed", expr.Status)
+	assert.Equal(t, &models.Complex{Re: 1, Im: 2}, expr.ComplexResult)
+}
+
+// TestUnits проверяет размерности аргументов задач и единицу измерения результата.
+func TestUnits(t *testing.T) {
+	tm := task_manager.NewTaskManager()
+
+	id, err := tm.AddExpression("(1 km + 2 km) / 30 min to km/h", nil, "", nil)
+	assert.NoError(t, err)
+
+	task, _, found := tm.GetTask()
+	assert.True(t, found)
+	assert.Equal(t, []string{"m", "m"}, task.Units)
+	assert.True(t, tm.CompleteTask(id, task.ID, task.Lease, "", 3000))
+
+	task, _, found = tm.GetTask()
+	assert.True(t, found)
+	assert.Equal(t, []string{"m", "s"}, task.Units)
+	assert.Equal(t, 1800.0, *task.Args[1])
+	assert.True(t, tm.CompleteTask(id, task.ID, task.Lease, "", 3000.0/1800))
+
+	// Перевод в km/h - деление на множитель единицы
+	task, _, found = tm.GetTask()
+	assert.True(t, found)
+	assert.Equal(t, []string{"m/s", ""}, task.Units)
+	assert.True(t, tm.CompleteTask(id, task.ID, task.Lease, "", 6))
+
+	expr, found := tm.GetExpression(id)
+	assert.True(t, found)
+	assert.Equal(t, "completed", expr.Status)
+	assert.Equal(t, "km/h", expr.Unit)
+}
+
 // TestCompleteTask проверяет завершение задачи.
 func TestCompleteTask(t *testing.T) {
 	tm := task_manager.NewTaskManager()
 
 	// Добавляем выражение
-	id, err := tm.AddExpression("2 + 2")
+	id, err := tm.AddExpression("2 + 2", nil, "", nil)
 	assert.NoError(t, err)
 
 	// Получаем задачу
@@ -139,7 +350,7 @@ func TestCompleteTask(t *testing.T) {
 	assert.Equal(t, id, exprID)
 
 	// Завершаем задачу
-	success := tm.CompleteTask(id, task.ID, "", 4.0)
+	success := tm.CompleteTask(id, task.ID, task.Lease, "", 4.0)
 	assert.True(t, success)
 
 	// Проверяем, что задача завершена
@@ -149,7 +360,7 @@ func TestCompleteTask(t *testing.T) {
 	assert.Equal(t, 4.0, *expr.Result)
 
 	// Пытаемся завершить несуществующую задачу
-	success = tm.CompleteTask("invalid-id", "invalid-task-id", "", 0.0)
+	success = tm.CompleteTask("invalid-id", "invalid-task-id", "", "", 0.0)
 	assert.False(t, success)
 }
 
@@ -158,7 +369,7 @@ func TestImpossibleTask(t *testing.T) {
 	tm := task_manager.NewTaskManager()
 
 	// Добавляем выражение
-	id, err := tm.AddExpression("2 + 2")
+	id, err := tm.AddExpression("2 + 2", nil, "", nil)
 	assert.NoError(t, err)
 
 	// Получаем задачу
@@ -167,7 +378,7 @@ func TestImpossibleTask(t *testing.T) {
 	assert.Equal(t, id, exprID)
 
 	// Помечаем задачу как невозможную
-	success := tm.CompleteTask(id, task.ID, "division by zero", 0.0)
+	success := tm.CompleteTask(id, task.ID, task.Lease, "division by zero", 0.0)
 	assert.True(t, success)
 
 	// Проверяем, что выражение помечено как "error"
@@ -177,23 +388,327 @@ func TestImpossibleTask(t *testing.T) {
 	assert.Equal(t, "division by zero", expr.Error)
 }
 
-// TestAreDependenciesCompleted проверяет проверку завершенности зависимостей.
-func TestAreDependenciesCompleted(t *testing.T) {
+// TestReferences проверяет ссылки на результаты других выражений: зависимое выражение ждет результат,
+// получает его, когда выражение вычислено, и получает ошибку выражения по ссылке.
+func TestReferences(t *testing.T) {
+	tm := task_manager.NewTaskManager()
+
+	first, err := tm.AddExpression("2 + 3", nil, "", nil)
+	assert.NoError(t, err)
+	second, err := tm.AddExpression("$ref("+first+") * 2", nil, "", nil)
+	assert.NoError(t, err)
+	third, err := tm.AddExpression("$ref("+second+")", nil, "", nil)
+	assert.NoError(t, err)
+
+	_, err = tm.AddExpression("$ref(missing) + 1", nil, "", nil)
+	assert.ErrorContains(t, err, "missing")
+
+	// Задача ссылки не выдается агентам, умножение ждет результат первого выражения
+	task, exprID, found := tm.GetTask()
+	assert.True(t, found)
+	assert.Equal(t, first, exprID)
+	_, _, found = tm.GetTask()
+	assert.False(t, found)
+
+	tm.CompleteTask(first, task.ID, task.Lease, "", 5)
+
+	task, exprID, found = tm.GetTask()
+	assert.True(t, found)
+	assert.Equal(t, second, exprID)
+	assert.Equal(t, "*", task.Operation)
+	assert.Equal(t, 5.0, *task.Args[0])
+
+	tm.CompleteTask(second, task.ID, task.Lease, "", 10)
+
+	// Третье выражение состоит из одной ссылки и вычисляется вместе со вторым
+	expr, found := tm.GetExpression(third)
+	assert.True(t, found)
+	assert.Equal(t, "completed", expr.Status)
+	if assert.NotNil(t, expr.Result) {
+		assert.Equal(t, 10.0, *expr.Result)
+	}
+
+	// Ссылка на уже вычисленное выражение получает результат сразу
+	id, err := tm.AddExpression("$ref("+second+") + 1", nil, "", nil)
+	assert.NoError(t, err)
+	task, exprID, found = tm.GetTask()
+	assert.True(t, found)
+	assert.Equal(t, id, exprID)
+	assert.Equal(t, 10.0, *task.Args[0])
+
+	// Ошибка передается по цепочке ссылок
+	failing, err := tm.AddExpression("1 / 0", nil, "", nil)
+	assert.NoError(t, err)
+	dependent, err := tm.AddExpression("$ref("+failing+") + 1", nil, "", nil)
+	assert.NoError(t, err)
+	chained, err := tm.AddExpression("$ref("+dependent+") * 2", nil, "", nil)
+	assert.NoError(t, err)
+
+	task, _, _ = tm.GetTask()
+	tm.CompleteTask(failing, task.ID, task.Lease, "division by zero", 0)
+
+	expr, _ = tm.GetExpression(dependent)
+	assert.Equal(t, "error", expr.Status)
+	assert.Equal(t, "ошибка в выражении "+failing+": division by zero", expr.Error)
+	expr, _ = tm.GetExpression(chained)
+	assert.Equal(t, "error", expr.Status)
+	assert.Contains(t, expr.Error, "division by zero")
+}
+
+// TestRestore проверяет восстановление выражений из хранилища на диске после перезапуска.
+func TestRestore(t *testing.T) {
+	dir := t.TempDir()
+	store, err := storage.NewFileStore(dir, 0, false)
+	assert.NoError(t, err)
+	tm, err := task_manager.NewTaskManagerWithStore(store)
+	assert.NoError(t, err)
+
+	id, err := tm.AddExpression("2 + 3", nil, "", nil)
+	assert.NoError(t, err)
+	dependent, err := tm.AddExpression("$ref("+id+") * 2", nil, "", nil)
+	assert.NoError(t, err)
+	task, _, found := tm.GetTask()
+	assert.True(t, found)
+	assert.NoError(t, tm.Close())
+
+	// Задача, выданная агенту до перезапуска, возвращается в очередь
+	store, err = storage.NewFileStore(dir, 0, false)
+	assert.NoError(t, err)
+	tm, err = task_manager.NewTaskManagerWithStore(store)
+	assert.NoError(t, err)
+	assert.Len(t, tm.GetExpressions(), 2)
+
+	requeued, exprID, found := tm.GetTask()
+	assert.True(t, found)
+	assert.Equal(t, id, exprID)
+	assert.Equal(t, task.ID, requeued.ID)
+	assert.True(t, tm.CompleteTask(id, requeued.ID, requeued.Lease, "", 5))
+
+	// Ссылка восстановленного выражения снова ожидает результат
+	task, exprID, found = tm.GetTask()
+	assert.True(t, found)
+	assert.Equal(t, dependent, exprID)
+	assert.Equal(t, 5.0, *task.Args[0])
+}
+
+// TestLeases проверяет аренду задач: поздний результат отклоняется, а задача с истекшей арендой выдается снова.
+func TestLeases(t *testing.T) {
+	tm := task_manager.NewTaskManager()
+
+	id, err := tm.AddExpression("2 + 3", nil, "", nil)
+	assert.NoError(t, err)
+
+	task, _, found := tm.GetTask()
+	assert.True(t, found)
+	assert.NotEmpty(t, task.Lease)
+	assert.False(t, tm.CompleteTask(id, task.ID, "чужая аренда", "", 5))
+
+	// До истечения аренды задача не возвращается в очередь
+	assert.Equal(t, 0, tm.ReapExpired(time.Now()))
+	_, _, found = tm.GetTask()
+	assert.False(t, found)
+
+	deadline := time.Now().Add(time.Duration(config.Cfg.Server.Orchestrator.TASK_LEASE_GRACE_MS+task.Operation_time) * time.Millisecond)
+	assert.Equal(t, 1, tm.ReapExpired(deadline.Add(time.Second)))
+
+	reissued, _, found := tm.GetTask()
+	assert.True(t, found)
+	assert.Equal(t, task.ID, reissued.ID)
+	assert.NotEqual(t, task.Lease, reissued.Lease)
+
+	// Результат по прежней аренде отклоняется, по новой - принимается
+	assert.False(t, tm.CompleteTask(id, task.ID, task.Lease, "", 5))
+	assert.True(t, tm.CompleteTask(id, reissued.ID, reissued.Lease, "", 5))
+	assert.False(t, tm.CompleteTask(id, reissued.ID, reissued.Lease, "", 5))
+
+	expr, _ := tm.GetExpression(id)
+	assert.Equal(t, "completed", expr.Status)
+}
+
+// TestGetTaskQueue проверяет очередь готовых задач: задачи выдаются в порядке готовности, зависимая задача
+// встает в очередь после вычисления зависимостей, а задачи выражения, завершившегося ошибкой, не выдаются.
+func TestGetTaskQueue(t *testing.T) {
 	tm := task_manager.NewTaskManager()
 
-	// Создаем задачи с зависимостями
-	tasks := []models.Task{
-		{ID: "task1", Status: "completed"},
-		{ID: "task2", Status: "pending"},
+	first, err := tm.AddExpression("x * 2 + 1", map[string]float64{"x": 1}, "", nil)
+	assert.NoError(t, err)
+	failing, err := tm.AddExpression("x * 3 + y * 4", map[string]float64{"x": 1, "y": 1}, "", nil)
+	assert.NoError(t, err)
+
+	multiply, exprID, found := tm.GetTask()
+	assert.True(t, found)
+	assert.Equal(t, first, exprID)
+	assert.Equal(t, "*", multiply.Operation)
+
+	// Задачи второго выражения уже в очереди, но ошибка выражения делает их неактуальными
+	task, exprID, found := tm.GetTask()
+	assert.True(t, found)
+	assert.Equal(t, failing, exprID)
+	assert.True(t, tm.CompleteTask(failing, task.ID, task.Lease, "overflow", 0))
+
+	_, _, found = tm.GetTask()
+	assert.False(t, found)
+
+	assert.True(t, tm.CompleteTask(first, multiply.ID, multiply.Lease, "", 2))
+	task, exprID, found = tm.GetTask()
+	assert.True(t, found)
+	assert.Equal(t, first, exprID)
+	assert.Equal(t, "+", task.Operation)
+	assert.Equal(t, 2.0, *task.Args[0])
+}
+
+// TestCancelExpression проверяет отмену выражения: задачи не выдаются, результаты не принимаются,
+// а выражения со ссылками на отмененное выражение завершаются ошибкой.
+func TestCancelExpression(t *testing.T) {
+	tm := task_manager.NewTaskManager()
+
+	id, err := tm.AddExpression("x * 2 + 1", map[string]float64{"x": 1}, "", nil)
+	assert.NoError(t, err)
+	dependent, err := tm.AddExpression("$ref("+id+") + 1", nil, "", nil)
+	assert.NoError(t, err)
+
+	task, _, found := tm.GetTask()
+	assert.True(t, found)
+	assert.NoError(t, tm.Heartbeat(id, task.ID, task.Lease))
+	assert.ErrorIs(t, tm.Heartbeat(id, task.ID, "чужая аренда"), task_manager.ErrLeaseInvalid)
+
+	assert.NoError(t, tm.CancelExpression(id))
+	assert.ErrorIs(t, tm.Heartbeat(id, task.ID, task.Lease), task_manager.ErrExpressionCancelled)
+	assert.False(t, tm.CompleteTask(id, task.ID, task.Lease, "", 2))
+	_, _, found = tm.GetTask()
+	assert.False(t, found)
+
+	expr, _ := tm.GetExpression(id)
+	assert.Equal(t, "cancelled", expr.Status)
+	for _, task := range expr.Tasks {
+		assert.Equal(t, "cancelled", task.Status)
 	}
+	expr, _ = tm.GetExpression(dependent)
+	assert.Equal(t, "error", expr.Status)
+	assert.Equal(t, "выражение "+id+" отменено", expr.Error)
 
-	// Проверяем завершенность зависимостей
-	completed := tm.AreDependenciesCompleted(tasks, []string{"task1"})
-	assert.True(t, completed)
+	assert.ErrorIs(t, tm.CancelExpression(id), task_manager.ErrExpressionFinished)
+	assert.ErrorIs(t, tm.CancelExpression(dependent), task_manager.ErrExpressionFinished)
+	assert.ErrorIs(t, tm.CancelExpression("missing"), task_manager.ErrExpressionNotFound)
+}
 
-	completed = tm.AreDependenciesCompleted(tasks, []string{"task2"})
-	assert.False(t, completed)
+// completeExpression добавляет выражение с одной задачей и вычисляет его.
+func completeExpression(t *testing.T, tm *task_manager.TaskManager, expression string) string {
+	id, err := tm.AddExpression(expression, nil, "", nil)
+	assert.NoError(t, err)
+	task, _, found := tm.GetTask()
+	assert.True(t, found)
+	assert.True(t, tm.CompleteTask(id, task.ID, task.Lease, "", 1))
+	return id
+}
+
+// TestRepeatedReads проверяет, что вычисленное выражение возвращается при каждом запросе,
+// а список выражений упорядочен по времени добавления.
+func TestRepeatedReads(t *testing.T) {
+	tm := task_manager.NewTaskManager()
+
+	first := completeExpression(t, tm, "2 + 2")
+	second, err := tm.AddExpression("3 + 3", nil, "", nil)
+	assert.NoError(t, err)
 
-	completed = tm.AreDependenciesCompleted(tasks, []string{"task3"})
-	assert.False(t, completed)
+	expr, found := tm.GetExpression(first)
+	assert.True(t, found)
+	again, found := tm.GetExpression(first)
+	assert.True(t, found)
+	assert.Equal(t, "completed", again.Status)
+	assert.Equal(t, expr, again)
+
+	for i := 0; i < 3; i++ {
+		expressions := tm.GetExpressions()
+		if assert.Len(t, expressions, 2) {
+			assert.Equal(t, first, expressions[0].ID)
+			assert.Equal(t, second, expressions[1].ID)
+		}
+	}
+}
+
+// TestEvict проверяет политику хранения: удаление по TTL и удаление давно не запрашивавшихся выражений
+// сверх MaxCount. Выражения, которые ещё вычисляются, не удаляются.
+func TestEvict(t *testing.T) {
+	retention := config.Cfg.Retention
+	defer func() { config.Cfg.Retention = retention }()
+	config.Cfg.Retention.TTL = 60
+	config.Cfg.Retention.MaxCount = 2
+
+	tm := task_manager.NewTaskManager()
+	oldest := completeExpression(t, tm, "1 + 1")
+	older := completeExpression(t, tm, "2 + 2")
+	newest := completeExpression(t, tm, "3 + 3")
+	pending, err := tm.AddExpression("4 + 4", nil, "", nil)
+	assert.NoError(t, err)
+
+	// Запрос делает выражение недавно использованным, лишним остается older
+	tm.GetExpression(oldest)
+	assert.Equal(t, 1, tm.Evict(time.Now()))
+	_, found := tm.GetExpression(older)
+	assert.False(t, found)
+	_, found = tm.GetExpression(newest)
+	assert.True(t, found)
+
+	// По истечении TTL удаляются все завершенные выражения
+	assert.Equal(t, 2, tm.Evict(time.Now().Add(time.Minute+time.Second)))
+	expressions := tm.GetExpressions()
+	if assert.Len(t, expressions, 1) {
+		assert.Equal(t, pending, expressions[0].ID)
+	}
+}
+
+// benchmarkExpressions - количество выражений в TaskManager в бенчмарках выдачи задач.
+const benchmarkExpressions = 10000
+
+// newBenchmarkTaskManager создает TaskManager с benchmarkExpressions выражениями по две задачи.
+func newBenchmarkTaskManager(b *testing.B) *task_manager.TaskManager {
+	tm := task_manager.NewTaskManager()
+	addBenchmarkExpressions(b, tm)
+	return tm
+}
+
+// addBenchmarkExpressions добавляет benchmarkExpressions выражений: умножение и зависящее от него сложение.
+func addBenchmarkExpressions(b *testing.B, tm *task_manager.TaskManager) {
+	for i := 0; i < benchmarkExpressions; i++ {
+		if _, err := tm.AddExpression("x * 2 + 1", map[string]float64{"x": float64(i)}, "", nil); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkGetTask измеряет опрос агентом при benchmarkExpressions выражениях с готовыми задачами:
+// каждая выданная задача сразу завершается, открывая зависимую задачу.
+func BenchmarkGetTask(b *testing.B) {
+	tm := newBenchmarkTaskManager(b)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		task, exprID, found := tm.GetTask()
+		if !found {
+			b.StopTimer()
+			addBenchmarkExpressions(b, tm)
+			b.StartTimer()
+			continue
+		}
+		tm.CompleteTask(exprID, task.ID, task.Lease, "", 1)
+	}
+}
+
+// BenchmarkGetTaskIdle измеряет опрос агентом, когда все задачи benchmarkExpressions выражений уже выданы
+// и готовых задач нет.
+func BenchmarkGetTaskIdle(b *testing.B) {
+	tm := newBenchmarkTaskManager(b)
+	for {
+		if _, _, found := tm.GetTask(); !found {
+			break
+		}
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, _, found := tm.GetTask(); found {
+			b.Fatal("задача выдана повторно")
+		}
+	}
 }
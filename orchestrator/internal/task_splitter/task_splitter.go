@@ -2,372 +2,1000 @@ package task_splitter
 
 import (
 	"errors"
+	"fmt"
+	"math"
+	"math/cmplx"
+	"slices"
 	"strconv"
 	"strings"
-	"unicode"
 
 	"github.com/OinkiePie/calc_2/config"
+	"github.com/OinkiePie/calc_2/orchestrator/internal/ast"
+	"github.com/OinkiePie/calc_2/orchestrator/internal/functions"
+	"github.com/OinkiePie/calc_2/orchestrator/internal/optimizer"
+	"github.com/OinkiePie/calc_2/pkg/constants"
+	"github.com/OinkiePie/calc_2/pkg/evaluator"
 	"github.com/OinkiePie/calc_2/pkg/logger"
 	"github.com/OinkiePie/calc_2/pkg/models"
 	"github.com/OinkiePie/calc_2/pkg/operators"
+	"github.com/OinkiePie/calc_2/pkg/units"
 	"github.com/google/uuid"
 )
 
 var (
-	errOneOperand        = errors.New("минимум два операнда требуются для расчета")
-	errUnopenedParen     = errors.New("неоткрытая скобка")
-	errUnclosedParen     = errors.New("незакрытая скобка")
-	errInvalidSyntax     = errors.New("неверный синтаксис")
-	errNotEnoughOperands = errors.New("недостаточно операндов")
-	errUnaryMinus        = errors.New("недостаточно операндов для унарного минуса")
-	errRPN               = errors.New("не удалось преобразовать RPN")
+	errUnboundIdent = errors.New("имя без значения в дереве выражения")
+	errPrecision    = errors.New("некорректная точность вычислений")
+	errUnits        = errors.New("единицы измерения доступны только при точности float64")
 )
 
+// UnboundVariablesError - ошибка, возникающая если в выражении используются переменные без значений.
+type UnboundVariablesError struct {
+	// Names - Имена переменных без значений в порядке первого появления в выражении.
+	Names []string
+}
+
+// Error возвращает описание ошибки со списком переменных без значений.
+func (e *UnboundVariablesError) Error() string {
+	return "не заданы значения переменных: " + strings.Join(e.Names, ", ")
+}
+
+// logDefaultBase - основание логарифма, если оно не указано явно.
+const logDefaultBase = 10
+
+// imaginaryUnit - имя мнимой единицы в комплексном режиме, например "3+4i".
+const imaginaryUnit = "i"
+
+// Plan - результат разбиения выражения на задачи.
+type Plan struct {
+	// Tasks - Задачи для агентов. Корневая задача последняя. Пусто, если выражение полностью свернуто.
+	Tasks []models.Task
+	// Result - Значение выражения, если оно полностью вычислено оркестратором при свертке констант, иначе nil.
+	Result *float64
+	// ExactResult - Точное значение полностью свернутого выражения, если задана точность отличная от float64, иначе nil.
+	ExactResult *string
+	// ComplexResult - Комплексное значение полностью свернутого выражения, если задана точность complex, иначе nil.
+	ComplexResult *models.Complex
+	// Unit - Единица измерения результата: единица перевода "to" или размерность в основных единицах СИ.
+	// Пустая строка, если в выражении нет единиц измерения или результат безразмерный.
+	Unit string
+	// Functions - Версии пользовательских функций, подставленных в выражение, по именам. nil, если функции не вызывались.
+	Functions map[string]int
+	// Stats - Статистика оптимизации.
+	Stats models.ExpressionStats
+}
+
 // ParseExpression разбирает математическое выражение, представленное в виде строки, и преобразует его в набор задач для выполнения.
+// Если выражение - одно число или полностью свернуто (см. SplitExpression), возвращается пустой срез задач.
 //
 // Args:
 //
 //	id: string - Уникальный идентификатор для связывания задач с выражением.
 //	expression: string - Математическое выражение, которое необходимо разобрать.
+//	variables: map[string]float64 - Значения переменных, используемых в выражении (может быть nil).
 //
 // Returns:
 //
 //	[]models.Task - Срез задач, представляющих операции, необходимые для вычисления выражения.
 //	error - Ошибка, если выражение не может быть разобрано или содержит неверные элементы.
-func ParseExpression(id, expression string) ([]models.Task, error) {
-	// expression = strings.TrimSpace(expression) не требуется
-	// т.к. была передана уже обрезанная строка
+//	        Если в выражении есть переменные без значений, возвращается *UnboundVariablesError,
+//	        синтаксические ошибки возвращаются как *ast.ParseError.
+func ParseExpression(id, expression string, variables map[string]float64) ([]models.Task, error) {
+	plan, err := SplitExpression(id, expression, variables, evaluator.PrecisionFloat64, nil)
+	return plan.Tasks, err
+}
 
-	// Удаляем все пробелы внутри строки
-	expression = strings.ReplaceAll(expression, " ", "")
+// SplitExpression разбирает математическое выражение и строит план его вычисления.
+// Одинаковые подвыражения, например "(a+b)" в "(a+b)*(a+b)", вычисляются одной задачей, от которой зависят все её потребители.
+// Выражение без операций ("2e3", "5 km", переменная) и условие, ветвь которого известна при разборе ("0 ? 1 : 2"),
+// не требуют задач: их значение возвращается в Plan.Result, как у полностью свернутого выражения.
+// Если включена свертка констант (optimizer.fold_constants), операции над одними числами вычисляются сразу,
+// без отправки агентам. Операции, которые завершились бы ошибкой или бесконечностью, не сворачиваются,
+// чтобы ошибку выражения, как и без свертки, сообщил агент.
+// При точности, отличной от float64, задачи получают аргументы в точной строковой записи (models.Task.ExactArgs)
+// и вычисляются агентом с помощью math/big, а функции с иррациональными значениями (sin, ln и т.п.) недоступны.
+// При точности complex имя i без значения - мнимая единица ("3+4i"), задачи получают комплексные аргументы
+// (models.Task.ComplexArgs), а сравнения на больше/меньше, %, //, факториал, max и min недоступны.
+// Числа с единицами измерения ("5 km/h") переводятся в основные единицы СИ, а размерности операндов проверяются
+// при построении задач: "3 m + 2 s" - ошибка. Задачи получают размерности аргументов (models.Task.Units),
+// перевод "to" в конце выражения выполняется последней задачей - делением на множитель единицы.
+// Вызовы пользовательских функций заменяются их телами до подстановки переменных: параметры функции
+// получают аргументы вызова, даже если в запросе есть переменная с тем же именем.
+// Ссылка на другое выражение "$ref(id)" становится задачей operators.OpReference (см. builder.reference),
+// результат которой подставляет TaskManager.
+//
+// Args:
+//
+//	id: string - Уникальный идентификатор для связывания задач с выражением.
+//	expression: string - Математическое выражение, которое необходимо разобрать.
+//	variables: map[string]float64 - Значения переменных, используемых в выражении (может быть nil).
+//	precision: string - Точность вычислений: "float64" (или пустая строка), "decimal", "rational", "bigfloat:N" или "complex".
+//	userFunctions: map[string]*functions.Function - Пользовательские функции, доступные выражению (может быть nil).
+//
+// Returns:
+//
+//	Plan - Задачи выражения, его значение (если оно свернуто полностью) и статистика оптимизации.
+//	error - Ошибка, если выражение не может быть разобрано или содержит неверные элементы (см. ParseExpression),
+//	        или точность задана неверно. Недоступная при заданной точности операция и несовместимые
+//	        размерности операндов возвращаются как *ast.ParseError.
+func SplitExpression(id, expression string, variables map[string]float64, precision string,
+	userFunctions map[string]*functions.Function) (Plan, error) {
+	mode, err := evaluator.ParsePrecision(precision)
+	if err != nil {
+		return Plan{}, fmt.Errorf("%w %q, допустимы: float64, decimal, rational, bigfloat:N, complex", errPrecision, precision)
+	}
 
-	rpn, err := infixToRPN(expression)
+	tree, err := ast.Parse(expression)
 	if err != nil {
-		return nil, err
+		return Plan{}, err
+	}
+	convert, _ := tree.(*ast.Convert)
+	if convert != nil {
+		tree = convert.Value
 	}
 
-	var tasks []models.Task
-	tasks, err = rpnToTasks(id, rpn)
+	tree, used, err := functions.Expand(tree, userFunctions)
+	if err != nil {
+		return Plan{}, err
+	}
 
+	var unbound []string
+	tree, err = bindNames(tree, variables, mode.IsComplex(), &unbound)
 	if err != nil {
-		return nil, err
+		return Plan{}, err
+	}
+	if len(unbound) > 0 {
+		return Plan{}, &UnboundVariablesError{Names: unbound}
+	}
+
+	withUnits := convert != nil || hasUnits(tree)
+	if withUnits && mode.Mode != evaluator.PrecisionFloat64 {
+		return Plan{}, errUnits
 	}
 
-	return tasks, nil
+	if config.Cfg.Optimizer.Rebalance {
+		tree = optimizer.Rebalance(tree)
+	}
+
+	b := &builder{
+		expression: id,
+		fold:       config.Cfg.Optimizer.FoldConstants,
+		precision:  mode,
+		units:      withUnits,
+		emitted:    make(map[string]operand),
+	}
+	root, err := b.build(tree)
+	if err != nil {
+		return Plan{}, err
+	}
+	if convert != nil {
+		if root, err = b.convert(root, convert); err != nil {
+			return Plan{}, err
+		}
+	}
+	b.stats.TasksAfter = len(b.tasks)
+
+	plan := Plan{Tasks: b.tasks, Functions: used, Stats: b.stats}
+	if convert != nil {
+		plan.Unit = convert.Unit
+	} else if withUnits {
+		plan.Unit = root.dim.String()
+	}
+	if root.taskID == "" {
+		plan.Result = &root.value
+		if mode.IsExact() {
+			// Значение выражения без операций - исходная запись литерала, она приводится к записи результата режима
+			text, err := evaluator.FormatExact(root.text, mode)
+			if err != nil {
+				return Plan{}, err
+			}
+			plan.ExactResult = &text
+		}
+		if mode.IsComplex() {
+			value := models.NewComplex(complex(root.value, root.imag))
+			plan.ComplexResult = &value
+		}
+	}
+	return plan, nil
 }
 
-// precedence определяет приоритет оператора для правильной вложенности при разбиении на задачи.
-// Более высокий приоритет означает, что оператор должен быть выполнен раньше.
+// bindNames подставляет значения переменных и констант вместо имен в синтаксическом дереве.
+// Переменные запроса имеют приоритет над константами. Узлы обходятся в порядке записи выражения.
+// В комплексном режиме имя i без значения - мнимая единица, а неявное произведение числа на неё ("4i") - одно мнимое число.
+// Число с единицей измерения переводится в основные единицы СИ и получает размерность. Если единица - одно имя,
+// совпадающее с переменной или константой, то это неявное умножение: при переменной g "2 g" = 2*g, а не 2 грамма.
 //
 // Args:
 //
-//	op: string - Строка, представляющая оператор (+, -, *, /, ^, u-).
+//	node: ast.Node - Узел дерева.
+//	variables: map[string]float64 - Значения переменных.
+//	imaginary: bool - Подставлять мнимую единицу вместо имени i.
+//	unbound: *[]string - Срез, в который добавляются имена без значений (без повторов).
 //
 // Returns:
 //
-//	int - Целое число, представляющее приоритет оператора. Чем больше число, тем выше приоритет.
-//	     Возвращает 0 для неопознанных операторов.
-func precedence(op string) int {
-	switch op {
-	case operators.OpAdd, operators.OpSubtract:
-		return 1
-	case operators.OpMultiply, operators.OpDivide:
-		return 2
-	case operators.OpPower:
-		return 3
-	case operators.OpUnaryMinus:
-		return 4
+//	ast.Node - Узел, в котором имена заменены на числа (*ast.Number).
+//	error - *ast.ParseError, если за именем без значения следует открывающая скобка (вызов неизвестной функции)
+//	        или единица измерения неизвестна.
+func bindNames(node ast.Node, variables map[string]float64, imaginary bool, unbound *[]string) (ast.Node, error) {
+	var err error
+	switch n := node.(type) {
+	case *ast.Number:
+		if n.Unit == "" {
+			return n, nil
+		}
+		_, isVariable := variables[n.Unit]
+		_, isConstant := constants.Lookup(n.Unit)
+		if isVariable || isConstant {
+			number := *n
+			number.Unit = ""
+			name := &ast.Ident{Name: n.Unit, Offset: n.Offset}
+			return bindNames(&ast.Binary{Op: operators.OpMultiply, Left: &number, Right: name, Implicit: true, Offset: n.Offset},
+				variables, imaginary, unbound)
+		}
+		unit, err := units.Parse(n.Unit)
+		if err != nil {
+			return nil, &ast.ParseError{Err: fmt.Errorf("неизвестная единица измерения: %s", n.Unit), Pos: n.Offset, Token: n.Unit}
+		}
+		value := n.Value * unit.Factor
+		return &ast.Number{Value: value, Text: evaluator.FormatFloat(value), Unit: n.Unit, Dim: unit.Dim, Offset: n.Offset}, nil
+	case *ast.Ident:
+		value, ok := variables[n.Name]
+		if !ok {
+			value, ok = constants.Lookup(n.Name)
+		}
+		if ok {
+			return &ast.Number{Value: value, Text: evaluator.FormatFloat(value), Offset: n.Offset}, nil
+		}
+		if imaginary && n.Name == imaginaryUnit {
+			return &ast.Number{Imag: 1, Offset: n.Offset}, nil
+		}
+		if !slices.Contains(*unbound, n.Name) {
+			*unbound = append(*unbound, n.Name)
+		}
+		return n, nil
+	case *ast.Unary:
+		bound := *n
+		bound.Operand, err = bindNames(n.Operand, variables, imaginary, unbound)
+		return &bound, err
+	case *ast.Binary:
+		bound := *n
+		if bound.Left, err = bindNames(n.Left, variables, imaginary, unbound); err != nil {
+			return nil, err
+		}
+		if bound.Right, err = bindNames(n.Right, variables, imaginary, unbound); err != nil {
+			return nil, err
+		}
+		// Мнимый литерал "4i": неявное произведение действительного числа на мнимую единицу
+		left, leftOk := bound.Left.(*ast.Number)
+		right, rightOk := bound.Right.(*ast.Number)
+		if bound.Implicit && leftOk && rightOk && left.Imag == 0 && right.Value == 0 && right.Imag == 1 {
+			return &ast.Number{Imag: left.Value, Offset: left.Offset}, nil
+		}
+		return &bound, nil
+	case *ast.Conditional:
+		bound := *n
+		if bound.Cond, err = bindNames(n.Cond, variables, imaginary, unbound); err != nil {
+			return nil, err
+		}
+		if bound.Then, err = bindNames(n.Then, variables, imaginary, unbound); err != nil {
+			return nil, err
+		}
+		bound.Else, err = bindNames(n.Else, variables, imaginary, unbound)
+		return &bound, err
+	case *ast.Call:
+		if !operators.IsFunction(n.Func) {
+			// Пользовательские функции уже подставлены, имя со скобкой - неявное умножение, например "x(3+4)"
+			_, isVariable := variables[n.Func]
+			_, isConstant := constants.Lookup(n.Func)
+			if len(n.Args) != 1 || !(isVariable || isConstant || (imaginary && n.Func == imaginaryUnit)) {
+				return nil, &ast.ParseError{Err: fmt.Errorf("неизвестная функция: %s", n.Func), Pos: n.Offset, Token: n.Func}
+			}
+			name := &ast.Ident{Name: n.Func, Offset: n.Offset}
+			product := &ast.Binary{Op: operators.OpMultiply, Left: name, Right: n.Args[0], Implicit: true, Offset: n.Args[0].Pos()}
+			return bindNames(product, variables, imaginary, unbound)
+		}
+		bound := *n
+		bound.Args = make([]ast.Node, len(n.Args))
+		for i, arg := range n.Args {
+			if bound.Args[i], err = bindNames(arg, variables, imaginary, unbound); err != nil {
+				return nil, err
+			}
+		}
+		return &bound, nil
 	default:
+		return node, nil
+	}
+}
+
+// hasUnits проверяет, есть ли в дереве числа с единицами измерения.
+func hasUnits(node ast.Node) bool {
+	switch n := node.(type) {
+	case *ast.Number:
+		return n.Unit != ""
+	case *ast.Unary:
+		return hasUnits(n.Operand)
+	case *ast.Binary:
+		return hasUnits(n.Left) || hasUnits(n.Right)
+	case *ast.Conditional:
+		return hasUnits(n.Cond) || hasUnits(n.Then) || hasUnits(n.Else)
+	case *ast.Call:
+		return slices.ContainsFunc(n.Args, hasUnits)
+	default:
+		return false
+	}
+}
+
+// opTime возвращает время операции
+//
+// Args:
+//
+//	operator: string - математический оператор.
+//
+// Returns:
+//
+//	int - длительность операции в миллисекундах, если значение отсутствует - 0.
+func opTime(operator string) int {
+	// Время не вынесено в отдельную переменную т.к. при этом конфиг не успевает инициализироваться
+	duration, ok := map[string]int{
+		operators.OpAdd:             config.Cfg.Math.TIME_ADDITION_MS,
+		operators.OpSubtract:        config.Cfg.Math.TIME_SUBTRACTION_MS,
+		operators.OpMultiply:        config.Cfg.Math.TIME_MULTIPLICATION_MS,
+		operators.OpDivide:          config.Cfg.Math.TIME_DIVISION_MS,
+		operators.OpPower:           config.Cfg.Math.TIME_POWER_MS,
+		operators.OpModulo:          config.Cfg.Math.TIME_MODULO_MS,
+		operators.OpFloorDivide:     config.Cfg.Math.TIME_FLOOR_DIVISION_MS,
+		operators.OpFactorial:       config.Cfg.Math.TIME_FACTORIAL_MS,
+		operators.OpUnaryMinus:      config.Cfg.Math.TIME_UNARY_MINUS_MS,
+		operators.FnSin:             config.Cfg.Math.TIME_SIN_MS,
+		operators.FnCos:             config.Cfg.Math.TIME_COS_MS,
+		operators.FnTan:             config.Cfg.Math.TIME_TAN_MS,
+		operators.FnSqrt:            config.Cfg.Math.TIME_SQRT_MS,
+		operators.FnLog:             config.Cfg.Math.TIME_LOG_MS,
+		operators.FnLn:              config.Cfg.Math.TIME_LN_MS,
+		operators.FnAbs:             config.Cfg.Math.TIME_ABS_MS,
+		operators.FnExp:             config.Cfg.Math.TIME_EXP_MS,
+		operators.FnMax:             config.Cfg.Math.TIME_MAX_MS,
+		operators.FnMin:             config.Cfg.Math.TIME_MIN_MS,
+		operators.OpLess:            config.Cfg.Math.TIME_COMPARISON_MS,
+		operators.OpLessEqual:       config.Cfg.Math.TIME_COMPARISON_MS,
+		operators.OpEqual:           config.Cfg.Math.TIME_COMPARISON_MS,
+		operators.OpNotEqual:        config.Cfg.Math.TIME_COMPARISON_MS,
+		operators.OpGreater:         config.Cfg.Math.TIME_COMPARISON_MS,
+		operators.OpGreaterEqual:    config.Cfg.Math.TIME_COMPARISON_MS,
+		operators.OpAnd:             config.Cfg.Math.TIME_LOGICAL_MS,
+		operators.OpOr:              config.Cfg.Math.TIME_LOGICAL_MS,
+		operators.OpNot:             config.Cfg.Math.TIME_LOGICAL_MS,
+		operators.OpPercent:         config.Cfg.Math.TIME_PERCENT_MS,
+		operators.OpAddPercent:      config.Cfg.Math.TIME_PERCENT_MS,
+		operators.OpSubtractPercent: config.Cfg.Math.TIME_PERCENT_MS,
+		operators.FnRound:           config.Cfg.Math.TIME_ROUND_MS,
+		operators.FnFloor:           config.Cfg.Math.TIME_FLOOR_MS,
+		operators.FnCeil:            config.Cfg.Math.TIME_CEIL_MS,
+		operators.FnPmt:             config.Cfg.Math.TIME_PMT_MS,
+		operators.FnFv:              config.Cfg.Math.TIME_FV_MS,
+		operators.FnMedian:          config.Cfg.Math.TIME_MEDIAN_MS,
+		operators.OpSelect:          0, // Ветвь выбирает оркестратор
+		operators.OpReference:       0, // Результат другого выражения подставляет оркестратор
+	}[operator]
+
+	if !ok {
+		logger.Log.Warnf("Оператор %s не найден", operator)
 		return 0
 	}
+	return duration
+}
+
+// operand - операнд задачи: число или результат другой задачи.
+type operand struct {
+	value  float64         // Значение, если операнд - число (действительная часть в комплексном режиме).
+	imag   float64         // Мнимая часть числа в комплексном режиме.
+	text   string          // Точная запись числа для точности, отличной от float64, в комплексном режиме - запись "(re+imi)".
+	taskID string          // ID задачи, результат которой является операндом. Пустая строка - операнд является числом.
+	dim    units.Dimension // Размерность значения, если в выражении есть единицы измерения.
+}
+
+// number создает операнд-число из значения float64.
+func number(value float64) operand {
+	return operand{value: value, text: evaluator.FormatFloat(value)}
+}
+
+// complexNumber создает операнд-число из комплексного значения.
+func complexNumber(value complex128) operand {
+	return operand{value: real(value), imag: imag(value), text: evaluator.FormatComplex(value)}
 }
 
-// isOperator проверяет, является ли токен строкой, представляющей математический оператор.
+// newTask создает задачу для операции над операндами.
+// Операнд-число записывается в Args, результат другой задачи - в Dependencies.
 //
 // Args:
 //
-//	token: string - Строка, которую необходимо проверить.
+//	expression: string - ID выражения, к которому принадлежит задача.
+//	operation: string - Операция задачи.
+//	operands: []operand - Операнды задачи.
 //
 // Returns:
 //
-//	bool - true, если токен является одним из допустимых операторов (+, -, *, /, ^, u-), иначе false.
-func isOperator(token string) bool {
-	switch token {
-	case operators.OpAdd, operators.OpSubtract, operators.OpMultiply, operators.OpDivide, operators.OpPower:
-		return true
-	default:
-		return false
+//	models.Task - Новая задача со статусом "pending".
+func newTask(expression, operation string, operands []operand) models.Task {
+	task := models.Task{
+		ID:             uuid.New().String(),
+		Args:           make([]*float64, len(operands)),
+		Operation:      operation,
+		Operation_time: opTime(operation),
+		Status:         "pending",
+		Expression:     expression,
+		Dependencies:   make([]string, len(operands)),
+	}
+
+	for i, op := range operands {
+		if op.taskID == "" {
+			value := op.value
+			task.Args[i] = &value // Аргумент - число
+		} else {
+			// Аргумент - nil (зависимость)
+			task.Dependencies[i] = op.taskID
+		}
+	}
+
+	return task
+}
+
+// builder строит задачи выражения из синтаксического дерева.
+type builder struct {
+	expression string                 // ID выражения, к которому принадлежат задачи.
+	fold       bool                   // Вычислять операции над одними числами без создания задач.
+	precision  evaluator.Precision    // Точность вычислений выражения.
+	units      bool                   // В выражении есть единицы измерения: размерности операндов проверяются и передаются агентам.
+	tasks      []models.Task          // Созданные задачи в порядке создания.
+	emitted    map[string]operand     // Результаты уже созданных операций по ключу ветви и операции (см. guardsKey, operationKey).
+	guards     []models.Guard         // Условия ветвей, внутри которых строятся задачи.
+	stats      models.ExpressionStats // Статистика оптимизации.
+}
+
+// guardsKey возвращает ключ набора условий ветви, например "#id=true;".
+func guardsKey(guards []models.Guard) string {
+	var key strings.Builder
+	for _, guard := range guards {
+		key.WriteString("#" + guard.Condition + "=" + strconv.FormatBool(guard.Branch) + ";")
 	}
+	return key.String()
 }
 
-// isUnaryMinus определяет, следует ли обрабатывать знак минус как унарный (например, "-5") или бинарный (например, "3 - 5").
+// operationKey возвращает ключ операции над операндами. Операнды - числа или ID задач, поэтому
+// одинаковые ключи имеют только операции над одинаковыми значениями, то есть одинаковые подвыражения.
+// Числа записываются точно, чтобы при повышенной точности не совпадали числа, равные только после округления до float64.
 //
 // Args:
 //
-//	tokens: []string - Срез строк, представляющий токены выражения.
-//	i: int - Индекс текущего токена в срезе.
+//	operation: string - Операция.
+//	operands: []operand - Операнды операции.
 //
 // Returns:
 //
-//	bool - true, если минус должен быть обработан как унарный, иначе false.
-func isUnaryMinus(tokens []string, i int) bool {
-	if i == 0 {
-		return true // Минус в начале выражения - унарный
+//	string - Ключ, например "+(1,#id задачи)".
+func operationKey(operation string, operands []operand) string {
+	keys := make([]string, len(operands))
+	for i, op := range operands {
+		if op.taskID != "" {
+			keys[i] = "#" + op.taskID
+		} else {
+			keys[i] = op.text
+		}
+		if !op.dim.IsZero() {
+			keys[i] += " " + op.dim.String() // 1 m и 1 s - разные величины
+		}
 	}
-	prevToken := tokens[i-1]
-	return prevToken == operators.ParenLeft || isOperator(prevToken)
+	return operation + "(" + strings.Join(keys, ",") + ")"
 }
 
-// infixToRPN преобразует математическое выражение в инфиксной нотации (обычная запись) в обратную польскую нотацию (RPN).
-// RPN упрощает вычисление выражений с помощью стека.
+// emit возвращает операнд с результатом операции над операндами.
+// Операция над числами вычисляется сразу, если включена свертка констант, повторная операция
+// ссылается на уже созданную задачу, иначе создается новая задача с условиями текущей ветви.
+// Повторной считается операция из текущей ветви или из объемлющих её ветвей: их задачи выполняются
+// всегда, когда выполняется текущая ветвь. Задача из другой ветви может быть пропущена и не используется.
 //
 // Args:
 //
-//	expression: string - Математическое выражение в инфиксной нотации.
+//	operation: string - Операция.
+//	operands: []operand - Операнды операции.
 //
 // Returns:
 //
-//	[]string - Срез строк, представляющий выражение в обратной польской нотации (RPN).
-//	error - Ошибка, если выражение не может быть преобразовано.
-func infixToRPN(expression string) ([]string, error) {
-
-	tokens := tokenize(expression) // Сначала разбиваем на токены
-	output := []string{}           // Выходная очередь
-	stack := []string{}            // Стек операторов
-
-	for i, token := range tokens {
-		switch {
-		case isNumber(token): // Если число, добавляем в выходную очередь
-			output = append(output, token)
-		case token == operators.ParenLeft: // Если открывающая скобка, помещаем в стек
-			stack = append(stack, token)
-		case token == operators.ParenRight: // Если закрывающая скобка
-			for len(stack) > 0 && stack[len(stack)-1] != operators.ParenLeft {
-				// Переносим операторы из стека в выходную очередь,
-				// пока он не опустеет, или мы не встретим открывающую скобку
-				output = append(output, stack[len(stack)-1])
-				stack = stack[:len(stack)-1]
-			}
-			if len(stack) == 0 {
-				// Если в стеке не осталось открывающей скобки, это означает, что у нас была
-				// закрывающая скобка, но не было соответствующей открывающей скобки в выражении
-				return nil, errUnopenedParen
-			}
-			stack = stack[:len(stack)-1] // Удаляем открывающую скобку из стека
-		case isOperator(token): // Если оператор
-			if token == "-" && isUnaryMinus(tokens, i) {
-				token = operators.OpUnaryMinus // Помечаем как унарный минус
+//	operand - Значение операции или ссылка на вычисляющую её задачу.
+func (b *builder) emit(operation string, operands []operand) operand {
+	b.stats.TasksBefore++
+
+	if b.fold {
+		if value, ok := b.foldOperation(operation, operands); ok {
+			b.stats.Folded++
+			return value
+		}
+	}
+
+	key := operationKey(operation, operands)
+	for depth := 0; depth <= len(b.guards); depth++ {
+		if op, ok := b.emitted[guardsKey(b.guards[:depth])+key]; ok {
+			b.stats.Deduplicated++
+			return op
+		}
+	}
+
+	task := newTask(b.expression, operation, operands)
+	task.Guards = slices.Clone(b.guards)
+	if b.precision.IsExact() {
+		task.Precision = b.precision.String()
+		task.ExactArgs = make([]*string, len(operands))
+		for i, op := range operands {
+			if op.taskID == "" {
+				text := op.text
+				task.ExactArgs[i] = &text
 			}
-			for len(stack) > 0 && precedence(token) <= precedence(stack[len(stack)-1]) {
-				// Переносим операторы из стека в выходную очередь, пока приоритет текущего оператора
-				// меньше или равен приоритету оператора на вершине стека
-				output = append(output, stack[len(stack)-1])
-				stack = stack[:len(stack)-1]
+		}
+	}
+	if b.precision.IsComplex() {
+		task.Precision = b.precision.String()
+		task.ComplexArgs = make([]*models.Complex, len(operands))
+		for i, op := range operands {
+			if op.taskID == "" {
+				value := models.Complex{Re: op.value, Im: op.imag}
+				task.ComplexArgs[i] = &value
 			}
-			stack = append(stack, token) // Помещаем текущий оператор в стек
-		default:
-			return nil, errInvalidSyntax
 		}
 	}
+	if b.units {
+		task.Units = make([]string, len(operands))
+		for i, op := range operands {
+			task.Units[i] = op.dim.String()
+		}
+	}
+	b.tasks = append(b.tasks, task)
+	b.emitted[guardsKey(b.guards)+key] = operand{taskID: task.ID}
+	return operand{taskID: task.ID}
+}
 
-	// Переносим все оставшиеся операторы из стека в выходную очередь
-	for len(stack) > 0 {
-		top := stack[len(stack)-1]
-		if top == operators.ParenLeft || top == operators.ParenRight {
-			return nil, errUnclosedParen
+// foldOperation вычисляет операцию, если все её операнды - числа.
+// При точности, отличной от float64, операция вычисляется точно, как её вычислил бы агент.
+//
+// Args:
+//
+//	operation: string - Операция.
+//	operands: []operand - Операнды операции.
+//
+// Returns:
+//
+//	operand - Значение операции.
+//	bool - false, если среди операндов есть результат задачи или операция завершилась ошибкой
+//	       либо бесконечностью (при точности float64).
+func (b *builder) foldOperation(operation string, operands []operand) (operand, bool) {
+	for _, op := range operands {
+		if op.taskID != "" {
+			return operand{}, false
 		}
-		output = append(output, stack[len(stack)-1])
-		stack = stack[:len(stack)-1]
 	}
 
-	if len(output) == 1 {
-		return nil, errOneOperand
+	if b.precision.IsComplex() {
+		args := make([]complex128, len(operands))
+		for i, op := range operands {
+			args[i] = complex(op.value, op.imag)
+		}
+		value, err := evaluator.EvaluateComplex(operation, args...)
+		if err != nil || cmplx.IsInf(value) || cmplx.IsNaN(value) {
+			return operand{}, false
+		}
+		return complexNumber(value), true
 	}
 
-	return output, nil
+	if b.precision.IsExact() {
+		args := make([]string, len(operands))
+		for i, op := range operands {
+			args[i] = op.text
+		}
+		text, err := evaluator.EvaluateExact(operation, b.precision, args...)
+		if err != nil {
+			return operand{}, false
+		}
+		// Приближение может быть вне диапазона float64, значение выражения определяет точная запись
+		value, err := evaluator.Approximate(text)
+		if err != nil {
+			return operand{}, false
+		}
+		return operand{value: value, text: text}, true
+	}
+
+	args := make([]float64, len(operands))
+	for i, op := range operands {
+		args[i] = op.value
+	}
+	value, err := evaluator.Evaluate(operation, args...)
+	if err != nil || math.IsInf(value, 0) || math.IsNaN(value) {
+		return operand{}, false
+	}
+	return number(value), true
 }
 
-// tokenize разбивает входную строку математического выражения на отдельные токены (числа, операторы, скобки).
-// Токены используются для дальнейшей обработки выражения.
+// balanced раскладывает ассоциативную операцию над операндами на сбалансированное дерево бинарных операций,
+// чтобы независимые ветви могли вычисляться разными агентами параллельно.
 //
 // Args:
 //
-//	expression: string - Строка, содержащая математическое выражение.
+//	operation: string - Бинарная ассоциативная операция.
+//	operands: []operand - Операнды, не менее одного.
 //
 // Returns:
 //
-//	[]string - Срез строк, представляющий токены выражения.
-func tokenize(expression string) []string {
-	var tokens []string
-	var currentNumber string
+//	operand - Операнд, представляющий результат дерева (результат корневой операции или единственный операнд).
+func (b *builder) balanced(operation string, operands []operand) operand {
+	if len(operands) == 1 {
+		return operands[0]
+	}
+	mid := len(operands) / 2
+	left := b.balanced(operation, operands[:mid])
+	right := b.balanced(operation, operands[mid:])
 
-	for i, r := range expression {
-		s := string(r)
-		// Если символ является цифрой, точкой или знаком "+" в начале числа
-		if unicode.IsDigit(r) || s == operators.Point || (s == "+" && (i == 0 || isOperator(string(expression[i-1])) || expression[i-1] == '(')) {
-			currentNumber += s
-		} else {
-			// Если накопилось число, добавляем его в токены
-			if currentNumber != "" {
-				tokens = append(tokens, currentNumber)
-				currentNumber = ""
-			}
-			// Добавляем текущий символ (оператор или скобку) в токены
-			if s != "" {
-				tokens = append(tokens, s)
-			}
+	result := b.emit(operation, []operand{left, right})
+	result.dim = left.dim // Размерности операндов сложения, max и min совпадают
+	return result
+}
+
+// aggregate строит задачи вариативной функции. max, min и sum раскладываются на сбалансированное дерево
+// бинарных задач, avg - на сумму и деление на количество значений, stddev - на среднее, квадраты отклонений от него,
+// их среднее и корень. Задачи одного уровня дерева независимы и выполняются разными агентами одновременно.
+// Медиану нельзя разложить на бинарные задачи, она вычисляется одной задачей со всеми значениями.
+//
+// Args:
+//
+//	function: string - Имя функции.
+//	operands: []operand - Значения, хотя бы одно.
+//	dim: units.Dimension - Размерность значений.
+//
+// Returns:
+//
+//	operand - Значение функции или ссылка на вычисляющую его задачу.
+func (b *builder) aggregate(function string, operands []operand, dim units.Dimension) operand {
+	var result operand
+	switch function {
+	case operators.FnSum:
+		result = b.balanced(operators.OpAdd, operands)
+	case operators.FnAvg:
+		result = b.mean(operands, dim)
+	case operators.FnStddev:
+		mean := b.mean(operands, dim)
+		square, _ := dim.Pow(2)
+		squares := make([]operand, len(operands))
+		for i, op := range operands {
+			deviation := b.emit(operators.OpSubtract, []operand{op, mean})
+			deviation.dim = dim
+			squares[i] = b.emit(operators.OpPower, []operand{deviation, number(2)})
+			squares[i].dim = square
+		}
+		result = b.emit(operators.FnSqrt, []operand{b.mean(squares, square)})
+	case operators.FnMedian:
+		if len(operands) == 1 {
+			return operands[0]
 		}
+		result = b.emit(operators.FnMedian, operands)
+	default:
+		result = b.balanced(function, operands)
 	}
+	result.dim = dim
+	return result
+}
+
+// reference создает задачу ссылки на результат другого выражения (operators.OpReference). Задача не отправляется
+// агентам: оркестратор завершает её, когда вычислено выражение, на которое она ссылается, а зависимые от неё задачи
+// получают его результат как обычный аргумент. Повторные ссылки на одно выражение используют одну задачу.
+// Размерность результата не известна при разбиении, поэтому ссылка - безразмерное число.
+//
+// Args:
+//
+//	ref: *ast.Ref - Ссылка.
+//
+// Returns:
+//
+//	operand - Ссылка на задачу, результатом которой станет результат выражения.
+func (b *builder) reference(ref *ast.Ref) operand {
+	b.stats.TasksBefore++
 
-	// Если осталось число, добавляем его в токены
-	if currentNumber != "" {
-		tokens = append(tokens, currentNumber)
+	key := ref.String()
+	for depth := 0; depth <= len(b.guards); depth++ {
+		if op, ok := b.emitted[guardsKey(b.guards[:depth])+key]; ok {
+			b.stats.Deduplicated++
+			return op
+		}
 	}
 
-	return tokens
+	task := newTask(b.expression, operators.OpReference, nil)
+	task.Reference = ref.ID
+	task.Guards = slices.Clone(b.guards)
+	b.tasks = append(b.tasks, task)
+	b.emitted[guardsKey(b.guards)+key] = operand{taskID: task.ID}
+	return operand{taskID: task.ID}
+}
+
+// mean строит задачи среднего арифметического: сумма значений деревом сложений и деление на их количество.
+func (b *builder) mean(operands []operand, dim units.Dimension) operand {
+	if len(operands) == 1 {
+		return operands[0]
+	}
+	sum := b.balanced(operators.OpAdd, operands)
+	sum.dim = dim
+	result := b.emit(operators.OpDivide, []operand{sum, number(float64(len(operands)))})
+	result.dim = dim
+	return result
 }
 
-// isNumber проверяет, является ли переданный токен числом.
+// branch строит задачи ветви условного выражения. Задачи ветви получают условие выполнения,
+// поэтому оркестратор отправляет их агентам только после вычисления условия и только для выбранной ветви.
 //
 // Args:
 //
-//	token: string - Строка, которую необходимо проверить.
+//	condition: string - ID задачи, вычисляющей условие.
+//	taken: bool - true для ветви "то", false для ветви "иначе".
+//	node: ast.Node - Выражение ветви.
 //
 // Returns:
 //
-//	bool - true, если токен может быть преобразован в число, иначе false.
-func isNumber(token string) bool {
-	_, err := strconv.ParseFloat(token, 64)
-	return err == nil
+//	operand - Операнд, представляющий значение ветви.
+//	error - Ошибка построения задач ветви.
+func (b *builder) branch(condition string, taken bool, node ast.Node) (operand, error) {
+	b.guards = append(b.guards, models.Guard{Condition: condition, Branch: taken})
+	defer func() { b.guards = b.guards[:len(b.guards)-1] }()
+	return b.build(node)
 }
 
-// opTime возвращает время операции
+// conditional строит задачи условного выражения. Если условие известно заранее (например, свернуто или задано числом),
+// строится только выбранная ветвь. Иначе строятся задача условия, задачи обеих ветвей с условиями выполнения
+// и задача выбора ветви (operators.OpSelect) с операндами: условие, значение ветви "то", значение ветви "иначе".
+// Задачу выбора выполняет оркестратор, когда вычислены условие и выбранная ветвь.
 //
 // Args:
 //
-//	operator: string - математический оператор.
+//	n: *ast.Conditional - Условное выражение.
 //
 // Returns:
 //
-//	int - длительность операции в миллисекундах, если значение отсутствует - 0.
-func opTime(operator string) int {
-	// Время не вынесено в отдельную переменную т.к. при этом конфиг не успевает инициализироваться
-	duration, ok := map[string]int{
-		operators.OpAdd:      config.Cfg.Math.TIME_ADDITION_MS,
-		operators.OpSubtract: config.Cfg.Math.TIME_SUBTRACTION_MS,
-		operators.OpMultiply: config.Cfg.Math.TIME_MULTIPLICATION_MS,
-		operators.OpDivide:   config.Cfg.Math.TIME_DIVISION_MS,
-		operators.OpPower:    config.Cfg.Math.TIME_POWER_MS,
-	}[operator]
+//	operand - Операнд, представляющий значение условного выражения.
+//	error - Ошибка построения задач.
+func (b *builder) conditional(n *ast.Conditional) (operand, error) {
+	cond, err := b.build(n.Cond)
+	if err != nil {
+		return operand{}, err
+	}
+	if cond.taskID == "" {
+		if b.isTrue(cond) {
+			return b.build(n.Then)
+		}
+		return b.build(n.Else)
+	}
 
-	if !ok {
-		logger.Log.Warnf("Оператор %s не найден", operator)
-		return 0
+	then, err := b.branch(cond.taskID, true, n.Then)
+	if err != nil {
+		return operand{}, err
+	}
+	otherwise, err := b.branch(cond.taskID, false, n.Else)
+	if err != nil {
+		return operand{}, err
+	}
+	operands := []operand{cond, then, otherwise}
+	dim, err := b.dimension(n, operators.OpSelect, operands)
+	if err != nil {
+		return operand{}, err
+	}
+	result := b.emit(operators.OpSelect, operands)
+	result.dim = dim
+	return result, nil
+}
+
+// isTrue проверяет истинность операнда-числа: истинно любое ненулевое число.
+func (b *builder) isTrue(op operand) bool {
+	switch {
+	case b.precision.IsComplex():
+		return op.value != 0 || op.imag != 0
+	case b.precision.IsExact():
+		return evaluator.IsTrue(op.text)
+	default:
+		return op.value != operators.False
 	}
-	return duration
 }
 
-// rpnToTasks преобразует выражение, представленное в обратной польской нотации (RPN), в набор задач (models.Task) с учетом зависимостей между ними.
-// Каждая задача представляет собой операцию, которую необходимо выполнить для вычисления части выражения.
+// supported проверяет, доступна ли операция узла при точности выражения.
 //
 // Args:
 //
-//	expression: string - Исходное математическое выражение.
-//	rpn: []string - Срез строк, представляющий выражение в обратной польской нотации (RPN).
+//	node: ast.Node - Узел дерева.
 //
 // Returns:
 //
-//	[]models.Task - Срез задач, представляющих операции для вычисления выражения, с установленными зависимостями.
-//	error: Ошибка, если RPN выражение не может быть преобразовано в задачи или содержит неверные элементы.
-func rpnToTasks(expression string, rpn []string) ([]models.Task, error) {
-	var tasks []models.Task
-	var stack []string // Стек для чисел и ID задач
-
-	for _, token := range rpn {
-		switch token {
-		case operators.OpAdd, operators.OpSubtract, operators.OpMultiply, operators.OpDivide, operators.OpPower:
-			if len(stack) < 2 {
-				return nil, errNotEnoughOperands
-			}
+//	error - *ast.ParseError с позицией оператора или имени функции, если операция недоступна, иначе nil.
+func (b *builder) supported(node ast.Node) error {
+	var operation, kind, token string
+	switch n := node.(type) {
+	case *ast.Unary:
+		operation, kind, token = n.Op, "операция", n.Op
+	case *ast.Binary:
+		operation, kind, token = n.Op, "операция", n.Op
+	case *ast.Call:
+		operation, kind, token = n.Func, "функция", n.Func
+	default:
+		return nil
+	}
+	if evaluator.Supports(operation, b.precision) {
+		return nil
+	}
+	err := fmt.Errorf("%s %s недоступна при точности %s", kind, token, b.precision)
+	return &ast.ParseError{Err: err, Pos: node.Pos(), Token: token}
+}
 
-			operand2Str := stack[len(stack)-1]
-			stack = stack[:len(stack)-1]
-			operand1Str := stack[len(stack)-1]
-			stack = stack[:len(stack)-1]
-
-			id := uuid.New().String()
-			task := models.Task{
-				ID:             id,
-				Args:           make([]*float64, 2),
-				Operation:      token,
-				Operation_time: opTime(token),
-				Status:         "pending",
-				Expression:     expression,
-				Dependencies:   make([]string, 2),
-			}
+// dimension вычисляет размерность результата операции узла и проверяет размерности операндов (см. units.Result).
+// Показатель степени известен, если он число, поэтому размерную величину нельзя возводить в степень,
+// вычисляемую задачей.
+//
+// Args:
+//
+//	node: ast.Node - Узел операции (для позиции ошибки).
+//	operation: string - Операция.
+//	operands: []operand - Операнды операции.
+//
+// Returns:
+//
+//	units.Dimension - Размерность результата, нулевая, если в выражении нет единиц измерения.
+//	error - *ast.ParseError с позицией оператора, если размерности операндов недопустимы для операции.
+func (b *builder) dimension(node ast.Node, operation string, operands []operand) (units.Dimension, error) {
+	if !b.units {
+		return units.Dimension{}, nil
+	}
+	dims := make([]units.Dimension, len(operands))
+	for i, op := range operands {
+		dims[i] = op.dim
+	}
+	var exponent *float64
+	if operation == operators.OpPower && operands[1].taskID == "" {
+		exponent = &operands[1].value
+	}
+	dim, err := units.Result(operation, dims, exponent)
+	if err == nil {
+		return dim, nil
+	}
 
-			// Проверяем, являются ли операнды числами или ID задач
-			if num1, err := strconv.ParseFloat(operand1Str, 64); err == nil {
-				task.Args[0] = &num1 // Arg1 - число
-			} else {
-				// Arg1 - nil (зависимость)
-				task.Dependencies[0] = operand1Str
+	token := operation
+	switch operation {
+	case operators.OpUnaryMinus:
+		token = operators.OpSubtract
+	case operators.OpNot:
+		token = operators.OpFactorial
+	case operators.OpSelect:
+		token = operators.OpQuestion
+		dims = dims[1:] // Условие может иметь любую размерность
+	}
+	switch {
+	case errors.Is(err, units.ErrIncompatible):
+		for _, d := range dims[1:] {
+			if d != dims[0] {
+				err = fmt.Errorf("несовместимые размерности %s и %s в операции %s", units.Format(dims[0]), units.Format(d), token)
+				break
 			}
-
-			if num2, err := strconv.ParseFloat(operand2Str, 64); err == nil {
-				task.Args[1] = &num2 // Arg2 - число
-			} else {
-				// Arg2 - nil (зависимость)
-				task.Dependencies[1] = operand2Str
+		}
+	case errors.Is(err, units.ErrDimensionless):
+		for _, d := range dims {
+			if !d.IsZero() {
+				err = fmt.Errorf("аргумент операции %s должен быть безразмерным, получено %s", token, units.Format(d))
+				break
 			}
+		}
+	case errors.Is(err, units.ErrExponent):
+		err = fmt.Errorf("величину размерности %s можно возводить только в известную степень, дающую целые степени единиц", units.Format(dims[0]))
+	}
+	return units.Dimension{}, &ast.ParseError{Err: err, Pos: node.Pos(), Token: token}
+}
 
-			tasks = append(tasks, task)
-			stack = append(stack, id) // Результат этой задачи будет использован далее
-		case operators.OpUnaryMinus:
-			// Унарный минус
-			// Если унарный минус не может быть один
-			if len(stack) < 1 {
-				return nil, errUnaryMinus
-			}
-			operandStr := stack[len(stack)-1]
-			stack = stack[:len(stack)-1]
-
-			id := uuid.New().String()
-			task := models.Task{
-				ID:             id,
-				Args:           make([]*float64, 2),
-				Operation:      operators.OpUnaryMinus,
-				Operation_time: config.Cfg.Math.TIME_UNARY_MINUS_MS,
-				Status:         "pending",
-				Expression:     expression,
-				Dependencies:   make([]string, 2),
-			}
+// convert строит задачу перевода результата выражения в единицу измерения: деление на множитель единицы.
+//
+// Args:
+//
+//	root: operand - Результат выражения в основных единицах СИ.
+//	n: *ast.Convert - Перевод.
+//
+// Returns:
+//
+//	operand - Результат выражения в заданной единице.
+//	error - *ast.ParseError с позицией "to", если размерность результата не совпадает с размерностью единицы.
+func (b *builder) convert(root operand, n *ast.Convert) (operand, error) {
+	unit, err := units.Parse(n.Unit)
+	if err != nil || unit.Dim != root.dim {
+		err = fmt.Errorf("нельзя перевести величину размерности %s в %s", units.Format(root.dim), n.Unit)
+		return operand{}, &ast.ParseError{Err: err, Pos: n.Offset, Token: "to"}
+	}
+	result := b.emit(operators.OpDivide, []operand{root, number(unit.Factor)})
+	result.dim = root.dim
+	return result, nil
+}
 
-			if num, err := strconv.ParseFloat(operandStr, 64); err == nil {
-				task.Args[0] = &num // Arg1 - число
-			} else {
-				// Arg1 - nil (зависимость)
-				task.Dependencies[0] = operandStr
-			}
-			tasks = append(tasks, task)
-			stack = append(stack, id)
-
-		default:
-			// Число
-			if _, err := strconv.ParseFloat(token, 64); err != nil {
-				// В результате всех прошлых операций и проверок можно сделать вывод, что
-				// суда ничего не может попасть (не может быть инородным символом или оператором
-				// в результате проверки в infixToRPN и прошлых case соответственно).
-				return nil, errRPN
+// build преобразует синтаксическое дерево в набор задач (models.Task) с учетом зависимостей между ними.
+// Задачи операндов добавляются раньше задачи операции, поэтому корневая задача выражения всегда последняя.
+//
+// Args:
+//
+//	node: ast.Node - Узел дерева с подставленными значениями имен.
+//
+// Returns:
+//
+//	operand - Операнд, представляющий значение узла.
+//	error - Ошибка, если в дереве осталось имя без значения.
+func (b *builder) build(node ast.Node) (operand, error) {
+	// buildOperands строит операнды для нескольких узлов
+	buildOperands := func(nodes ...ast.Node) ([]operand, error) {
+		operands := make([]operand, len(nodes))
+		for i, n := range nodes {
+			op, err := b.build(n)
+			if err != nil {
+				return nil, err
 			}
-			stack = append(stack, token) // Просто добавляем число в стек
+			operands[i] = op
 		}
+		return operands, nil
 	}
 
-	if len(stack) != 1 {
-		return nil, errRPN
+	if err := b.supported(node); err != nil {
+		return operand{}, err
 	}
 
-	// Все задачи созданы.
-	return tasks, nil
+	var operation string
+	var operands []operand
+	var err error
+
+	switch n := node.(type) {
+	case *ast.Number:
+		if b.precision.IsComplex() {
+			return complexNumber(complex(n.Value, n.Imag)), nil
+		}
+		if b.precision.IsExact() && n.Text != "" {
+			return operand{value: n.Value, text: n.Text}, nil // Исходная запись литерала без потери цифр
+		}
+		op := number(n.Value)
+		op.dim = n.Dim
+		return op, nil
+	case *ast.Unary:
+		operation = n.Op
+		operands, err = buildOperands(n.Operand)
+	case *ast.Binary:
+		operation = n.Op
+		operands, err = buildOperands(n.Left, n.Right)
+	case *ast.Conditional:
+		return b.conditional(n)
+	case *ast.Ref:
+		return b.reference(n), nil
+	case *ast.Call:
+		operation = n.Func
+		operands, err = buildOperands(n.Args...)
+		if err != nil {
+			return operand{}, err
+		}
+		if operators.Functions[n.Func].Max == operators.Variadic {
+			dim, err := b.dimension(n, n.Func, operands)
+			if err != nil {
+				return operand{}, err
+			}
+			return b.aggregate(n.Func, operands, dim), nil
+		}
+		if n.Func == operators.FnLog && len(operands) == 1 {
+			operands = append(operands, number(logDefaultBase)) // log(x) - десятичный логарифм
+		}
+		// Остальные необязательные аргументы (знаки round, fv и тип платежа pmt и fv) по умолчанию равны нулю
+		for len(operands) < evaluator.ArgCount(n.Func) {
+			operands = append(operands, number(0))
+		}
+	default:
+		return operand{}, fmt.Errorf("%w: %s", errUnboundIdent, node)
+	}
+	if err != nil {
+		return operand{}, err
+	}
+
+	dim, err := b.dimension(node, operation, operands)
+	if err != nil {
+		return operand{}, err
+	}
+	result := b.emit(operation, operands)
+	result.dim = dim
+	return result, nil
 }
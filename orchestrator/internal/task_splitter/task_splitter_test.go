@@ -3,10 +3,16 @@ package task_splitter
 import (
 	"io"
 	"log"
+	"math"
 	"testing"
 
 	"github.com/OinkiePie/calc_2/config"
+	"github.com/OinkiePie/calc_2/orchestrator/internal/ast"
+	"github.com/OinkiePie/calc_2/orchestrator/internal/functions"
+	"github.com/OinkiePie/calc_2/pkg/evaluator"
 	"github.com/OinkiePie/calc_2/pkg/logger"
+	"github.com/OinkiePie/calc_2/pkg/models"
+	"github.com/OinkiePie/calc_2/pkg/operators"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -109,11 +115,10 @@ func TestParseExpression(t *testing.T) {
 			err:         "неверный синтаксис",
 		},
 		{
-			name:        "Invalid expression: single operand",
+			name:        "Valid expression: single operand",
 			expression:  "2",
 			expectedLen: 0,
-			expectError: true,
-			err:         "минимум два операнда требуются для расчета",
+			expectError: false,
 		},
 		{
 			name:        "Invalid expression: empty expression",
@@ -191,11 +196,47 @@ func TestParseExpression(t *testing.T) {
 			expectedLen: 3,
 			expectError: false,
 		},
+		{
+			name:        "Function: unary",
+			expression:  "sqrt(2)",
+			expectedLen: 1,
+			expectError: false,
+		},
+		{
+			name:        "Function: nested with operators",
+			expression:  "sin(1) + cos(2 * 3)",
+			expectedLen: 4,
+			expectError: false,
+		},
+		{
+			name:        "Function: log with base",
+			expression:  "log(8, 2)",
+			expectedLen: 1,
+			expectError: false,
+		},
+		{
+			name:        "Function: log with default base",
+			expression:  "log(100)",
+			expectedLen: 1,
+			expectError: false,
+		},
+		{
+			name:        "Function: variadic split into binary tree",
+			expression:  "max(1, 2, 3, 4)",
+			expectedLen: 3,
+			expectError: false,
+		},
+		{
+			name:        "Function: unary minus in argument",
+			expression:  "abs(-5)",
+			expectedLen: 2,
+			expectError: false,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			tasks, err := ParseExpression("test-id", tt.expression)
+			tasks, err := ParseExpression("test-id", tt.expression, nil)
 			if tt.expectError {
 				assert.Error(t, err)
 				return
@@ -205,3 +246,1107 @@ func TestParseExpression(t *testing.T) {
 		})
 	}
 }
+
+// TestParseExpressionFunctionErrors проверяет сообщения об ошибках при вызове функций.
+func TestParseExpressionFunctionErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		err        string
+	}{
+		{"Wrong argument count", "sin(1, 2)", "функция sin принимает 1 аргументов, передано 2"},
+		{"Too few variadic arguments", "max(1) + 2", "функция max принимает не менее 2 аргументов, передано 1"},
+		{"Log argument range", "log() + 1", "функция log принимает от 1 до 2 аргументов, передано 0"},
+		{"Missing parenthesis", "sqrt + 4", "после имени функции ожидается открывающая скобка"},
+		{"Empty argument", "max(1,,2)", "пустой аргумент функции"},
+		{"Trailing separator", "max(1,2,)", "пустой аргумент функции"},
+		{"Separator outside call", "(1, 2) + 3", "разделитель аргументов вне вызова функции"},
+		{"Unknown function", "foo(1) + 2", "неизвестная функция: foo"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseExpression("test-id", tt.expression, nil)
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+// TestParseExpressionVariadicTree проверяет, что вариативная функция раскладывается на сбалансированное дерево.
+func TestParseExpressionVariadicTree(t *testing.T) {
+	tasks, err := ParseExpression("test-id", "max(1, 2, 3, 4)", nil)
+	assert.NoError(t, err)
+	assert.Len(t, tasks, 3)
+
+	// Два листа не зависят от других задач и могут выполняться параллельно
+	independent := 0
+	for _, task := range tasks {
+		assert.Equal(t, "max", task.Operation)
+		assert.Len(t, task.Args, 2)
+		if task.Dependencies[0] == "" && task.Dependencies[1] == "" {
+			independent++
+		}
+	}
+	assert.Equal(t, 2, independent)
+
+	// Корневая задача последняя и зависит от обоих листов
+	root := tasks[len(tasks)-1]
+	assert.Equal(t, []string{tasks[0].ID, tasks[1].ID}, root.Dependencies)
+}
+
+// TestParseExpressionVariables проверяет подстановку значений переменных в аргументы задач.
+func TestParseExpressionVariables(t *testing.T) {
+	variables := map[string]float64{"a": 1, "x": 3, "b": 0.1}
+
+	tasks, err := ParseExpression("test-id", "a*x^2 + b", variables)
+	assert.NoError(t, err)
+	assert.Len(t, tasks, 3)
+
+	// x^2
+	assert.Equal(t, "^", tasks[0].Operation)
+	assert.Equal(t, 3.0, *tasks[0].Args[0])
+	assert.Equal(t, 2.0, *tasks[0].Args[1])
+	// a*(x^2)
+	assert.Equal(t, 1.0, *tasks[1].Args[0])
+	assert.Equal(t, tasks[0].ID, tasks[1].Dependencies[1])
+	// ... + b, значение подставлено без потери точности
+	assert.Equal(t, 0.1, *tasks[2].Args[1])
+
+	// Отрицательное значение переменной остается одним операндом
+	tasks, err = ParseExpression("test-id", "x^2", map[string]float64{"x": -3})
+	assert.NoError(t, err)
+	assert.Len(t, tasks, 1)
+	assert.Equal(t, -3.0, *tasks[0].Args[0])
+}
+
+// TestParseExpressionUnboundVariables проверяет, что ошибка перечисляет все переменные без значений.
+func TestParseExpressionUnboundVariables(t *testing.T) {
+	_, err := ParseExpression("test-id", "a*x^2 + b*x + c", map[string]float64{"x": 3})
+
+	var unboundErr *UnboundVariablesError
+	assert.ErrorAs(t, err, &unboundErr)
+	assert.Equal(t, []string{"a", "b", "c"}, unboundErr.Names)
+	assert.EqualError(t, err, "не заданы значения переменных: a, b, c")
+}
+
+// TestParseExpressionConstants проверяет подстановку встроенных констант и констант из конфигурации.
+func TestParseExpressionConstants(t *testing.T) {
+	tasks, err := ParseExpression("test-id", "2*pi*r", map[string]float64{"r": 1.5})
+	assert.NoError(t, err)
+	assert.Len(t, tasks, 2)
+	assert.Equal(t, math.Pi, *tasks[0].Args[1]) // Полная точность float64
+
+	tasks, err = ParseExpression("test-id", "e + phi", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, math.E, *tasks[0].Args[0])
+	assert.Equal(t, math.Phi, *tasks[0].Args[1])
+
+	// Переменная запроса перекрывает константу
+	tasks, err = ParseExpression("test-id", "e + 1", map[string]float64{"e": 5})
+	assert.NoError(t, err)
+	assert.Equal(t, 5.0, *tasks[0].Args[0])
+
+	// Константа из конфигурации
+	config.Cfg.Math.Constants = map[string]float64{"c": 299792458}
+	defer func() { config.Cfg.Math.Constants = nil }()
+	tasks, err = ParseExpression("test-id", "c / 2", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 299792458.0, *tasks[0].Args[0])
+}
+
+// TestParseExpressionImplicitMultiplication проверяет разбор выражений с неявным умножением и Unicode-операторами.
+func TestParseExpressionImplicitMultiplication(t *testing.T) {
+	tests := []struct {
+		name        string
+		expression  string
+		variables   map[string]float64
+		expectedLen int
+		rootOp      string
+	}{
+		{"Number and paren", "2(3+4)", nil, 2, "*"},
+		{"Two parens", "(1+2)(3+4)", nil, 3, "*"},
+		{"Unicode multiply", "3×4", nil, 1, "*"},
+		{"Unicode divide", "8÷2", nil, 1, "/"},
+		{"Middle dot with pi", "2·π", nil, 1, "*"},
+		{"Superscript", "x² + 1", map[string]float64{"x": 3}, 2, "+"},
+		{"Variable before paren", "x(3+4)", map[string]float64{"x": 2}, 2, "*"},
+		{"Number and variable", "2x", map[string]float64{"x": 3}, 1, "*"},
+		{"Unary minus stays unary", "−2(3)", nil, 2, "*"},
+		{"Unary minus inside implicit paren", "2(−3)", nil, 2, "*"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tasks, err := ParseExpression("test-id", tt.expression, tt.variables)
+			assert.NoError(t, err)
+			assert.Len(t, tasks, tt.expectedLen)
+			if len(tasks) > 0 {
+				assert.Equal(t, tt.rootOp, tasks[len(tasks)-1].Operation)
+			}
+		})
+	}
+}
+
+// TestParseExpressionNumericLiterals проверяет разбор экспоненциальной записи, литералов с префиксом системы счисления и разделителей разрядов.
+func TestParseExpressionNumericLiterals(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		expected   float64
+	}{
+		{"Negative exponent", "1e-9 + 0", 1e-9},
+		{"Upper case exponent", "6.02E23 + 0", 6.02e23},
+		{"Explicit plus exponent", "2.5e+3 + 0", 2500},
+		{"Exponent with point", "5.e2 + 0", 500},
+		{"Hex", "0xFF + 0", 255},
+		{"Hex upper prefix", "0XfF + 0", 255},
+		{"Binary", "0b1010 + 0", 10},
+		{"Octal", "0o17 + 0", 15},
+		{"Digit separators", "1_000_000 + 0", 1000000},
+		{"Separators in fraction", "3.141_592 + 0", 3.141592},
+		{"Hex with separators", "0xFF_FF + 0", 65535},
+		{"Leading point", ".25 + 0", 0.25},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tasks, err := ParseExpression("test-id", tt.expression, nil)
+			assert.NoError(t, err)
+			assert.Len(t, tasks, 1)
+			assert.Equal(t, tt.expected, *tasks[0].Args[0])
+		})
+	}
+}
+
+// TestSplitExpressionSingleOperand проверяет, что выражение без операций, как и условие с известной ветвью,
+// не требует задач и сразу получает значение.
+func TestSplitExpressionSingleOperand(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		expected   float64
+		unit       string
+	}{
+		{"Digit separators", "1_000_000", 1000000, ""},
+		{"Exponent", "2e3", 2000, ""},
+		{"Hex", "0xFF", 255, ""},
+		{"Variable", "x", 7, ""},
+		{"Constant", "pi", math.Pi, ""},
+		{"Parenthesized", "(42)", 42, ""},
+		{"Unit", "5 km", 5000, "m"},
+		{"Known condition", "0 ? 1 : 0 ? 2 : 3", 3, ""},
+		{"Known if", "if(0, 1/0, 5)", 5, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan, err := SplitExpression("test-id", tt.expression, map[string]float64{"x": 7}, "", nil)
+			assert.NoError(t, err)
+			assert.Empty(t, plan.Tasks)
+			if assert.NotNil(t, plan.Result) {
+				assert.Equal(t, tt.expected, *plan.Result)
+			}
+			assert.Equal(t, tt.unit, plan.Unit)
+		})
+	}
+
+	plan, err := SplitExpression("test-id", "1/3", nil, "rational", nil)
+	assert.NoError(t, err)
+	assert.Len(t, plan.Tasks, 1)
+	for expression, expected := range map[string]string{"0.1": "1/10", "0xFF": "255", "1_000": "1000"} {
+		plan, err = SplitExpression("test-id", expression, nil, "rational", nil)
+		assert.NoError(t, err)
+		if assert.NotNil(t, plan.ExactResult) {
+			assert.Equal(t, expected, *plan.ExactResult)
+		}
+	}
+}
+
+// TestParseExpressionMalformedLiterals проверяет сообщения об ошибках для некорректных числовых литералов.
+func TestParseExpressionMalformedLiterals(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		err        string
+	}{
+		{"Missing exponent", "1e * 2", `некорректный числовой литерал "1e": отсутствует показатель степени`},
+		{"Missing exponent after sign", "2 + 1e-", `некорректный числовой литерал "1e-": отсутствует показатель степени`},
+		{"Empty hex", "0x + 1", `некорректный числовой литерал "0x": отсутствуют цифры в шестнадцатеричном литерале`},
+		{"Empty binary", "0b + 1", `некорректный числовой литерал "0b": отсутствуют цифры в двоичном литерале`},
+		{"Invalid binary digit", "0b102 + 1", `некорректный числовой литерал "0b102": недопустимая цифра '2' в двоичном литерале`},
+		{"Invalid octal digit", "0o8 + 1", `некорректный числовой литерал "0o8": недопустимая цифра '8' в восьмеричном литерале`},
+		{"Invalid hex digit", "0xFG + 1", `некорректный числовой литерал "0xFG": недопустимая цифра 'G' в шестнадцатеричном литерале`},
+		{"Hex fraction", "0x1.8 + 1", `некорректный числовой литерал "0x1.8": недопустимая цифра '.' в шестнадцатеричном литерале`},
+		{"Two points", "1.2.3 + 1", `некорректный числовой литерал "1.2.3": лишняя десятичная точка`},
+		{"Trailing separator", "1_ + 1", `некорректный числовой литерал "1_": разделитель разрядов "_" должен стоять между цифрами`},
+		{"Double separator", "1__0 + 1", `некорректный числовой литерал "1__0": разделитель разрядов "_" должен стоять между цифрами`},
+		{"Separator before point", "1_.5 + 1", `некорректный числовой литерал "1_.5": разделитель разрядов "_" должен стоять между цифрами`},
+		{"Separator after prefix", "0x_FF + 1", `некорректный числовой литерал "0x_FF": разделитель разрядов "_" должен стоять между цифрами`},
+		{"Out of range", "1e400 + 1", `некорректный числовой литерал "1e400": значение вне допустимого диапазона`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseExpression("test-id", tt.expression, nil)
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+// TestParseExpressionModuloFloorFactorial проверяет разбор остатка от деления, целочисленного деления и факториала.
+func TestParseExpressionModuloFloorFactorial(t *testing.T) {
+	tests := []struct {
+		name        string
+		expression  string
+		expectedLen int
+		rootOp      string
+	}{
+		{"Modulo", "7 % 3", 1, "%"},
+		{"Floor division", "7 // 2", 1, "//"},
+		{"Factorial", "5!", 1, "!"},
+		{"Modulo binds like multiplication", "1 + 7 % 3", 2, "+"},
+		{"Floor division left to right", "8 // 2 * 3", 2, "*"},
+		{"Factorial before power", "2^3!", 2, "^"},
+		{"Factorial before unary minus", "-3!", 2, "u-"},
+		{"Factorial of parens", "(2+1)!", 2, "!"},
+		{"Binary minus after factorial", "3!-1", 2, "-"},
+		{"Double factorial applied twice", "3!!", 2, "!"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tasks, err := ParseExpression("test-id", tt.expression, nil)
+			assert.NoError(t, err)
+			assert.Len(t, tasks, tt.expectedLen)
+			if len(tasks) > 0 {
+				assert.Equal(t, tt.rootOp, tasks[len(tasks)-1].Operation)
+			}
+		})
+	}
+
+	// "!" перед операндом - логическое НЕ, а не факториал
+	for _, expression := range []string{"(!)", "2 + !"} {
+		_, err := ParseExpression("test-id", expression, nil)
+		assert.EqualError(t, err, "недостаточно операндов для логического отрицания", expression)
+	}
+}
+
+// evaluateTasks последовательно вычисляет задачи выражения и возвращает результат корневой задачи.
+// Задачи невыбранных ветвей условных выражений пропускаются, как это делает оркестратор.
+func evaluateTasks(t *testing.T, tasks []models.Task) float64 {
+	result, _ := evaluateTasksCount(t, tasks)
+	return result
+}
+
+// evaluateTasksCount вычисляет задачи как evaluateTasks и дополнительно возвращает
+// количество задач, которые были бы отправлены агентам.
+func evaluateTasksCount(t *testing.T, tasks []models.Task) (float64, int) {
+	results := make(map[string]float64, len(tasks))
+	evaluated := 0
+	for _, task := range tasks {
+		skipped := false
+		for _, guard := range task.Guards {
+			condition, ok := results[guard.Condition]
+			if !ok || (condition != 0) != guard.Branch {
+				skipped = true
+			}
+		}
+		if skipped {
+			continue
+		}
+
+		args := make([]float64, len(task.Args))
+		for i, arg := range task.Args {
+			if arg != nil {
+				args[i] = *arg
+			} else if result, ok := results[task.Dependencies[i]]; ok {
+				args[i] = result
+			} else if task.Operation != operators.OpSelect {
+				t.Fatalf("задача %s зависит от пропущенной задачи", task.Operation)
+			}
+		}
+
+		if task.Operation == operators.OpSelect {
+			if args[0] != 0 {
+				results[task.ID] = args[1]
+			} else {
+				results[task.ID] = args[2]
+			}
+			continue
+		}
+
+		result, err := evaluator.Evaluate(task.Operation, args...)
+		if err != nil {
+			t.Fatalf("ошибка вычисления задачи %s: %v", task.Operation, err)
+		}
+		results[task.ID] = result
+		evaluated++
+	}
+	return results[tasks[len(tasks)-1].ID], evaluated
+}
+
+// TestParseExpressionAssociativity фиксирует порядок вычисления степени и унарного минуса.
+func TestParseExpressionAssociativity(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		expected   float64
+	}{
+		{"Power is right-associative", "2^3^2", 512},
+		{"Power chain of four", "2^1^3^2", 2},
+		{"Unary minus weaker than power", "-2^2", -4},
+		{"Parenthesized negative base", "(-2)^2", 4},
+		{"Negative exponent", "2^-1", 0.5},
+		{"Negative exponent chain", "2^-3^2", math.Pow(2, -9)},
+		{"Unary minus after multiplication", "3*-2^2", -12},
+		{"Unary minus stronger than multiplication", "-6/2*3", -9},
+		{"Subtraction is left-associative", "10-4-3", 3},
+		{"Division is left-associative", "64/4/2", 8},
+		{"Binary minus before power", "1-2^2", -3},
+		{"Double negation in parens", "-(-5)^2", -25},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tasks, err := ParseExpression("test-id", tt.expression, nil)
+			assert.NoError(t, err)
+			assert.InDelta(t, tt.expected, evaluateTasks(t, tasks), 1e-12)
+		})
+	}
+}
+
+// TestParseExpressionRebalance проверяет, что цепочка сложений раскладывается на независимые задачи,
+// а при отключенной оптимизации вычисляется строго слева направо.
+func TestParseExpressionRebalance(t *testing.T) {
+	expression := "1+2+3+4+5+6+7+8"
+
+	// independent подсчитывает задачи, не зависящие от других задач
+	independent := func(tasks []models.Task) int {
+		count := 0
+		for _, task := range tasks {
+			if task.Dependencies[0] == "" && task.Dependencies[1] == "" {
+				count++
+			}
+		}
+		return count
+	}
+
+	tasks, err := ParseExpression("test-id", expression, nil)
+	assert.NoError(t, err)
+	assert.Len(t, tasks, 7)
+	assert.Equal(t, 4, independent(tasks))
+	assert.Equal(t, 36.0, evaluateTasks(t, tasks))
+
+	config.Cfg.Optimizer.Rebalance = false
+	defer func() { config.Cfg.Optimizer.Rebalance = true }()
+
+	tasks, err = ParseExpression("test-id", expression, nil)
+	assert.NoError(t, err)
+	assert.Len(t, tasks, 7)
+	assert.Equal(t, 1, independent(tasks))
+	assert.Equal(t, 36.0, evaluateTasks(t, tasks))
+}
+
+// TestSplitExpressionCommonSubexpressions проверяет, что одинаковые подвыражения вычисляются одной задачей.
+func TestSplitExpressionCommonSubexpressions(t *testing.T) {
+	variables := map[string]float64{"a": 1, "b": 2}
+
+	plan, err := SplitExpression("test-id", "(a+b)*(a+b)", variables, "", nil)
+	assert.NoError(t, err)
+	assert.Nil(t, plan.Result)
+	assert.Len(t, plan.Tasks, 2)
+	assert.Equal(t, models.ExpressionStats{TasksBefore: 3, TasksAfter: 2, Deduplicated: 1}, plan.Stats)
+
+	// Корневая задача дважды зависит от одной и той же задачи
+	sum, root := plan.Tasks[0], plan.Tasks[1]
+	assert.Equal(t, []string{sum.ID, sum.ID}, root.Dependencies)
+	assert.Equal(t, 9.0, evaluateTasks(t, plan.Tasks))
+
+	// Повторяются и вложенные подвыражения: sin(a+b) строится из уже созданной задачи a+b
+	plan, err = SplitExpression("test-id", "sin(a+b) + sin(a+b) + (a+b)", variables, "", nil)
+	assert.NoError(t, err)
+	assert.Len(t, plan.Tasks, 4)
+	assert.Equal(t, 3, plan.Stats.Deduplicated)
+	assert.InDelta(t, 2*math.Sin(3)+3, evaluateTasks(t, plan.Tasks), 1e-12)
+
+	// Разные значения дают разные задачи
+	plan, err = SplitExpression("test-id", "(1+2)*(2+1)", nil, "", nil)
+	assert.NoError(t, err)
+	assert.Len(t, plan.Tasks, 3)
+	assert.Zero(t, plan.Stats.Deduplicated)
+}
+
+// TestSplitExpressionFoldConstants проверяет свертку операций над числами.
+func TestSplitExpressionFoldConstants(t *testing.T) {
+	config.Cfg.Optimizer.FoldConstants = true
+	defer func() { config.Cfg.Optimizer.FoldConstants = false }()
+
+	t.Run("Fully folded", func(t *testing.T) {
+		plan, err := SplitExpression("test-id", "(2+3)*4 - sqrt(16)", nil, "", nil)
+		assert.NoError(t, err)
+		assert.Empty(t, plan.Tasks)
+		if assert.NotNil(t, plan.Result) {
+			assert.Equal(t, 16.0, *plan.Result)
+		}
+		assert.Equal(t, models.ExpressionStats{TasksBefore: 4, TasksAfter: 0, Folded: 4}, plan.Stats)
+	})
+
+	// Переменные подставляются до свертки и сворачиваются как числа
+	t.Run("Variables", func(t *testing.T) {
+		plan, err := SplitExpression("test-id", "(2+3)*x", map[string]float64{"x": 7}, "", nil)
+		assert.NoError(t, err)
+		assert.Empty(t, plan.Tasks)
+		if assert.NotNil(t, plan.Result) {
+			assert.Equal(t, 35.0, *plan.Result)
+		}
+	})
+
+	// Ошибки вычисления сообщает агент, поэтому такие операции и зависящие от них остаются задачами
+	t.Run("Errors are not folded", func(t *testing.T) {
+		plan, err := SplitExpression("test-id", "(2+3) + 1/(2-2)", nil, "", nil)
+		assert.NoError(t, err)
+		assert.Nil(t, plan.Result)
+		assert.Equal(t, models.ExpressionStats{TasksBefore: 4, TasksAfter: 2, Folded: 2}, plan.Stats)
+		if assert.Len(t, plan.Tasks, 2) {
+			assert.Equal(t, operators.OpDivide, plan.Tasks[0].Operation)
+			assert.Equal(t, 0.0, *plan.Tasks[0].Args[1])
+			assert.Equal(t, 5.0, *plan.Tasks[1].Args[0])
+		}
+
+		plan, err = SplitExpression("test-id", "10^400", nil, "", nil)
+		assert.NoError(t, err)
+		assert.Len(t, plan.Tasks, 1)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		config.Cfg.Optimizer.FoldConstants = false
+		defer func() { config.Cfg.Optimizer.FoldConstants = true }()
+
+		plan, err := SplitExpression("test-id", "(2+3)*4", nil, "", nil)
+		assert.NoError(t, err)
+		assert.Nil(t, plan.Result)
+		assert.Len(t, plan.Tasks, 2)
+	})
+}
+
+// TestParseExpressionLogic проверяет значения сравнений и логических операций.
+func TestParseExpressionLogic(t *testing.T) {
+	tests := []struct {
+		expression string
+		expected   float64
+	}{
+		{"1 < 2", 1},
+		{"2 <= 1", 0},
+		{"3 == 3", 1},
+		{"3 != 3", 0},
+		{"0.1 + 0.2 > 0.3", 1},
+		{"2 >= 2", 1},
+		{"1 < 2 && 3 > 4", 0},
+		{"1 < 2 || 3 > 4", 1},
+		{"!0 + !5", 1},
+		{"!(1 < 2)", 0},
+		{"2 + 2 == 4 && !(1 > 2)", 1},
+		{"5 && -1", 1},
+		{"5! == 120", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			tasks, err := ParseExpression("test-id", tt.expression, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, evaluateTasks(t, tasks))
+		})
+	}
+}
+
+// TestSplitExpressionConditional проверяет, что ветви условного выражения выполняются только после условия
+// и задачи невыбранной ветви не вычисляются.
+func TestSplitExpressionConditional(t *testing.T) {
+	t.Run("Branch tasks are guarded", func(t *testing.T) {
+		plan, err := SplitExpression("test-id", "x > 0 ? sqrt(x) : -x", map[string]float64{"x": 4}, "", nil)
+		assert.NoError(t, err)
+		if !assert.Len(t, plan.Tasks, 4) {
+			return
+		}
+		cond, then, otherwise, root := plan.Tasks[0], plan.Tasks[1], plan.Tasks[2], plan.Tasks[3]
+		assert.Equal(t, operators.OpGreater, cond.Operation)
+		assert.Empty(t, cond.Guards)
+		assert.Equal(t, []models.Guard{{Condition: cond.ID, Branch: true}}, then.Guards)
+		assert.Equal(t, []models.Guard{{Condition: cond.ID, Branch: false}}, otherwise.Guards)
+		assert.Equal(t, operators.OpSelect, root.Operation)
+		assert.Equal(t, []string{cond.ID, then.ID, otherwise.ID}, root.Dependencies)
+
+		result, evaluated := evaluateTasksCount(t, plan.Tasks)
+		assert.Equal(t, 2.0, result)
+		assert.Equal(t, 2, evaluated) // Условие и sqrt, унарный минус пропущен
+	})
+
+	t.Run("Untaken branch is never evaluated", func(t *testing.T) {
+		tasks, err := ParseExpression("test-id", "x != 0 ? 1/x : 0", map[string]float64{"x": 0})
+		assert.NoError(t, err)
+		assert.Equal(t, 0.0, evaluateTasks(t, tasks))
+	})
+
+	tests := []struct {
+		name       string
+		expression string
+		x          float64
+		expected   float64
+	}{
+		{"Ternary else", "x > 0 ? sqrt(x) : -x", -3, 3},
+		{"If function", "if(x > 0, sqrt(x), -x)", 9, 3},
+		{"Nested in then", "x > 0 ? (x > 10 ? 2 : 1) : 0", 5, 1},
+		{"Chained conditionals", "x < 0 ? -1 : x == 0 ? 0 : 1", 0, 0},
+		{"Chained conditionals last", "x < 0 ? -1 : x == 0 ? 0 : 1", 7, 1},
+		{"Conditional as operand", "2 * (x > 1 ? x : 1) + 1", 3, 7},
+		{"Conditional as condition", "(x > 1 ? x : 0) ? 10 : 20", 0, 20},
+		{"Conditional in arguments", "max(x > 0 ? x : 0, 1)", 5, 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tasks, err := ParseExpression("test-id", tt.expression, map[string]float64{"x": tt.x})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, evaluateTasks(t, tasks))
+		})
+	}
+
+	t.Run("Nested guards", func(t *testing.T) {
+		tasks, err := ParseExpression("test-id", "x > 0 ? (x > 10 ? x*2 : 1) : 0", map[string]float64{"x": 5})
+		assert.NoError(t, err)
+		outer := tasks[0]
+		inner := tasks[1]
+		assert.Equal(t, []models.Guard{{Condition: outer.ID, Branch: true}}, inner.Guards)
+		assert.Equal(t, []models.Guard{{Condition: outer.ID, Branch: true}, {Condition: inner.ID, Branch: true}}, tasks[2].Guards)
+	})
+
+	t.Run("Known condition builds one branch", func(t *testing.T) {
+		plan, err := SplitExpression("test-id", "1 ? x+1 : x+2", map[string]float64{"x": 1}, "", nil)
+		assert.NoError(t, err)
+		if assert.Len(t, plan.Tasks, 1) {
+			assert.Equal(t, operators.OpAdd, plan.Tasks[0].Operation)
+			assert.Empty(t, plan.Tasks[0].Guards)
+		}
+
+		plan, err = SplitExpression("test-id", "0 ? 1 : 2", nil, "", nil)
+		assert.NoError(t, err)
+		assert.Empty(t, plan.Tasks)
+		if assert.NotNil(t, plan.Result) {
+			assert.Equal(t, 2.0, *plan.Result)
+		}
+	})
+
+	t.Run("Common subexpressions and branches", func(t *testing.T) {
+		// Задача вне ветвей используется и внутри ветви
+		plan, err := SplitExpression("test-id", "(x+1) * (x > 0 ? x+1 : 0)", map[string]float64{"x": 2}, "", nil)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, plan.Stats.Deduplicated)
+		assert.Equal(t, 9.0, evaluateTasks(t, plan.Tasks))
+
+		// Задача одной ветви не используется в другой: она может быть пропущена
+		plan, err = SplitExpression("test-id", "x > 0 ? x+1 : (x+1)*2", map[string]float64{"x": -2}, "", nil)
+		assert.NoError(t, err)
+		assert.Zero(t, plan.Stats.Deduplicated)
+		assert.Equal(t, -2.0, evaluateTasks(t, plan.Tasks))
+	})
+}
+
+// TestParseExpressionParseErrors проверяет позицию, токен и ожидаемые токены синтаксических ошибок.
+func TestParseExpressionParseErrors(t *testing.T) {
+	operand := []string{"число", "имя", "(", "-"}
+	tests := []struct {
+		name       string
+		expression string
+		err        string
+		pos        int
+		token      string
+		expected   []string
+	}{
+		{"Operator instead of operand", "2 + * 3", "неверный синтаксис", 4, "*", operand},
+		{"Unclosed paren points to open paren", "2 + (3 * 4", "незакрытая скобка", 4, "(", []string{")"}},
+		{"Unopened paren", "2 + 3) * 4", "неоткрытая скобка", 5, ")", nil},
+		{"Unexpected end", "2 +", "недостаточно операндов", 3, "", operand},
+		{"Lone unary minus", "-", "недостаточно операндов для унарного минуса", 1, "", operand},
+		{"Function without paren", "sqrt + 4", "после имени функции ожидается открывающая скобка", 5, "+", []string{"("}},
+		{"Unknown function", "1 + foo(2)", "неизвестная функция: foo", 4, "foo", nil},
+		{"Wrong argument count", "sin(1, 2)", "функция sin принимает 1 аргументов, передано 2", 0, "sin", nil},
+		{"Empty argument", "max(1,,2)", "пустой аргумент функции", 6, ",", operand},
+		{"Separator outside call", "(1, 2) + 3", "разделитель аргументов вне вызова функции", 2, ",", nil},
+		{"Malformed literal", "1 + 0x", `некорректный числовой литерал "0x": отсутствуют цифры в шестнадцатеричном литерале`, 4, "0x", nil},
+		{"Numbers separated by space", "1 000", "неверный синтаксис", 2, "000", []string{"оператор"}},
+		{"Logical not without operand", "(!)", "недостаточно операндов для логического отрицания", 2, ")", operand},
+		{"Byte offset after multibyte runes", "π × (2 + )", "неверный синтаксис", 11, ")", operand},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseExpression("test-id", tt.expression, nil)
+			var parseErr *ast.ParseError
+			if assert.ErrorAs(t, err, &parseErr) {
+				assert.EqualError(t, parseErr, tt.err)
+				assert.Equal(t, tt.pos, parseErr.Pos)
+				assert.Equal(t, tt.token, parseErr.Token)
+				assert.Equal(t, tt.expected, parseErr.Expected)
+			}
+		})
+	}
+}
+
+// TestSplitExpressionPrecision проверяет передачу точных аргументов задачам и свертку при повышенной точности.
+func TestSplitExpressionPrecision(t *testing.T) {
+	t.Run("Exact args", func(t *testing.T) {
+		plan, err := SplitExpression("test-id", "0.1 + 12345678901234567890123 * x", map[string]float64{"x": 0.5}, "rational", nil)
+		assert.NoError(t, err)
+		if assert.Len(t, plan.Tasks, 2) {
+			mul, add := plan.Tasks[0], plan.Tasks[1]
+			assert.Equal(t, "rational", mul.Precision)
+			// Литерал передается без потери цифр, переменная - в кратчайшей записи float64
+			assert.Equal(t, "12345678901234567890123", *mul.ExactArgs[0])
+			assert.Equal(t, "0.5", *mul.ExactArgs[1])
+			assert.Equal(t, "0.1", *add.ExactArgs[0])
+			assert.Nil(t, add.ExactArgs[1])
+			assert.Equal(t, mul.ID, add.Dependencies[1])
+		}
+	})
+
+	t.Run("Float64 has no exact args", func(t *testing.T) {
+		plan, err := SplitExpression("test-id", "0.1 + x", map[string]float64{"x": 1}, "float64", nil)
+		assert.NoError(t, err)
+		if assert.Len(t, plan.Tasks, 1) {
+			assert.Empty(t, plan.Tasks[0].Precision)
+			assert.Nil(t, plan.Tasks[0].ExactArgs)
+		}
+	})
+
+	t.Run("Folded", func(t *testing.T) {
+		config.Cfg.Optimizer.FoldConstants = true
+		defer func() { config.Cfg.Optimizer.FoldConstants = false }()
+
+		tests := []struct {
+			expression string
+			precision  string
+			expected   string
+		}{
+			{"0.1 + 0.2", "decimal", "0.3"},
+			{"1/3 + 1/6", "rational", "1/2"},
+			{"2^-3 + 0xFF", "rational", "2041/8"},
+			{"1/3 > 0.3333 ? 1 : 2", "rational", "1"},
+			{"10^400 / 10^399", "decimal", "10"},
+		}
+		for _, tt := range tests {
+			plan, err := SplitExpression("test-id", tt.expression, nil, tt.precision, nil)
+			assert.NoError(t, err, tt.expression)
+			if assert.NotNil(t, plan.ExactResult, tt.expression) {
+				assert.Equal(t, tt.expected, *plan.ExactResult, tt.expression)
+			}
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		_, err := SplitExpression("test-id", "1 + 2", nil, "double", nil)
+		assert.EqualError(t, err, `некорректная точность вычислений "double", допустимы: float64, decimal, rational, bigfloat:N, complex`)
+
+		_, err = SplitExpression("test-id", "1 + sin(2)", nil, "decimal", nil)
+		var parseErr *ast.ParseError
+		if assert.ErrorAs(t, err, &parseErr) {
+			assert.Equal(t, 4, parseErr.Pos)
+			assert.Equal(t, "sin", parseErr.Token)
+			assert.EqualError(t, err, "функция sin недоступна при точности decimal")
+		}
+	})
+}
+
+// TestSplitExpressionComplex проверяет мнимые литералы и комплексные аргументы задач.
+func TestSplitExpressionComplex(t *testing.T) {
+	t.Run("Imaginary literal", func(t *testing.T) {
+		plan, err := SplitExpression("test-id", "3+4i", nil, "complex", nil)
+		assert.NoError(t, err)
+		if assert.Len(t, plan.Tasks, 1) {
+			task := plan.Tasks[0]
+			assert.Equal(t, "complex", task.Precision)
+			assert.Equal(t, "+", task.Operation)
+			assert.Equal(t, []*models.Complex{{Re: 3}, {Im: 4}}, task.ComplexArgs)
+		}
+
+		// Имя i - мнимая единица, если не задана переменная с таким именем
+		plan, err = SplitExpression("test-id", "x * i", map[string]float64{"x": 2}, "complex", nil)
+		assert.NoError(t, err)
+		assert.Equal(t, &models.Complex{Im: 1}, plan.Tasks[0].ComplexArgs[1])
+
+		plan, err = SplitExpression("test-id", "2i", map[string]float64{"i": 5}, "complex", nil)
+		assert.NoError(t, err)
+		assert.Equal(t, []*models.Complex{{Re: 2}, {Re: 5}}, plan.Tasks[0].ComplexArgs)
+
+		// Без комплексного режима i - обычная переменная
+		_, err = SplitExpression("test-id", "3+4i", nil, "", nil)
+		var unboundErr *UnboundVariablesError
+		if assert.ErrorAs(t, err, &unboundErr) {
+			assert.Equal(t, []string{"i"}, unboundErr.Names)
+		}
+	})
+
+	t.Run("Folded", func(t *testing.T) {
+		config.Cfg.Optimizer.FoldConstants = true
+		defer func() { config.Cfg.Optimizer.FoldConstants = false }()
+
+		plan, err := SplitExpression("test-id", "sqrt(-4) * (1+i) == -2+2i ? (3+4i)*(3-4i) : 0", nil, "complex", nil)
+		assert.NoError(t, err)
+		assert.Empty(t, plan.Tasks)
+		if assert.NotNil(t, plan.ComplexResult) {
+			assert.Equal(t, models.Complex{Re: 25}, *plan.ComplexResult)
+		}
+	})
+
+	t.Run("Unsupported operations", func(t *testing.T) {
+		for expression, token := range map[string]string{"1 + (i < 2)": "<", "max(1, i)": "max", "5 % 2": "%"} {
+			_, err := SplitExpression("test-id", expression, nil, "complex", nil)
+			var parseErr *ast.ParseError
+			if assert.ErrorAs(t, err, &parseErr, expression) {
+				assert.Equal(t, token, parseErr.Token)
+			}
+		}
+	})
+}
+
+// TestSplitExpressionUnits проверяет перевод единиц измерения в СИ, проверку размерностей и перевод "to".
+func TestSplitExpressionUnits(t *testing.T) {
+	t.Run("Values in SI", func(t *testing.T) {
+		plan, err := SplitExpression("test-id", "5 km/h * 2 h", nil, "", nil)
+		assert.NoError(t, err)
+		if assert.Len(t, plan.Tasks, 1) {
+			task := plan.Tasks[0]
+			assert.InDelta(t, 5000.0/3600, *task.Args[0], 1e-12)
+			assert.Equal(t, 7200.0, *task.Args[1])
+			assert.Equal(t, []string{"m/s", "s"}, task.Units)
+		}
+		assert.Equal(t, "m", plan.Unit)
+
+		// Без единиц измерения размерности не передаются
+		plan, err = SplitExpression("test-id", "2 + 3", nil, "", nil)
+		assert.NoError(t, err)
+		assert.Nil(t, plan.Tasks[0].Units)
+		assert.Empty(t, plan.Unit)
+	})
+
+	t.Run("Conversion", func(t *testing.T) {
+		plan, err := SplitExpression("test-id", "5 km/h to m/s", nil, "", nil)
+		assert.NoError(t, err)
+		if assert.Len(t, plan.Tasks, 1) {
+			assert.Equal(t, operators.OpDivide, plan.Tasks[0].Operation)
+			assert.Equal(t, 1.0, *plan.Tasks[0].Args[1])
+		}
+		assert.Equal(t, "m/s", plan.Unit)
+
+		config.Cfg.Optimizer.FoldConstants = true
+		defer func() { config.Cfg.Optimizer.FoldConstants = false }()
+		plan, err = SplitExpression("test-id", "1 h + 30 min to min", nil, "", nil)
+		assert.NoError(t, err)
+		if assert.NotNil(t, plan.Result) {
+			assert.Equal(t, 90.0, *plan.Result)
+		}
+		assert.Equal(t, "min", plan.Unit)
+	})
+
+	t.Run("Variable instead of unit", func(t *testing.T) {
+		plan, err := SplitExpression("test-id", "2 g", map[string]float64{"g": 9.8}, "", nil)
+		assert.NoError(t, err)
+		if assert.Len(t, plan.Tasks, 1) {
+			assert.Equal(t, 2.0, *plan.Tasks[0].Args[0])
+			assert.Equal(t, 9.8, *plan.Tasks[0].Args[1])
+		}
+		assert.Empty(t, plan.Unit)
+	})
+
+	t.Run("Dimension errors", func(t *testing.T) {
+		tests := []struct {
+			expression string
+			pos        int
+			token      string
+		}{
+			{"3 m + 2 s", 4, "+"},
+			{"max(1 m, 2 m, 3 kg)", 0, "max"},
+			{"sin(2 m)", 0, "sin"},
+			{"(2 m)^1.5", 5, "^"},
+			{"x > 0 ? 1 m : 1 s", 6, "?"},
+			{"3 m + 2 m to s", 10, "to"},
+		}
+		for _, tt := range tests {
+			_, err := SplitExpression("test-id", tt.expression, map[string]float64{"x": 1}, "", nil)
+			var parseErr *ast.ParseError
+			if assert.ErrorAs(t, err, &parseErr, tt.expression) {
+				assert.Equal(t, tt.pos, parseErr.Pos, tt.expression)
+				assert.Equal(t, tt.token, parseErr.Token, tt.expression)
+			}
+		}
+
+		_, err := SplitExpression("test-id", "3 m + 2 s", nil, "", nil)
+		assert.EqualError(t, err, "несовместимые размерности m и s в операции +")
+	})
+
+	t.Run("Valid dimensions", func(t *testing.T) {
+		for expression, unit := range map[string]string{
+			"sqrt(4 m^2)":        "m",
+			"(3 m)^2 / 9 s":      "m^2/s",
+			"10 N * 2 m to kWh":  "kWh",
+			"sin(30 deg) + 1":    "",
+			"2 kg * 9.8 m/s²":    "kg*m/s^2",
+			"1 / 2 s":            "1/s",
+			"2 kg·m² / 1 s^2":    "kg*m^2/s^2",
+			"3 µm + 1 nm":        "m",
+			"max(1 km, 200 m)":   "m",
+			"3 m > 2 ft ? 1 : 0": "",
+		} {
+			plan, err := SplitExpression("test-id", expression, nil, "", nil)
+			if assert.NoError(t, err, expression) {
+				assert.Equal(t, unit, plan.Unit, expression)
+			}
+		}
+	})
+
+	t.Run("Precision", func(t *testing.T) {
+		_, err := SplitExpression("test-id", "3 m + 2 m", nil, "decimal", nil)
+		assert.ErrorIs(t, err, errUnits)
+	})
+}
+
+// TestSplitExpressionPercentAndFinancial проверяет процент и необязательные аргументы финансовых функций.
+func TestSplitExpressionPercentAndFinancial(t *testing.T) {
+	t.Run("Percent", func(t *testing.T) {
+		tests := []struct {
+			expression string
+			operation  string
+			args       []float64
+		}{
+			{"200 + 15%", operators.OpAddPercent, []float64{200, 15}},
+			{"200 - 15%", operators.OpSubtractPercent, []float64{200, 15}},
+			{"15%", operators.OpPercent, []float64{15}},
+			{"7 % 3", operators.OpModulo, []float64{7, 3}},
+		}
+		for _, tt := range tests {
+			plan, err := SplitExpression("test-id", tt.expression, nil, "", nil)
+			if assert.NoError(t, err, tt.expression) && assert.Len(t, plan.Tasks, 1, tt.expression) {
+				task := plan.Tasks[0]
+				assert.Equal(t, tt.operation, task.Operation, tt.expression)
+				for i, arg := range tt.args {
+					assert.Equal(t, arg, *task.Args[i], tt.expression)
+				}
+			}
+		}
+
+		// 200 * 15% - обычное умножение на 0.15
+		plan, err := SplitExpression("test-id", "200 * 15%", nil, "", nil)
+		assert.NoError(t, err)
+		if assert.Len(t, plan.Tasks, 2) {
+			assert.Equal(t, operators.OpPercent, plan.Tasks[0].Operation)
+			assert.Equal(t, operators.OpMultiply, plan.Tasks[1].Operation)
+		}
+	})
+
+	t.Run("Default arguments", func(t *testing.T) {
+		plan, err := SplitExpression("test-id", "round(2.5)", nil, "", nil)
+		assert.NoError(t, err)
+		if assert.Len(t, plan.Tasks, 1) && assert.Len(t, plan.Tasks[0].Args, 2) {
+			assert.Equal(t, 0.0, *plan.Tasks[0].Args[1])
+		}
+
+		plan, err = SplitExpression("test-id", "pmt(0.05/12, 360, 200000)", nil, "", nil)
+		assert.NoError(t, err)
+		if assert.Len(t, plan.Tasks, 2) && assert.Len(t, plan.Tasks[1].Args, 5) {
+			assert.Nil(t, plan.Tasks[1].Args[0])
+			assert.Equal(t, 0.0, *plan.Tasks[1].Args[3])
+			assert.Equal(t, 0.0, *plan.Tasks[1].Args[4])
+		}
+
+		_, err = SplitExpression("test-id", "pmt(0.05, 12)", nil, "", nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("Folded", func(t *testing.T) {
+		config.Cfg.Optimizer.FoldConstants = true
+		defer func() { config.Cfg.Optimizer.FoldConstants = false }()
+
+		for expression, expected := range map[string]float64{
+			"200 + 15%":                           230,
+			"round(1.005, 2)":                     1.01,
+			"round(pmt(0.05/12, 360, 200000), 2)": -1073.64,
+			"floor(-1.5) + ceil(1.2)":             0,
+		} {
+			plan, err := SplitExpression("test-id", expression, nil, "", nil)
+			if assert.NoError(t, err, expression) && assert.NotNil(t, plan.Result, expression) {
+				assert.Equal(t, expected, *plan.Result, expression)
+			}
+		}
+
+		plan, err := SplitExpression("test-id", "round(1/3, 5)", nil, "rational", nil)
+		assert.NoError(t, err)
+		if assert.NotNil(t, plan.ExactResult) {
+			assert.Equal(t, "33333/100000", *plan.ExactResult)
+		}
+	})
+
+	t.Run("Units", func(t *testing.T) {
+		plan, err := SplitExpression("test-id", "200 m + 15%", nil, "", nil)
+		assert.NoError(t, err)
+		assert.Equal(t, "m", plan.Unit)
+
+		_, err = SplitExpression("test-id", "200 + 15 m%", nil, "", nil)
+		assert.Error(t, err)
+	})
+}
+
+// TestSplitExpressionAggregates проверяет разложение агрегатных функций на задачи.
+func TestSplitExpressionAggregates(t *testing.T) {
+	t.Run("Reduction tree", func(t *testing.T) {
+		plan, err := SplitExpression("test-id", "sum([1, 2, 3, 4])", nil, "", nil)
+		assert.NoError(t, err)
+		if assert.Len(t, plan.Tasks, 3) {
+			// Две независимые суммы пар и их сумма
+			assert.Equal(t, 1.0, *plan.Tasks[0].Args[0])
+			assert.Equal(t, 3.0, *plan.Tasks[1].Args[0])
+			assert.Equal(t, []string{plan.Tasks[0].ID, plan.Tasks[1].ID}, plan.Tasks[2].Dependencies)
+		}
+
+		plan, err = SplitExpression("test-id", "avg([1, 2, 3])", nil, "", nil)
+		assert.NoError(t, err)
+		if assert.Len(t, plan.Tasks, 3) {
+			assert.Equal(t, operators.OpDivide, plan.Tasks[2].Operation)
+			assert.Equal(t, 3.0, *plan.Tasks[2].Args[1])
+		}
+	})
+
+	t.Run("Median is one task", func(t *testing.T) {
+		plan, err := SplitExpression("test-id", "median([5, 1, x], 2 + 2)", map[string]float64{"x": 3}, "", nil)
+		assert.NoError(t, err)
+		if assert.Len(t, plan.Tasks, 2) {
+			task := plan.Tasks[1]
+			assert.Equal(t, operators.FnMedian, task.Operation)
+			assert.Len(t, task.Args, 4)
+			assert.Equal(t, 3.0, *task.Args[2])
+			assert.Nil(t, task.Args[3])
+		}
+	})
+
+	t.Run("Stddev", func(t *testing.T) {
+		plan, err := SplitExpression("test-id", "stddev([2, 4, 4, 4, 5, 5, 7, 9])", nil, "", nil)
+		assert.NoError(t, err)
+		// Среднее вычисляется один раз: 7 сложений и деление, по отклонению и квадрату для 5 различных значений,
+		// 7 сложений квадратов, деление и корень
+		assert.Len(t, plan.Tasks, 27)
+		assert.Equal(t, operators.FnSqrt, plan.Tasks[len(plan.Tasks)-1].Operation)
+	})
+
+	t.Run("Folded", func(t *testing.T) {
+		config.Cfg.Optimizer.FoldConstants = true
+		defer func() { config.Cfg.Optimizer.FoldConstants = false }()
+
+		for expression, expected := range map[string]float64{
+			"sum([1, 2, 3], 4)":                10,
+			"avg([1, 2, 3, 4])":                2.5,
+			"min([3, 1, 2])":                   1,
+			"max([3, 1], [7])":                 7,
+			"median([5, 1, 3])":                3,
+			"median([4, 1, 3, 2])":             2.5,
+			"stddev([2, 4, 4, 4, 5, 5, 7, 9])": 2,
+			"stddev([5])":                      0,
+		} {
+			plan, err := SplitExpression("test-id", expression, nil, "", nil)
+			if assert.NoError(t, err, expression) && assert.NotNil(t, plan.Result, expression) {
+				assert.Equal(t, expected, *plan.Result, expression)
+			}
+		}
+
+		plan, err := SplitExpression("test-id", "median([1/3, 1/2])", nil, "rational", nil)
+		assert.NoError(t, err)
+		if assert.NotNil(t, plan.ExactResult) {
+			assert.Equal(t, "5/12", *plan.ExactResult)
+		}
+	})
+
+	t.Run("Units", func(t *testing.T) {
+		plan, err := SplitExpression("test-id", "stddev([1 m, 2 m, 300 cm])", nil, "", nil)
+		assert.NoError(t, err)
+		assert.Equal(t, "m", plan.Unit)
+
+		_, err = SplitExpression("test-id", "avg([1 m, 2 s])", nil, "", nil)
+		var parseErr *ast.ParseError
+		if assert.ErrorAs(t, err, &parseErr) {
+			assert.Equal(t, "avg", parseErr.Token)
+		}
+	})
+
+	t.Run("Complex", func(t *testing.T) {
+		_, err := SplitExpression("test-id", "sum([1+i, 2])", nil, "complex", nil)
+		assert.NoError(t, err)
+
+		_, err = SplitExpression("test-id", "median([1, 2])", nil, "complex", nil)
+		assert.Error(t, err)
+	})
+}
+
+// TestSplitExpressionUserFunctions проверяет подстановку пользовательских функций.
+func TestSplitExpressionUserFunctions(t *testing.T) {
+	config.Cfg.Optimizer.FoldConstants = true
+	defer func() { config.Cfg.Optimizer.FoldConstants = false }()
+
+	registry := functions.NewRegistry()
+	for _, definition := range []string{"f(x, y) = x^2 + y^2", "g(x) = f(x, x) / 2"} {
+		_, err := registry.Define("", definition)
+		assert.NoError(t, err)
+	}
+	fns := registry.Functions("")
+
+	t.Run("Folded", func(t *testing.T) {
+		plan, err := SplitExpression("test-id", "f(3, 4) + g(2)", nil, "", fns)
+		assert.NoError(t, err)
+		if assert.NotNil(t, plan.Result) {
+			assert.Equal(t, 29.0, *plan.Result)
+		}
+		assert.Equal(t, map[string]int{"f": 1, "g": 1}, plan.Functions)
+	})
+
+	t.Run("Parameters shadow variables", func(t *testing.T) {
+		plan, err := SplitExpression("test-id", "f(a, x)", map[string]float64{"a": 1, "x": 2, "y": 10}, "", fns)
+		assert.NoError(t, err)
+		if assert.NotNil(t, plan.Result) {
+			assert.Equal(t, 5.0, *plan.Result)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		_, err := SplitExpression("test-id", "1 + f(1)", nil, "", fns)
+		var parseErr *ast.ParseError
+		if assert.ErrorAs(t, err, &parseErr) {
+			assert.Equal(t, 4, parseErr.Pos)
+		}
+
+		_, err = SplitExpression("test-id", "k(1)", nil, "", fns)
+		assert.EqualError(t, err, "неизвестная функция: k")
+
+		_, err = SplitExpression("test-id", "f(b, 1)", nil, "", fns)
+		var unboundErr *UnboundVariablesError
+		if assert.ErrorAs(t, err, &unboundErr) {
+			assert.Equal(t, []string{"b"}, unboundErr.Names)
+		}
+	})
+}
+
+// TestSplitExpressionReferences проверяет задачи ссылок на результаты других выражений.
+func TestSplitExpressionReferences(t *testing.T) {
+	plan, err := SplitExpression("test-id", "$ref(a) * $ref(a) + $ref(b)", nil, "", nil)
+	assert.NoError(t, err)
+
+	var references []string
+	for _, task := range plan.Tasks {
+		if task.Operation == operators.OpReference {
+			references = append(references, task.Reference)
+			assert.Empty(t, task.Args)
+		}
+	}
+	// Повторная ссылка на выражение использует ту же задачу
+	assert.Equal(t, []string{"a", "b"}, references)
+	assert.Len(t, plan.Tasks, 4)
+	assert.Equal(t, 1, plan.Stats.Deduplicated)
+
+	// Результат другого выражения - безразмерное число
+	_, err = SplitExpression("test-id", "$ref(a) + 1 m", nil, "", nil)
+	assert.Error(t, err)
+}
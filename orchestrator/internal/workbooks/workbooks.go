@@ -0,0 +1,461 @@
+package workbooks
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/OinkiePie/calc_2/orchestrator/internal/ast"
+	"github.com/OinkiePie/calc_2/orchestrator/internal/functions"
+	"github.com/OinkiePie/calc_2/orchestrator/internal/task_manager"
+	"github.com/OinkiePie/calc_2/pkg/constants"
+	"github.com/OinkiePie/calc_2/pkg/operators"
+)
+
+var (
+	ErrCellNotFound = errors.New("ячейка не найдена")
+	ErrCellInUse    = errors.New("на ячейку ссылаются другие ячейки")
+	errCellName     = errors.New("некорректное имя ячейки")
+	errCycle        = errors.New("циклическая ссылка между ячейками")
+	errDependency   = errors.New("ошибка в ячейке")
+)
+
+// cellName - допустимое имя ячейки: латинские буквы, цифры и подчеркивание, первым символом - буква или подчеркивание.
+var cellName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// reservedNames - имена, которые нельзя дать ячейке: ключевое слово перевода единиц и мнимая единица.
+var reservedNames = []string{"to", "i"}
+
+// Cell - ячейка рабочей книги.
+type Cell struct {
+	// Name - Имя ячейки, например "A1" или "price".
+	Name string
+	// Formula - Формула ячейки: выражение, в котором имена других ячеек заменяются их значениями.
+	Formula string
+	// Precision - Точность вычисления формулы (см. task_splitter.SplitExpression).
+	Precision string
+	// Dependencies - Имена ячеек, на которые ссылается формула, по алфавиту.
+	Dependencies []string
+	// ExpressionID - ID выражения TaskManager, вычисляющего последнюю версию формулы.
+	// Пустая строка, если формулу не удалось отправить на вычисление (см. Error).
+	ExpressionID string
+	// Error - Ошибка отправки формулы на вычисление при пересчете, пустая строка - ошибки нет.
+	// Ошибки вычисления содержит выражение ExpressionID.
+	Error string
+
+	// functions - Пользовательские функции, доступные формуле. Сохраняются вместе с ячейкой,
+	// чтобы пересчет использовал те же версии функций, что и определение ячейки.
+	functions map[string]*functions.Function
+}
+
+// Workbooks - хранилище рабочих книг. Каждая ячейка вычисляется отдельным выражением TaskManager,
+// а ссылки на другие ячейки становятся ссылками "$ref(id)" на их выражения, поэтому ячейки вычисляются агентами
+// параллельно, в порядке зависимостей, без ожидания на стороне клиента.
+type Workbooks struct {
+	// taskManager - TaskManager, вычисляющий формулы ячеек.
+	taskManager *task_manager.TaskManager
+	// workbooks - Ячейки рабочих книг: ID книги -> имя ячейки -> ячейка.
+	workbooks map[string]map[string]*Cell
+	// mu - Mutex для защиты map от конкурентного доступа. Изменение ячейки и пересчет зависимых ячеек
+	// выполняются под одной блокировкой, поэтому пересчеты одной книги не перемешиваются.
+	mu sync.RWMutex
+}
+
+// NewWorkbooks - конструктор для Workbooks. Создает пустое хранилище рабочих книг.
+//
+// Args:
+//
+//	tm: *task_manager.TaskManager - TaskManager, вычисляющий формулы ячеек.
+//
+// Returns:
+//
+//	*Workbooks - Указатель на новый экземпляр Workbooks.
+func NewWorkbooks(tm *task_manager.TaskManager) *Workbooks {
+	return &Workbooks{taskManager: tm, workbooks: make(map[string]map[string]*Cell)}
+}
+
+// SetCell задает формулу ячейки и пересчитывает её и все ячейки, которые от неё зависят, прямо или через другие ячейки.
+// Книга создается при первой записи в неё. Остальные ячейки не пересчитываются, если их выражения ещё хранятся
+// в TaskManager, иначе они вычисляются заново вместе с зависимыми ячейками.
+//
+// Args:
+//
+//	workbook: string - ID рабочей книги.
+//	name: string - Имя ячейки.
+//	formula: string - Формула ячейки, например "A1 * (1 + rate)".
+//	precision: string - Точность вычислений, пустая строка - float64.
+//	userFunctions: map[string]*functions.Function - Пользовательские функции, доступные формуле (может быть nil).
+//
+// Returns:
+//
+//	Cell - Ячейка после изменения.
+//	[]string - Имена пересчитанных ячеек в порядке отправки на вычисление (зависимости раньше зависимых).
+//	error - Ошибка, если имя ячейки некорректно (занято функцией, константой, ключевым словом "to" или мнимой единицей i),
+//	        формула ссылается на саму ячейку (прямо или через другие ячейки) или не может быть отправлена на вычисление.
+//	        Синтаксические ошибки возвращаются как *ast.ParseError с позицией в формуле.
+func (w *Workbooks) SetCell(workbook, name, formula, precision string,
+	userFunctions map[string]*functions.Function) (Cell, []string, error) {
+	if !cellName.MatchString(name) || operators.IsFunction(name) || slices.Contains(reservedNames, name) {
+		return Cell{}, nil, fmt.Errorf("%w: %s", errCellName, name)
+	}
+	if _, ok := constants.Lookup(name); ok {
+		return Cell{}, nil, fmt.Errorf("%w: %s - константа", errCellName, name)
+	}
+	tree, err := ast.Parse(formula)
+	if err != nil {
+		return Cell{}, nil, err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	cells := w.workbooks[workbook]
+	if cells == nil {
+		cells = make(map[string]*Cell)
+	}
+	cell := &Cell{Name: name, Formula: formula, Precision: precision, functions: userFunctions}
+	for _, ident := range cellReferences(tree, cells, name) {
+		if !slices.Contains(cell.Dependencies, ident.Name) {
+			cell.Dependencies = append(cell.Dependencies, ident.Name)
+		}
+	}
+	sort.Strings(cell.Dependencies)
+	if cycle := findCycle(cells, name, cell.Dependencies); cycle != nil {
+		return Cell{}, nil, fmt.Errorf("%w: %s", errCycle, strings.Join(cycle, " -> "))
+	}
+
+	// Ячейка заменяется только если её формулу удалось отправить на вычисление
+	updated := make(map[string]*Cell, len(cells)+1)
+	for cellName, c := range cells {
+		updated[cellName] = c
+	}
+	updated[name] = cell
+
+	order := recalculationOrder(updated, w.stale(updated, name))
+	for _, dirty := range order {
+		recalculated := *updated[dirty]
+		err := w.submit(updated, &recalculated)
+		if err != nil && dirty == name {
+			return Cell{}, nil, err
+		}
+		if err != nil {
+			recalculated.ExpressionID, recalculated.Error = "", err.Error()
+		}
+		updated[dirty] = &recalculated
+	}
+
+	// Выражения ячеек закрепляются, чтобы политика хранения не удаляла их, пока ячейка существует
+	for _, dirty := range order {
+		if previous, ok := cells[dirty]; ok && previous.ExpressionID != "" {
+			w.taskManager.Unpin(previous.ExpressionID)
+		}
+		if id := updated[dirty].ExpressionID; id != "" {
+			w.taskManager.Pin(id)
+		}
+	}
+	w.workbooks[workbook] = updated
+	return *updated[name], order, nil
+}
+
+// Cells возвращает ячейки рабочей книги.
+//
+// Args:
+//
+//	workbook: string - ID рабочей книги.
+//
+// Returns:
+//
+//	[]Cell - Ячейки книги по алфавиту.
+//	bool - true, если книга найдена, иначе false.
+func (w *Workbooks) Cells(workbook string) ([]Cell, bool) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	cells, ok := w.workbooks[workbook]
+	list := make([]Cell, 0, len(cells))
+	for _, cell := range cells {
+		list = append(list, *cell)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list, ok
+}
+
+// Cell возвращает ячейку рабочей книги.
+//
+// Args:
+//
+//	workbook: string - ID рабочей книги.
+//	name: string - Имя ячейки.
+//
+// Returns:
+//
+//	Cell - Ячейка.
+//	bool - true, если ячейка найдена, иначе false.
+func (w *Workbooks) Cell(workbook, name string) (Cell, bool) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	cell, ok := w.workbooks[workbook][name]
+	if !ok {
+		return Cell{}, false
+	}
+	return *cell, true
+}
+
+// DeleteCell удаляет ячейку рабочей книги. Ячейку, на которую ссылаются другие ячейки, удалить нельзя.
+// Книга без ячеек удаляется, а выражение ячейки открепляется и удаляется политикой хранения.
+//
+// Args:
+//
+//	workbook: string - ID рабочей книги.
+//	name: string - Имя ячейки.
+//
+// Returns:
+//
+//	error - ErrCellNotFound, если ячейки нет, ErrCellInUse, если на неё ссылаются другие ячейки.
+func (w *Workbooks) DeleteCell(workbook, name string) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	cells := w.workbooks[workbook]
+	if _, ok := cells[name]; !ok {
+		return ErrCellNotFound
+	}
+	var dependents []string
+	for _, cell := range cells {
+		if slices.Contains(cell.Dependencies, name) {
+			dependents = append(dependents, cell.Name)
+		}
+	}
+	if len(dependents) > 0 {
+		sort.Strings(dependents)
+		return fmt.Errorf("%w: %s", ErrCellInUse, strings.Join(dependents, ", "))
+	}
+
+	if id := cells[name].ExpressionID; id != "" {
+		w.taskManager.Unpin(id)
+	}
+	delete(cells, name)
+	if len(cells) == 0 {
+		delete(w.workbooks, workbook)
+	}
+	return nil
+}
+
+// stale возвращает ячейки, которые нужно пересчитать: измененную ячейку и ячейки, выражения которых
+// не удалось отправить или уже нет в TaskManager.
+// Вызывается под блокировкой mu.
+func (w *Workbooks) stale(cells map[string]*Cell, changed string) []string {
+	names := []string{changed}
+	for name, cell := range cells {
+		if name == changed {
+			continue
+		}
+		if _, ok := w.taskManager.LookupExpression(cell.ExpressionID); !ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// submit отправляет формулу ячейки на вычисление: имена ячеек в формуле заменяются ссылками "$ref(id)"
+// на выражения этих ячеек. Вызывается под блокировкой mu, зависимости ячейки должны быть уже отправлены.
+//
+// Args:
+//
+//	cells: map[string]*Cell - Ячейки книги.
+//	cell: *Cell - Ячейка, ExpressionID и Error которой заполняются.
+//
+// Returns:
+//
+//	error - Ошибка добавления выражения с позицией в исходной формуле или ошибка ячейки, от которой зависит формула.
+func (w *Workbooks) submit(cells map[string]*Cell, cell *Cell) error {
+	for _, dependency := range cell.Dependencies {
+		if cells[dependency].ExpressionID == "" {
+			return fmt.Errorf("%w %s: %s", errDependency, dependency, cells[dependency].Error)
+		}
+	}
+
+	tree, err := ast.Parse(cell.Formula)
+	if err != nil {
+		return err
+	}
+	// Замены выполняются с конца формулы, чтобы не сдвигать смещения ещё не замененных имен
+	idents := cellReferences(tree, cells, "")
+	sort.Slice(idents, func(i, j int) bool { return idents[i].Offset > idents[j].Offset })
+	expression := cell.Formula
+	for _, ident := range idents {
+		reference := operators.OpReference + operators.ParenLeft + cells[ident.Name].ExpressionID + operators.ParenRight
+		expression = expression[:ident.Offset] + reference + expression[ident.Offset+len(ident.Name):]
+	}
+	id, err := w.taskManager.AddExpression(expression, nil, cell.Precision, cell.functions)
+	if err != nil {
+		var parseErr *ast.ParseError
+		if errors.As(err, &parseErr) {
+			mapped := *parseErr
+			mapped.Pos = formulaOffset(idents, cells, parseErr.Pos)
+			return &mapped
+		}
+		return err
+	}
+	cell.ExpressionID, cell.Error = id, ""
+	return nil
+}
+
+// formulaOffset переводит смещение в выражении с подставленными ссылками в смещение в исходной формуле.
+//
+// Args:
+//
+//	idents: []*ast.Ident - Замененные имена ячеек, от конца формулы к началу.
+//	cells: map[string]*Cell - Ячейки книги.
+//	pos: int - Смещение в выражении со ссылками.
+//
+// Returns:
+//
+//	int - Смещение в формуле. Смещение внутри подставленной ссылки - смещение имени ячейки.
+func formulaOffset(idents []*ast.Ident, cells map[string]*Cell, pos int) int {
+	shift := 0
+	for i := len(idents) - 1; i >= 0; i-- {
+		ident := idents[i]
+		start := ident.Offset + shift // Начало ссылки в выражении
+		length := len(operators.OpReference+operators.ParenLeft+operators.ParenRight) + len(cells[ident.Name].ExpressionID)
+		if pos < start {
+			break
+		}
+		if pos < start+length {
+			return ident.Offset
+		}
+		shift += length - len(ident.Name)
+	}
+	return pos - shift
+}
+
+// cellReferences возвращает имена ячеек книги в формуле. Имена, которые не являются ячейками, остаются в формуле:
+// это константы или ошибка, о которой сообщит разбиватель на задачи.
+//
+// Args:
+//
+//	node: ast.Node - Синтаксическое дерево формулы.
+//	cells: map[string]*Cell - Ячейки книги.
+//	self: string - Имя ячейки, которой принадлежит формула: ссылка на неё считается ссылкой на ячейку,
+//	               даже если ячейки ещё нет. Пустая строка - не учитывать.
+//
+// Returns:
+//
+//	[]*ast.Ident - Узлы имен ячеек в порядке обхода дерева.
+func cellReferences(node ast.Node, cells map[string]*Cell, self string) []*ast.Ident {
+	var idents []*ast.Ident
+	var walk func(node ast.Node)
+	walk = func(node ast.Node) {
+		switch n := node.(type) {
+		case *ast.Ident:
+			if _, ok := cells[n.Name]; ok || n.Name == self {
+				idents = append(idents, n)
+			}
+		case *ast.Unary:
+			walk(n.Operand)
+		case *ast.Binary:
+			walk(n.Left)
+			walk(n.Right)
+		case *ast.Conditional:
+			walk(n.Cond)
+			walk(n.Then)
+			walk(n.Else)
+		case *ast.Call:
+			for _, arg := range n.Args {
+				walk(arg)
+			}
+		case *ast.Convert:
+			walk(n.Value)
+		}
+	}
+	walk(node)
+	return idents
+}
+
+// findCycle ищет цепочку ссылок, которая начинается в формуле ячейки и возвращается к ней.
+//
+// Args:
+//
+//	cells: map[string]*Cell - Ячейки книги до изменения.
+//	name: string - Имя изменяемой ячейки.
+//	dependencies: []string - Ячейки, на которые ссылается новая формула.
+//
+// Returns:
+//
+//	[]string - Цепочка имен от ячейки до неё же, например ["A1", "B1", "A1"], или nil, если цикла нет.
+func findCycle(cells map[string]*Cell, name string, dependencies []string) []string {
+	visited := make(map[string]bool)
+	var walk func(path []string, dependencies []string) []string
+	walk = func(path []string, dependencies []string) []string {
+		for _, dependency := range dependencies {
+			if dependency == name {
+				return append(slices.Clone(path), name)
+			}
+			if visited[dependency] {
+				continue
+			}
+			visited[dependency] = true
+			if cycle := walk(append(path, dependency), cells[dependency].Dependencies); cycle != nil {
+				return cycle
+			}
+		}
+		return nil
+	}
+	return walk([]string{name}, dependencies)
+}
+
+// recalculationOrder возвращает ячейки, которые нужно пересчитать, в порядке отправки на вычисление:
+// ячейки stale и все ячейки, зависящие от них прямо или через другие ячейки; зависимости раньше зависимых.
+//
+// Args:
+//
+//	cells: map[string]*Cell - Ячейки книги, циклов нет.
+//	stale: []string - Ячейки, требующие пересчета.
+//
+// Returns:
+//
+//	[]string - Имена ячеек для пересчета.
+func recalculationOrder(cells map[string]*Cell, stale []string) []string {
+	dirty := make(map[string]bool)
+	for changed := true; changed; {
+		changed = false
+		for name, cell := range cells {
+			if dirty[name] {
+				continue
+			}
+			if slices.Contains(stale, name) || slices.ContainsFunc(cell.Dependencies, func(d string) bool { return dirty[d] }) {
+				dirty[name] = true
+				changed = true
+			}
+		}
+	}
+
+	names := make([]string, 0, len(dirty))
+	for name := range dirty {
+		names = append(names, name)
+	}
+	sort.Strings(names) // Одинаковый порядок независимых ячеек при каждом пересчете
+
+	var order []string
+	visited := make(map[string]bool)
+	var visit func(name string)
+	visit = func(name string) {
+		if visited[name] || !dirty[name] {
+			return
+		}
+		visited[name] = true
+		for _, dependency := range cells[name].Dependencies {
+			visit(dependency)
+		}
+		order = append(order, name)
+	}
+	for _, name := range names {
+		visit(name)
+	}
+	return order
+}
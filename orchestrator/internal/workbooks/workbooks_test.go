@@ -0,0 +1,188 @@
+package workbooks_test
+
+import (
+	"io"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/OinkiePie/calc_2/config"
+	"github.com/OinkiePie/calc_2/orchestrator/internal/ast"
+	"github.com/OinkiePie/calc_2/orchestrator/internal/task_manager"
+	"github.com/OinkiePie/calc_2/orchestrator/internal/workbooks"
+	"github.com/OinkiePie/calc_2/pkg/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	// Отключаем выводы и инициализируем конфиг
+	log.SetOutput(io.Discard)
+	config.InitConfig()
+	logger.InitLogger(logger.Options{Level: 6})
+}
+
+// TestSetCell проверяет вычисление ячеек, которые ссылаются друг на друга.
+func TestSetCell(t *testing.T) {
+	tm := task_manager.NewTaskManager()
+	books := workbooks.NewWorkbooks(tm)
+
+	a1, recalculated, err := books.SetCell("book", "A1", "2 + 3", "", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"A1"}, recalculated)
+	assert.Empty(t, a1.Dependencies)
+
+	b1, recalculated, err := books.SetCell("book", "B1", "A1 * 2", "", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"B1"}, recalculated)
+	assert.Equal(t, []string{"A1"}, b1.Dependencies)
+
+	// Агент вычисляет сложение, умножение ждет результат A1
+	task, exprID, found := tm.GetTask()
+	assert.True(t, found)
+	assert.Equal(t, a1.ExpressionID, exprID)
+	_, _, found = tm.GetTask()
+	assert.False(t, found)
+	tm.CompleteTask(exprID, task.ID, task.Lease, "", 5)
+
+	task, exprID, found = tm.GetTask()
+	assert.True(t, found)
+	assert.Equal(t, b1.ExpressionID, exprID)
+	assert.Equal(t, 5.0, *task.Args[0])
+	tm.CompleteTask(exprID, task.ID, task.Lease, "", 10)
+
+	expr, found := tm.LookupExpression(b1.ExpressionID)
+	assert.True(t, found)
+	if assert.NotNil(t, expr.Result) {
+		assert.Equal(t, 10.0, *expr.Result)
+	}
+
+	cells, found := books.Cells("book")
+	assert.True(t, found)
+	assert.Len(t, cells, 2)
+	assert.Equal(t, "A1", cells[0].Name)
+	_, found = books.Cells("missing")
+	assert.False(t, found)
+}
+
+// TestRecalculation проверяет пересчет зависимых ячеек при изменении формулы.
+func TestRecalculation(t *testing.T) {
+	books := workbooks.NewWorkbooks(task_manager.NewTaskManager())
+
+	for _, cell := range []struct{ name, formula string }{
+		{"A1", "100"},
+		{"B1", "A1 * 2"},
+		{"C1", "B1 + A1"},
+		{"D1", "-pi"},
+	} {
+		_, _, err := books.SetCell("book", cell.name, cell.formula, "", nil)
+		assert.NoError(t, err)
+	}
+	before, _ := books.Cell("book", "C1")
+
+	// Пересчитываются только ячейки, зависящие от A1, зависимости раньше зависимых
+	_, recalculated, err := books.SetCell("book", "A1", "200", "", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"A1", "B1", "C1"}, recalculated)
+
+	after, _ := books.Cell("book", "C1")
+	assert.NotEqual(t, before.ExpressionID, after.ExpressionID)
+	assert.Equal(t, []string{"A1", "B1"}, after.Dependencies)
+}
+
+// TestSetCellErrors проверяет ошибки изменения ячейки.
+func TestSetCellErrors(t *testing.T) {
+	books := workbooks.NewWorkbooks(task_manager.NewTaskManager())
+
+	_, _, err := books.SetCell("book", "A1", "B1 + 1", "", nil)
+	assert.ErrorContains(t, err, "B1")
+
+	_, _, err = books.SetCell("book", "A1", "10", "", nil)
+	assert.NoError(t, err)
+	_, _, err = books.SetCell("book", "B1", "A1 * 2", "", nil)
+	assert.NoError(t, err)
+
+	// Циклы через другие ячейки и на саму себя
+	_, _, err = books.SetCell("book", "A1", "B1 + 1", "", nil)
+	assert.EqualError(t, err, "циклическая ссылка между ячейками: A1 -> B1 -> A1")
+	_, _, err = books.SetCell("book", "C1", "C1 + 1", "", nil)
+	assert.EqualError(t, err, "циклическая ссылка между ячейками: C1 -> C1")
+
+	// Ячейка не изменилась после ошибки
+	cell, _ := books.Cell("book", "A1")
+	assert.Equal(t, "10", cell.Formula)
+
+	for _, name := range []string{"1A", "sin", "pi", "to", "i", ""} {
+		_, _, err = books.SetCell("book", name, "1", "", nil)
+		assert.ErrorContains(t, err, "некорректное имя ячейки", name)
+	}
+
+	// Позиция синтаксической ошибки - смещение в формуле
+	_, _, err = books.SetCell("book", "C1", "A1 + * 2", "", nil)
+	var parseErr *ast.ParseError
+	if assert.ErrorAs(t, err, &parseErr) {
+		assert.Equal(t, 5, parseErr.Pos)
+	}
+}
+
+// TestDeleteCell проверяет удаление ячеек.
+func TestDeleteCell(t *testing.T) {
+	books := workbooks.NewWorkbooks(task_manager.NewTaskManager())
+
+	_, _, err := books.SetCell("book", "A1", "1", "", nil)
+	assert.NoError(t, err)
+	_, _, err = books.SetCell("book", "B1", "A1 + 1", "", nil)
+	assert.NoError(t, err)
+
+	assert.ErrorIs(t, books.DeleteCell("book", "A1"), workbooks.ErrCellInUse)
+	assert.ErrorIs(t, books.DeleteCell("book", "C1"), workbooks.ErrCellNotFound)
+
+	assert.NoError(t, books.DeleteCell("book", "B1"))
+	assert.NoError(t, books.DeleteCell("book", "A1"))
+
+	// Книга без ячеек удаляется
+	_, found := books.Cells("book")
+	assert.False(t, found)
+}
+
+// TestRetention проверяет, что политика хранения не удаляет выражения ячеек, пока ячейки существуют.
+func TestRetention(t *testing.T) {
+	retention := config.Cfg.Retention
+	defer func() { config.Cfg.Retention = retention }()
+	config.Cfg.Retention.TTL = 60
+	config.Cfg.Retention.MaxCount = 0
+
+	tm := task_manager.NewTaskManager()
+	books := workbooks.NewWorkbooks(tm)
+
+	a1, _, err := books.SetCell("book", "A1", "2 + 3", "", nil)
+	assert.NoError(t, err)
+	b1, _, err := books.SetCell("book", "B1", "A1 * 2", "", nil)
+	assert.NoError(t, err)
+	for range 2 {
+		task, exprID, found := tm.GetTask()
+		assert.True(t, found)
+		tm.CompleteTask(exprID, task.ID, task.Lease, "", 5)
+	}
+
+	// Ячейки не запрашивались дольше TTL, но их выражения сохраняются
+	assert.Equal(t, 0, tm.Evict(time.Now().Add(time.Hour)))
+	for _, id := range []string{a1.ExpressionID, b1.ExpressionID} {
+		expr, found := tm.LookupExpression(id)
+		assert.True(t, found)
+		assert.Equal(t, "completed", expr.Status)
+	}
+
+	// Новая формула и удаление ячейки открепляют прежние выражения
+	_, recalculated, err := books.SetCell("book", "A1", "1 + 1", "", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"A1", "B1"}, recalculated)
+	assert.NoError(t, books.DeleteCell("book", "B1"))
+	assert.Equal(t, 2, tm.Evict(time.Now().Add(time.Hour)))
+	_, found := tm.LookupExpression(a1.ExpressionID)
+	assert.False(t, found)
+
+	// Пересчитанная ячейка по-прежнему вычисляется своим выражением
+	cell, _ := books.Cell("book", "A1")
+	_, found = tm.LookupExpression(cell.ExpressionID)
+	assert.True(t, found)
+}
@@ -0,0 +1,76 @@
+package constants
+
+import (
+	"math"
+	"sort"
+
+	"github.com/OinkiePie/calc_2/config"
+)
+
+// Встроенные математические константы.
+const (
+	Pi  = "pi"  // отношение длины окружности к диаметру
+	E   = "e"   // основание натурального логарифма
+	Phi = "phi" // золотое сечение
+)
+
+// Symbols - Unicode-символы, заменяемые на имена встроенных констант (например, "2π" = "2*pi").
+var Symbols = map[rune]string{
+	'π': Pi,
+	'φ': Phi,
+}
+
+// builtin - значения встроенных констант с полной точностью float64.
+var builtin = map[string]float64{
+	Pi:  math.Pi,
+	E:   math.E,
+	Phi: math.Phi,
+}
+
+// Constant представляет именованную математическую константу.
+type Constant struct {
+	// Name - Имя константы, используемое в выражениях.
+	Name string `json:"name"`
+	// Value - Значение константы.
+	Value float64 `json:"value"`
+}
+
+// Lookup возвращает значение константы по имени.
+// Встроенные константы имеют приоритет над константами из конфигурации (math.constants).
+//
+// Args:
+//
+//	name: string - Имя константы.
+//
+// Returns:
+//
+//	float64 - Значение константы.
+//	bool - true, если константа найдена, иначе false.
+func Lookup(name string) (float64, bool) {
+	if value, ok := builtin[name]; ok {
+		return value, true
+	}
+	// Конфигурация читается при каждом вызове, т.к. может быть инициализирована позже пакета
+	value, ok := config.Cfg.Math.Constants[name]
+	return value, ok
+}
+
+// List возвращает все доступные константы (встроенные и из конфигурации), отсортированные по имени.
+//
+// Returns:
+//
+//	[]Constant - Срез констант.
+func List() []Constant {
+	list := make([]Constant, 0, len(builtin)+len(config.Cfg.Math.Constants))
+	for name, value := range builtin {
+		list = append(list, Constant{Name: name, Value: value})
+	}
+	for name, value := range config.Cfg.Math.Constants {
+		if _, ok := builtin[name]; ok {
+			continue // Встроенные константы не переопределяются
+		}
+		list = append(list, Constant{Name: name, Value: value})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
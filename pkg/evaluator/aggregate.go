@@ -0,0 +1,39 @@
+package evaluator
+
+import (
+	"math/big"
+	"slices"
+)
+
+// median возвращает медиану значений: среднее значение после сортировки,
+// а при четном количестве - полусумму двух средних значений.
+//
+// Args:
+//
+//	values: []float64 - Значения, не пустой срез. Срез не изменяется.
+//
+// Returns:
+//
+//	float64 - Медиана значений.
+func median(values []float64) float64 {
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return sorted[mid]
+	}
+	// Полусумма без переполнения для больших значений одного знака
+	return sorted[mid-1] + (sorted[mid]-sorted[mid-1])/2
+}
+
+// ratMedian возвращает медиану дробей точно (см. median).
+func ratMedian(values []*big.Rat) *big.Rat {
+	sorted := slices.Clone(values)
+	slices.SortFunc(sorted, (*big.Rat).Cmp)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return new(big.Rat).Set(sorted[mid])
+	}
+	sum := new(big.Rat).Add(sorted[mid-1], sorted[mid])
+	return sum.Quo(sum, big.NewRat(2, 1))
+}
@@ -0,0 +1,179 @@
+package evaluator
+
+import (
+	"errors"
+	"fmt"
+	"math/cmplx"
+	"strconv"
+
+	"github.com/OinkiePie/calc_2/pkg/operators"
+)
+
+var (
+	ErrNotComplex    = errors.New("operation is not defined for complex numbers")
+	ErrComplexResult = errors.New("result is not a real number, use complex precision")
+)
+
+// unordered - операции, недоступные в комплексном режиме: комплексные числа не упорядочены,
+// а остаток, целочисленное деление, факториал, округление, финансовые функции и стандартное отклонение
+// определены только для действительных чисел.
+var unordered = map[string]bool{
+	operators.OpLess:         true,
+	operators.OpLessEqual:    true,
+	operators.OpGreater:      true,
+	operators.OpGreaterEqual: true,
+	operators.OpModulo:       true,
+	operators.OpFloorDivide:  true,
+	operators.OpFactorial:    true,
+	operators.FnMax:          true,
+	operators.FnMin:          true,
+	operators.FnRound:        true,
+	operators.FnFloor:        true,
+	operators.FnCeil:         true,
+	operators.FnPmt:          true,
+	operators.FnFv:           true,
+	operators.FnMedian:       true,
+	operators.FnStddev:       true,
+}
+
+// SupportsComplex проверяет, может ли операция быть вычислена над комплексными числами.
+//
+// Args:
+//
+//	operation: string - Операция.
+//
+// Returns:
+//
+//	bool - false для сравнений на больше/меньше, %, //, факториала, max, min, median, stddev, округления и финансовых функций.
+func SupportsComplex(operation string) bool {
+	return !unordered[operation]
+}
+
+// FormatComplex возвращает запись комплексного числа, например "(3+4i)".
+func FormatComplex(value complex128) string {
+	return strconv.FormatComplex(value, 'g', -1, 128)
+}
+
+// complexBool возвращает логическое значение в виде комплексного числа: 1 или 0.
+func complexBool(b bool) complex128 {
+	return complex(boolValue(b), 0)
+}
+
+// EvaluateComplex выполняет операцию задачи над комплексными аргументами с помощью math/cmplx.
+// Функции вычисляются в главной ветви: sqrt(-1) = i, (-8)^(1/3) = 1+1.732i. Функция abs возвращает модуль числа,
+// сравнения на равенство и логические операции - 1 или 0, истинным считается любое ненулевое число.
+//
+// Args:
+//
+//	operation: string - Операция.
+//	args: ...complex128 - Аргументы операции. Унарные операции используют только первый аргумент, бинарные - первые два.
+//
+// Returns:
+//
+//	complex128 - Результат выполнения операции.
+//	error - Ошибка, если операция не определена для комплексных чисел или не может быть выполнена.
+func EvaluateComplex(operation string, args ...complex128) (complex128, error) {
+	if !SupportsComplex(operation) {
+		return 0, fmt.Errorf("%w: %s", ErrNotComplex, operation)
+	}
+	if len(args) == 0 {
+		return 0, ErrArgCount
+	}
+	arg1 := args[0]
+
+	// Унарные операции
+	switch operation {
+	case operators.OpUnaryMinus:
+		// Вычитание из нуля не дает мнимой части -0: иначе sqrt(-4) попал бы на другую сторону разреза и дал бы -2i
+		return 0 - arg1, nil
+
+	case operators.OpNot:
+		return complexBool(arg1 == 0), nil
+
+	case operators.FnSin:
+		return cmplx.Sin(arg1), nil
+
+	case operators.FnCos:
+		return cmplx.Cos(arg1), nil
+
+	case operators.FnTan:
+		return cmplx.Tan(arg1), nil
+
+	case operators.FnSqrt:
+		return cmplx.Sqrt(arg1), nil
+
+	case operators.FnLn:
+		if arg1 == 0 {
+			return 0, ErrLogDomain
+		}
+		return cmplx.Log(arg1), nil
+
+	case operators.FnAbs:
+		return complex(cmplx.Abs(arg1), 0), nil
+
+	case operators.FnExp:
+		return cmplx.Exp(arg1), nil
+
+	case operators.OpPercent:
+		return arg1 / 100, nil
+	}
+
+	// Остальные операции бинарные
+	if len(args) < 2 {
+		return 0, ErrArgCount
+	}
+	arg2 := args[1]
+
+	switch operation {
+	case operators.OpAdd:
+		return arg1 + arg2, nil
+
+	case operators.OpSubtract:
+		return arg1 - arg2, nil
+
+	case operators.OpMultiply:
+		return arg1 * arg2, nil
+
+	case operators.OpDivide:
+		if arg2 == 0 {
+			return 0, ErrDivisionByZero
+		}
+		return arg1 / arg2, nil
+
+	case operators.OpPower:
+		if arg1 == 0 && real(arg2) < 0 {
+			return 0, ErrDivisionByZero
+		}
+		return cmplx.Pow(arg1, arg2), nil
+
+	case operators.FnLog:
+		// Второй аргумент - основание логарифма
+		if arg1 == 0 {
+			return 0, ErrLogDomain
+		}
+		if arg2 == 0 || arg2 == 1 {
+			return 0, ErrLogBase
+		}
+		return cmplx.Log(arg1) / cmplx.Log(arg2), nil
+
+	case operators.OpEqual:
+		return complexBool(arg1 == arg2), nil
+
+	case operators.OpNotEqual:
+		return complexBool(arg1 != arg2), nil
+
+	case operators.OpAnd:
+		return complexBool(arg1 != 0 && arg2 != 0), nil
+
+	case operators.OpOr:
+		return complexBool(arg1 != 0 || arg2 != 0), nil
+
+	case operators.OpAddPercent:
+		return arg1 + arg1*arg2/100, nil
+
+	case operators.OpSubtractPercent:
+		return arg1 - arg1*arg2/100, nil
+	}
+
+	return 0, fmt.Errorf("unknown operator: %s", operation)
+}
@@ -0,0 +1,262 @@
+package evaluator
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/OinkiePie/calc_2/pkg/operators"
+)
+
+var (
+	ErrDivisionByZero = errors.New("division by zero not allowed")
+	ErrNegativeSqrt   = errors.New("square root of negative number")
+	ErrLogDomain      = errors.New("logarithm of non-positive number")
+	ErrLogBase        = errors.New("invalid logarithm base")
+	ErrModuloByZero   = errors.New("modulo by zero not allowed")
+	ErrFactorial      = errors.New("factorial of negative integer")
+	ErrArgCount       = errors.New("not enough arguments")
+)
+
+// unary - операции, использующие только первый аргумент.
+var unary = map[string]bool{
+	operators.OpUnaryMinus: true,
+	operators.OpFactorial:  true,
+	operators.OpNot:        true,
+	operators.FnSin:        true,
+	operators.FnCos:        true,
+	operators.FnTan:        true,
+	operators.FnSqrt:       true,
+	operators.FnLn:         true,
+	operators.FnAbs:        true,
+	operators.FnExp:        true,
+	operators.OpPercent:    true,
+	operators.FnFloor:      true,
+	operators.FnCeil:       true,
+}
+
+// argCounts - количество аргументов операций, использующих больше двух аргументов.
+var argCounts = map[string]int{
+	operators.FnPmt:    5,
+	operators.FnFv:     5,
+	operators.FnMedian: operators.Variadic,
+}
+
+// IsUnary проверяет, использует ли операция только первый аргумент.
+//
+// Args:
+//
+//	operation: string - Операция задачи.
+//
+// Returns:
+//
+//	bool - true для унарного минуса, факториала, логического НЕ и функций одного аргумента, иначе false.
+func IsUnary(operation string) bool {
+	return unary[operation]
+}
+
+// ArgCount возвращает количество аргументов, которое использует операция.
+//
+// Args:
+//
+//	operation: string - Операция задачи.
+//
+// Returns:
+//
+//	int - 1 для унарных операций (см. IsUnary), 5 для pmt и fv, operators.Variadic для медианы
+//	      (она использует все аргументы, но не меньше одного), 2 для остальных.
+func ArgCount(operation string) int {
+	if unary[operation] {
+		return 1
+	}
+	if count, ok := argCounts[operation]; ok {
+		return count
+	}
+	return 2
+}
+
+// boolValue возвращает логическое значение в виде числа: operators.True или operators.False.
+func boolValue(b bool) float64 {
+	if b {
+		return operators.True
+	}
+	return operators.False
+}
+
+// Evaluate выполняет математическую операцию задачи над числовыми аргументами.
+// Сравнения и логические операции возвращают 1 (истина) или 0 (ложь), истинным считается любое ненулевое число.
+// Используется агентом для вычисления задач и оркестратором для свертки констант,
+// поэтому результат одной и той же операции не зависит от того, где она вычислена.
+//
+// Args:
+//
+//	operation: string - Операция (арифметическая, сравнение, логическая или имя функции).
+//	args: ...float64 - Аргументы операции. Операция использует первые ArgCount(operation) аргументов.
+//
+// Returns:
+//
+//	float64 - Результат выполнения операции.
+//	error - Ошибка, если операция не может быть выполнена (например, деление на ноль или неизвестная операция).
+func Evaluate(operation string, args ...float64) (float64, error) {
+	if len(args) == 0 {
+		return 0, ErrArgCount
+	}
+	if operation == operators.FnMedian {
+		return median(args), nil
+	}
+	arg1 := args[0]
+
+	// Унарные операции
+	switch operation {
+	case operators.OpUnaryMinus:
+		return -arg1, nil
+
+	case operators.OpNot:
+		return boolValue(arg1 == 0), nil
+
+	case operators.OpFactorial:
+		// Гамма-функция не определена в целых неположительных точках: (-1)! = Γ(0)
+		if arg1 < 0 && arg1 == math.Trunc(arg1) {
+			return 0, ErrFactorial
+		}
+		return math.Gamma(arg1 + 1), nil
+
+	case operators.FnSin:
+		return math.Sin(arg1), nil
+
+	case operators.FnCos:
+		return math.Cos(arg1), nil
+
+	case operators.FnTan:
+		return math.Tan(arg1), nil
+
+	case operators.FnSqrt:
+		if arg1 < 0 {
+			return 0, ErrNegativeSqrt
+		}
+		return math.Sqrt(arg1), nil
+
+	case operators.FnLn:
+		if arg1 <= 0 {
+			return 0, ErrLogDomain
+		}
+		return math.Log(arg1), nil
+
+	case operators.FnAbs:
+		return math.Abs(arg1), nil
+
+	case operators.FnExp:
+		return math.Exp(arg1), nil
+
+	case operators.OpPercent:
+		return arg1 / 100, nil
+
+	case operators.FnFloor:
+		return math.Floor(arg1), nil
+
+	case operators.FnCeil:
+		return math.Ceil(arg1), nil
+	}
+
+	// Остальные операции бинарные, кроме финансовых функций
+	if len(args) < ArgCount(operation) {
+		return 0, ErrArgCount
+	}
+	arg2 := args[1]
+
+	switch operation {
+
+	case operators.OpAdd:
+		return arg1 + arg2, nil
+
+	case operators.OpSubtract:
+		return arg1 - arg2, nil
+
+	case operators.OpMultiply:
+		return arg1 * arg2, nil
+
+	case operators.OpDivide:
+		if arg2 == 0 {
+			return 0, ErrDivisionByZero
+		}
+		return arg1 / arg2, nil
+
+	case operators.OpPower:
+		// Отрицательное число в дробной степени - комплексное число, а math.Pow возвращает NaN
+		if arg1 < 0 && arg2 != math.Trunc(arg2) && !math.IsInf(arg2, 0) {
+			return 0, ErrComplexResult
+		}
+		return math.Pow(arg1, arg2), nil
+
+	case operators.OpModulo:
+		if arg2 == 0 {
+			return 0, ErrModuloByZero
+		}
+		// Остаток имеет знак делителя, чтобы a == b*(a//b) + a%b
+		mod := math.Mod(arg1, arg2)
+		if mod != 0 && (mod < 0) != (arg2 < 0) {
+			mod += arg2
+		}
+		return mod, nil
+
+	case operators.OpFloorDivide:
+		if arg2 == 0 {
+			return 0, ErrDivisionByZero
+		}
+		return math.Floor(arg1 / arg2), nil
+
+	case operators.FnLog:
+		// Второй аргумент - основание логарифма
+		if arg1 <= 0 {
+			return 0, ErrLogDomain
+		}
+		if arg2 <= 0 || arg2 == 1 {
+			return 0, ErrLogBase
+		}
+		return math.Log(arg1) / math.Log(arg2), nil
+
+	case operators.FnMax:
+		return math.Max(arg1, arg2), nil
+
+	case operators.FnMin:
+		return math.Min(arg1, arg2), nil
+
+	case operators.OpLess:
+		return boolValue(arg1 < arg2), nil
+
+	case operators.OpLessEqual:
+		return boolValue(arg1 <= arg2), nil
+
+	case operators.OpEqual:
+		return boolValue(arg1 == arg2), nil
+
+	case operators.OpNotEqual:
+		return boolValue(arg1 != arg2), nil
+
+	case operators.OpGreater:
+		return boolValue(arg1 > arg2), nil
+
+	case operators.OpGreaterEqual:
+		return boolValue(arg1 >= arg2), nil
+
+	case operators.OpAnd:
+		return boolValue(arg1 != 0 && arg2 != 0), nil
+
+	case operators.OpOr:
+		return boolValue(arg1 != 0 || arg2 != 0), nil
+
+	case operators.OpAddPercent:
+		return arg1 + arg1*arg2/100, nil
+
+	case operators.OpSubtractPercent:
+		return arg1 - arg1*arg2/100, nil
+
+	case operators.FnRound:
+		return roundFloat(arg1, arg2)
+
+	case operators.FnPmt, operators.FnFv:
+		return annuity(operation, args)
+	}
+
+	return 0, fmt.Errorf("unknown operator: %s", operation)
+}
@@ -0,0 +1,158 @@
+package evaluator
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"strconv"
+
+	"github.com/OinkiePie/calc_2/pkg/operators"
+)
+
+var (
+	ErrRoundDigits = errors.New("number of digits must be an integer")
+	ErrPeriods     = errors.New("number of periods must not be zero")
+	ErrPaymentType = errors.New("payment type must be 0 (end of period) or 1 (beginning of period)")
+)
+
+// maxRoundDigits - наибольший модуль количества знаков округления float64: дальше округление
+// либо не меняет число (|x| < 10^309 имеет не больше 17 значащих цифр), либо дает ноль.
+const maxRoundDigits = 400
+
+// ratRound округляет дробь до digits знаков после запятой, половина - от нуля, как на калькуляторе.
+// Отрицательное digits округляет до десятков, сотен и т.д.: round(1250, -2) = 1300.
+func ratRound(x *big.Rat, digits int) *big.Rat {
+	scale := pow10Rat(digits)
+	scaled := new(big.Rat).Mul(x, scale)
+	quotient, remainder := new(big.Int).QuoRem(scaled.Num(), scaled.Denom(), new(big.Int))
+	doubled := new(big.Int).Mul(remainder.Abs(remainder), big.NewInt(2))
+	if doubled.Cmp(scaled.Denom()) >= 0 {
+		quotient.Add(quotient, big.NewInt(int64(scaled.Sign())))
+	}
+	return new(big.Rat).Quo(new(big.Rat).SetInt(quotient), scale)
+}
+
+// roundFloat округляет число до digits знаков после запятой по его десятичной записи,
+// поэтому round(1.005, 2) = 1.01, хотя в двоичном виде 1.005 немного меньше.
+//
+// Args:
+//
+//	x: float64 - Округляемое число.
+//	digits: float64 - Количество знаков после запятой, целое.
+//
+// Returns:
+//
+//	float64 - Округленное число.
+//	error - ErrRoundDigits, если количество знаков не целое.
+func roundFloat(x, digits float64) (float64, error) {
+	if digits != math.Trunc(digits) {
+		return 0, ErrRoundDigits
+	}
+	if math.IsInf(x, 0) || math.IsNaN(x) || digits >= maxRoundDigits {
+		return x, nil
+	}
+	decimal, _ := new(big.Rat).SetString(strconv.FormatFloat(x, 'g', -1, 64))
+	value, _ := ratRound(decimal, int(max(digits, -maxRoundDigits))).Float64()
+	return value, nil
+}
+
+// paymentType проверяет тип платежа: 0 - в конце периода, 1 - в начале.
+func paymentType(t float64) error {
+	if t != 0 && t != 1 {
+		return ErrPaymentType
+	}
+	return nil
+}
+
+// annuity вычисляет платеж (pmt) или будущую стоимость (fv) аннуитета по формулам табличных процессоров.
+// Деньги, которые платятся, отрицательны, получаемые - положительны: pmt(5%/12, 360, 200000) = -1073.64.
+//
+// Args:
+//
+//	operation: string - operators.FnPmt или operators.FnFv.
+//	args: []float64 - Ставка за период, количество периодов, pv (для pmt) или платеж (для fv),
+//	                  fv (для pmt) или pv (для fv), тип платежа.
+//
+// Returns:
+//
+//	float64 - Платеж или будущая стоимость.
+//	error - ErrPeriods, если периодов ноль, ErrPaymentType, если тип платежа не 0 и не 1.
+func annuity(operation string, args []float64) (float64, error) {
+	rate, periods, typ := args[0], args[1], args[4]
+	if periods == 0 {
+		return 0, ErrPeriods
+	}
+	if err := paymentType(typ); err != nil {
+		return 0, err
+	}
+
+	if operation == operators.FnPmt {
+		pv, fv := args[2], args[3]
+		if rate == 0 {
+			return -(pv + fv) / periods, nil
+		}
+		growth := math.Pow(1+rate, periods)
+		denominator := (1 + rate*typ) * (growth - 1)
+		if denominator == 0 {
+			return 0, ErrDivisionByZero
+		}
+		return -rate * (pv*growth + fv) / denominator, nil
+	}
+
+	payment, pv := args[2], args[3]
+	if rate == 0 {
+		return -(pv + payment*periods), nil
+	}
+	growth := math.Pow(1+rate, periods)
+	return -(pv*growth + payment*(1+rate*typ)*(growth-1)/rate), nil
+}
+
+// ratAnnuity вычисляет платеж или будущую стоимость аннуитета точно (см. annuity).
+// Количество периодов должно быть целым, не больше 10000 по модулю.
+func ratAnnuity(operation string, args []*big.Rat) (*big.Rat, error) {
+	rate, typ := args[0], args[4]
+	periods, err := exactExponent(args[1])
+	if err != nil {
+		return nil, err
+	}
+	if periods == 0 {
+		return nil, ErrPeriods
+	}
+	if typ.Sign() != 0 && typ.Cmp(big.NewRat(1, 1)) != 0 {
+		return nil, ErrPaymentType
+	}
+
+	one := big.NewRat(1, 1)
+	n := new(big.Rat).SetInt64(int64(periods))
+	if rate.Sign() == 0 {
+		if operation == operators.FnPmt {
+			sum := new(big.Rat).Add(args[2], args[3])
+			return sum.Neg(sum.Quo(sum, n)), nil
+		}
+		total := new(big.Rat).Mul(args[2], n)
+		return total.Neg(total.Add(total, args[3])), nil
+	}
+
+	growth, err := ratPow(new(big.Rat).Add(one, rate), periods)
+	if err != nil {
+		return nil, err
+	}
+	accumulated := new(big.Rat).Sub(growth, one)                 // (1+r)^n - 1
+	timing := new(big.Rat).Add(one, new(big.Rat).Mul(rate, typ)) // 1 + r*тип
+	if operation == operators.FnPmt {
+		denominator := new(big.Rat).Mul(timing, accumulated)
+		if denominator.Sign() == 0 {
+			return nil, ErrDivisionByZero
+		}
+		pv, fv := args[2], args[3]
+		numerator := new(big.Rat).Add(new(big.Rat).Mul(pv, growth), fv)
+		numerator.Mul(numerator, rate)
+		return numerator.Neg(numerator.Quo(numerator, denominator)), nil
+	}
+
+	payment, pv := args[2], args[3]
+	annuityValue := new(big.Rat).Mul(payment, timing)
+	annuityValue.Mul(annuityValue, accumulated).Quo(annuityValue, rate)
+	result := new(big.Rat).Mul(pv, growth)
+	return result.Neg(result.Add(result, annuityValue)), nil
+}
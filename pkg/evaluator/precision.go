@@ -0,0 +1,730 @@
+package evaluator
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"math/bits"
+	"strconv"
+	"strings"
+
+	"github.com/OinkiePie/calc_2/pkg/operators"
+)
+
+// Режимы точности вычислений.
+const (
+	PrecisionFloat64  = "float64"  // числа float64 (по умолчанию)
+	PrecisionDecimal  = "decimal"  // десятичные числа, результат каждой операции округляется до DecimalDigits значащих цифр
+	PrecisionRational = "rational" // точные рациональные дроби "p/q"
+	PrecisionBigFloat = "bigfloat" // двоичные числа с плавающей точкой, "bigfloat:N" - N бит мантиссы
+	PrecisionComplex  = "complex"  // комплексные числа complex128
+)
+
+const (
+	// DecimalDigits - количество значащих цифр результатов в режиме decimal (как у decimal128).
+	DecimalDigits = 34
+	// MaxBigFloatBits - наибольшая точность мантиссы в режиме bigfloat.
+	MaxBigFloatBits = 1 << 16
+	// maxExactExponent - наибольший модуль целого показателя степени, аргумента факториала и количества
+	// знаков округления в точных режимах.
+	maxExactExponent = 10000
+	// maxExactBits - наибольший размер в битах числителя или знаменателя результата степени и факториала
+	// в точных режимах. Большое основание в допустимой степени дает число в сотни мегабайт, поэтому одного
+	// ограничения показателя недостаточно, чтобы одна задача не могла исчерпать память и время агента.
+	maxExactBits = MaxBigFloatBits
+	// sqrtBits - точность промежуточного вычисления корня в режиме decimal, с запасом больше DecimalDigits.
+	sqrtBits = 256
+)
+
+var (
+	ErrPrecision       = errors.New("invalid precision")
+	ErrExactNumber     = errors.New("invalid exact number")
+	ErrNotExact        = errors.New("operation is not supported in exact precision")
+	ErrIntegerExponent = errors.New("exponent must be an integer in exact precision")
+	ErrExponentRange   = errors.New("exponent is too large in exact precision")
+	ErrIrrationalSqrt  = errors.New("square root is not rational")
+)
+
+// inexact - функции, значения которых в общем случае иррациональны и не вычисляются в точных режимах.
+var inexact = map[string]bool{
+	operators.FnSin: true,
+	operators.FnCos: true,
+	operators.FnTan: true,
+	operators.FnLog: true,
+	operators.FnLn:  true,
+	operators.FnExp: true,
+}
+
+// Precision описывает режим точности вычислений выражения.
+type Precision struct {
+	Mode string // Режим: PrecisionFloat64, PrecisionDecimal, PrecisionRational или PrecisionBigFloat.
+	Bits uint   // Точность мантиссы в битах для PrecisionBigFloat.
+}
+
+// ParsePrecision разбирает запись режима точности: "float64", "decimal", "rational", "bigfloat:N" или "complex".
+// Пустая строка означает float64.
+//
+// Args:
+//
+//	s: string - Запись режима точности.
+//
+// Returns:
+//
+//	Precision - Режим точности.
+//	error - ErrPrecision, если запись не распознана или точность bigfloat вне диапазона 1..MaxBigFloatBits.
+func ParsePrecision(s string) (Precision, error) {
+	switch s {
+	case "", PrecisionFloat64:
+		return Precision{Mode: PrecisionFloat64}, nil
+	case PrecisionDecimal, PrecisionRational, PrecisionComplex:
+		return Precision{Mode: s}, nil
+	}
+
+	bitsStr, ok := strings.CutPrefix(s, PrecisionBigFloat+":")
+	if !ok {
+		return Precision{}, fmt.Errorf("%w: %q", ErrPrecision, s)
+	}
+	bits, err := strconv.ParseUint(bitsStr, 10, 32)
+	if err != nil || bits == 0 || bits > MaxBigFloatBits {
+		return Precision{}, fmt.Errorf("%w: %q", ErrPrecision, s)
+	}
+	return Precision{Mode: PrecisionBigFloat, Bits: uint(bits)}, nil
+}
+
+// String возвращает запись режима точности, обратную ParsePrecision.
+func (p Precision) String() string {
+	if p.Mode == PrecisionBigFloat {
+		return PrecisionBigFloat + ":" + strconv.FormatUint(uint64(p.Bits), 10)
+	}
+	if p.Mode == "" {
+		return PrecisionFloat64
+	}
+	return p.Mode
+}
+
+// IsExact проверяет, используются ли в режиме числа в строковой записи вместо float64.
+func (p Precision) IsExact() bool {
+	return p.Mode != "" && p.Mode != PrecisionFloat64 && p.Mode != PrecisionComplex
+}
+
+// IsComplex проверяет, вычисляется ли выражение в комплексных числах.
+func (p Precision) IsComplex() bool {
+	return p.Mode == PrecisionComplex
+}
+
+// Supports проверяет, может ли операция быть вычислена в режиме точности.
+// В точных режимах недоступны функции с иррациональными значениями: sin, cos, tan, log, ln, exp,
+// в комплексном режиме - операции, требующие упорядоченности или целочисленности (см. SupportsComplex).
+//
+// Args:
+//
+//	operation: string - Операция.
+//	p: Precision - Режим точности.
+//
+// Returns:
+//
+//	bool - true, если операция доступна.
+func Supports(operation string, p Precision) bool {
+	if p.IsComplex() {
+		return SupportsComplex(operation)
+	}
+	return !p.IsExact() || !inexact[operation]
+}
+
+// FormatFloat возвращает кратчайшую десятичную запись числа float64, из которой оно восстанавливается точно.
+// Используется для перевода переменных и констант в строковую запись точных режимов.
+func FormatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+// parseExact разбирает число в строковой записи: десятичное ("0.1", "1e-9") или дробь ("1/3").
+func parseExact(text string) (*big.Rat, error) {
+	r, ok := new(big.Rat).SetString(text)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrExactNumber, text)
+	}
+	return r, nil
+}
+
+// Approximate возвращает ближайшее к числу в строковой записи значение float64.
+// Для чисел вне диапазона float64 возвращается бесконечность соответствующего знака.
+//
+// Args:
+//
+//	text: string - Число в строковой записи точного режима.
+//
+// Returns:
+//
+//	float64 - Приближенное значение.
+//	error - ErrExactNumber, если запись не является числом.
+func Approximate(text string) (float64, error) {
+	if !strings.Contains(text, "/") {
+		// Десятичная запись может иметь большой показатель, разбор через big.Float не строит огромных целых
+		f, _, err := big.ParseFloat(text, 10, 64, big.ToNearestEven)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %q", ErrExactNumber, text)
+		}
+		value, _ := f.Float64()
+		return value, nil
+	}
+	r, err := parseExact(text)
+	if err != nil {
+		return 0, err
+	}
+	value, _ := r.Float64()
+	return value, nil
+}
+
+// IsTrue проверяет истинность числа в строковой записи: истинно любое ненулевое число.
+//
+// Args:
+//
+//	text: string - Число в строковой записи точного режима.
+//
+// Returns:
+//
+//	bool - true, если число не равно нулю или запись не является числом.
+func IsTrue(text string) bool {
+	value, err := Approximate(text)
+	if err != nil || value != 0 {
+		return true
+	}
+	// Очень малые по модулю числа приближаются нулем, проверяем точно
+	r, err := parseExact(text)
+	return err != nil || r.Sign() != 0
+}
+
+// EvaluateExact выполняет операцию над числами в строковой записи в точном режиме с помощью math/big.
+// В режиме rational результат - несократимая дробь "p/q" (или целое "p"), в режиме decimal - десятичная запись,
+// округленная до DecimalDigits значащих цифр, в режиме bigfloat - кратчайшая десятичная запись числа заданной точности.
+// Показатель степени должен быть целым. Функции sin, cos, tan, log, ln, exp недоступны (см. Supports).
+//
+// Args:
+//
+//	operation: string - Операция.
+//	p: Precision - Точный режим.
+//	args: ...string - Аргументы в строковой записи. Операция использует первые ArgCount(operation) аргументов.
+//
+// Returns:
+//
+//	string - Результат в строковой записи режима.
+//	error - Ошибка, если операция не может быть выполнена.
+func EvaluateExact(operation string, p Precision, args ...string) (string, error) {
+	if !Supports(operation, p) {
+		return "", fmt.Errorf("%w: %s", ErrNotExact, operation)
+	}
+	count := ArgCount(operation)
+	if count == operators.Variadic {
+		count = max(len(args), 1)
+	}
+	if len(args) < count {
+		return "", ErrArgCount
+	}
+	args = args[:count]
+
+	if p.Mode == PrecisionBigFloat {
+		values := make([]*big.Float, len(args))
+		for i, arg := range args {
+			value, _, err := big.ParseFloat(arg, 10, p.Bits, big.ToNearestEven)
+			if err != nil {
+				return "", fmt.Errorf("%w: %q", ErrExactNumber, arg)
+			}
+			values[i] = value
+		}
+		result, err := evaluateFloat(operation, p.Bits, values)
+		if err != nil {
+			return "", err
+		}
+		return result.Text('g', -1), nil
+	}
+
+	values := make([]*big.Rat, len(args))
+	for i, arg := range args {
+		value, err := parseExact(arg)
+		if err != nil {
+			return "", err
+		}
+		values[i] = value
+	}
+	result, err := evaluateRat(operation, p, values)
+	if err != nil {
+		return "", err
+	}
+	return formatRat(result, p), nil
+}
+
+// FormatExact приводит число в строковой записи к записи результата точного режима (см. EvaluateExact):
+// "0.1" в режиме rational - "1/10".
+//
+// Args:
+//
+//	text: string - Число в строковой записи.
+//	p: Precision - Точный режим.
+//
+// Returns:
+//
+//	string - Число в записи режима.
+//	error - ErrExactNumber, если запись не является числом.
+func FormatExact(text string, p Precision) (string, error) {
+	if p.Mode == PrecisionBigFloat {
+		value, _, err := big.ParseFloat(text, 10, p.Bits, big.ToNearestEven)
+		if err != nil {
+			return "", fmt.Errorf("%w: %q", ErrExactNumber, text)
+		}
+		return value.Text('g', -1), nil
+	}
+	value, err := parseExact(text)
+	if err != nil {
+		return "", err
+	}
+	return formatRat(value, p), nil
+}
+
+// formatRat возвращает запись дроби в режиме rational или decimal.
+func formatRat(x *big.Rat, p Precision) string {
+	if p.Mode == PrecisionDecimal {
+		return formatDecimal(roundSignificant(x, DecimalDigits))
+	}
+	return x.RatString()
+}
+
+// ratBool возвращает логическое значение в виде дроби: 1 или 0.
+func ratBool(b bool) *big.Rat {
+	return new(big.Rat).SetFloat64(boolValue(b))
+}
+
+// ratFloor возвращает наибольшее целое, не превосходящее x.
+func ratFloor(x *big.Rat) *big.Rat {
+	// Знаменатель дроби всегда положителен, поэтому евклидово деление совпадает с округлением вниз
+	return new(big.Rat).SetInt(new(big.Int).Div(x.Num(), x.Denom()))
+}
+
+// exactExponent проверяет показатель степени или аргумент факториала и возвращает его как int.
+func exactExponent(x *big.Rat) (int, error) {
+	if !x.IsInt() {
+		return 0, ErrIntegerExponent
+	}
+	n := x.Num()
+	if n.CmpAbs(big.NewInt(maxExactExponent)) > 0 {
+		return 0, ErrExponentRange
+	}
+	return int(n.Int64()), nil
+}
+
+// ratSqrt возвращает точный корень из дроби, если числитель и знаменатель - полные квадраты.
+func ratSqrt(x *big.Rat) (*big.Rat, bool) {
+	num := new(big.Int).Sqrt(x.Num())
+	den := new(big.Int).Sqrt(x.Denom())
+	if new(big.Int).Mul(num, num).Cmp(x.Num()) != 0 || new(big.Int).Mul(den, den).Cmp(x.Denom()) != 0 {
+		return nil, false
+	}
+	return new(big.Rat).SetFrac(num, den), true
+}
+
+// evaluateRat выполняет операцию над дробями для режимов rational и decimal.
+func evaluateRat(operation string, p Precision, args []*big.Rat) (*big.Rat, error) {
+	if operation == operators.FnMedian {
+		return ratMedian(args), nil
+	}
+	arg1 := args[0]
+
+	switch operation {
+	case operators.OpUnaryMinus:
+		return new(big.Rat).Neg(arg1), nil
+
+	case operators.OpNot:
+		return ratBool(arg1.Sign() == 0), nil
+
+	case operators.OpFactorial:
+		n, err := exactExponent(arg1)
+		if err != nil {
+			return nil, err
+		}
+		if n < 0 {
+			return nil, ErrFactorial
+		}
+		if err := checkFactorialBits(int64(n)); err != nil {
+			return nil, err
+		}
+		return new(big.Rat).SetInt(new(big.Int).MulRange(1, int64(n))), nil
+
+	case operators.FnSqrt:
+		if arg1.Sign() < 0 {
+			return nil, ErrNegativeSqrt
+		}
+		if root, ok := ratSqrt(arg1); ok {
+			return root, nil
+		}
+		if p.Mode == PrecisionRational {
+			return nil, ErrIrrationalSqrt
+		}
+		// В режиме decimal корень вычисляется с запасом точности и затем округляется до DecimalDigits цифр
+		value := new(big.Float).SetPrec(sqrtBits).SetRat(arg1)
+		root, _ := value.Sqrt(value).Rat(nil)
+		return root, nil
+
+	case operators.FnAbs:
+		return new(big.Rat).Abs(arg1), nil
+
+	case operators.OpPercent:
+		return new(big.Rat).Quo(arg1, big.NewRat(100, 1)), nil
+
+	case operators.FnFloor:
+		return ratFloor(arg1), nil
+
+	case operators.FnCeil:
+		floor := ratFloor(new(big.Rat).Neg(arg1))
+		return floor.Neg(floor), nil
+
+	case operators.FnPmt, operators.FnFv:
+		return ratAnnuity(operation, args)
+	}
+
+	arg2 := args[1]
+
+	switch operation {
+	case operators.OpAdd:
+		return new(big.Rat).Add(arg1, arg2), nil
+
+	case operators.OpSubtract:
+		return new(big.Rat).Sub(arg1, arg2), nil
+
+	case operators.OpMultiply:
+		return new(big.Rat).Mul(arg1, arg2), nil
+
+	case operators.OpDivide:
+		if arg2.Sign() == 0 {
+			return nil, ErrDivisionByZero
+		}
+		return new(big.Rat).Quo(arg1, arg2), nil
+
+	case operators.OpFloorDivide:
+		if arg2.Sign() == 0 {
+			return nil, ErrDivisionByZero
+		}
+		return ratFloor(new(big.Rat).Quo(arg1, arg2)), nil
+
+	case operators.OpModulo:
+		if arg2.Sign() == 0 {
+			return nil, ErrModuloByZero
+		}
+		// a - b*floor(a/b): остаток имеет знак делителя, как и в режиме float64
+		quotient := ratFloor(new(big.Rat).Quo(arg1, arg2))
+		return new(big.Rat).Sub(arg1, new(big.Rat).Mul(arg2, quotient)), nil
+
+	case operators.OpPower:
+		n, err := exactExponent(arg2)
+		if err != nil {
+			return nil, err
+		}
+		return ratPow(arg1, n)
+
+	case operators.FnMax:
+		if arg1.Cmp(arg2) >= 0 {
+			return arg1, nil
+		}
+		return arg2, nil
+
+	case operators.FnMin:
+		if arg1.Cmp(arg2) <= 0 {
+			return arg1, nil
+		}
+		return arg2, nil
+
+	case operators.OpLess:
+		return ratBool(arg1.Cmp(arg2) < 0), nil
+
+	case operators.OpLessEqual:
+		return ratBool(arg1.Cmp(arg2) <= 0), nil
+
+	case operators.OpEqual:
+		return ratBool(arg1.Cmp(arg2) == 0), nil
+
+	case operators.OpNotEqual:
+		return ratBool(arg1.Cmp(arg2) != 0), nil
+
+	case operators.OpGreater:
+		return ratBool(arg1.Cmp(arg2) > 0), nil
+
+	case operators.OpGreaterEqual:
+		return ratBool(arg1.Cmp(arg2) >= 0), nil
+
+	case operators.OpAnd:
+		return ratBool(arg1.Sign() != 0 && arg2.Sign() != 0), nil
+
+	case operators.OpOr:
+		return ratBool(arg1.Sign() != 0 || arg2.Sign() != 0), nil
+
+	case operators.OpAddPercent, operators.OpSubtractPercent:
+		percent := new(big.Rat).Mul(arg1, arg2)
+		percent.Quo(percent, big.NewRat(100, 1))
+		if operation == operators.OpSubtractPercent {
+			percent.Neg(percent)
+		}
+		return percent.Add(arg1, percent), nil
+
+	case operators.FnRound:
+		if !arg2.IsInt() {
+			return nil, ErrRoundDigits
+		}
+		if arg2.Num().CmpAbs(big.NewInt(maxExactExponent)) > 0 {
+			return nil, ErrExponentRange
+		}
+		return ratRound(arg1, int(arg2.Num().Int64())), nil
+	}
+
+	return nil, fmt.Errorf("unknown operator: %s", operation)
+}
+
+// ratPow возвращает x в целой степени n.
+func ratPow(x *big.Rat, n int) (*big.Rat, error) {
+	if x.Sign() == 0 && n < 0 {
+		return nil, ErrDivisionByZero
+	}
+	// Размер степени в битах не больше размера основания, умноженного на показатель
+	abs := max(n, -n)
+	if x.Num().BitLen()*abs > maxExactBits || x.Denom().BitLen()*abs > maxExactBits {
+		return nil, ErrExponentRange
+	}
+	exponent := big.NewInt(int64(abs))
+	num := new(big.Int).Exp(x.Num(), exponent, nil)
+	den := new(big.Int).Exp(x.Denom(), exponent, nil)
+	if n < 0 {
+		num, den = den, num
+	}
+	// SetFrac нормализует знак: при отрицательном основании знаменатель может стать отрицательным
+	return new(big.Rat).SetFrac(num, den), nil
+}
+
+// checkFactorialBits проверяет, что n! помещается в maxExactBits бит.
+func checkFactorialBits(n int64) error {
+	// n! < n^n, поэтому его размер в битах не больше n * bitlen(n)
+	if n*int64(bits.Len64(uint64(n))) > maxExactBits {
+		return ErrExponentRange
+	}
+	return nil
+}
+
+// roundSignificant округляет дробь до digits значащих десятичных цифр (половина - к четному).
+func roundSignificant(x *big.Rat, digits int) *big.Rat {
+	if x.Sign() == 0 {
+		return x
+	}
+	abs := new(big.Rat).Abs(x)
+
+	// Десятичный порядок числа: 10^exponent <= |x| < 10^(exponent+1)
+	exponent := len(abs.Num().String()) - len(abs.Denom().String())
+	if abs.Cmp(pow10Rat(exponent)) < 0 {
+		exponent--
+	}
+
+	// Сдвигаем запятую так, чтобы в целой части осталось digits цифр, и округляем
+	scale := pow10Rat(digits - 1 - exponent)
+	scaled := new(big.Rat).Mul(x, scale)
+	quotient, remainder := new(big.Int).QuoRem(scaled.Num(), scaled.Denom(), new(big.Int))
+	doubled := new(big.Int).Mul(remainder.Abs(remainder), big.NewInt(2))
+	if c := doubled.Cmp(scaled.Denom()); c > 0 || (c == 0 && quotient.Bit(0) == 1) {
+		quotient.Add(quotient, big.NewInt(int64(scaled.Sign())))
+	}
+	return new(big.Rat).Quo(new(big.Rat).SetInt(quotient), scale)
+}
+
+// pow10Rat возвращает 10^n для любого целого n.
+func pow10Rat(n int) *big.Rat {
+	power := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(max(n, -n))), nil)
+	if n < 0 {
+		return new(big.Rat).SetFrac(big.NewInt(1), power)
+	}
+	return new(big.Rat).SetInt(power)
+}
+
+// formatDecimal возвращает десятичную запись дроби с конечной десятичной записью без лишних нулей.
+func formatDecimal(x *big.Rat) string {
+	// Знаменатель округленного числа - делитель степени десяти, количество цифр после запятой не больше её показателя
+	fraction := len(x.Denom().String()) + DecimalDigits
+	text := x.FloatString(fraction)
+	if strings.Contains(text, ".") {
+		text = strings.TrimRight(strings.TrimRight(text, "0"), ".")
+	}
+	return text
+}
+
+// floatBool возвращает логическое значение в виде числа заданной точности: 1 или 0.
+func floatBool(b bool, bits uint) *big.Float {
+	return new(big.Float).SetPrec(bits).SetFloat64(boolValue(b))
+}
+
+// floatFloor возвращает наибольшее целое, не превосходящее x.
+func floatFloor(x *big.Float, bits uint) *big.Float {
+	i, accuracy := x.Int(nil) // Отбрасывание дробной части
+	if accuracy == big.Above {
+		i.Sub(i, big.NewInt(1)) // Для отрицательных нецелых чисел округляем вниз
+	}
+	return new(big.Float).SetPrec(bits).SetInt(i)
+}
+
+// evaluateFloat выполняет операцию над числами с плавающей точкой заданной точности для режима bigfloat.
+func evaluateFloat(operation string, bits uint, args []*big.Float) (*big.Float, error) {
+	result := new(big.Float).SetPrec(bits)
+	arg1 := args[0]
+
+	// Округление до знаков, медиана и финансовые функции вычисляются точно над дробями
+	// и затем округляются до точности режима
+	if operation == operators.FnRound || operation == operators.FnMedian || operation == operators.FnPmt || operation == operators.FnFv {
+		values := make([]*big.Rat, len(args))
+		for i, arg := range args {
+			values[i], _ = arg.Rat(nil)
+		}
+		value, err := evaluateRat(operation, Precision{Mode: PrecisionRational}, values)
+		if err != nil {
+			return nil, err
+		}
+		return result.SetRat(value), nil
+	}
+
+	switch operation {
+	case operators.OpUnaryMinus:
+		return result.Neg(arg1), nil
+
+	case operators.OpNot:
+		return floatBool(arg1.Sign() == 0, bits), nil
+
+	case operators.OpFactorial:
+		if !arg1.IsInt() {
+			return nil, ErrIntegerExponent
+		}
+		if arg1.Sign() < 0 {
+			return nil, ErrFactorial
+		}
+		n, _ := arg1.Int64()
+		if n > maxExactExponent {
+			return nil, ErrExponentRange
+		}
+		if err := checkFactorialBits(n); err != nil {
+			return nil, err
+		}
+		return result.SetInt(new(big.Int).MulRange(1, n)), nil
+
+	case operators.FnSqrt:
+		if arg1.Sign() < 0 {
+			return nil, ErrNegativeSqrt
+		}
+		return result.Sqrt(arg1), nil
+
+	case operators.FnAbs:
+		return result.Abs(arg1), nil
+
+	case operators.OpPercent:
+		return result.Quo(arg1, big.NewFloat(100)), nil
+
+	case operators.FnFloor:
+		return floatFloor(arg1, bits), nil
+
+	case operators.FnCeil:
+		floor := floatFloor(new(big.Float).Neg(arg1), bits)
+		return floor.Neg(floor), nil
+	}
+
+	arg2 := args[1]
+
+	switch operation {
+	case operators.OpAdd:
+		return result.Add(arg1, arg2), nil
+
+	case operators.OpSubtract:
+		return result.Sub(arg1, arg2), nil
+
+	case operators.OpMultiply:
+		return result.Mul(arg1, arg2), nil
+
+	case operators.OpDivide:
+		if arg2.Sign() == 0 {
+			return nil, ErrDivisionByZero
+		}
+		return result.Quo(arg1, arg2), nil
+
+	case operators.OpFloorDivide:
+		if arg2.Sign() == 0 {
+			return nil, ErrDivisionByZero
+		}
+		return floatFloor(result.Quo(arg1, arg2), bits), nil
+
+	case operators.OpModulo:
+		if arg2.Sign() == 0 {
+			return nil, ErrModuloByZero
+		}
+		quotient := floatFloor(new(big.Float).SetPrec(bits).Quo(arg1, arg2), bits)
+		return result.Sub(arg1, quotient.Mul(quotient, arg2)), nil
+
+	case operators.OpPower:
+		if !arg2.IsInt() {
+			return nil, ErrIntegerExponent
+		}
+		n, _ := arg2.Int64()
+		if n > maxExactExponent || n < -maxExactExponent {
+			return nil, ErrExponentRange
+		}
+		if arg1.Sign() == 0 && n < 0 {
+			return nil, ErrDivisionByZero
+		}
+		// Возведение в степень последовательным возведением в квадрат
+		result.SetInt64(1)
+		base := new(big.Float).SetPrec(bits).Set(arg1)
+		for e := max(n, -n); e > 0; e >>= 1 {
+			if e&1 == 1 {
+				result.Mul(result, base)
+			}
+			base.Mul(base, base)
+		}
+		if n < 0 {
+			result.Quo(new(big.Float).SetPrec(bits).SetInt64(1), result)
+		}
+		return result, nil
+
+	case operators.FnMax:
+		if arg1.Cmp(arg2) >= 0 {
+			return arg1, nil
+		}
+		return arg2, nil
+
+	case operators.FnMin:
+		if arg1.Cmp(arg2) <= 0 {
+			return arg1, nil
+		}
+		return arg2, nil
+
+	case operators.OpLess:
+		return floatBool(arg1.Cmp(arg2) < 0, bits), nil
+
+	case operators.OpLessEqual:
+		return floatBool(arg1.Cmp(arg2) <= 0, bits), nil
+
+	case operators.OpEqual:
+		return floatBool(arg1.Cmp(arg2) == 0, bits), nil
+
+	case operators.OpNotEqual:
+		return floatBool(arg1.Cmp(arg2) != 0, bits), nil
+
+	case operators.OpGreater:
+		return floatBool(arg1.Cmp(arg2) > 0, bits), nil
+
+	case operators.OpGreaterEqual:
+		return floatBool(arg1.Cmp(arg2) >= 0, bits), nil
+
+	case operators.OpAnd:
+		return floatBool(arg1.Sign() != 0 && arg2.Sign() != 0, bits), nil
+
+	case operators.OpOr:
+		return floatBool(arg1.Sign() != 0 || arg2.Sign() != 0, bits), nil
+
+	case operators.OpAddPercent, operators.OpSubtractPercent:
+		percent := new(big.Float).SetPrec(bits).Mul(arg1, arg2)
+		percent.Quo(percent, big.NewFloat(100))
+		if operation == operators.OpSubtractPercent {
+			return result.Sub(arg1, percent), nil
+		}
+		return result.Add(arg1, percent), nil
+	}
+
+	return nil, fmt.Errorf("unknown operator: %s", operation)
+}
@@ -1,10 +1,12 @@
 package models
 
+import "time"
+
 // Expression представляет структуру арифметического выражения.
 type Expression struct {
 	// ID - Уникальный идентификатор выражения.
 	ID string
-	// Status - Статус выражения ("pending", "processing", "completed", "error").
+	// Status - Статус выражения ("pending", "processing", "completed", "error", "cancelled").
 	Status string
 	// Result - Указатель на результат вычисления выражения. Может быть nil, если вычисление ещё не завершено или ошибочно.
 	Result *float64
@@ -12,8 +14,36 @@ type Expression struct {
 	Tasks []Task
 	// ExpressionString - Исходное выражение в виде строки.
 	ExpressionString string
+	// Variables - Значения переменных, подставленные в выражение.
+	Variables map[string]float64
 	// Error - Описание ошибки если выражение невозможно выполнить.
 	Error string
+	// Stats - Статистика оптимизации выражения при разбиении на задачи.
+	Stats ExpressionStats
+	// Precision - Точность вычислений ("float64", "decimal", "rational" или "bigfloat:N").
+	Precision string
+	// ExactResult - Точный результат в строковой записи, если точность отличается от float64. Может быть nil.
+	ExactResult *string
+	// ComplexResult - Комплексный результат, если выражение вычисляется в комплексных числах. Может быть nil.
+	ComplexResult *Complex
+	// Unit - Единица измерения результата, если в выражении есть единицы измерения.
+	Unit string
+	// Functions - Версии пользовательских функций, подставленных в выражение, по именам.
+	Functions map[string]int
+	// CreatedAt - Время добавления выражения. Список выражений упорядочен по нему.
+	CreatedAt time.Time
+}
+
+// ExpressionStats представляет статистику оптимизации выражения при разбиении на задачи.
+type ExpressionStats struct {
+	// TasksBefore - Количество задач без свертки констант и устранения повторных подвыражений.
+	TasksBefore int `json:"tasks_before"`
+	// TasksAfter - Количество задач, отправляемых агентам.
+	TasksAfter int `json:"tasks_after"`
+	// Folded - Количество операций, вычисленных оркестратором при свертке констант.
+	Folded int `json:"folded"`
+	// Deduplicated - Количество повторных подвыражений, использующих результат уже созданной задачи.
+	Deduplicated int `json:"deduplicated"`
 }
 
 // ExpressionResponse представляет структуру для отправки информации о выражении в HTTP-ответе.
@@ -26,6 +56,20 @@ type ExpressionResponse struct {
 	Result *float64 `json:"result,omitempty"` //omitempty - если result nil, то не выводить его
 	// Error - Описание ошибки если выражение невозможно выполнить. Если nil, то поле не включается в JSON-ответ (omitempty).
 	Error string `json:"error,omitempty"` //omitempty - если result nil, то не выводить его
+	// Stats - Статистика оптимизации. Заполняется только при запросе одного выражения.
+	Stats *ExpressionStats `json:"stats,omitempty"`
+	// ExactResult - Точный результат в строковой записи, если точность отличается от float64 (например, "1/3").
+	// Result в этом случае содержит ближайшее значение float64 и отсутствует, если оно вне диапазона float64.
+	ExactResult *string `json:"exact_result,omitempty"`
+	// ComplexResult - Комплексный результат, если точность "complex". Result в этом случае
+	// содержит действительную часть и отсутствует, если мнимая часть не равна нулю.
+	ComplexResult *Complex `json:"complex_result,omitempty"`
+	// Unit - Единица измерения результата: единица из перевода "to" или размерность в основных единицах СИ
+	// (например, "kg*m/s^2"). Отсутствует, если в выражении нет единиц измерения или результат безразмерный.
+	Unit string `json:"unit,omitempty"`
+	// Functions - Версии пользовательских функций, использованных в выражении, по именам.
+	// Отсутствует, если выражение не вызывает пользовательские функции.
+	Functions map[string]int `json:"functions,omitempty"`
 }
 
 // ExpressionAdd представляет структуру для получения математического выражения из HTTP-запроса.
@@ -33,4 +77,8 @@ type ExpressionResponse struct {
 type ExpressionAdd struct {
 	// Expression - Математическое выражение в виде строки.
 	Expression string `json:"expression"`
+	// Variables - Значения переменных, используемых в выражении. Может отсутствовать.
+	Variables map[string]float64 `json:"variables,omitempty"`
+	// Precision - Точность вычислений: "float64" (по умолчанию), "decimal", "rational", "bigfloat:N" или "complex". Может отсутствовать.
+	Precision string `json:"precision,omitempty"`
 }
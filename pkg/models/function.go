@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// Function представляет версию пользовательской функции в HTTP-ответе.
+type Function struct {
+	// Name - Имя функции.
+	Name string `json:"name"`
+	// Params - Имена параметров в порядке записи.
+	Params []string `json:"params"`
+	// Definition - Определение функции, например "f(x, y) = x^2 + y^2".
+	Definition string `json:"definition"`
+	// Version - Номер версии функции, начиная с 1.
+	Version int `json:"version"`
+	// CreatedAt - Время регистрации версии.
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// FunctionAdd представляет структуру для получения определения пользовательской функции из HTTP-запроса.
+type FunctionAdd struct {
+	// Definition - Определение функции: имя, параметры в скобках и тело после знака "=", например "f(x, y) = x^2 + y^2".
+	Definition string `json:"definition"`
+}
@@ -1,23 +1,77 @@
 package models
 
+import "time"
+
 // Task представляет структуру для части арифметического выражения, которую нужно вычислить.
 type Task struct {
 	// ID - Уникальный идентификатор задачи.
 	ID string
 	// Args - Срез указателей на аргументы задачи. Может быть nil, если аргумент является зависит от другой задачи.
 	Args []*float64
-	// Operation - Операция, которую необходимо выполнить (+, -, *, /, ^, -u).
+	// Operation - Операция, которую необходимо выполнить (+, -, *, /, ^, u-, или имя функции).
 	Operation string
 	// Operation_time - Время, необходимое для выполнения операции.
 	Operation_time int
 	// Dependencies - Список ID задач, результаты которых необходимы для выполнения данной задачи.
 	Dependencies []string
-	// Status - Статус задачи ("pending", "processing", "completed", "error").
+	// Status - Статус задачи ("pending", "processing", "completed", "error", "skipped", "cancelled").
+	// Задача получает статус "skipped", если находится в невыбранной ветви условного выражения,
+	// и "cancelled", если выражение отменено до её выполнения.
 	Status string
 	// Result - Указатель на результат выполнения задачи. Может быть nil, если задача ещё не выполнена.
 	Result *float64
 	// Expression - ID выражения, к которому принадлежит данная задача.
 	Expression string
+	// Guards - Условия, при которых задача выполняется, если она находится в ветви условного выражения
+	// (от внешнего условия к внутреннему). Пусто для задач, выполняемых всегда.
+	Guards []Guard
+	// Precision - Точность вычислений выражения. Пустая строка - float64.
+	Precision string
+	// ExactArgs - Аргументы в точной строковой записи, если точность отличается от float64. Как и в Args, nil - зависимость.
+	ExactArgs []*string
+	// ExactResult - Точный результат задачи в строковой записи, если точность отличается от float64.
+	ExactResult *string
+	// ComplexArgs - Комплексные аргументы, если выражение вычисляется в комплексных числах. Как и в Args, nil - зависимость.
+	ComplexArgs []*Complex
+	// ComplexResult - Комплексный результат задачи, если выражение вычисляется в комплексных числах.
+	ComplexResult *Complex
+	// Units - Размерности аргументов в основных единицах СИ (например, "m/s", пустая строка - безразмерный аргумент),
+	// если в выражении есть единицы измерения. Значения аргументов при этом заданы в основных единицах СИ.
+	Units []string
+	// Reference - ID выражения, результат которого становится результатом задачи ссылки (operators.OpReference).
+	Reference string
+	// Lease - Токен аренды, выданный агенту вместе с задачей. Результат принимается только с этим токеном.
+	// Пусто, если задача не выдана агенту.
+	Lease string
+	// LeaseDeadline - Срок аренды задачи. Если агент не вернул результат до этого момента,
+	// задача возвращается в очередь и выдается с новым токеном.
+	LeaseDeadline time.Time
+}
+
+// Guard представляет условие выполнения задачи из ветви условного выражения.
+type Guard struct {
+	// Condition - ID задачи, вычисляющей условие.
+	Condition string
+	// Branch - Ветвь, в которой находится задача: true - "то" (условие истинно), false - "иначе".
+	Branch bool
+}
+
+// Complex представляет комплексное число, в JSON - {"re": 3, "im": 4}.
+type Complex struct {
+	// Re - Действительная часть.
+	Re float64 `json:"re"`
+	// Im - Мнимая часть.
+	Im float64 `json:"im"`
+}
+
+// NewComplex создает Complex из значения complex128.
+func NewComplex(value complex128) Complex {
+	return Complex{Re: real(value), Im: imag(value)}
+}
+
+// Value возвращает значение complex128.
+func (c Complex) Value() complex128 {
+	return complex(c.Re, c.Im)
 }
 
 // TaskResponse представляет структуру для отправки информации о задаче в HTTP-ответе.
@@ -35,6 +89,16 @@ type TaskResponse struct {
 	Expression string `json:"expression"`
 	// Error - Указывает на невыполниасть задачи
 	Error string `json:"error,omitempty"`
+	// Precision - Точность вычислений. Если отсутствует - float64, иначе агент вычисляет задачу по ExactArgs.
+	Precision string `json:"precision,omitempty"`
+	// ExactArgs - Аргументы в точной строковой записи, например "1/3" или "0.1".
+	ExactArgs []*string `json:"exact_args,omitempty"`
+	// ComplexArgs - Комплексные аргументы, если точность "complex".
+	ComplexArgs []*Complex `json:"complex_args,omitempty"`
+	// Units - Размерности аргументов, если в выражении есть единицы измерения. Агент проверяет по ним допустимость операции.
+	Units []string `json:"units,omitempty"`
+	// Lease - Токен аренды задачи, агент возвращает его вместе с результатом.
+	Lease string `json:"lease"`
 }
 
 // TaskCompleted представляет структуру для получения информации о завершенной задаче из HTTP-запроса.
@@ -48,4 +112,21 @@ type TaskCompleted struct {
 	Result float64 `json:"result"`
 	// Error - Указывает на невыполниасть задачи
 	Error string `json:"error,omitempty"`
+	// ExactResult - Точный результат в строковой записи, если задача вычислялась с точностью, отличной от float64.
+	ExactResult string `json:"exact_result,omitempty"`
+	// ComplexResult - Комплексный результат, если задача вычислялась с точностью "complex".
+	ComplexResult *Complex `json:"complex_result,omitempty"`
+	// Lease - Токен аренды из TaskResponse. Результат с истекшей или чужой арендой отклоняется.
+	Lease string `json:"lease"`
+}
+
+// TaskHeartbeat представляет структуру запроса агента, проверяющего во время вычисления задачи,
+// что её выражение не отменено и аренда действительна.
+type TaskHeartbeat struct {
+	// Expression - ID выражения, к которому принадлежит задача.
+	Expression string `json:"expression"`
+	// ID - Уникальный идентификатор задачи.
+	ID string `json:"id"`
+	// Lease - Токен аренды из TaskResponse.
+	Lease string `json:"lease"`
 }
@@ -0,0 +1,41 @@
+package models
+
+// CellSet представляет структуру для получения формулы ячейки рабочей книги из HTTP-запроса.
+type CellSet struct {
+	// Formula - Формула ячейки: выражение, в котором можно использовать имена других ячеек книги, например "A1 * 2".
+	Formula string `json:"formula"`
+	// Precision - Точность вычислений: "float64" (по умолчанию), "decimal", "rational", "bigfloat:N" или "complex". Может отсутствовать.
+	Precision string `json:"precision,omitempty"`
+}
+
+// CellResponse представляет ячейку рабочей книги в HTTP-ответе.
+type CellResponse struct {
+	// Name - Имя ячейки.
+	Name string `json:"name"`
+	// Formula - Формула ячейки.
+	Formula string `json:"formula"`
+	// Dependencies - Имена ячеек, на которые ссылается формула.
+	Dependencies []string `json:"dependencies"`
+	// Expression - ID выражения, вычисляющего ячейку. Отсутствует, если формулу не удалось отправить на вычисление.
+	Expression string `json:"expression,omitempty"`
+	// Status - Статус вычисления ячейки ("pending", "processing", "completed", "error").
+	Status string `json:"status"`
+	// Result - Значение ячейки. Отсутствует, если вычисление не завершено или завершилось ошибкой.
+	Result *float64 `json:"result,omitempty"`
+	// ExactResult - Точное значение ячейки, если точность отличается от float64.
+	ExactResult *string `json:"exact_result,omitempty"`
+	// ComplexResult - Комплексное значение ячейки, если точность "complex".
+	ComplexResult *Complex `json:"complex_result,omitempty"`
+	// Unit - Единица измерения значения, если в формуле есть единицы измерения.
+	Unit string `json:"unit,omitempty"`
+	// Error - Описание ошибки вычисления ячейки.
+	Error string `json:"error,omitempty"`
+}
+
+// WorkbookResponse представляет рабочую книгу в HTTP-ответе.
+type WorkbookResponse struct {
+	// ID - Идентификатор книги.
+	ID string `json:"id"`
+	// Cells - Ячейки книги по алфавиту.
+	Cells []CellResponse `json:"cells"`
+}
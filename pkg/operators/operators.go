@@ -3,13 +3,141 @@ package operators
 // Математические операторы.
 // Используются оркестратором и агентом.
 const (
-	Point        = "."
-	OpAdd        = "+"
-	OpSubtract   = "-"
-	OpMultiply   = "*"
-	OpDivide     = "/"
-	OpPower      = "^"  // оператор возведения в степень
-	OpUnaryMinus = "u-" // оператор унарного минуса
-	ParenLeft    = "("
-	ParenRight   = ")"
+	Point          = "."
+	OpAdd          = "+"
+	OpSubtract     = "-"
+	OpMultiply     = "*"
+	OpDivide       = "/"
+	OpPower        = "^"  // оператор возведения в степень
+	OpModulo       = "%"  // остаток от деления (знак совпадает со знаком делителя)
+	OpFloorDivide  = "//" // целочисленное деление с округлением вниз
+	OpFactorial    = "!"  // постфиксный факториал, для нецелых чисел - через гамма-функцию
+	OpUnaryMinus   = "u-" // оператор унарного минуса
+	OpLess         = "<"
+	OpLessEqual    = "<="
+	OpEqual        = "=="
+	OpNotEqual     = "!="
+	OpGreater      = ">"
+	OpGreaterEqual = ">="
+	OpAnd          = "&&" // логическое И, оба операнда вычисляются всегда
+	OpOr           = "||" // логическое ИЛИ, оба операнда вычисляются всегда
+	OpNot          = "u!" // логическое НЕ, в выражении записывается префиксным "!"
+	OpQuestion     = "?"  // начало ветвей условного выражения "условие ? то : иначе"
+	OpColon        = ":"  // разделитель ветвей условного выражения
+	OpSelect       = "?:" // выбор ветви условного выражения, выполняется оркестратором, а не агентом
+	// Ссылка на результат другого выражения записывается "$ref(id)". Задача ссылки получает результат выражения id,
+	// когда оно будет вычислено, и выполняется оркестратором, а не агентом.
+	OpReference = "$ref"
+	// Процент записывается в выражении постфиксным "%": "15%" = 0.15. Справа от "+" и "-" процент берется
+	// от левого операнда, как на калькуляторе: "200 + 15%" = 230, "200 - 15%" = 170.
+	OpPercent         = "u%" // постфиксный процент: x% = x/100
+	OpAddPercent      = "+%" // прибавление процента: a + b% = a + a*b/100
+	OpSubtractPercent = "-%" // вычитание процента: a - b% = a - a*b/100
+	ParenLeft         = "("
+	ParenRight        = ")"
+	ArgSeparator      = "," // разделитель аргументов функции и элементов списка
+	ListLeft          = "[" // начало списка, например "sum([1, 2, 3])"
+	ListRight         = "]" // конец списка
 )
+
+// Логические значения. Результат сравнений и логических операций - 1 или 0,
+// а истинным считается любое ненулевое число.
+const (
+	True  = 1.0
+	False = 0.0
+)
+
+// Aliases - Unicode-символы, заменяемые на соответствующие операторы.
+var Aliases = map[rune]string{
+	'×': OpMultiply, // знак умножения
+	'·': OpMultiply, // точка посередине
+	'⋅': OpMultiply, // оператор точки
+	'÷': OpDivide,   // знак деления
+	'−': OpSubtract, // математический минус
+}
+
+// Superscripts - надстрочные цифры, означающие возведение в степень (например, "x²" = "x^2").
+var Superscripts = map[rune]string{
+	'⁰': "0",
+	'¹': "1",
+	'²': "2",
+	'³': "3",
+	'⁴': "4",
+	'⁵': "5",
+	'⁶': "6",
+	'⁷': "7",
+	'⁸': "8",
+	'⁹': "9",
+}
+
+// Математические функции.
+// Используются оркестратором и агентом.
+const (
+	FnSin   = "sin"   // синус (радианы)
+	FnCos   = "cos"   // косинус (радианы)
+	FnTan   = "tan"   // тангенс (радианы)
+	FnSqrt  = "sqrt"  // квадратный корень
+	FnLog   = "log"   // логарифм, log(x) - десятичный, log(x, b) - по основанию b
+	FnLn    = "ln"    // натуральный логарифм
+	FnAbs   = "abs"   // модуль числа
+	FnExp   = "exp"   // экспонента
+	FnMax   = "max"   // максимум из аргументов
+	FnMin   = "min"   // минимум из аргументов
+	FnIf    = "if"    // условное выражение if(условие, то, иначе), аналог "условие ? то : иначе"
+	FnRound = "round" // округление до n знаков после запятой (половина - от нуля), round(x) = round(x, 0)
+	FnFloor = "floor" // округление вниз до целого
+	FnCeil  = "ceil"  // округление вверх до целого
+	FnPmt   = "pmt"   // платеж аннуитета pmt(ставка, периоды, pv[, fv[, тип]])
+	FnFv    = "fv"    // будущая стоимость fv(ставка, периоды, платеж[, pv[, тип]])
+	// Агрегатные функции принимают значения аргументами или списками: sum(1, 2, 3) = sum([1, 2], 3)
+	FnSum    = "sum"    // сумма значений
+	FnAvg    = "avg"    // среднее арифметическое
+	FnMedian = "median" // медиана
+	FnStddev = "stddev" // стандартное отклонение по генеральной совокупности (деление на n)
+)
+
+// Variadic обозначает неограниченное количество аргументов функции.
+const Variadic = -1
+
+// Arity описывает допустимое количество аргументов функции.
+type Arity struct {
+	Min int // Минимальное количество аргументов.
+	Max int // Максимальное количество аргументов, Variadic - без ограничения.
+}
+
+// Functions - таблица поддерживаемых функций и их арности.
+//
+// Вариативные функции (max, min, sum, avg, stddev) оркестратор раскладывает на дерево бинарных задач,
+// поэтому агент получает max и min всегда с двумя аргументами, а sum, avg и stddev - в виде сложений,
+// делений и других операций. Медиана вычисляется агентом одной задачей со всеми значениями. Функцию if оркестратор
+// разбирает как условное выражение, агенту она не отправляется. Необязательные аргументы
+// (основание log, знаки round, fv и тип платежа pmt и fv) оркестратор дополняет значениями по умолчанию,
+// поэтому агент получает их всегда.
+var Functions = map[string]Arity{
+	FnSin:    {Min: 1, Max: 1},
+	FnCos:    {Min: 1, Max: 1},
+	FnTan:    {Min: 1, Max: 1},
+	FnSqrt:   {Min: 1, Max: 1},
+	FnLog:    {Min: 1, Max: 2},
+	FnLn:     {Min: 1, Max: 1},
+	FnAbs:    {Min: 1, Max: 1},
+	FnExp:    {Min: 1, Max: 1},
+	FnMax:    {Min: 2, Max: Variadic},
+	FnMin:    {Min: 2, Max: Variadic},
+	FnIf:     {Min: 3, Max: 3},
+	FnRound:  {Min: 1, Max: 2},
+	FnFloor:  {Min: 1, Max: 1},
+	FnCeil:   {Min: 1, Max: 1},
+	FnPmt:    {Min: 3, Max: 5},
+	FnFv:     {Min: 3, Max: 5},
+	FnSum:    {Min: 1, Max: Variadic},
+	FnAvg:    {Min: 1, Max: Variadic},
+	FnMedian: {Min: 1, Max: Variadic},
+	FnStddev: {Min: 1, Max: Variadic},
+}
+
+// IsFunction проверяет, является ли имя поддерживаемой функцией.
+func IsFunction(name string) bool {
+	_, ok := Functions[name]
+	return ok
+}
@@ -0,0 +1,428 @@
+package units
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/OinkiePie/calc_2/pkg/operators"
+)
+
+var (
+	ErrUnknownUnit   = errors.New("unknown unit")
+	ErrIncompatible  = errors.New("incompatible dimensions")
+	ErrDimensionless = errors.New("argument must be dimensionless")
+	ErrExponent      = errors.New("exponent of a dimensional quantity must be a known number giving integer dimensions")
+)
+
+// Основные величины СИ - индексы показателей в Dimension.
+const (
+	Length      = iota // длина, метр
+	Mass               // масса, килограмм
+	Time               // время, секунда
+	Current            // сила тока, ампер
+	Temperature        // температура, кельвин
+	Amount             // количество вещества, моль
+	Luminosity         // сила света, кандела
+	baseCount
+)
+
+// baseSymbols - обозначения основных единиц СИ в порядке индексов величин.
+var baseSymbols = [baseCount]string{"m", "kg", "s", "A", "K", "mol", "cd"}
+
+// Dimension - размерность величины: показатели степеней основных величин СИ.
+// Нулевое значение - безразмерная величина.
+type Dimension [baseCount]int
+
+// IsZero проверяет, является ли величина безразмерной.
+func (d Dimension) IsZero() bool {
+	return d == Dimension{}
+}
+
+// Mul возвращает размерность произведения величин.
+func (d Dimension) Mul(other Dimension) Dimension {
+	for i := range d {
+		d[i] += other[i]
+	}
+	return d
+}
+
+// Div возвращает размерность частного величин.
+func (d Dimension) Div(other Dimension) Dimension {
+	for i := range d {
+		d[i] -= other[i]
+	}
+	return d
+}
+
+// Pow возвращает размерность величины в степени exponent.
+//
+// Args:
+//
+//	exponent: float64 - Показатель степени.
+//
+// Returns:
+//
+//	Dimension - Размерность степени.
+//	bool - false, если хотя бы один показатель размерности получается нецелым (например, sqrt(m)).
+func (d Dimension) Pow(exponent float64) (Dimension, bool) {
+	for i := range d {
+		power := float64(d[i]) * exponent
+		if power != math.Trunc(power) || math.IsInf(power, 0) {
+			return Dimension{}, false
+		}
+		d[i] = int(power)
+	}
+	return d, true
+}
+
+// String возвращает запись размерности в основных единицах СИ, например "kg*m^2/s^2" или "1/s".
+// Безразмерной величине соответствует пустая строка. Запись разбирается функцией Parse.
+func (d Dimension) String() string {
+	var numerator, denominator []string
+	// Килограмм первым, как в записи производных единиц: kg*m^2/s^2
+	for _, i := range []int{Mass, Length, Time, Current, Temperature, Amount, Luminosity} {
+		factor := baseSymbols[i]
+		if power := max(d[i], -d[i]); power != 1 {
+			factor += "^" + strconv.Itoa(power)
+		}
+		switch {
+		case d[i] > 0:
+			numerator = append(numerator, factor)
+		case d[i] < 0:
+			denominator = append(denominator, factor)
+		}
+	}
+	if len(numerator) == 0 && len(denominator) == 0 {
+		return ""
+	}
+	text := strings.Join(numerator, "*")
+	if text == "" {
+		text = "1"
+	}
+	for _, factor := range denominator {
+		text += "/" + factor
+	}
+	return text
+}
+
+// Unit - единица измерения: множитель перевода в основные единицы СИ и размерность.
+type Unit struct {
+	Factor float64   // Значение единицы в основных единицах СИ, например 1000 для km.
+	Dim    Dimension // Размерность.
+}
+
+// dim создает размерность из показателей основных величин в порядке m, kg, s, A, K, mol, cd.
+func dim(exponents ...int) Dimension {
+	var d Dimension
+	copy(d[:], exponents)
+	return d
+}
+
+var (
+	dimLength    = dim(1)
+	dimMass      = dim(0, 1)
+	dimTime      = dim(0, 0, 1)
+	dimArea      = dim(2)
+	dimVolume    = dim(3)
+	dimFrequency = dim(0, 0, -1)
+	dimForce     = dim(1, 1, -2)
+	dimEnergy    = dim(2, 1, -2)
+	dimPower     = dim(2, 1, -3)
+	dimPressure  = dim(-1, 1, -2)
+	dimCharge    = dim(0, 0, 1, 1)
+	dimVoltage   = dim(2, 1, -3, -1)
+	dimOhm       = dim(2, 1, -3, -2)
+)
+
+// table - поддерживаемые единицы измерения.
+// Единицы с ненулевой точкой отсчета (градусы Цельсия и Фаренгейта) не поддерживаются:
+// их нельзя складывать и умножать как обычные величины.
+var table = map[string]Unit{
+	// Основные единицы СИ
+	"m":   {1, dimLength},
+	"kg":  {1, dimMass},
+	"s":   {1, dimTime},
+	"A":   {1, dim(0, 0, 0, 1)},
+	"K":   {1, dim(0, 0, 0, 0, 1)},
+	"mol": {1, dim(0, 0, 0, 0, 0, 1)},
+	"cd":  {1, dim(0, 0, 0, 0, 0, 0, 1)},
+
+	// Длина
+	"km": {1e3, dimLength},
+	"cm": {1e-2, dimLength},
+	"mm": {1e-3, dimLength},
+	"µm": {1e-6, dimLength},
+	"nm": {1e-9, dimLength},
+	"mi": {1609.344, dimLength},
+	"yd": {0.9144, dimLength},
+	"ft": {0.3048, dimLength},
+
+	// Масса
+	"g":  {1e-3, dimMass},
+	"mg": {1e-6, dimMass},
+	"lb": {0.45359237, dimMass},
+	"oz": {0.028349523125, dimMass},
+
+	// Время
+	"ms":  {1e-3, dimTime},
+	"min": {60, dimTime},
+	"h":   {3600, dimTime},
+	"day": {86400, dimTime},
+
+	// Площадь и объем
+	"ha": {1e4, dimArea},
+	"L":  {1e-3, dimVolume},
+	"mL": {1e-6, dimVolume},
+
+	// Частота
+	"Hz":  {1, dimFrequency},
+	"kHz": {1e3, dimFrequency},
+	"MHz": {1e6, dimFrequency},
+	"GHz": {1e9, dimFrequency},
+
+	// Сила, энергия, мощность, давление
+	"N":    {1, dimForce},
+	"kN":   {1e3, dimForce},
+	"J":    {1, dimEnergy},
+	"kJ":   {1e3, dimEnergy},
+	"Wh":   {3600, dimEnergy},
+	"kWh":  {3.6e6, dimEnergy},
+	"cal":  {4.184, dimEnergy},
+	"kcal": {4184, dimEnergy},
+	"eV":   {1.602176634e-19, dimEnergy},
+	"W":    {1, dimPower},
+	"kW":   {1e3, dimPower},
+	"MW":   {1e6, dimPower},
+	"Pa":   {1, dimPressure},
+	"kPa":  {1e3, dimPressure},
+	"MPa":  {1e6, dimPressure},
+	"bar":  {1e5, dimPressure},
+	"atm":  {101325, dimPressure},
+
+	// Электричество
+	"C":   {1, dimCharge},
+	"V":   {1, dimVoltage},
+	"mV":  {1e-3, dimVoltage},
+	"kV":  {1e3, dimVoltage},
+	"mA":  {1e-3, dim(0, 0, 0, 1)},
+	"ohm": {1, dimOhm},
+	"Ω":   {1, dimOhm},
+
+	// Углы безразмерны: sin(30 deg) = sin(π/6)
+	"rad": {1, Dimension{}},
+	"deg": {math.Pi / 180, Dimension{}},
+}
+
+// Lookup возвращает единицу измерения по обозначению.
+//
+// Args:
+//
+//	name: string - Обозначение единицы, например "km".
+//
+// Returns:
+//
+//	Unit - Единица измерения.
+//	bool - true, если единица найдена.
+func Lookup(name string) (Unit, bool) {
+	unit, ok := table[name]
+	return unit, ok
+}
+
+// IsUnit проверяет, является ли имя обозначением единицы измерения.
+func IsUnit(name string) bool {
+	_, ok := table[name]
+	return ok
+}
+
+// Parse разбирает запись составной единицы измерения: обозначения единиц, соединенные "*" и "/",
+// с необязательными целыми показателями степени, например "km/h", "m/s^2", "kg*m^2/s^2", "m^-1" или "1/s".
+// Операции выполняются слева направо: "m/s*kg" = "m*kg/s".
+//
+// Args:
+//
+//	text: string - Запись единицы. Пустая строка - безразмерная единица с множителем 1.
+//
+// Returns:
+//
+//	Unit - Единица с множителем перевода в СИ и размерностью.
+//	error - ErrUnknownUnit, если запись содержит неизвестное обозначение или неверна.
+func Parse(text string) (Unit, error) {
+	result := Unit{Factor: 1}
+	if text == "" {
+		return result, nil
+	}
+
+	divide := false
+	rest := text
+	for {
+		end := strings.IndexAny(rest, "*/")
+		if end < 0 {
+			end = len(rest)
+		}
+		factor, err := parseFactor(rest[:end], result.Factor == 1 && result.Dim.IsZero() && !divide)
+		if err != nil {
+			return Unit{}, fmt.Errorf("%w: %q", err, text)
+		}
+		if divide {
+			result.Factor /= factor.Factor
+			result.Dim = result.Dim.Div(factor.Dim)
+		} else {
+			result.Factor *= factor.Factor
+			result.Dim = result.Dim.Mul(factor.Dim)
+		}
+		if end == len(rest) {
+			return result, nil
+		}
+		divide = rest[end] == '/'
+		rest = rest[end+1:]
+	}
+}
+
+// parseFactor разбирает множитель составной единицы: обозначение с необязательным показателем "^n".
+// Множитель "1" допустим только первым, например в "1/s".
+func parseFactor(text string, first bool) (Unit, error) {
+	if text == "1" && first {
+		return Unit{Factor: 1}, nil
+	}
+	name, powerText, hasPower := strings.Cut(text, "^")
+	unit, ok := table[name]
+	if !ok {
+		return Unit{}, ErrUnknownUnit
+	}
+	if !hasPower {
+		return unit, nil
+	}
+	power, err := strconv.Atoi(powerText)
+	if err != nil {
+		return Unit{}, ErrUnknownUnit
+	}
+	dimension, _ := unit.Dim.Pow(float64(power))
+	return Unit{Factor: math.Pow(unit.Factor, float64(power)), Dim: dimension}, nil
+}
+
+// Result вычисляет размерность результата операции и проверяет размерности аргументов.
+// Значения величин хранятся в основных единицах СИ, поэтому сама операция над значениями
+// выполняется как обычно, а размерность определяет, допустима ли она и что означает результат.
+//
+// Правила:
+//   - "+", "-", "%", max, min, median - размерности аргументов совпадают, результат той же размерности
+//     (так же проверяются значения агрегатных функций sum, avg и stddev);
+//   - "//" и сравнения - размерности совпадают, результат безразмерный;
+//   - "*" и "/" - показатели размерностей складываются и вычитаются;
+//   - "^" и sqrt - показатель степени безразмерный, а для размерного основания ещё и известен
+//     и дает целые показатели размерности (m^2, (m^2)^0.5);
+//   - унарный минус, abs, процент - размерность аргумента;
+//   - процент от величины ("+%", "-%") - размерность величины, процент безразмерный;
+//   - логические операции - любые аргументы, результат безразмерный;
+//   - выбор ветви условного выражения ("?:") - размерности ветвей совпадают;
+//   - остальные функции и факториал - только безразмерные аргументы.
+//
+// Args:
+//
+//	operation: string - Операция.
+//	dims: []Dimension - Размерности аргументов.
+//	exponent: *float64 - Показатель степени для "^" или nil, если он неизвестен.
+//
+// Returns:
+//
+//	Dimension - Размерность результата.
+//	error - ErrIncompatible, ErrDimensionless или ErrExponent, если размерности аргументов недопустимы.
+func Result(operation string, dims []Dimension, exponent *float64) (Dimension, error) {
+	if len(dims) == 0 {
+		return Dimension{}, nil
+	}
+	first := dims[0]
+
+	// same проверяет, что размерности аргументов совпадают
+	same := func(dims []Dimension) error {
+		for _, d := range dims {
+			if d != dims[0] {
+				return fmt.Errorf("%w: %s and %s", ErrIncompatible, Format(dims[0]), Format(d))
+			}
+		}
+		return nil
+	}
+
+	switch operation {
+	case operators.OpUnaryMinus, operators.FnAbs, operators.OpPercent:
+		return first, nil
+
+	case operators.OpAddPercent, operators.OpSubtractPercent:
+		if len(dims) > 1 && !dims[1].IsZero() {
+			return Dimension{}, fmt.Errorf("%w: %s", ErrDimensionless, Format(dims[1]))
+		}
+		return first, nil
+
+	case operators.OpNot, operators.OpAnd, operators.OpOr:
+		return Dimension{}, nil
+
+	case operators.OpAdd, operators.OpSubtract, operators.OpModulo, operators.FnMax, operators.FnMin,
+		operators.FnSum, operators.FnAvg, operators.FnMedian, operators.FnStddev:
+		return first, same(dims)
+
+	case operators.OpFloorDivide, operators.OpLess, operators.OpLessEqual, operators.OpEqual,
+		operators.OpNotEqual, operators.OpGreater, operators.OpGreaterEqual:
+		return Dimension{}, same(dims)
+
+	case operators.OpMultiply:
+		if len(dims) < 2 {
+			return first, nil
+		}
+		return first.Mul(dims[1]), nil
+
+	case operators.OpDivide:
+		if len(dims) < 2 {
+			return first, nil
+		}
+		return first.Div(dims[1]), nil
+
+	case operators.OpSelect:
+		if len(dims) < 3 {
+			return Dimension{}, nil
+		}
+		return dims[1], same(dims[1:])
+
+	case operators.OpPower:
+		if len(dims) > 1 && !dims[1].IsZero() {
+			return Dimension{}, fmt.Errorf("%w: %s", ErrDimensionless, Format(dims[1]))
+		}
+		return power(first, exponent)
+
+	case operators.FnSqrt:
+		half := 0.5
+		return power(first, &half)
+	}
+
+	for _, d := range dims {
+		if !d.IsZero() {
+			return Dimension{}, fmt.Errorf("%w: %s", ErrDimensionless, Format(d))
+		}
+	}
+	return Dimension{}, nil
+}
+
+// power возвращает размерность степени с проверкой показателя.
+func power(base Dimension, exponent *float64) (Dimension, error) {
+	if base.IsZero() {
+		return base, nil
+	}
+	if exponent == nil {
+		return Dimension{}, ErrExponent
+	}
+	result, ok := base.Pow(*exponent)
+	if !ok {
+		return Dimension{}, ErrExponent
+	}
+	return result, nil
+}
+
+// Format возвращает запись размерности для сообщений: обозначения СИ или "1" для безразмерной величины.
+func Format(d Dimension) string {
+	if d.IsZero() {
+		return "1"
+	}
+	return d.String()
+}
@@ -46,4 +46,4 @@
 `+f[a].replace(" at new "," at ");return l.displayName&&m.includes("<anonymous>")&&(m=m.replace("<anonymous>",l.displayName)),m}while(1<=a&&0<=e);break}}}finally{qn=!1,Error.prepareStackTrace=u}return(u=l?l.displayName||l.name:"")?ha(u):""}function $d(l){switch(l.tag){case 26:case 27:case 5:return ha(l.type);case 16:return ha("Lazy");case 13:return ha("Suspense");case 19:return ha("SuspenseList");case 0:case 15:return l=Yn(l.type,!1),l;case 11:return l=Yn(l.type.render,!1),l;case 1:return l=Yn(l.type,!0),l;default:return""}}function df(l){try{var t="";do t+=$d(l),l=l.return;while(l);return t}catch(u){return`
 Error generating stack: `+u.message+`
 `+u.stack}}function Fu(l){var t=l,u=l;if(l.alternate)for(;t.return;)t=t.return;else{l=t;do t=l,t.flags&4098&&(u=t.return),l=t.return;while(l)}return t.tag===3?u:null}function J0(l){if(l.tag===13){var t=l.memoizedState;if(t===null&&(l=l.alternate,l!==null&&(t=l.memoizedState)),t!==null)return t.dehydrated}return null}function vf(l){if(Fu(l)!==l)throw Error(S(188))}function Wd(l){var t=l.alternate;if(!t){if(t=Fu(l),t===null)throw Error(S(188));return t!==l?null:l}for(var u=l,a=t;;){var e=u.return;if(e===null)break;var n=e.alternate;if(n===null){if(a=e.return,a!==null){u=a;continue}break}if(e.child===n.child){for(n=e.child;n;){if(n===u)return vf(e),l;if(n===a)return vf(e),t;n=n.sibling}throw Error(S(188))}if(u.return!==a.return)u=e,a=n;else{for(var c=!1,i=e.child;i;){if(i===u){c=!0,u=e,a=n;break}if(i===a){c=!0,a=e,u=n;break}i=i.sibling}if(!c){for(i=n.child;i;){if(i===u){c=!0,u=n,a=e;break}if(i===a){c=!0,a=n,u=e;break}i=i.sibling}if(!c)throw Error(S(189))}}if(u.alternate!==a)throw Error(S(190))}if(u.tag!==3)throw Error(S(188));return u.stateNode.current===u?l:t}function w0(l){var t=l.tag;if(t===5||t===26||t===27||t===6)return l;for(l=l.child;l!==null;){if(t=w0(l),t!==null)return t;l=l.sibling}return null}var oa=Array.isArray,L=Vd.__DOM_INTERNALS_DO_NOT_USE_OR_WARN_USERS_THEY_CANNOT_UPGRADE,It={pending:!1,data:null,method:null,action:null},rc=[],zu=-1;function lt(l){return{current:l}}function vl(l){0>zu||(l.current=rc[zu],rc[zu]=null,zu--)}function J(l,t){zu++,rc[zu]=l.current,l.current=t}var kl=lt(null),pa=lt(null),pt=lt(null),Be=lt(null);function Ce(l,t){switch(J(pt,t),J(pa,l),J(kl,null),l=t.nodeType,l){case 9:case 11:t=(t=t.documentElement)&&(t=t.namespaceURI)?h0(t):0;break;default:if(l=l===8?t.parentNode:t,t=l.tagName,l=l.namespaceURI)l=h0(l),t=nd(l,t);else switch(t){case"svg":t=1;break;case"math":t=2;break;default:t=0}}vl(kl),J(kl,t)}function xu(){vl(kl),vl(pa),vl(pt)}function gc(l){l.memoizedState!==null&&J(Be,l);var t=kl.current,u=nd(t,l.type);t!==u&&(J(pa,l),J(kl,u))}function Ge(l){pa.current===l&&(vl(kl),vl(pa)),Be.current===l&&(vl(Be),Va._currentValue=It)}var Sc=Object.prototype.hasOwnProperty,hi=cl.unstable_scheduleCallback,jn=cl.unstable_cancelCallback,kd=cl.unstable_shouldYield,Fd=cl.unstable_requestPaint,Fl=cl.unstable_now,Pd=cl.unstable_getCurrentPriorityLevel,$0=cl.unstable_ImmediatePriority,W0=cl.unstable_UserBlockingPriority,Xe=cl.unstable_NormalPriority,Id=cl.unstable_LowPriority,k0=cl.unstable_IdlePriority,lv=cl.log,tv=cl.unstable_setDisableYieldValue,wa=null,Ul=null;function uv(l){if(Ul&&typeof Ul.onCommitFiberRoot=="function")try{Ul.onCommitFiberRoot(wa,l,void 0,(l.current.flags&128)===128)}catch{}}function Rt(l){if(typeof lv=="function"&&tv(l),Ul&&typeof Ul.setStrictMode=="function")try{Ul.setStrictMode(wa,l)}catch{}}var Hl=Math.clz32?Math.clz32:nv,av=Math.log,ev=Math.LN2;function nv(l){return l>>>=0,l===0?32:31-(av(l)/ev|0)|0}var de=128,ve=4194304;function $t(l){var t=l&42;if(t!==0)return t;switch(l&-l){case 1:return 1;case 2:return 2;case 4:return 4;case 8:return 8;case 16:return 16;case 32:return 32;case 64:return 64;case 128:case 256:case 512:case 1024:case 2048:case 4096:case 8192:case 16384:case 32768:case 65536:case 131072:case 262144:case 524288:case 1048576:case 2097152:return l&4194176;case 4194304:case 8388608:case 16777216:case 33554432:return l&62914560;case 67108864:return 67108864;case 134217728:return 134217728;case 268435456:return 268435456;case 536870912:return 536870912;case 1073741824:return 0;default:return l}}function yn(l,t){var u=l.pendingLanes;if(u===0)return 0;var a=0,e=l.suspendedLanes,n=l.pingedLanes,c=l.warmLanes;l=l.finishedLanes!==0;var i=u&134217727;return i!==0?(u=i&~e,u!==0?a=$t(u):(n&=i,n!==0?a=$t(n):l||(c=i&~c,c!==0&&(a=$t(c))))):(i=u&~e,i!==0?a=$t(i):n!==0?a=$t(n):l||(c=u&~c,c!==0&&(a=$t(c)))),a===0?0:t!==0&&t!==a&&!(t&e)&&(e=a&-a,c=t&-t,e>=c||e===32&&(c&4194176)!==0)?t:a}function $a(l,t){return(l.pendingLanes&~(l.suspendedLanes&~l.pingedLanes)&t)===0}function cv(l,t){switch(l){case 1:case 2:case 4:case 8:return t+250;case 16:case 32:case 64:case 128:case 256:case 512:case 1024:case 2048:case 4096:case 8192:case 16384:case 32768:case 65536:case 131072:case 262144:case 524288:case 1048576:case 2097152:return t+5e3;case 4194304:case 8388608:case 16777216:case 33554432:return-1;case 67108864:case 134217728:case 268435456:case 536870912:case 1073741824:return-1;default:return-1}}function F0(){var l=de;return de<<=1,!(de&4194176)&&(de=128),l}function P0(){var l=ve;return ve<<=1,!(ve&62914560)&&(ve=4194304),l}function Bn(l){for(var t=[],u=0;31>u;u++)t.push(l);return t}function Wa(l,t){l.pendingLanes|=t,t!==268435456&&(l.suspendedLanes=0,l.pingedLanes=0,l.warmLanes=0)}function iv(l,t,u,a,e,n){var c=l.pendingLanes;l.pendingLanes=u,l.suspendedLanes=0,l.pingedLanes=0,l.warmLanes=0,l.expiredLanes&=u,l.entangledLanes&=u,l.errorRecoveryDisabledLanes&=u,l.shellSuspendCounter=0;var i=l.entanglements,f=l.expirationTimes,s=l.hiddenUpdates;for(u=c&~u;0<u;){var m=31-Hl(u),g=1<<m;i[m]=0,f[m]=-1;var h=s[m];if(h!==null)for(s[m]=null,m=0;m<h.length;m++){var r=h[m];r!==null&&(r.lane&=-536870913)}u&=~g}a!==0&&I0(l,a,0),n!==0&&e===0&&l.tag!==0&&(l.suspendedLanes|=n&~(c&~t))}function I0(l,t,u){l.pendingLanes|=t,l.suspendedLanes&=~t;var a=31-Hl(t);l.entangledLanes|=t,l.entanglements[a]=l.entanglements[a]|1073741824|u&4194218}function ls(l,t){var u=l.entangledLanes|=t;for(l=l.entanglements;u;){var a=31-Hl(u),e=1<<a;e&t|l[a]&t&&(l[a]|=t),u&=~e}}function ts(l){return l&=-l,2<l?8<l?l&134217727?32:268435456:8:2}function us(){var l=L.p;return l!==0?l:(l=window.event,l===void 0?32:md(l.type))}function fv(l,t){var u=L.p;try{return L.p=l,t()}finally{L.p=u}}var Lt=Math.random().toString(36).slice(2),rl="__reactFiber$"+Lt,zl="__reactProps$"+Lt,Pu="__reactContainer$"+Lt,bc="__reactEvents$"+Lt,sv="__reactListeners$"+Lt,dv="__reactHandles$"+Lt,hf="__reactResources$"+Lt,qa="__reactMarker$"+Lt;function oi(l){delete l[rl],delete l[zl],delete l[bc],delete l[sv],delete l[dv]}function Ft(l){var t=l[rl];if(t)return t;for(var u=l.parentNode;u;){if(t=u[Pu]||u[rl]){if(u=t.alternate,t.child!==null||u!==null&&u.child!==null)for(l=y0(l);l!==null;){if(u=l[rl])return u;l=y0(l)}return t}l=u,u=l.parentNode}return null}function Iu(l){if(l=l[rl]||l[Pu]){var t=l.tag;if(t===5||t===6||t===13||t===26||t===27||t===3)return l}return null}function ya(l){var t=l.tag;if(t===5||t===26||t===27||t===6)return l.stateNode;throw Error(S(33))}function qu(l){var t=l[hf];return t||(t=l[hf]={hoistableStyles:new Map,hoistableScripts:new Map}),t}function sl(l){l[qa]=!0}var as=new Set,es={};function du(l,t){Zu(l,t),Zu(l+"Capture",t)}function Zu(l,t){for(es[l]=t,l=0;l<t.length;l++)as.add(t[l])}var mt=!(typeof window>"u"||typeof window.document>"u"||typeof window.document.createElement>"u"),vv=RegExp("^[:A-Z_a-z\\u00C0-\\u00D6\\u00D8-\\u00F6\\u00F8-\\u02FF\\u0370-\\u037D\\u037F-\\u1FFF\\u200C-\\u200D\\u2070-\\u218F\\u2C00-\\u2FEF\\u3001-\\uD7FF\\uF900-\\uFDCF\\uFDF0-\\uFFFD][:A-Z_a-z\\u00C0-\\u00D6\\u00D8-\\u00F6\\u00F8-\\u02FF\\u0370-\\u037D\\u037F-\\u1FFF\\u200C-\\u200D\\u2070-\\u218F\\u2C00-\\u2FEF\\u3001-\\uD7FF\\uF900-\\uFDCF\\uFDF0-\\uFFFD\\-.0-9\\u00B7\\u0300-\\u036F\\u203F-\\u2040]*$"),of={},yf={};function hv(l){return Sc.call(yf,l)?!0:Sc.call(of,l)?!1:vv.test(l)?yf[l]=!0:(of[l]=!0,!1)}function Oe(l,t,u){if(hv(t))if(u===null)l.removeAttribute(t);else{switch(typeof u){case"undefined":case"function":case"symbol":l.removeAttribute(t);return;case"boolean":var a=t.toLowerCase().slice(0,5);if(a!=="data-"&&a!=="aria-"){l.removeAttribute(t);return}}l.setAttribute(t,""+u)}}function he(l,t,u){if(u===null)l.removeAttribute(t);else{switch(typeof u){case"undefined":case"function":case"symbol":case"boolean":l.removeAttribute(t);return}l.setAttribute(t,""+u)}}function ut(l,t,u,a){if(a===null)l.removeAttribute(u);else{switch(typeof a){case"undefined":case"function":case"symbol":case"boolean":l.removeAttribute(u);return}l.setAttributeNS(t,u,""+a)}}function jl(l){switch(typeof l){case"bigint":case"boolean":case"number":case"string":case"undefined":return l;case"object":return l;default:return""}}function ns(l){var t=l.type;return(l=l.nodeName)&&l.toLowerCase()==="input"&&(t==="checkbox"||t==="radio")}function ov(l){var t=ns(l)?"checked":"value",u=Object.getOwnPropertyDescriptor(l.constructor.prototype,t),a=""+l[t];if(!l.hasOwnProperty(t)&&typeof u<"u"&&typeof u.get=="function"&&typeof u.set=="function"){var e=u.get,n=u.set;return Object.defineProperty(l,t,{configurable:!0,get:function(){return e.call(this)},set:function(c){a=""+c,n.call(this,c)}}),Object.defineProperty(l,t,{enumerable:u.enumerable}),{getValue:function(){return a},setValue:function(c){a=""+c},stopTracking:function(){l._valueTracker=null,delete l[t]}}}}function Qe(l){l._valueTracker||(l._valueTracker=ov(l))}function cs(l){if(!l)return!1;var t=l._valueTracker;if(!t)return!0;var u=t.getValue(),a="";return l&&(a=ns(l)?l.checked?"true":"false":l.value),l=a,l!==u?(t.setValue(l),!0):!1}function xe(l){if(l=l||(typeof document<"u"?document:void 0),typeof l>"u")return null;try{return l.activeElement||l.body}catch{return l.body}}var yv=/[\n"\\]/g;function Gl(l){return l.replace(yv,function(t){return"\\"+t.charCodeAt(0).toString(16)+" "})}function Ec(l,t,u,a,e,n,c,i){l.name="",c!=null&&typeof c!="function"&&typeof c!="symbol"&&typeof c!="boolean"?l.type=c:l.removeAttribute("type"),t!=null?c==="number"?(t===0&&l.value===""||l.value!=t)&&(l.value=""+jl(t)):l.value!==""+jl(t)&&(l.value=""+jl(t)):c!=="submit"&&c!=="reset"||l.removeAttribute("value"),t!=null?Tc(l,c,jl(t)):u!=null?Tc(l,c,jl(u)):a!=null&&l.removeAttribute("value"),e==null&&n!=null&&(l.defaultChecked=!!n),e!=null&&(l.checked=e&&typeof e!="function"&&typeof e!="symbol"),i!=null&&typeof i!="function"&&typeof i!="symbol"&&typeof i!="boolean"?l.name=""+jl(i):l.removeAttribute("name")}function is(l,t,u,a,e,n,c,i){if(n!=null&&typeof n!="function"&&typeof n!="symbol"&&typeof n!="boolean"&&(l.type=n),t!=null||u!=null){if(!(n!=="submit"&&n!=="reset"||t!=null))return;u=u!=null?""+jl(u):"",t=t!=null?""+jl(t):u,i||t===l.value||(l.value=t),l.defaultValue=t}a=a??e,a=typeof a!="function"&&typeof a!="symbol"&&!!a,l.checked=i?l.checked:!!a,l.defaultChecked=!!a,c!=null&&typeof c!="function"&&typeof c!="symbol"&&typeof c!="boolean"&&(l.name=c)}function Tc(l,t,u){t==="number"&&xe(l.ownerDocument)===l||l.defaultValue===""+u||(l.defaultValue=""+u)}function Yu(l,t,u,a){if(l=l.options,t){t={};for(var e=0;e<u.length;e++)t["$"+u[e]]=!0;for(u=0;u<l.length;u++)e=t.hasOwnProperty("$"+l[u].value),l[u].selected!==e&&(l[u].selected=e),e&&a&&(l[u].defaultSelected=!0)}else{for(u=""+jl(u),t=null,e=0;e<l.length;e++){if(l[e].value===u){l[e].selected=!0,a&&(l[e].defaultSelected=!0);return}t!==null||l[e].disabled||(t=l[e])}t!==null&&(t.selected=!0)}}function fs(l,t,u){if(t!=null&&(t=""+jl(t),t!==l.value&&(l.value=t),u==null)){l.defaultValue!==t&&(l.defaultValue=t);return}l.defaultValue=u!=null?""+jl(u):""}function ss(l,t,u,a){if(t==null){if(a!=null){if(u!=null)throw Error(S(92));if(oa(a)){if(1<a.length)throw Error(S(93));a=a[0]}u=a}u==null&&(u=""),t=u}u=jl(t),l.defaultValue=u,a=l.textContent,a===u&&a!==""&&a!==null&&(l.value=a)}function Vu(l,t){if(t){var u=l.firstChild;if(u&&u===l.lastChild&&u.nodeType===3){u.nodeValue=t;return}}l.textContent=t}var mv=new Set("animationIterationCount aspectRatio borderImageOutset borderImageSlice borderImageWidth boxFlex boxFlexGroup boxOrdinalGroup columnCount columns flex flexGrow flexPositive flexShrink flexNegative flexOrder gridArea gridRow gridRowEnd gridRowSpan gridRowStart gridColumn gridColumnEnd gridColumnSpan gridColumnStart fontWeight lineClamp lineHeight opacity order orphans scale tabSize widows zIndex zoom fillOpacity floodOpacity stopOpacity strokeDasharray strokeDashoffset strokeMiterlimit strokeOpacity strokeWidth MozAnimationIterationCount MozBoxFlex MozBoxFlexGroup MozLineClamp msAnimationIterationCount msFlex msZoom msFlexGrow msFlexNegative msFlexOrder msFlexPositive msFlexShrink msGridColumn msGridColumnSpan msGridRow msGridRowSpan WebkitAnimationIterationCount WebkitBoxFlex WebKitBoxFlexGroup WebkitBoxOrdinalGroup WebkitColumnCount WebkitColumns WebkitFlex WebkitFlexGrow WebkitFlexPositive WebkitFlexShrink WebkitLineClamp".split(" "));function mf(l,t,u){var a=t.indexOf("--")===0;u==null||typeof u=="boolean"||u===""?a?l.setProperty(t,""):t==="float"?l.cssFloat="":l[t]="":a?l.setProperty(t,u):typeof u!="number"||u===0||mv.has(t)?t==="float"?l.cssFloat=u:l[t]=(""+u).trim():l[t]=u+"px"}function ds(l,t,u){if(t!=null&&typeof t!="object")throw Error(S(62));if(l=l.style,u!=null){for(var a in u)!u.hasOwnProperty(a)||t!=null&&t.hasOwnProperty(a)||(a.indexOf("--")===0?l.setProperty(a,""):a==="float"?l.cssFloat="":l[a]="");for(var e in t)a=t[e],t.hasOwnProperty(e)&&u[e]!==a&&mf(l,e,a)}else for(var n in t)t.hasOwnProperty(n)&&mf(l,n,t[n])}function yi(l){if(l.indexOf("-")===-1)return!1;switch(l){case"annotation-xml":case"color-profile":case"font-face":case"font-face-src":case"font-face-uri":case"font-face-format":case"font-face-name":case"missing-glyph":return!1;default:return!0}}var rv=new Map([["acceptCharset","accept-charset"],["htmlFor","for"],["httpEquiv","http-equiv"],["crossOrigin","crossorigin"],["accentHeight","accent-height"],["alignmentBaseline","alignment-baseline"],["arabicForm","arabic-form"],["baselineShift","baseline-shift"],["capHeight","cap-height"],["clipPath","clip-path"],["clipRule","clip-rule"],["colorInterpolation","color-interpolation"],["colorInterpolationFilters","color-interpolation-filters"],["colorProfile","color-profile"],["colorRendering","color-rendering"],["dominantBaseline","dominant-baseline"],["enableBackground","enable-background"],["fillOpacity","fill-opacity"],["fillRule","fill-rule"],["floodColor","flood-color"],["floodOpacity","flood-opacity"],["fontFamily","font-family"],["fontSize","font-size"],["fontSizeAdjust","font-size-adjust"],["fontStretch","font-stretch"],["fontStyle","font-style"],["fontVariant","font-variant"],["fontWeight","font-weight"],["glyphName","glyph-name"],["glyphOrientationHorizontal","glyph-orientation-horizontal"],["glyphOrientationVertical","glyph-orientation-vertical"],["horizAdvX","horiz-adv-x"],["horizOriginX","horiz-origin-x"],["imageRendering","image-rendering"],["letterSpacing","letter-spacing"],["lightingColor","lighting-color"],["markerEnd","marker-end"],["markerMid","marker-mid"],["markerStart","marker-start"],["overlinePosition","overline-position"],["overlineThickness","overline-thickness"],["paintOrder","paint-order"],["panose-1","panose-1"],["pointerEvents","pointer-events"],["renderingIntent","rendering-intent"],["shapeRendering","shape-rendering"],["stopColor","stop-color"],["stopOpacity","stop-opacity"],["strikethroughPosition","strikethrough-position"],["strikethroughThickness","strikethrough-thickness"],["strokeDasharray","stroke-dasharray"],["strokeDashoffset","stroke-dashoffset"],["strokeLinecap","stroke-linecap"],["strokeLinejoin","stroke-linejoin"],["strokeMiterlimit","stroke-miterlimit"],["strokeOpacity","stroke-opacity"],["strokeWidth","stroke-width"],["textAnchor","text-anchor"],["textDecoration","text-decoration"],["textRendering","text-rendering"],["transformOrigin","transform-origin"],["underlinePosition","underline-position"],["underlineThickness","underline-thickness"],["unicodeBidi","unicode-bidi"],["unicodeRange","unicode-range"],["unitsPerEm","units-per-em"],["vAlphabetic","v-alphabetic"],["vHanging","v-hanging"],["vIdeographic","v-ideographic"],["vMathematical","v-mathematical"],["vectorEffect","vector-effect"],["vertAdvY","vert-adv-y"],["vertOriginX","vert-origin-x"],["vertOriginY","vert-origin-y"],["wordSpacing","word-spacing"],["writingMode","writing-mode"],["xmlnsXlink","xmlns:xlink"],["xHeight","x-height"]]),gv=/^[\u0000-\u001F ]*j[\r\n\t]*a[\r\n\t]*v[\r\n\t]*a[\r\n\t]*s[\r\n\t]*c[\r\n\t]*r[\r\n\t]*i[\r\n\t]*p[\r\n\t]*t[\r\n\t]*:/i;function _e(l){return gv.test(""+l)?"javascript:throw new Error('React has blocked a javascript: URL as a security precaution.')":l}var Ac=null;function mi(l){return l=l.target||l.srcElement||window,l.correspondingUseElement&&(l=l.correspondingUseElement),l.nodeType===3?l.parentNode:l}var Ou=null,ju=null;function rf(l){var t=Iu(l);if(t&&(l=t.stateNode)){var u=l[zl]||null;l:switch(l=t.stateNode,t.type){case"input":if(Ec(l,u.value,u.defaultValue,u.defaultValue,u.checked,u.defaultChecked,u.type,u.name),t=u.name,u.type==="radio"&&t!=null){for(u=l;u.parentNode;)u=u.parentNode;for(u=u.querySelectorAll('input[name="'+Gl(""+t)+'"][type="radio"]'),t=0;t<u.length;t++){var a=u[t];if(a!==l&&a.form===l.form){var e=a[zl]||null;if(!e)throw Error(S(90));Ec(a,e.value,e.defaultValue,e.defaultValue,e.checked,e.defaultChecked,e.type,e.name)}}for(t=0;t<u.length;t++)a=u[t],a.form===l.form&&cs(a)}break l;case"textarea":fs(l,u.value,u.defaultValue);break l;case"select":t=u.value,t!=null&&Yu(l,!!u.multiple,t,!1)}}}var Cn=!1;function vs(l,t,u){if(Cn)return l(t,u);Cn=!0;try{var a=l(t);return a}finally{if(Cn=!1,(Ou!==null||ju!==null)&&(On(),Ou&&(t=Ou,l=ju,ju=Ou=null,rf(t),l)))for(t=0;t<l.length;t++)rf(l[t])}}function Ya(l,t){var u=l.stateNode;if(u===null)return null;var a=u[zl]||null;if(a===null)return null;u=a[t];l:switch(t){case"onClick":case"onClickCapture":case"onDoubleClick":case"onDoubleClickCapture":case"onMouseDown":case"onMouseDownCapture":case"onMouseMove":case"onMouseMoveCapture":case"onMouseUp":case"onMouseUpCapture":case"onMouseEnter":(a=!a.disabled)||(l=l.type,a=!(l==="button"||l==="input"||l==="select"||l==="textarea")),l=!a;break l;default:l=!1}if(l)return null;if(u&&typeof u!="function")throw Error(S(231,t,typeof u));return u}var zc=!1;if(mt)try{var ea={};Object.defineProperty(ea,"passive",{get:function(){zc=!0}}),window.addEventListener("test",ea,ea),window.removeEventListener("test",ea,ea)}catch{zc=!1}var Nt=null,ri=null,De=null;function hs(){if(De)return De;var l,t=ri,u=t.length,a,e="value"in Nt?Nt.value:Nt.textContent,n=e.length;for(l=0;l<u&&t[l]===e[l];l++);var c=u-l;for(a=1;a<=c&&t[u-a]===e[n-a];a++);return De=e.slice(l,1<a?1-a:void 0)}function Me(l){var t=l.keyCode;return"charCode"in l?(l=l.charCode,l===0&&t===13&&(l=13)):l=t,l===10&&(l=13),32<=l||l===13?l:0}function oe(){return!0}function gf(){return!1}function Ol(l){function t(u,a,e,n,c){this._reactName=u,this._targetInst=e,this.type=a,this.nativeEvent=n,this.target=c,this.currentTarget=null;for(var i in l)l.hasOwnProperty(i)&&(u=l[i],this[i]=u?u(n):n[i]);return this.isDefaultPrevented=(n.defaultPrevented!=null?n.defaultPrevented:n.returnValue===!1)?oe:gf,this.isPropagationStopped=gf,this}return K(t.prototype,{preventDefault:function(){this.defaultPrevented=!0;var u=this.nativeEvent;u&&(u.preventDefault?u.preventDefault():typeof u.returnValue!="unknown"&&(u.returnValue=!1),this.isDefaultPrevented=oe)},stopPropagation:function(){var u=this.nativeEvent;u&&(u.stopPropagation?u.stopPropagation():typeof u.cancelBubble!="unknown"&&(u.cancelBubble=!0),this.isPropagationStopped=oe)},persist:function(){},isPersistent:oe}),t}var vu={eventPhase:0,bubbles:0,cancelable:0,timeStamp:function(l){return l.timeStamp||Date.now()},defaultPrevented:0,isTrusted:0},mn=Ol(vu),ka=K({},vu,{view:0,detail:0}),Sv=Ol(ka),Gn,Xn,na,rn=K({},ka,{screenX:0,screenY:0,clientX:0,clientY:0,pageX:0,pageY:0,ctrlKey:0,shiftKey:0,altKey:0,metaKey:0,getModifierState:gi,button:0,buttons:0,relatedTarget:function(l){return l.relatedTarget===void 0?l.fromElement===l.srcElement?l.toElement:l.fromElement:l.relatedTarget},movementX:function(l){return"movementX"in l?l.movementX:(l!==na&&(na&&l.type==="mousemove"?(Gn=l.screenX-na.screenX,Xn=l.screenY-na.screenY):Xn=Gn=0,na=l),Gn)},movementY:function(l){return"movementY"in l?l.movementY:Xn}}),Sf=Ol(rn),bv=K({},rn,{dataTransfer:0}),Ev=Ol(bv),Tv=K({},ka,{relatedTarget:0}),Qn=Ol(Tv),Av=K({},vu,{animationName:0,elapsedTime:0,pseudoElement:0}),zv=Ol(Av),Ov=K({},vu,{clipboardData:function(l){return"clipboardData"in l?l.clipboardData:window.clipboardData}}),_v=Ol(Ov),Dv=K({},vu,{data:0}),bf=Ol(Dv),Mv={Esc:"Escape",Spacebar:" ",Left:"ArrowLeft",Up:"ArrowUp",Right:"ArrowRight",Down:"ArrowDown",Del:"Delete",Win:"OS",Menu:"ContextMenu",Apps:"ContextMenu",Scroll:"ScrollLock",MozPrintableKey:"Unidentified"},Uv={8:"Backspace",9:"Tab",12:"Clear",13:"Enter",16:"Shift",17:"Control",18:"Alt",19:"Pause",20:"CapsLock",27:"Escape",32:" ",33:"PageUp",34:"PageDown",35:"End",36:"Home",37:"ArrowLeft",38:"ArrowUp",39:"ArrowRight",40:"ArrowDown",45:"Insert",46:"Delete",112:"F1",113:"F2",114:"F3",115:"F4",116:"F5",117:"F6",118:"F7",119:"F8",120:"F9",121:"F10",122:"F11",123:"F12",144:"NumLock",145:"ScrollLock",224:"Meta"},Hv={Alt:"altKey",Control:"ctrlKey",Meta:"metaKey",Shift:"shiftKey"};function Rv(l){var t=this.nativeEvent;return t.getModifierState?t.getModifierState(l):(l=Hv[l])?!!t[l]:!1}function gi(){return Rv}var Nv=K({},ka,{key:function(l){if(l.key){var t=Mv[l.key]||l.key;if(t!=="Unidentified")return t}return l.type==="keypress"?(l=Me(l),l===13?"Enter":String.fromCharCode(l)):l.type==="keydown"||l.type==="keyup"?Uv[l.keyCode]||"Unidentified":""},code:0,location:0,ctrlKey:0,shiftKey:0,altKey:0,metaKey:0,repeat:0,locale:0,getModifierState:gi,charCode:function(l){return l.type==="keypress"?Me(l):0},keyCode:function(l){return l.type==="keydown"||l.type==="keyup"?l.keyCode:0},which:function(l){return l.type==="keypress"?Me(l):l.type==="keydown"||l.type==="keyup"?l.keyCode:0}}),pv=Ol(Nv),qv=K({},rn,{pointerId:0,width:0,height:0,pressure:0,tangentialPressure:0,tiltX:0,tiltY:0,twist:0,pointerType:0,isPrimary:0}),Ef=Ol(qv),Yv=K({},ka,{touches:0,targetTouches:0,changedTouches:0,altKey:0,metaKey:0,ctrlKey:0,shiftKey:0,getModifierState:gi}),jv=Ol(Yv),Bv=K({},vu,{propertyName:0,elapsedTime:0,pseudoElement:0}),Cv=Ol(Bv),Gv=K({},rn,{deltaX:function(l){return"deltaX"in l?l.deltaX:"wheelDeltaX"in l?-l.wheelDeltaX:0},deltaY:function(l){return"deltaY"in l?l.deltaY:"wheelDeltaY"in l?-l.wheelDeltaY:"wheelDelta"in l?-l.wheelDelta:0},deltaZ:0,deltaMode:0}),Xv=Ol(Gv),Qv=K({},vu,{newState:0,oldState:0}),xv=Ol(Qv),Zv=[9,13,27,32],Si=mt&&"CompositionEvent"in window,ga=null;mt&&"documentMode"in document&&(ga=document.documentMode);var Vv=mt&&"TextEvent"in window&&!ga,os=mt&&(!Si||ga&&8<ga&&11>=ga),Tf=" ",Af=!1;function ys(l,t){switch(l){case"keyup":return Zv.indexOf(t.keyCode)!==-1;case"keydown":return t.keyCode!==229;case"keypress":case"mousedown":case"focusout":return!0;default:return!1}}function ms(l){return l=l.detail,typeof l=="object"&&"data"in l?l.data:null}var _u=!1;function Lv(l,t){switch(l){case"compositionend":return ms(t);case"keypress":return t.which!==32?null:(Af=!0,Tf);case"textInput":return l=t.data,l===Tf&&Af?null:l;default:return null}}function Kv(l,t){if(_u)return l==="compositionend"||!Si&&ys(l,t)?(l=hs(),De=ri=Nt=null,_u=!1,l):null;switch(l){case"paste":return null;case"keypress":if(!(t.ctrlKey||t.altKey||t.metaKey)||t.ctrlKey&&t.altKey){if(t.char&&1<t.char.length)return t.char;if(t.which)return String.fromCharCode(t.which)}return null;case"compositionend":return os&&t.locale!=="ko"?null:t.data;default:return null}}var Jv={color:!0,date:!0,datetime:!0,"datetime-local":!0,email:!0,month:!0,number:!0,password:!0,range:!0,search:!0,tel:!0,text:!0,time:!0,url:!0,week:!0};function zf(l){var t=l&&l.nodeName&&l.nodeName.toLowerCase();return t==="input"?!!Jv[l.type]:t==="textarea"}function rs(l,t,u,a){Ou?ju?ju.push(a):ju=[a]:Ou=a,t=an(t,"onChange"),0<t.length&&(u=new mn("onChange","change",null,u,a),l.push({event:u,listeners:t}))}var Sa=null,ja=null;function wv(l){ud(l,0)}function gn(l){var t=ya(l);if(cs(t))return l}function Of(l,t){if(l==="change")return t}var gs=!1;if(mt){var xn;if(mt){var Zn="oninput"in document;if(!Zn){var _f=document.createElement("div");_f.setAttribute("oninput","return;"),Zn=typeof _f.oninput=="function"}xn=Zn}else xn=!1;gs=xn&&(!document.documentMode||9<document.documentMode)}function Df(){Sa&&(Sa.detachEvent("onpropertychange",Ss),ja=Sa=null)}function Ss(l){if(l.propertyName==="value"&&gn(ja)){var t=[];rs(t,ja,l,mi(l)),vs(wv,t)}}function $v(l,t,u){l==="focusin"?(Df(),Sa=t,ja=u,Sa.attachEvent("onpropertychange",Ss)):l==="focusout"&&Df()}function Wv(l){if(l==="selectionchange"||l==="keyup"||l==="keydown")return gn(ja)}function kv(l,t){if(l==="click")return gn(t)}function Fv(l,t){if(l==="input"||l==="change")return gn(t)}function Pv(l,t){return l===t&&(l!==0||1/l===1/t)||l!==l&&t!==t}var Nl=typeof Object.is=="function"?Object.is:Pv;function Ba(l,t){if(Nl(l,t))return!0;if(typeof l!="object"||l===null||typeof t!="object"||t===null)return!1;var u=Object.keys(l),a=Object.keys(t);if(u.length!==a.length)return!1;for(a=0;a<u.length;a++){var e=u[a];if(!Sc.call(t,e)||!Nl(l[e],t[e]))return!1}return!0}function Mf(l){for(;l&&l.firstChild;)l=l.firstChild;return l}function Uf(l,t){var u=Mf(l);l=0;for(var a;u;){if(u.nodeType===3){if(a=l+u.textContent.length,l<=t&&a>=t)return{node:u,offset:t-l};l=a}l:{for(;u;){if(u.nextSibling){u=u.nextSibling;break l}u=u.parentNode}u=void 0}u=Mf(u)}}function bs(l,t){return l&&t?l===t?!0:l&&l.nodeType===3?!1:t&&t.nodeType===3?bs(l,t.parentNode):"contains"in l?l.contains(t):l.compareDocumentPosition?!!(l.compareDocumentPosition(t)&16):!1:!1}function Es(l){l=l!=null&&l.ownerDocument!=null&&l.ownerDocument.defaultView!=null?l.ownerDocument.defaultView:window;for(var t=xe(l.document);t instanceof l.HTMLIFrameElement;){try{var u=typeof t.contentWindow.location.href=="string"}catch{u=!1}if(u)l=t.contentWindow;else break;t=xe(l.document)}return t}function bi(l){var t=l&&l.nodeName&&l.nodeName.toLowerCase();return t&&(t==="input"&&(l.type==="text"||l.type==="search"||l.type==="tel"||l.type==="url"||l.type==="password")||t==="textarea"||l.contentEditable==="true")}function Iv(l,t){var u=Es(t);t=l.focusedElem;var a=l.selectionRange;if(u!==t&&t&&t.ownerDocument&&bs(t.ownerDocument.documentElement,t)){if(a!==null&&bi(t)){if(l=a.start,u=a.end,u===void 0&&(u=l),"selectionStart"in t)t.selectionStart=l,t.selectionEnd=Math.min(u,t.value.length);else if(u=(l=t.ownerDocument||document)&&l.defaultView||window,u.getSelection){u=u.getSelection();var e=t.textContent.length,n=Math.min(a.start,e);a=a.end===void 0?n:Math.min(a.end,e),!u.extend&&n>a&&(e=a,a=n,n=e),e=Uf(t,n);var c=Uf(t,a);e&&c&&(u.rangeCount!==1||u.anchorNode!==e.node||u.anchorOffset!==e.offset||u.focusNode!==c.node||u.focusOffset!==c.offset)&&(l=l.createRange(),l.setStart(e.node,e.offset),u.removeAllRanges(),n>a?(u.addRange(l),u.extend(c.node,c.offset)):(l.setEnd(c.node,c.offset),u.addRange(l)))}}for(l=[],u=t;u=u.parentNode;)u.nodeType===1&&l.push({element:u,left:u.scrollLeft,top:u.scrollTop});for(typeof t.focus=="function"&&t.focus(),t=0;t<l.length;t++)u=l[t],u.element.scrollLeft=u.left,u.element.scrollTop=u.top}}var lh=mt&&"documentMode"in document&&11>=document.documentMode,Du=null,Oc=null,ba=null,_c=!1;function Hf(l,t,u){var a=u.window===u?u.document:u.nodeType===9?u:u.ownerDocument;_c||Du==null||Du!==xe(a)||(a=Du,"selectionStart"in a&&bi(a)?a={start:a.selectionStart,end:a.selectionEnd}:(a=(a.ownerDocument&&a.ownerDocument.defaultView||window).getSelection(),a={anchorNode:a.anchorNode,anchorOffset:a.anchorOffset,focusNode:a.focusNode,focusOffset:a.focusOffset}),ba&&Ba(ba,a)||(ba=a,a=an(Oc,"onSelect"),0<a.length&&(t=new mn("onSelect","select",null,t,u),l.push({event:t,listeners:a}),t.target=Du)))}function wt(l,t){var u={};return u[l.toLowerCase()]=t.toLowerCase(),u["Webkit"+l]="webkit"+t,u["Moz"+l]="moz"+t,u}var Mu={animationend:wt("Animation","AnimationEnd"),animationiteration:wt("Animation","AnimationIteration"),animationstart:wt("Animation","AnimationStart"),transitionrun:wt("Transition","TransitionRun"),transitionstart:wt("Transition","TransitionStart"),transitioncancel:wt("Transition","TransitionCancel"),transitionend:wt("Transition","TransitionEnd")},Vn={},Ts={};mt&&(Ts=document.createElement("div").style,"AnimationEvent"in window||(delete Mu.animationend.animation,delete Mu.animationiteration.animation,delete Mu.animationstart.animation),"TransitionEvent"in window||delete Mu.transitionend.transition);function hu(l){if(Vn[l])return Vn[l];if(!Mu[l])return l;var t=Mu[l],u;for(u in t)if(t.hasOwnProperty(u)&&u in Ts)return Vn[l]=t[u];return l}var As=hu("animationend"),zs=hu("animationiteration"),Os=hu("animationstart"),th=hu("transitionrun"),uh=hu("transitionstart"),ah=hu("transitioncancel"),_s=hu("transitionend"),Ds=new Map,Rf="abort auxClick beforeToggle cancel canPlay canPlayThrough click close contextMenu copy cut drag dragEnd dragEnter dragExit dragLeave dragOver dragStart drop durationChange emptied encrypted ended error gotPointerCapture input invalid keyDown keyPress keyUp load loadedData loadedMetadata loadStart lostPointerCapture mouseDown mouseMove mouseOut mouseOver mouseUp paste pause play playing pointerCancel pointerDown pointerMove pointerOut pointerOver pointerUp progress rateChange reset resize seeked seeking stalled submit suspend timeUpdate touchCancel touchEnd touchStart volumeChange scroll scrollEnd toggle touchMove waiting wheel".split(" ");function wl(l,t){Ds.set(l,t),du(t,[l])}var Yl=[],Uu=0,Ei=0;function Sn(){for(var l=Uu,t=Ei=Uu=0;t<l;){var u=Yl[t];Yl[t++]=null;var a=Yl[t];Yl[t++]=null;var e=Yl[t];Yl[t++]=null;var n=Yl[t];if(Yl[t++]=null,a!==null&&e!==null){var c=a.pending;c===null?e.next=e:(e.next=c.next,c.next=e),a.pending=e}n!==0&&Ms(u,e,n)}}function bn(l,t,u,a){Yl[Uu++]=l,Yl[Uu++]=t,Yl[Uu++]=u,Yl[Uu++]=a,Ei|=a,l.lanes|=a,l=l.alternate,l!==null&&(l.lanes|=a)}function Ti(l,t,u,a){return bn(l,t,u,a),Ze(l)}function Qt(l,t){return bn(l,null,null,t),Ze(l)}function Ms(l,t,u){l.lanes|=u;var a=l.alternate;a!==null&&(a.lanes|=u);for(var e=!1,n=l.return;n!==null;)n.childLanes|=u,a=n.alternate,a!==null&&(a.childLanes|=u),n.tag===22&&(l=n.stateNode,l===null||l._visibility&1||(e=!0)),l=n,n=n.return;e&&t!==null&&l.tag===3&&(n=l.stateNode,e=31-Hl(u),n=n.hiddenUpdates,l=n[e],l===null?n[e]=[t]:l.push(t),t.lane=u|536870912)}function Ze(l){if(50<Na)throw Na=0,wc=null,Error(S(185));for(var t=l.return;t!==null;)l=t,t=l.return;return l.tag===3?l.stateNode:null}var Hu={},Nf=new WeakMap;function Xl(l,t){if(typeof l=="object"&&l!==null){var u=Nf.get(l);return u!==void 0?u:(t={value:l,source:t,stack:df(t)},Nf.set(l,t),t)}return{value:l,source:t,stack:df(t)}}var Ru=[],Nu=0,Ve=null,Le=0,Bl=[],Cl=0,lu=null,dt=1,vt="";function Wt(l,t){Ru[Nu++]=Le,Ru[Nu++]=Ve,Ve=l,Le=t}function Us(l,t,u){Bl[Cl++]=dt,Bl[Cl++]=vt,Bl[Cl++]=lu,lu=l;var a=dt;l=vt;var e=32-Hl(a)-1;a&=~(1<<e),u+=1;var n=32-Hl(t)+e;if(30<n){var c=e-e%5;n=(a&(1<<c)-1).toString(32),a>>=c,e-=c,dt=1<<32-Hl(t)+e|u<<e|a,vt=n+l}else dt=1<<n|u<<e|a,vt=l}function Ai(l){l.return!==null&&(Wt(l,1),Us(l,1,0))}function zi(l){for(;l===Ve;)Ve=Ru[--Nu],Ru[Nu]=null,Le=Ru[--Nu],Ru[Nu]=null;for(;l===lu;)lu=Bl[--Cl],Bl[Cl]=null,vt=Bl[--Cl],Bl[Cl]=null,dt=Bl[--Cl],Bl[Cl]=null}var Sl=null,ol=null,B=!1,Kl=null,$l=!1,Dc=Error(S(519));function nu(l){var t=Error(S(418,""));throw Ca(Xl(t,l)),Dc}function pf(l){var t=l.stateNode,u=l.type,a=l.memoizedProps;switch(t[rl]=l,t[zl]=a,u){case"dialog":Y("cancel",t),Y("close",t);break;case"iframe":case"object":case"embed":Y("load",t);break;case"video":case"audio":for(u=0;u<Qa.length;u++)Y(Qa[u],t);break;case"source":Y("error",t);break;case"img":case"image":case"link":Y("error",t),Y("load",t);break;case"details":Y("toggle",t);break;case"input":Y("invalid",t),is(t,a.value,a.defaultValue,a.checked,a.defaultChecked,a.type,a.name,!0),Qe(t);break;case"select":Y("invalid",t);break;case"textarea":Y("invalid",t),ss(t,a.value,a.defaultValue,a.children),Qe(t)}u=a.children,typeof u!="string"&&typeof u!="number"&&typeof u!="bigint"||t.textContent===""+u||a.suppressHydrationWarning===!0||ed(t.textContent,u)?(a.popover!=null&&(Y("beforetoggle",t),Y("toggle",t)),a.onScroll!=null&&Y("scroll",t),a.onScrollEnd!=null&&Y("scrollend",t),a.onClick!=null&&(t.onclick=Dn),t=!0):t=!1,t||nu(l)}function qf(l){for(Sl=l.return;Sl;)switch(Sl.tag){case 3:case 27:$l=!0;return;case 5:case 13:$l=!1;return;default:Sl=Sl.return}}function ca(l){if(l!==Sl)return!1;if(!B)return qf(l),B=!0,!1;var t=!1,u;if((u=l.tag!==3&&l.tag!==27)&&((u=l.tag===5)&&(u=l.type,u=!(u!=="form"&&u!=="button")||li(l.type,l.memoizedProps)),u=!u),u&&(t=!0),t&&ol&&nu(l),qf(l),l.tag===13){if(l=l.memoizedState,l=l!==null?l.dehydrated:null,!l)throw Error(S(317));l:{for(l=l.nextSibling,t=0;l;){if(l.nodeType===8)if(u=l.data,u==="/$"){if(t===0){ol=Jl(l.nextSibling);break l}t--}else u!=="$"&&u!=="$!"&&u!=="$?"||t++;l=l.nextSibling}ol=null}}else ol=Sl?Jl(l.stateNode.nextSibling):null;return!0}function Fa(){ol=Sl=null,B=!1}function Ca(l){Kl===null?Kl=[l]:Kl.push(l)}var Ea=Error(S(460)),Hs=Error(S(474)),Mc={then:function(){}};function Yf(l){return l=l.status,l==="fulfilled"||l==="rejected"}function ye(){}function Rs(l,t,u){switch(u=l[u],u===void 0?l.push(t):u!==t&&(t.then(ye,ye),t=u),t.status){case"fulfilled":return t.value;case"rejected":throw l=t.reason,l===Ea?Error(S(483)):l;default:if(typeof t.status=="string")t.then(ye,ye);else{if(l=V,l!==null&&100<l.shellSuspendCounter)throw Error(S(482));l=t,l.status="pending",l.then(function(a){if(t.status==="pending"){var e=t;e.status="fulfilled",e.value=a}},function(a){if(t.status==="pending"){var e=t;e.status="rejected",e.reason=a}})}switch(t.status){case"fulfilled":return t.value;case"rejected":throw l=t.reason,l===Ea?Error(S(483)):l}throw Ta=t,Ea}}var Ta=null;function jf(){if(Ta===null)throw Error(S(459));var l=Ta;return Ta=null,l}var Bu=null,Ga=0;function me(l){var t=Ga;return Ga+=1,Bu===null&&(Bu=[]),Rs(Bu,l,t)}function ia(l,t){t=t.props.ref,l.ref=t!==void 0?t:null}function re(l,t){throw t.$$typeof===Ld?Error(S(525)):(l=Object.prototype.toString.call(t),Error(S(31,l==="[object Object]"?"object with keys {"+Object.keys(t).join(", ")+"}":l)))}function Bf(l){var t=l._init;return t(l._payload)}function Ns(l){function t(v,d){if(l){var o=v.deletions;o===null?(v.deletions=[d],v.flags|=16):o.push(d)}}function u(v,d){if(!l)return null;for(;d!==null;)t(v,d),d=d.sibling;return null}function a(v){for(var d=new Map;v!==null;)v.key!==null?d.set(v.key,v):d.set(v.index,v),v=v.sibling;return d}function e(v,d){return v=jt(v,d),v.index=0,v.sibling=null,v}function n(v,d,o){return v.index=o,l?(o=v.alternate,o!==null?(o=o.index,o<d?(v.flags|=33554434,d):o):(v.flags|=33554434,d)):(v.flags|=1048576,d)}function c(v){return l&&v.alternate===null&&(v.flags|=33554434),v}function i(v,d,o,y){return d===null||d.tag!==6?(d=tc(o,v.mode,y),d.return=v,d):(d=e(d,o),d.return=v,d)}function f(v,d,o,y){var b=o.type;return b===Au?m(v,d,o.props.children,y,o.key):d!==null&&(d.elementType===b||typeof b=="object"&&b!==null&&b.$$typeof===Ot&&Bf(b)===d.type)?(d=e(d,o.props),ia(d,o),d.return=v,d):(d=pe(o.type,o.key,o.props,null,v.mode,y),ia(d,o),d.return=v,d)}function s(v,d,o,y){return d===null||d.tag!==4||d.stateNode.containerInfo!==o.containerInfo||d.stateNode.implementation!==o.implementation?(d=uc(o,v.mode,y),d.return=v,d):(d=e(d,o.children||[]),d.return=v,d)}function m(v,d,o,y,b){return d===null||d.tag!==7?(d=uu(o,v.mode,y,b),d.return=v,d):(d=e(d,o),d.return=v,d)}function g(v,d,o){if(typeof d=="string"&&d!==""||typeof d=="number"||typeof d=="bigint")return d=tc(""+d,v.mode,o),d.return=v,d;if(typeof d=="object"&&d!==null){switch(d.$$typeof){case se:return o=pe(d.type,d.key,d.props,null,v.mode,o),ia(o,d),o.return=v,o;case va:return d=uc(d,v.mode,o),d.return=v,d;case Ot:var y=d._init;return d=y(d._payload),g(v,d,o)}if(oa(d)||aa(d))return d=uu(d,v.mode,o,null),d.return=v,d;if(typeof d.then=="function")return g(v,me(d),o);if(d.$$typeof===st)return g(v,ge(v,d),o);re(v,d)}return null}function h(v,d,o,y){var b=d!==null?d.key:null;if(typeof o=="string"&&o!==""||typeof o=="number"||typeof o=="bigint")return b!==null?null:i(v,d,""+o,y);if(typeof o=="object"&&o!==null){switch(o.$$typeof){case se:return o.key===b?f(v,d,o,y):null;case va:return o.key===b?s(v,d,o,y):null;case Ot:return b=o._init,o=b(o._payload),h(v,d,o,y)}if(oa(o)||aa(o))return b!==null?null:m(v,d,o,y,null);if(typeof o.then=="function")return h(v,d,me(o),y);if(o.$$typeof===st)return h(v,d,ge(v,o),y);re(v,o)}return null}function r(v,d,o,y,b){if(typeof y=="string"&&y!==""||typeof y=="number"||typeof y=="bigint")return v=v.get(o)||null,i(d,v,""+y,b);if(typeof y=="object"&&y!==null){switch(y.$$typeof){case se:return v=v.get(y.key===null?o:y.key)||null,f(d,v,y,b);case va:return v=v.get(y.key===null?o:y.key)||null,s(d,v,y,b);case Ot:var D=y._init;return y=D(y._payload),r(v,d,o,y,b)}if(oa(y)||aa(y))return v=v.get(o)||null,m(d,v,y,b,null);if(typeof y.then=="function")return r(v,d,o,me(y),b);if(y.$$typeof===st)return r(v,d,o,ge(d,y),b);re(d,y)}return null}function T(v,d,o,y){for(var b=null,D=null,A=d,O=d=0,C=null;A!==null&&O<o.length;O++){A.index>O?(C=A,A=null):C=A.sibling;var U=h(v,A,o[O],y);if(U===null){A===null&&(A=C);break}l&&A&&U.alternate===null&&t(v,A),d=n(U,d,O),D===null?b=U:D.sibling=U,D=U,A=C}if(O===o.length)return u(v,A),B&&Wt(v,O),b;if(A===null){for(;O<o.length;O++)A=g(v,o[O],y),A!==null&&(d=n(A,d,O),D===null?b=A:D.sibling=A,D=A);return B&&Wt(v,O),b}for(A=a(A);O<o.length;O++)C=r(A,v,O,o[O],y),C!==null&&(l&&C.alternate!==null&&A.delete(C.key===null?O:C.key),d=n(C,d,O),D===null?b=C:D.sibling=C,D=C);return l&&A.forEach(function(ml){return t(v,ml)}),B&&Wt(v,O),b}function E(v,d,o,y){if(o==null)throw Error(S(151));for(var b=null,D=null,A=d,O=d=0,C=null,U=o.next();A!==null&&!U.done;O++,U=o.next()){A.index>O?(C=A,A=null):C=A.sibling;var ml=h(v,A,U.value,y);if(ml===null){A===null&&(A=C);break}l&&A&&ml.alternate===null&&t(v,A),d=n(ml,d,O),D===null?b=ml:D.sibling=ml,D=ml,A=C}if(U.done)return u(v,A),B&&Wt(v,O),b;if(A===null){for(;!U.done;O++,U=o.next())U=g(v,U.value,y),U!==null&&(d=n(U,d,O),D===null?b=U:D.sibling=U,D=U);return B&&Wt(v,O),b}for(A=a(A);!U.done;O++,U=o.next())U=r(A,v,O,U.value,y),U!==null&&(l&&U.alternate!==null&&A.delete(U.key===null?O:U.key),d=n(U,d,O),D===null?b=U:D.sibling=U,D=U);return l&&A.forEach(function(Et){return t(v,Et)}),B&&Wt(v,O),b}function Q(v,d,o,y){if(typeof o=="object"&&o!==null&&o.type===Au&&o.key===null&&(o=o.props.children),typeof o=="object"&&o!==null){switch(o.$$typeof){case se:l:{for(var b=o.key;d!==null;){if(d.key===b){if(b=o.type,b===Au){if(d.tag===7){u(v,d.sibling),y=e(d,o.props.children),y.return=v,v=y;break l}}else if(d.elementType===b||typeof b=="object"&&b!==null&&b.$$typeof===Ot&&Bf(b)===d.type){u(v,d.sibling),y=e(d,o.props),ia(y,o),y.return=v,v=y;break l}u(v,d);break}else t(v,d);d=d.sibling}o.type===Au?(y=uu(o.props.children,v.mode,y,o.key),y.return=v,v=y):(y=pe(o.type,o.key,o.props,null,v.mode,y),ia(y,o),y.return=v,v=y)}return c(v);case va:l:{for(b=o.key;d!==null;){if(d.key===b)if(d.tag===4&&d.stateNode.containerInfo===o.containerInfo&&d.stateNode.implementation===o.implementation){u(v,d.sibling),y=e(d,o.children||[]),y.return=v,v=y;break l}else{u(v,d);break}else t(v,d);d=d.sibling}y=uc(o,v.mode,y),y.return=v,v=y}return c(v);case Ot:return b=o._init,o=b(o._payload),Q(v,d,o,y)}if(oa(o))return T(v,d,o,y);if(aa(o)){if(b=aa(o),typeof b!="function")throw Error(S(150));return o=b.call(o),E(v,d,o,y)}if(typeof o.then=="function")return Q(v,d,me(o),y);if(o.$$typeof===st)return Q(v,d,ge(v,o),y);re(v,o)}return typeof o=="string"&&o!==""||typeof o=="number"||typeof o=="bigint"?(o=""+o,d!==null&&d.tag===6?(u(v,d.sibling),y=e(d,o),y.return=v,v=y):(u(v,d),y=tc(o,v.mode,y),y.return=v,v=y),c(v)):u(v,d)}return function(v,d,o,y){try{Ga=0;var b=Q(v,d,o,y);return Bu=null,b}catch(A){if(A===Ea)throw A;var D=Ql(29,A,null,v.mode);return D.lanes=y,D.return=v,D}finally{}}}var cu=Ns(!0),ps=Ns(!1),Lu=lt(null),Ke=lt(0);function Cf(l,t){l=St,J(Ke,l),J(Lu,t),St=l|t.baseLanes}function Uc(){J(Ke,St),J(Lu,Lu.current)}function Oi(){St=Ke.current,vl(Lu),vl(Ke)}var Zl=lt(null),Pl=null;function Dt(l){var t=l.alternate;J(nl,nl.current&1),J(Zl,l),Pl===null&&(t===null||Lu.current!==null||t.memoizedState!==null)&&(Pl=l)}function qs(l){if(l.tag===22){if(J(nl,nl.current),J(Zl,l),Pl===null){var t=l.alternate;t!==null&&t.memoizedState!==null&&(Pl=l)}}else Mt()}function Mt(){J(nl,nl.current),J(Zl,Zl.current)}function ht(l){vl(Zl),Pl===l&&(Pl=null),vl(nl)}var nl=lt(0);function Je(l){for(var t=l;t!==null;){if(t.tag===13){var u=t.memoizedState;if(u!==null&&(u=u.dehydrated,u===null||u.data==="$?"||u.data==="$!"))return t}else if(t.tag===19&&t.memoizedProps.revealOrder!==void 0){if(t.flags&128)return t}else if(t.child!==null){t.child.return=t,t=t.child;continue}if(t===l)break;for(;t.sibling===null;){if(t.return===null||t.return===l)return null;t=t.return}t.sibling.return=t.return,t=t.sibling}return null}var eh=typeof AbortController<"u"?AbortController:function(){var l=[],t=this.signal={aborted:!1,addEventListener:function(u,a){l.push(a)}};this.abort=function(){t.aborted=!0,l.forEach(function(u){return u()})}},nh=cl.unstable_scheduleCallback,ch=cl.unstable_NormalPriority,el={$$typeof:st,Consumer:null,Provider:null,_currentValue:null,_currentValue2:null,_threadCount:0};function _i(){return{controller:new eh,data:new Map,refCount:0}}function Pa(l){l.refCount--,l.refCount===0&&nh(ch,function(){l.controller.abort()})}var Aa=null,Hc=0,Ku=0,Cu=null;function ih(l,t){if(Aa===null){var u=Aa=[];Hc=0,Ku=$i(),Cu={status:"pending",value:void 0,then:function(a){u.push(a)}}}return Hc++,t.then(Gf,Gf),t}function Gf(){if(--Hc===0&&Aa!==null){Cu!==null&&(Cu.status="fulfilled");var l=Aa;Aa=null,Ku=0,Cu=null;for(var t=0;t<l.length;t++)(0,l[t])()}}function fh(l,t){var u=[],a={status:"pending",value:null,reason:null,then:function(e){u.push(e)}};return l.then(function(){a.status="fulfilled",a.value=t;for(var e=0;e<u.length;e++)(0,u[e])(t)},function(e){for(a.status="rejected",a.reason=e,e=0;e<u.length;e++)(0,u[e])(void 0)}),a}var Xf=M.S;M.S=function(l,t){typeof t=="object"&&t!==null&&typeof t.then=="function"&&ih(l,t),Xf!==null&&Xf(l,t)};var tu=lt(null);function Di(){var l=tu.current;return l!==null?l:V.pooledCache}function Ue(l,t){t===null?J(tu,tu.current):J(tu,t.pool)}function Ys(){var l=Di();return l===null?null:{parent:el._currentValue,pool:l}}var xt=0,R=null,X=null,tl=null,we=!1,Gu=!1,iu=!1,$e=0,Xa=0,Xu=null,sh=0;function I(){throw Error(S(321))}function Mi(l,t){if(t===null)return!1;for(var u=0;u<t.length&&u<l.length;u++)if(!Nl(l[u],t[u]))return!1;return!0}function Ui(l,t,u,a,e,n){return xt=n,R=t,t.memoizedState=null,t.updateQueue=null,t.lanes=0,M.H=l===null||l.memoizedState===null?ou:Kt,iu=!1,n=u(a,e),iu=!1,Gu&&(n=Bs(t,u,a,e)),js(l),n}function js(l){M.H=Il;var t=X!==null&&X.next!==null;if(xt=0,tl=X=R=null,we=!1,Xa=0,Xu=null,t)throw Error(S(300));l===null||dl||(l=l.dependencies,l!==null&&Fe(l)&&(dl=!0))}function Bs(l,t,u,a){R=l;var e=0;do{if(Gu&&(Xu=null),Xa=0,Gu=!1,25<=e)throw Error(S(301));if(e+=1,tl=X=null,l.updateQueue!=null){var n=l.updateQueue;n.lastEffect=null,n.events=null,n.stores=null,n.memoCache!=null&&(n.memoCache.index=0)}M.H=yu,n=t(u,a)}while(Gu);return n}function dh(){var l=M.H,t=l.useState()[0];return t=typeof t.then=="function"?Ia(t):t,l=l.useState()[0],(X!==null?X.memoizedState:null)!==l&&(R.flags|=1024),t}function Hi(){var l=$e!==0;return $e=0,l}function Ri(l,t,u){t.updateQueue=l.updateQueue,t.flags&=-2053,l.lanes&=~u}function Ni(l){if(we){for(l=l.memoizedState;l!==null;){var t=l.queue;t!==null&&(t.pending=null),l=l.next}we=!1}xt=0,tl=X=R=null,Gu=!1,Xa=$e=0,Xu=null}function Al(){var l={memoizedState:null,baseState:null,baseQueue:null,queue:null,next:null};return tl===null?R.memoizedState=tl=l:tl=tl.next=l,tl}function ul(){if(X===null){var l=R.alternate;l=l!==null?l.memoizedState:null}else l=X.next;var t=tl===null?R.memoizedState:tl.next;if(t!==null)tl=t,X=l;else{if(l===null)throw R.alternate===null?Error(S(467)):Error(S(310));X=l,l={memoizedState:X.memoizedState,baseState:X.baseState,baseQueue:X.baseQueue,queue:X.queue,next:null},tl===null?R.memoizedState=tl=l:tl=tl.next=l}return tl}var En;En=function(){return{lastEffect:null,events:null,stores:null,memoCache:null}};function Ia(l){var t=Xa;return Xa+=1,Xu===null&&(Xu=[]),l=Rs(Xu,l,t),t=R,(tl===null?t.memoizedState:tl.next)===null&&(t=t.alternate,M.H=t===null||t.memoizedState===null?ou:Kt),l}function Tn(l){if(l!==null&&typeof l=="object"){if(typeof l.then=="function")return Ia(l);if(l.$$typeof===st)return gl(l)}throw Error(S(438,String(l)))}function pi(l){var t=null,u=R.updateQueue;if(u!==null&&(t=u.memoCache),t==null){var a=R.alternate;a!==null&&(a=a.updateQueue,a!==null&&(a=a.memoCache,a!=null&&(t={data:a.data.map(function(e){return e.slice()}),index:0})))}if(t==null&&(t={data:[],index:0}),u===null&&(u=En(),R.updateQueue=u),u.memoCache=t,u=t.data[t.index],u===void 0)for(u=t.data[t.index]=Array(l),a=0;a<l;a++)u[a]=Jd;return t.index++,u}function rt(l,t){return typeof t=="function"?t(l):t}function He(l){var t=ul();return qi(t,X,l)}function qi(l,t,u){var a=l.queue;if(a===null)throw Error(S(311));a.lastRenderedReducer=u;var e=l.baseQueue,n=a.pending;if(n!==null){if(e!==null){var c=e.next;e.next=n.next,n.next=c}t.baseQueue=e=n,a.pending=null}if(n=l.baseState,e===null)l.memoizedState=n;else{t=e.next;var i=c=null,f=null,s=t,m=!1;do{var g=s.lane&-536870913;if(g!==s.lane?(j&g)===g:(xt&g)===g){var h=s.revertLane;if(h===0)f!==null&&(f=f.next={lane:0,revertLane:0,action:s.action,hasEagerState:s.hasEagerState,eagerState:s.eagerState,next:null}),g===Ku&&(m=!0);else if((xt&h)===h){s=s.next,h===Ku&&(m=!0);continue}else g={lane:0,revertLane:s.revertLane,action:s.action,hasEagerState:s.hasEagerState,eagerState:s.eagerState,next:null},f===null?(i=f=g,c=n):f=f.next=g,R.lanes|=h,Vt|=h;g=s.action,iu&&u(n,g),n=s.hasEagerState?s.eagerState:u(n,g)}else h={lane:g,revertLane:s.revertLane,action:s.action,hasEagerState:s.hasEagerState,eagerState:s.eagerState,next:null},f===null?(i=f=h,c=n):f=f.next=h,R.lanes|=g,Vt|=g;s=s.next}while(s!==null&&s!==t);if(f===null?c=n:f.next=i,!Nl(n,l.memoizedState)&&(dl=!0,m&&(u=Cu,u!==null)))throw u;l.memoizedState=n,l.baseState=c,l.baseQueue=f,a.lastRenderedState=n}return e===null&&(a.lanes=0),[l.memoizedState,a.dispatch]}function Ln(l){var t=ul(),u=t.queue;if(u===null)throw Error(S(311));u.lastRenderedReducer=l;var a=u.dispatch,e=u.pending,n=t.memoizedState;if(e!==null){u.pending=null;var c=e=e.next;do n=l(n,c.action),c=c.next;while(c!==e);Nl(n,t.memoizedState)||(dl=!0),t.memoizedState=n,t.baseQueue===null&&(t.baseState=n),u.lastRenderedState=n}return[n,a]}function Cs(l,t,u){var a=R,e=ul(),n=B;if(n){if(u===void 0)throw Error(S(407));u=u()}else u=t();var c=!Nl((X||e).memoizedState,u);if(c&&(e.memoizedState=u,dl=!0),e=e.queue,Yi(Qs.bind(null,a,e,l),[l]),e.getSnapshot!==t||c||tl!==null&&tl.memoizedState.tag&1){if(a.flags|=2048,Ju(9,Xs.bind(null,a,e,u,t),{destroy:void 0},null),V===null)throw Error(S(349));n||xt&60||Gs(a,t,u)}return u}function Gs(l,t,u){l.flags|=16384,l={getSnapshot:t,value:u},t=R.updateQueue,t===null?(t=En(),R.updateQueue=t,t.stores=[l]):(u=t.stores,u===null?t.stores=[l]:u.push(l))}function Xs(l,t,u,a){t.value=u,t.getSnapshot=a,xs(t)&&Zs(l)}function Qs(l,t,u){return u(function(){xs(t)&&Zs(l)})}function xs(l){var t=l.getSnapshot;l=l.value;try{var u=t();return!Nl(l,u)}catch{return!0}}function Zs(l){var t=Qt(l,2);t!==null&&El(t,l,2)}function Rc(l){var t=Al();if(typeof l=="function"){var u=l;if(l=u(),iu){Rt(!0);try{u()}finally{Rt(!1)}}}return t.memoizedState=t.baseState=l,t.queue={pending:null,lanes:0,dispatch:null,lastRenderedReducer:rt,lastRenderedState:l},t}function Vs(l,t,u,a){return l.baseState=u,qi(l,X,typeof a=="function"?a:rt)}function vh(l,t,u,a,e){if(zn(l))throw Error(S(485));if(l=t.action,l!==null){var n={payload:e,action:l,next:null,isTransition:!0,status:"pending",value:null,reason:null,listeners:[],then:function(c){n.listeners.push(c)}};M.T!==null?u(!0):n.isTransition=!1,a(n),u=t.pending,u===null?(n.next=t.pending=n,Ls(t,n)):(n.next=u.next,t.pending=u.next=n)}}function Ls(l,t){var u=t.action,a=t.payload,e=l.state;if(t.isTransition){var n=M.T,c={};M.T=c;try{var i=u(e,a),f=M.S;f!==null&&f(c,i),Qf(l,t,i)}catch(s){Nc(l,t,s)}finally{M.T=n}}else try{n=u(e,a),Qf(l,t,n)}catch(s){Nc(l,t,s)}}function Qf(l,t,u){u!==null&&typeof u=="object"&&typeof u.then=="function"?u.then(function(a){xf(l,t,a)},function(a){return Nc(l,t,a)}):xf(l,t,u)}function xf(l,t,u){t.status="fulfilled",t.value=u,Ks(t),l.state=u,t=l.pending,t!==null&&(u=t.next,u===t?l.pending=null:(u=u.next,t.next=u,Ls(l,u)))}function Nc(l,t,u){var a=l.pending;if(l.pending=null,a!==null){a=a.next;do t.status="rejected",t.reason=u,Ks(t),t=t.next;while(t!==a)}l.action=null}function Ks(l){l=l.listeners;for(var t=0;t<l.length;t++)(0,l[t])()}function Js(l,t){return t}function ws(l,t){if(B){var u=V.formState;if(u!==null){l:{var a=R;if(B){if(ol){t:{for(var e=ol,n=$l;e.nodeType!==8;){if(!n){e=null;break t}if(e=Jl(e.nextSibling),e===null){e=null;break t}}n=e.data,e=n==="F!"||n==="F"?e:null}if(e){ol=Jl(e.nextSibling),a=e.data==="F!";break l}}nu(a)}a=!1}a&&(t=u[0])}}return u=Al(),u.memoizedState=u.baseState=t,a={pending:null,lanes:0,dispatch:null,lastRenderedReducer:Js,lastRenderedState:t},u.queue=a,u=d1.bind(null,R,a),a.dispatch=u,a=Rc(!1),n=Gi.bind(null,R,!1,a.queue),a=Al(),e={state:t,dispatch:null,action:l,pending:null},a.queue=e,u=vh.bind(null,R,e,n,u),e.dispatch=u,a.memoizedState=l,[t,u,!1]}function $s(l){var t=ul();return Ws(t,X,l)}function Ws(l,t,u){t=qi(l,t,Js)[0],l=He(rt)[0],t=typeof t=="object"&&t!==null&&typeof t.then=="function"?Ia(t):t;var a=ul(),e=a.queue,n=e.dispatch;return u!==a.memoizedState&&(R.flags|=2048,Ju(9,hh.bind(null,e,u),{destroy:void 0},null)),[t,n,l]}function hh(l,t){l.action=t}function ks(l){var t=ul(),u=X;if(u!==null)return Ws(t,u,l);ul(),t=t.memoizedState,u=ul();var a=u.queue.dispatch;return u.memoizedState=l,[t,a,!1]}function Ju(l,t,u,a){return l={tag:l,create:t,inst:u,deps:a,next:null},t=R.updateQueue,t===null&&(t=En(),R.updateQueue=t),u=t.lastEffect,u===null?t.lastEffect=l.next=l:(a=u.next,u.next=l,l.next=a,t.lastEffect=l),l}function Fs(){return ul().memoizedState}function Re(l,t,u,a){var e=Al();R.flags|=l,e.memoizedState=Ju(1|t,u,{destroy:void 0},a===void 0?null:a)}function An(l,t,u,a){var e=ul();a=a===void 0?null:a;var n=e.memoizedState.inst;X!==null&&a!==null&&Mi(a,X.memoizedState.deps)?e.memoizedState=Ju(t,u,n,a):(R.flags|=l,e.memoizedState=Ju(1|t,u,n,a))}function Zf(l,t){Re(8390656,8,l,t)}function Yi(l,t){An(2048,8,l,t)}function Ps(l,t){return An(4,2,l,t)}function Is(l,t){return An(4,4,l,t)}function l1(l,t){if(typeof t=="function"){l=l();var u=t(l);return function(){typeof u=="function"?u():t(null)}}if(t!=null)return l=l(),t.current=l,function(){t.current=null}}function t1(l,t,u){u=u!=null?u.concat([l]):null,An(4,4,l1.bind(null,t,l),u)}function ji(){}function u1(l,t){var u=ul();t=t===void 0?null:t;var a=u.memoizedState;return t!==null&&Mi(t,a[1])?a[0]:(u.memoizedState=[l,t],l)}function a1(l,t){var u=ul();t=t===void 0?null:t;var a=u.memoizedState;if(t!==null&&Mi(t,a[1]))return a[0];if(a=l(),iu){Rt(!0);try{l()}finally{Rt(!1)}}return u.memoizedState=[a,t],a}function Bi(l,t,u){return u===void 0||xt&1073741824?l.memoizedState=t:(l.memoizedState=u,l=K1(),R.lanes|=l,Vt|=l,u)}function e1(l,t,u,a){return Nl(u,t)?u:Lu.current!==null?(l=Bi(l,u,a),Nl(l,t)||(dl=!0),l):xt&42?(l=K1(),R.lanes|=l,Vt|=l,t):(dl=!0,l.memoizedState=u)}function n1(l,t,u,a,e){var n=L.p;L.p=n!==0&&8>n?n:8;var c=M.T,i={};M.T=i,Gi(l,!1,t,u);try{var f=e(),s=M.S;if(s!==null&&s(i,f),f!==null&&typeof f=="object"&&typeof f.then=="function"){var m=fh(f,a);za(l,t,m,Rl(l))}else za(l,t,a,Rl(l))}catch(g){za(l,t,{then:function(){},status:"rejected",reason:g},Rl())}finally{L.p=n,M.T=c}}function oh(){}function pc(l,t,u,a){if(l.tag!==5)throw Error(S(476));var e=c1(l).queue;n1(l,e,t,It,u===null?oh:function(){return i1(l),u(a)})}function c1(l){var t=l.memoizedState;if(t!==null)return t;t={memoizedState:It,baseState:It,baseQueue:null,queue:{pending:null,lanes:0,dispatch:null,lastRenderedReducer:rt,lastRenderedState:It},next:null};var u={};return t.next={memoizedState:u,baseState:u,baseQueue:null,queue:{pending:null,lanes:0,dispatch:null,lastRenderedReducer:rt,lastRenderedState:u},next:null},l.memoizedState=t,l=l.alternate,l!==null&&(l.memoizedState=t),t}function i1(l){var t=c1(l).next.queue;za(l,t,{},Rl())}function Ci(){return gl(Va)}function f1(){return ul().memoizedState}function s1(){return ul().memoizedState}function yh(l){for(var t=l.return;t!==null;){switch(t.tag){case 24:case 3:var u=Rl();l=qt(u);var a=Yt(t,l,u);a!==null&&(El(a,t,u),_a(a,t,u)),t={cache:_i()},l.payload=t;return}t=t.return}}function mh(l,t,u){var a=Rl();u={lane:a,revertLane:0,action:u,hasEagerState:!1,eagerState:null,next:null},zn(l)?v1(t,u):(u=Ti(l,t,u,a),u!==null&&(El(u,l,a),h1(u,t,a)))}function d1(l,t,u){var a=Rl();za(l,t,u,a)}function za(l,t,u,a){var e={lane:a,revertLane:0,action:u,hasEagerState:!1,eagerState:null,next:null};if(zn(l))v1(t,e);else{var n=l.alternate;if(l.lanes===0&&(n===null||n.lanes===0)&&(n=t.lastRenderedReducer,n!==null))try{var c=t.lastRenderedState,i=n(c,u);if(e.hasEagerState=!0,e.eagerState=i,Nl(i,c))return bn(l,t,e,0),V===null&&Sn(),!1}catch{}finally{}if(u=Ti(l,t,e,a),u!==null)return El(u,l,a),h1(u,t,a),!0}return!1}function Gi(l,t,u,a){if(a={lane:2,revertLane:$i(),action:a,hasEagerState:!1,eagerState:null,next:null},zn(l)){if(t)throw Error(S(479))}else t=Ti(l,u,a,2),t!==null&&El(t,l,2)}function zn(l){var t=l.alternate;return l===R||t!==null&&t===R}function v1(l,t){Gu=we=!0;var u=l.pending;u===null?t.next=t:(t.next=u.next,u.next=t),l.pending=t}function h1(l,t,u){if(u&4194176){var a=t.lanes;a&=l.pendingLanes,u|=a,t.lanes=u,ls(l,u)}}var Il={readContext:gl,use:Tn,useCallback:I,useContext:I,useEffect:I,useImperativeHandle:I,useLayoutEffect:I,useInsertionEffect:I,useMemo:I,useReducer:I,useRef:I,useState:I,useDebugValue:I,useDeferredValue:I,useTransition:I,useSyncExternalStore:I,useId:I};Il.useCacheRefresh=I;Il.useMemoCache=I;Il.useHostTransitionStatus=I;Il.useFormState=I;Il.useActionState=I;Il.useOptimistic=I;var ou={readContext:gl,use:Tn,useCallback:function(l,t){return Al().memoizedState=[l,t===void 0?null:t],l},useContext:gl,useEffect:Zf,useImperativeHandle:function(l,t,u){u=u!=null?u.concat([l]):null,Re(4194308,4,l1.bind(null,t,l),u)},useLayoutEffect:function(l,t){return Re(4194308,4,l,t)},useInsertionEffect:function(l,t){Re(4,2,l,t)},useMemo:function(l,t){var u=Al();t=t===void 0?null:t;var a=l();if(iu){Rt(!0);try{l()}finally{Rt(!1)}}return u.memoizedState=[a,t],a},useReducer:function(l,t,u){var a=Al();if(u!==void 0){var e=u(t);if(iu){Rt(!0);try{u(t)}finally{Rt(!1)}}}else e=t;return a.memoizedState=a.baseState=e,l={pending:null,lanes:0,dispatch:null,lastRenderedReducer:l,lastRenderedState:e},a.queue=l,l=l.dispatch=mh.bind(null,R,l),[a.memoizedState,l]},useRef:function(l){var t=Al();return l={current:l},t.memoizedState=l},useState:function(l){l=Rc(l);var t=l.queue,u=d1.bind(null,R,t);return t.dispatch=u,[l.memoizedState,u]},useDebugValue:ji,useDeferredValue:function(l,t){var u=Al();return Bi(u,l,t)},useTransition:function(){var l=Rc(!1);return l=n1.bind(null,R,l.queue,!0,!1),Al().memoizedState=l,[!1,l]},useSyncExternalStore:function(l,t,u){var a=R,e=Al();if(B){if(u===void 0)throw Error(S(407));u=u()}else{if(u=t(),V===null)throw Error(S(349));j&60||Gs(a,t,u)}e.memoizedState=u;var n={value:u,getSnapshot:t};return e.queue=n,Zf(Qs.bind(null,a,n,l),[l]),a.flags|=2048,Ju(9,Xs.bind(null,a,n,u,t),{destroy:void 0},null),u},useId:function(){var l=Al(),t=V.identifierPrefix;if(B){var u=vt,a=dt;u=(a&~(1<<32-Hl(a)-1)).toString(32)+u,t=":"+t+"R"+u,u=$e++,0<u&&(t+="H"+u.toString(32)),t+=":"}else u=sh++,t=":"+t+"r"+u.toString(32)+":";return l.memoizedState=t},useCacheRefresh:function(){return Al().memoizedState=yh.bind(null,R)}};ou.useMemoCache=pi;ou.useHostTransitionStatus=Ci;ou.useFormState=ws;ou.useActionState=ws;ou.useOptimistic=function(l){var t=Al();t.memoizedState=t.baseState=l;var u={pending:null,lanes:0,dispatch:null,lastRenderedReducer:null,lastRenderedState:null};return t.queue=u,t=Gi.bind(null,R,!0,u),u.dispatch=t,[l,t]};var Kt={readContext:gl,use:Tn,useCallback:u1,useContext:gl,useEffect:Yi,useImperativeHandle:t1,useInsertionEffect:Ps,useLayoutEffect:Is,useMemo:a1,useReducer:He,useRef:Fs,useState:function(){return He(rt)},useDebugValue:ji,useDeferredValue:function(l,t){var u=ul();return e1(u,X.memoizedState,l,t)},useTransition:function(){var l=He(rt)[0],t=ul().memoizedState;return[typeof l=="boolean"?l:Ia(l),t]},useSyncExternalStore:Cs,useId:f1};Kt.useCacheRefresh=s1;Kt.useMemoCache=pi;Kt.useHostTransitionStatus=Ci;Kt.useFormState=$s;Kt.useActionState=$s;Kt.useOptimistic=function(l,t){var u=ul();return Vs(u,X,l,t)};var yu={readContext:gl,use:Tn,useCallback:u1,useContext:gl,useEffect:Yi,useImperativeHandle:t1,useInsertionEffect:Ps,useLayoutEffect:Is,useMemo:a1,useReducer:Ln,useRef:Fs,useState:function(){return Ln(rt)},useDebugValue:ji,useDeferredValue:function(l,t){var u=ul();return X===null?Bi(u,l,t):e1(u,X.memoizedState,l,t)},useTransition:function(){var l=Ln(rt)[0],t=ul().memoizedState;return[typeof l=="boolean"?l:Ia(l),t]},useSyncExternalStore:Cs,useId:f1};yu.useCacheRefresh=s1;yu.useMemoCache=pi;yu.useHostTransitionStatus=Ci;yu.useFormState=ks;yu.useActionState=ks;yu.useOptimistic=function(l,t){var u=ul();return X!==null?Vs(u,X,l,t):(u.baseState=l,[l,u.queue.dispatch])};function Kn(l,t,u,a){t=l.memoizedState,u=u(a,t),u=u==null?t:K({},t,u),l.memoizedState=u,l.lanes===0&&(l.updateQueue.baseState=u)}var qc={isMounted:function(l){return(l=l._reactInternals)?Fu(l)===l:!1},enqueueSetState:function(l,t,u){l=l._reactInternals;var a=Rl(),e=qt(a);e.payload=t,u!=null&&(e.callback=u),t=Yt(l,e,a),t!==null&&(El(t,l,a),_a(t,l,a))},enqueueReplaceState:function(l,t,u){l=l._reactInternals;var a=Rl(),e=qt(a);e.tag=1,e.payload=t,u!=null&&(e.callback=u),t=Yt(l,e,a),t!==null&&(El(t,l,a),_a(t,l,a))},enqueueForceUpdate:function(l,t){l=l._reactInternals;var u=Rl(),a=qt(u);a.tag=2,t!=null&&(a.callback=t),t=Yt(l,a,u),t!==null&&(El(t,l,u),_a(t,l,u))}};function Vf(l,t,u,a,e,n,c){return l=l.stateNode,typeof l.shouldComponentUpdate=="function"?l.shouldComponentUpdate(a,n,c):t.prototype&&t.prototype.isPureReactComponent?!Ba(u,a)||!Ba(e,n):!0}function Lf(l,t,u,a){l=t.state,typeof t.componentWillReceiveProps=="function"&&t.componentWillReceiveProps(u,a),typeof t.UNSAFE_componentWillReceiveProps=="function"&&t.UNSAFE_componentWillReceiveProps(u,a),t.state!==l&&qc.enqueueReplaceState(t,t.state,null)}function fu(l,t){var u=t;if("ref"in t){u={};for(var a in t)a!=="ref"&&(u[a]=t[a])}if(l=l.defaultProps){u===t&&(u=K({},u));for(var e in l)u[e]===void 0&&(u[e]=l[e])}return u}var We=typeof reportError=="function"?reportError:function(l){if(typeof window=="object"&&typeof window.ErrorEvent=="function"){var t=new window.ErrorEvent("error",{bubbles:!0,cancelable:!0,message:typeof l=="object"&&l!==null&&typeof l.message=="string"?String(l.message):String(l),error:l});if(!window.dispatchEvent(t))return}else if(typeof process=="object"&&typeof process.emit=="function"){process.emit("uncaughtException",l);return}console.error(l)};function o1(l){We(l)}function y1(l){console.error(l)}function m1(l){We(l)}function ke(l,t){try{var u=l.onUncaughtError;u(t.value,{componentStack:t.stack})}catch(a){setTimeout(function(){throw a})}}function Kf(l,t,u){try{var a=l.onCaughtError;a(u.value,{componentStack:u.stack,errorBoundary:t.tag===1?t.stateNode:null})}catch(e){setTimeout(function(){throw e})}}function Yc(l,t,u){return u=qt(u),u.tag=3,u.payload={element:null},u.callback=function(){ke(l,t)},u}function r1(l){return l=qt(l),l.tag=3,l}function g1(l,t,u,a){var e=u.type.getDerivedStateFromError;if(typeof e=="function"){var n=a.value;l.payload=function(){return e(n)},l.callback=function(){Kf(t,u,a)}}var c=u.stateNode;c!==null&&typeof c.componentDidCatch=="function"&&(l.callback=function(){Kf(t,u,a),typeof e!="function"&&(Bt===null?Bt=new Set([this]):Bt.add(this));var i=a.stack;this.componentDidCatch(a.value,{componentStack:i!==null?i:""})})}function rh(l,t,u,a,e){if(u.flags|=32768,a!==null&&typeof a=="object"&&typeof a.then=="function"){if(t=u.alternate,t!==null&&le(t,u,e,!0),u=Zl.current,u!==null){switch(u.tag){case 13:return Pl===null?Wc():u.alternate===null&&F===0&&(F=3),u.flags&=-257,u.flags|=65536,u.lanes=e,a===Mc?u.flags|=16384:(t=u.updateQueue,t===null?u.updateQueue=new Set([a]):t.add(a),ec(l,a,e)),!1;case 22:return u.flags|=65536,a===Mc?u.flags|=16384:(t=u.updateQueue,t===null?(t={transitions:null,markerInstances:null,retryQueue:new Set([a])},u.updateQueue=t):(u=t.retryQueue,u===null?t.retryQueue=new Set([a]):u.add(a)),ec(l,a,e)),!1}throw Error(S(435,u.tag))}return ec(l,a,e),Wc(),!1}if(B)return t=Zl.current,t!==null?(!(t.flags&65536)&&(t.flags|=256),t.flags|=65536,t.lanes=e,a!==Dc&&(l=Error(S(422),{cause:a}),Ca(Xl(l,u)))):(a!==Dc&&(t=Error(S(423),{cause:a}),Ca(Xl(t,u))),l=l.current.alternate,l.flags|=65536,e&=-e,l.lanes|=e,a=Xl(a,u),e=Yc(l.stateNode,a,e),Fn(l,e),F!==4&&(F=2)),!1;var n=Error(S(520),{cause:a});if(n=Xl(n,u),Ha===null?Ha=[n]:Ha.push(n),F!==4&&(F=2),t===null)return!0;a=Xl(a,u),u=t;do{switch(u.tag){case 3:return u.flags|=65536,l=e&-e,u.lanes|=l,l=Yc(u.stateNode,a,l),Fn(u,l),!1;case 1:if(t=u.type,n=u.stateNode,(u.flags&128)===0&&(typeof t.getDerivedStateFromError=="function"||n!==null&&typeof n.componentDidCatch=="function"&&(Bt===null||!Bt.has(n))))return u.flags|=65536,e&=-e,u.lanes|=e,e=r1(e),g1(e,l,u,a),Fn(u,e),!1}u=u.return}while(u!==null);return!1}var S1=Error(S(461)),dl=!1;function hl(l,t,u,a){t.child=l===null?ps(t,null,u,a):cu(t,l.child,u,a)}function Jf(l,t,u,a,e){u=u.render;var n=t.ref;if("ref"in a){var c={};for(var i in a)i!=="ref"&&(c[i]=a[i])}else c=a;return su(t),a=Ui(l,t,u,c,n,e),i=Hi(),l!==null&&!dl?(Ri(l,t,e),gt(l,t,e)):(B&&i&&Ai(t),t.flags|=1,hl(l,t,a,e),t.child)}function wf(l,t,u,a,e){if(l===null){var n=u.type;return typeof n=="function"&&!Vi(n)&&n.defaultProps===void 0&&u.compare===null?(t.tag=15,t.type=n,b1(l,t,n,a,e)):(l=pe(u.type,null,a,t,t.mode,e),l.ref=t.ref,l.return=t,t.child=l)}if(n=l.child,!Xi(l,e)){var c=n.memoizedProps;if(u=u.compare,u=u!==null?u:Ba,u(c,a)&&l.ref===t.ref)return gt(l,t,e)}return t.flags|=1,l=jt(n,a),l.ref=t.ref,l.return=t,t.child=l}function b1(l,t,u,a,e){if(l!==null){var n=l.memoizedProps;if(Ba(n,a)&&l.ref===t.ref)if(dl=!1,t.pendingProps=a=n,Xi(l,e))l.flags&131072&&(dl=!0);else return t.lanes=l.lanes,gt(l,t,e)}return jc(l,t,u,a,e)}function E1(l,t,u){var a=t.pendingProps,e=a.children,n=(t.stateNode._pendingVisibility&2)!==0,c=l!==null?l.memoizedState:null;if(Oa(l,t),a.mode==="hidden"||n){if(t.flags&128){if(a=c!==null?c.baseLanes|u:u,l!==null){for(e=t.child=l.child,n=0;e!==null;)n=n|e.lanes|e.childLanes,e=e.sibling;t.childLanes=n&~a}else t.childLanes=0,t.child=null;return $f(l,t,a,u)}if(u&536870912)t.memoizedState={baseLanes:0,cachePool:null},l!==null&&Ue(t,c!==null?c.cachePool:null),c!==null?Cf(t,c):Uc(),qs(t);else return t.lanes=t.childLanes=536870912,$f(l,t,c!==null?c.baseLanes|u:u,u)}else c!==null?(Ue(t,c.cachePool),Cf(t,c),Mt(),t.memoizedState=null):(l!==null&&Ue(t,null),Uc(),Mt());return hl(l,t,e,u),t.child}function $f(l,t,u,a){var e=Di();return e=e===null?null:{parent:el._currentValue,pool:e},t.memoizedState={baseLanes:u,cachePool:e},l!==null&&Ue(t,null),Uc(),qs(t),l!==null&&le(l,t,a,!0),null}function Oa(l,t){var u=t.ref;if(u===null)l!==null&&l.ref!==null&&(t.flags|=2097664);else{if(typeof u!="function"&&typeof u!="object")throw Error(S(284));(l===null||l.ref!==u)&&(t.flags|=2097664)}}function jc(l,t,u,a,e){return su(t),u=Ui(l,t,u,a,void 0,e),a=Hi(),l!==null&&!dl?(Ri(l,t,e),gt(l,t,e)):(B&&a&&Ai(t),t.flags|=1,hl(l,t,u,e),t.child)}function Wf(l,t,u,a,e,n){return su(t),t.updateQueue=null,u=Bs(t,a,u,e),js(l),a=Hi(),l!==null&&!dl?(Ri(l,t,n),gt(l,t,n)):(B&&a&&Ai(t),t.flags|=1,hl(l,t,u,n),t.child)}function kf(l,t,u,a,e){if(su(t),t.stateNode===null){var n=Hu,c=u.contextType;typeof c=="object"&&c!==null&&(n=gl(c)),n=new u(a,n),t.memoizedState=n.state!==null&&n.state!==void 0?n.state:null,n.updater=qc,t.stateNode=n,n._reactInternals=t,n=t.stateNode,n.props=a,n.state=t.memoizedState,n.refs={},Qi(t),c=u.contextType,n.context=typeof c=="object"&&c!==null?gl(c):Hu,n.state=t.memoizedState,c=u.getDerivedStateFromProps,typeof c=="function"&&(Kn(t,u,c,a),n.state=t.memoizedState),typeof u.getDerivedStateFromProps=="function"||typeof n.getSnapshotBeforeUpdate=="function"||typeof n.UNSAFE_componentWillMount!="function"&&typeof n.componentWillMount!="function"||(c=n.state,typeof n.componentWillMount=="function"&&n.componentWillMount(),typeof n.UNSAFE_componentWillMount=="function"&&n.UNSAFE_componentWillMount(),c!==n.state&&qc.enqueueReplaceState(n,n.state,null),Ma(t,a,n,e),Da(),n.state=t.memoizedState),typeof n.componentDidMount=="function"&&(t.flags|=4194308),a=!0}else if(l===null){n=t.stateNode;var i=t.memoizedProps,f=fu(u,i);n.props=f;var s=n.context,m=u.contextType;c=Hu,typeof m=="object"&&m!==null&&(c=gl(m));var g=u.getDerivedStateFromProps;m=typeof g=="function"||typeof n.getSnapshotBeforeUpdate=="function",i=t.pendingProps!==i,m||typeof n.UNSAFE_componentWillReceiveProps!="function"&&typeof n.componentWillReceiveProps!="function"||(i||s!==c)&&Lf(t,n,a,c),_t=!1;var h=t.memoizedState;n.state=h,Ma(t,a,n,e),Da(),s=t.memoizedState,i||h!==s||_t?(typeof g=="function"&&(Kn(t,u,g,a),s=t.memoizedState),(f=_t||Vf(t,u,f,a,h,s,c))?(m||typeof n.UNSAFE_componentWillMount!="function"&&typeof n.componentWillMount!="function"||(typeof n.componentWillMount=="function"&&n.componentWillMount(),typeof n.UNSAFE_componentWillMount=="function"&&n.UNSAFE_componentWillMount()),typeof n.componentDidMount=="function"&&(t.flags|=4194308)):(typeof n.componentDidMount=="function"&&(t.flags|=4194308),t.memoizedProps=a,t.memoizedState=s),n.props=a,n.state=s,n.context=c,a=f):(typeof n.componentDidMount=="function"&&(t.flags|=4194308),a=!1)}else{n=t.stateNode,xc(l,t),c=t.memoizedProps,m=fu(u,c),n.props=m,g=t.pendingProps,h=n.context,s=u.contextType,f=Hu,typeof s=="object"&&s!==null&&(f=gl(s)),i=u.getDerivedStateFromProps,(s=typeof i=="function"||typeof n.getSnapshotBeforeUpdate=="function")||typeof n.UNSAFE_componentWillReceiveProps!="function"&&typeof n.componentWillReceiveProps!="function"||(c!==g||h!==f)&&Lf(t,n,a,f),_t=!1,h=t.memoizedState,n.state=h,Ma(t,a,n,e),Da();var r=t.memoizedState;c!==g||h!==r||_t||l!==null&&l.dependencies!==null&&Fe(l.dependencies)?(typeof i=="function"&&(Kn(t,u,i,a),r=t.memoizedState),(m=_t||Vf(t,u,m,a,h,r,f)||l!==null&&l.dependencies!==null&&Fe(l.dependencies))?(s||typeof n.UNSAFE_componentWillUpdate!="function"&&typeof n.componentWillUpdate!="function"||(typeof n.componentWillUpdate=="function"&&n.componentWillUpdate(a,r,f),typeof n.UNSAFE_componentWillUpdate=="function"&&n.UNSAFE_componentWillUpdate(a,r,f)),typeof n.componentDidUpdate=="function"&&(t.flags|=4),typeof n.getSnapshotBeforeUpdate=="function"&&(t.flags|=1024)):(typeof n.componentDidUpdate!="function"||c===l.memoizedProps&&h===l.memoizedState||(t.flags|=4),typeof n.getSnapshotBeforeUpdate!="function"||c===l.memoizedProps&&h===l.memoizedState||(t.flags|=1024),t.memoizedProps=a,t.memoizedState=r),n.props=a,n.state=r,n.context=f,a=m):(typeof n.componentDidUpdate!="function"||c===l.memoizedProps&&h===l.memoizedState||(t.flags|=4),typeof n.getSnapshotBeforeUpdate!="function"||c===l.memoizedProps&&h===l.memoizedState||(t.flags|=1024),a=!1)}return n=a,Oa(l,t),a=(t.flags&128)!==0,n||a?(n=t.stateNode,u=a&&typeof u.getDerivedStateFromError!="function"?null:n.render(),t.flags|=1,l!==null&&a?(t.child=cu(t,l.child,null,e),t.child=cu(t,null,u,e)):hl(l,t,u,e),t.memoizedState=n.state,l=t.child):l=gt(l,t,e),l}function Ff(l,t,u,a){return Fa(),t.flags|=256,hl(l,t,u,a),t.child}var Jn={dehydrated:null,treeContext:null,retryLane:0};function wn(l){return{baseLanes:l,cachePool:Ys()}}function $n(l,t,u){return l=l!==null?l.childLanes&~u:0,t&&(l|=xl),l}function T1(l,t,u){var a=t.pendingProps,e=!1,n=(t.flags&128)!==0,c;if((c=n)||(c=l!==null&&l.memoizedState===null?!1:(nl.current&2)!==0),c&&(e=!0,t.flags&=-129),c=(t.flags&32)!==0,t.flags&=-33,l===null){if(B){if(e?Dt(t):Mt(),B){var i=ol,f;if(f=i){l:{for(f=i,i=$l;f.nodeType!==8;){if(!i){i=null;break l}if(f=Jl(f.nextSibling),f===null){i=null;break l}}i=f}i!==null?(t.memoizedState={dehydrated:i,treeContext:lu!==null?{id:dt,overflow:vt}:null,retryLane:536870912},f=Ql(18,null,null,0),f.stateNode=i,f.return=t,t.child=f,Sl=t,ol=null,f=!0):f=!1}f||nu(t)}if(i=t.memoizedState,i!==null&&(i=i.dehydrated,i!==null))return i.data==="$!"?t.lanes=16:t.lanes=536870912,null;ht(t)}return i=a.children,a=a.fallback,e?(Mt(),e=t.mode,i=Cc({mode:"hidden",children:i},e),a=uu(a,e,u,null),i.return=t,a.return=t,i.sibling=a,t.child=i,e=t.child,e.memoizedState=wn(u),e.childLanes=$n(l,c,u),t.memoizedState=Jn,a):(Dt(t),Bc(t,i))}if(f=l.memoizedState,f!==null&&(i=f.dehydrated,i!==null)){if(n)t.flags&256?(Dt(t),t.flags&=-257,t=Wn(l,t,u)):t.memoizedState!==null?(Mt(),t.child=l.child,t.flags|=128,t=null):(Mt(),e=a.fallback,i=t.mode,a=Cc({mode:"visible",children:a.children},i),e=uu(e,i,u,null),e.flags|=2,a.return=t,e.return=t,a.sibling=e,t.child=a,cu(t,l.child,null,u),a=t.child,a.memoizedState=wn(u),a.childLanes=$n(l,c,u),t.memoizedState=Jn,t=e);else if(Dt(t),i.data==="$!"){if(c=i.nextSibling&&i.nextSibling.dataset,c)var s=c.dgst;c=s,a=Error(S(419)),a.stack="",a.digest=c,Ca({value:a,source:null,stack:null}),t=Wn(l,t,u)}else if(dl||le(l,t,u,!1),c=(u&l.childLanes)!==0,dl||c){if(c=V,c!==null){if(a=u&-u,a&42)a=1;else switch(a){case 2:a=1;break;case 8:a=4;break;case 32:a=16;break;case 128:case 256:case 512:case 1024:case 2048:case 4096:case 8192:case 16384:case 32768:case 65536:case 131072:case 262144:case 524288:case 1048576:case 2097152:case 4194304:case 8388608:case 16777216:case 33554432:a=64;break;case 268435456:a=134217728;break;default:a=0}if(a=a&(c.suspendedLanes|u)?0:a,a!==0&&a!==f.retryLane)throw f.retryLane=a,Qt(l,a),El(c,l,a),S1}i.data==="$?"||Wc(),t=Wn(l,t,u)}else i.data==="$?"?(t.flags|=128,t.child=l.child,t=Nh.bind(null,l),i._reactRetry=t,t=null):(l=f.treeContext,ol=Jl(i.nextSibling),Sl=t,B=!0,Kl=null,$l=!1,l!==null&&(Bl[Cl++]=dt,Bl[Cl++]=vt,Bl[Cl++]=lu,dt=l.id,vt=l.overflow,lu=t),t=Bc(t,a.children),t.flags|=4096);return t}return e?(Mt(),e=a.fallback,i=t.mode,f=l.child,s=f.sibling,a=jt(f,{mode:"hidden",children:a.children}),a.subtreeFlags=f.subtreeFlags&31457280,s!==null?e=jt(s,e):(e=uu(e,i,u,null),e.flags|=2),e.return=t,a.return=t,a.sibling=e,t.child=a,a=e,e=t.child,i=l.child.memoizedState,i===null?i=wn(u):(f=i.cachePool,f!==null?(s=el._currentValue,f=f.parent!==s?{parent:s,pool:s}:f):f=Ys(),i={baseLanes:i.baseLanes|u,cachePool:f}),e.memoizedState=i,e.childLanes=$n(l,c,u),t.memoizedState=Jn,a):(Dt(t),u=l.child,l=u.sibling,u=jt(u,{mode:"visible",children:a.children}),u.return=t,u.sibling=null,l!==null&&(c=t.deletions,c===null?(t.deletions=[l],t.flags|=16):c.push(l)),t.child=u,t.memoizedState=null,u)}function Bc(l,t){return t=Cc({mode:"visible",children:t},l.mode),t.return=l,l.child=t}function Cc(l,t){return V1(l,t,0,null)}function Wn(l,t,u){return cu(t,l.child,null,u),l=Bc(t,t.pendingProps.children),l.flags|=2,t.memoizedState=null,l}function Pf(l,t,u){l.lanes|=t;var a=l.alternate;a!==null&&(a.lanes|=t),Xc(l.return,t,u)}function kn(l,t,u,a,e){var n=l.memoizedState;n===null?l.memoizedState={isBackwards:t,rendering:null,renderingStartTime:0,last:a,tail:u,tailMode:e}:(n.isBackwards=t,n.rendering=null,n.renderingStartTime=0,n.last=a,n.tail=u,n.tailMode=e)}function A1(l,t,u){var a=t.pendingProps,e=a.revealOrder,n=a.tail;if(hl(l,t,a.children,u),a=nl.current,a&2)a=a&1|2,t.flags|=128;else{if(l!==null&&l.flags&128)l:for(l=t.child;l!==null;){if(l.tag===13)l.memoizedState!==null&&Pf(l,u,t);else if(l.tag===19)Pf(l,u,t);else if(l.child!==null){l.child.return=l,l=l.child;continue}if(l===t)break l;for(;l.sibling===null;){if(l.return===null||l.return===t)break l;l=l.return}l.sibling.return=l.return,l=l.sibling}a&=1}switch(J(nl,a),e){case"forwards":for(u=t.child,e=null;u!==null;)l=u.alternate,l!==null&&Je(l)===null&&(e=u),u=u.sibling;u=e,u===null?(e=t.child,t.child=null):(e=u.sibling,u.sibling=null),kn(t,!1,e,u,n);break;case"backwards":for(u=null,e=t.child,t.child=null;e!==null;){if(l=e.alternate,l!==null&&Je(l)===null){t.child=e;break}l=e.sibling,e.sibling=u,u=e,e=l}kn(t,!0,u,null,n);break;case"together":kn(t,!1,null,null,void 0);break;default:t.memoizedState=null}return t.child}function gt(l,t,u){if(l!==null&&(t.dependencies=l.dependencies),Vt|=t.lanes,!(u&t.childLanes))if(l!==null){if(le(l,t,u,!1),(u&t.childLanes)===0)return null}else return null;if(l!==null&&t.child!==l.child)throw Error(S(153));if(t.child!==null){for(l=t.child,u=jt(l,l.pendingProps),t.child=u,u.return=t;l.sibling!==null;)l=l.sibling,u=u.sibling=jt(l,l.pendingProps),u.return=t;u.sibling=null}return t.child}function Xi(l,t){return l.lanes&t?!0:(l=l.dependencies,!!(l!==null&&Fe(l)))}function gh(l,t,u){switch(t.tag){case 3:Ce(t,t.stateNode.containerInfo),Ut(t,el,l.memoizedState.cache),Fa();break;case 27:case 5:gc(t);break;case 4:Ce(t,t.stateNode.containerInfo);break;case 10:Ut(t,t.type,t.memoizedProps.value);break;case 13:var a=t.memoizedState;if(a!==null)return a.dehydrated!==null?(Dt(t),t.flags|=128,null):u&t.child.childLanes?T1(l,t,u):(Dt(t),l=gt(l,t,u),l!==null?l.sibling:null);Dt(t);break;case 19:var e=(l.flags&128)!==0;if(a=(u&t.childLanes)!==0,a||(le(l,t,u,!1),a=(u&t.childLanes)!==0),e){if(a)return A1(l,t,u);t.flags|=128}if(e=t.memoizedState,e!==null&&(e.rendering=null,e.tail=null,e.lastEffect=null),J(nl,nl.current),a)break;return null;case 22:case 23:return t.lanes=0,E1(l,t,u);case 24:Ut(t,el,l.memoizedState.cache)}return gt(l,t,u)}function z1(l,t,u){if(l!==null)if(l.memoizedProps!==t.pendingProps)dl=!0;else{if(!Xi(l,u)&&!(t.flags&128))return dl=!1,gh(l,t,u);dl=!!(l.flags&131072)}else dl=!1,B&&t.flags&1048576&&Us(t,Le,t.index);switch(t.lanes=0,t.tag){case 16:l:{l=t.pendingProps;var a=t.elementType,e=a._init;if(a=e(a._payload),t.type=a,typeof a=="function")Vi(a)?(l=fu(a,l),t.tag=1,t=kf(null,t,a,l,u)):(t.tag=0,t=jc(null,t,a,l,u));else{if(a!=null){if(e=a.$$typeof,e===di){t.tag=11,t=Jf(null,t,a,l,u);break l}else if(e===vi){t.tag=14,t=wf(null,t,a,l,u);break l}}throw t=mc(a)||a,Error(S(306,t,""))}}return t;case 0:return jc(l,t,t.type,t.pendingProps,u);case 1:return a=t.type,e=fu(a,t.pendingProps),kf(l,t,a,e,u);case 3:l:{if(Ce(t,t.stateNode.containerInfo),l===null)throw Error(S(387));var n=t.pendingProps;e=t.memoizedState,a=e.element,xc(l,t),Ma(t,n,null,u);var c=t.memoizedState;if(n=c.cache,Ut(t,el,n),n!==e.cache&&Qc(t,[el],u,!0),Da(),n=c.element,e.isDehydrated)if(e={element:n,isDehydrated:!1,cache:c.cache},t.updateQueue.baseState=e,t.memoizedState=e,t.flags&256){t=Ff(l,t,n,u);break l}else if(n!==a){a=Xl(Error(S(424)),t),Ca(a),t=Ff(l,t,n,u);break l}else for(ol=Jl(t.stateNode.containerInfo.firstChild),Sl=t,B=!0,Kl=null,$l=!0,u=ps(t,null,n,u),t.child=u;u;)u.flags=u.flags&-3|4096,u=u.sibling;else{if(Fa(),n===a){t=gt(l,t,u);break l}hl(l,t,n,u)}t=t.child}return t;case 26:return Oa(l,t),l===null?(u=r0(t.type,null,t.pendingProps,null))?t.memoizedState=u:B||(u=t.type,l=t.pendingProps,a=en(pt.current).createElement(u),a[rl]=t,a[zl]=l,yl(a,u,l),sl(a),t.stateNode=a):t.memoizedState=r0(t.type,l.memoizedProps,t.pendingProps,l.memoizedState),null;case 27:return gc(t),l===null&&B&&(a=t.stateNode=id(t.type,t.pendingProps,pt.current),Sl=t,$l=!0,ol=Jl(a.firstChild)),a=t.pendingProps.children,l!==null||B?hl(l,t,a,u):t.child=cu(t,null,a,u),Oa(l,t),t.child;case 5:return l===null&&B&&((e=a=ol)&&(a=wh(a,t.type,t.pendingProps,$l),a!==null?(t.stateNode=a,Sl=t,ol=Jl(a.firstChild),$l=!1,e=!0):e=!1),e||nu(t)),gc(t),e=t.type,n=t.pendingProps,c=l!==null?l.memoizedProps:null,a=n.children,li(e,n)?a=null:c!==null&&li(e,c)&&(t.flags|=32),t.memoizedState!==null&&(e=Ui(l,t,dh,null,null,u),Va._currentValue=e),Oa(l,t),hl(l,t,a,u),t.child;case 6:return l===null&&B&&((l=u=ol)&&(u=$h(u,t.pendingProps,$l),u!==null?(t.stateNode=u,Sl=t,ol=null,l=!0):l=!1),l||nu(t)),null;case 13:return T1(l,t,u);case 4:return Ce(t,t.stateNode.containerInfo),a=t.pendingProps,l===null?t.child=cu(t,null,a,u):hl(l,t,a,u),t.child;case 11:return Jf(l,t,t.type,t.pendingProps,u);case 7:return hl(l,t,t.pendingProps,u),t.child;case 8:return hl(l,t,t.pendingProps.children,u),t.child;case 12:return hl(l,t,t.pendingProps.children,u),t.child;case 10:return a=t.pendingProps,Ut(t,t.type,a.value),hl(l,t,a.children,u),t.child;case 9:return e=t.type._context,a=t.pendingProps.children,su(t),e=gl(e),a=a(e),t.flags|=1,hl(l,t,a,u),t.child;case 14:return wf(l,t,t.type,t.pendingProps,u);case 15:return b1(l,t,t.type,t.pendingProps,u);case 19:return A1(l,t,u);case 22:return E1(l,t,u);case 24:return su(t),a=gl(el),l===null?(e=Di(),e===null&&(e=V,n=_i(),e.pooledCache=n,n.refCount++,n!==null&&(e.pooledCacheLanes|=u),e=n),t.memoizedState={parent:a,cache:e},Qi(t),Ut(t,el,e)):(l.lanes&u&&(xc(l,t),Ma(t,null,null,u),Da()),e=l.memoizedState,n=t.memoizedState,e.parent!==a?(e={parent:a,cache:a},t.memoizedState=e,t.lanes===0&&(t.memoizedState=t.updateQueue.baseState=e),Ut(t,el,a)):(a=n.cache,Ut(t,el,a),a!==e.cache&&Qc(t,[el],u,!0))),hl(l,t,t.pendingProps.children,u),t.child;case 29:throw t.pendingProps}throw Error(S(156,t.tag))}var Gc=lt(null),mu=null,ot=null;function Ut(l,t,u){J(Gc,t._currentValue),t._currentValue=u}function yt(l){l._currentValue=Gc.current,vl(Gc)}function Xc(l,t,u){for(;l!==null;){var a=l.alternate;if((l.childLanes&t)!==t?(l.childLanes|=t,a!==null&&(a.childLanes|=t)):a!==null&&(a.childLanes&t)!==t&&(a.childLanes|=t),l===u)break;l=l.return}}function Qc(l,t,u,a){var e=l.child;for(e!==null&&(e.return=l);e!==null;){var n=e.dependencies;if(n!==null){var c=e.child;n=n.firstContext;l:for(;n!==null;){var i=n;n=e;for(var f=0;f<t.length;f++)if(i.context===t[f]){n.lanes|=u,i=n.alternate,i!==null&&(i.lanes|=u),Xc(n.return,u,l),a||(c=null);break l}n=i.next}}else if(e.tag===18){if(c=e.return,c===null)throw Error(S(341));c.lanes|=u,n=c.alternate,n!==null&&(n.lanes|=u),Xc(c,u,l),c=null}else c=e.child;if(c!==null)c.return=e;else for(c=e;c!==null;){if(c===l){c=null;break}if(e=c.sibling,e!==null){e.return=c.return,c=e;break}c=c.return}e=c}}function le(l,t,u,a){l=null;for(var e=t,n=!1;e!==null;){if(!n){if(e.flags&524288)n=!0;else if(e.flags&262144)break}if(e.tag===10){var c=e.alternate;if(c===null)throw Error(S(387));if(c=c.memoizedProps,c!==null){var i=e.type;Nl(e.pendingProps.value,c.value)||(l!==null?l.push(i):l=[i])}}else if(e===Be.current){if(c=e.alternate,c===null)throw Error(S(387));c.memoizedState.memoizedState!==e.memoizedState.memoizedState&&(l!==null?l.push(Va):l=[Va])}e=e.return}l!==null&&Qc(t,l,u,a),t.flags|=262144}function Fe(l){for(l=l.firstContext;l!==null;){if(!Nl(l.context._currentValue,l.memoizedValue))return!0;l=l.next}return!1}function su(l){mu=l,ot=null,l=l.dependencies,l!==null&&(l.firstContext=null)}function gl(l){return O1(mu,l)}function ge(l,t){return mu===null&&su(l),O1(l,t)}function O1(l,t){var u=t._currentValue;if(t={context:t,memoizedValue:u,next:null},ot===null){if(l===null)throw Error(S(308));ot=t,l.dependencies={lanes:0,firstContext:t},l.flags|=524288}else ot=ot.next=t;return u}var _t=!1;function Qi(l){l.updateQueue={baseState:l.memoizedState,firstBaseUpdate:null,lastBaseUpdate:null,shared:{pending:null,lanes:0,hiddenCallbacks:null},callbacks:null}}function xc(l,t){l=l.updateQueue,t.updateQueue===l&&(t.updateQueue={baseState:l.baseState,firstBaseUpdate:l.firstBaseUpdate,lastBaseUpdate:l.lastBaseUpdate,shared:l.shared,callbacks:null})}function qt(l){return{lane:l,tag:0,payload:null,callback:null,next:null}}function Yt(l,t,u){var a=l.updateQueue;if(a===null)return null;if(a=a.shared,W&2){var e=a.pending;return e===null?t.next=t:(t.next=e.next,e.next=t),a.pending=t,t=Ze(l),Ms(l,null,u),t}return bn(l,a,t,u),Ze(l)}function _a(l,t,u){if(t=t.updateQueue,t!==null&&(t=t.shared,(u&4194176)!==0)){var a=t.lanes;a&=l.pendingLanes,u|=a,t.lanes=u,ls(l,u)}}function Fn(l,t){var u=l.updateQueue,a=l.alternate;if(a!==null&&(a=a.updateQueue,u===a)){var e=null,n=null;if(u=u.firstBaseUpdate,u!==null){do{var c={lane:u.lane,tag:u.tag,payload:u.payload,callback:null,next:null};n===null?e=n=c:n=n.next=c,u=u.next}while(u!==null);n===null?e=n=t:n=n.next=t}else e=n=t;u={baseState:a.baseState,firstBaseUpdate:e,lastBaseUpdate:n,shared:a.shared,callbacks:a.callbacks},l.updateQueue=u;return}l=u.lastBaseUpdate,l===null?u.firstBaseUpdate=t:l.next=t,u.lastBaseUpdate=t}var Zc=!1;function Da(){if(Zc){var l=Cu;if(l!==null)throw l}}function Ma(l,t,u,a){Zc=!1;var e=l.updateQueue;_t=!1;var n=e.firstBaseUpdate,c=e.lastBaseUpdate,i=e.shared.pending;if(i!==null){e.shared.pending=null;var f=i,s=f.next;f.next=null,c===null?n=s:c.next=s,c=f;var m=l.alternate;m!==null&&(m=m.updateQueue,i=m.lastBaseUpdate,i!==c&&(i===null?m.firstBaseUpdate=s:i.next=s,m.lastBaseUpdate=f))}if(n!==null){var g=e.baseState;c=0,m=s=f=null,i=n;do{var h=i.lane&-536870913,r=h!==i.lane;if(r?(j&h)===h:(a&h)===h){h!==0&&h===Ku&&(Zc=!0),m!==null&&(m=m.next={lane:0,tag:i.tag,payload:i.payload,callback:null,next:null});l:{var T=l,E=i;h=t;var Q=u;switch(E.tag){case 1:if(T=E.payload,typeof T=="function"){g=T.call(Q,g,h);break l}g=T;break l;case 3:T.flags=T.flags&-65537|128;case 0:if(T=E.payload,h=typeof T=="function"?T.call(Q,g,h):T,h==null)break l;g=K({},g,h);break l;case 2:_t=!0}}h=i.callback,h!==null&&(l.flags|=64,r&&(l.flags|=8192),r=e.callbacks,r===null?e.callbacks=[h]:r.push(h))}else r={lane:h,tag:i.tag,payload:i.payload,callback:i.callback,next:null},m===null?(s=m=r,f=g):m=m.next=r,c|=h;if(i=i.next,i===null){if(i=e.shared.pending,i===null)break;r=i,i=r.next,r.next=null,e.lastBaseUpdate=r,e.shared.pending=null}}while(!0);m===null&&(f=g),e.baseState=f,e.firstBaseUpdate=s,e.lastBaseUpdate=m,n===null&&(e.shared.lanes=0),Vt|=c,l.lanes=c,l.memoizedState=g}}function _1(l,t){if(typeof l!="function")throw Error(S(191,l));l.call(t)}function D1(l,t){var u=l.callbacks;if(u!==null)for(l.callbacks=null,l=0;l<u.length;l++)_1(u[l],t)}function te(l,t){try{var u=t.updateQueue,a=u!==null?u.lastEffect:null;if(a!==null){var e=a.next;u=e;do{if((u.tag&l)===l){a=void 0;var n=u.create,c=u.inst;a=n(),c.destroy=a}u=u.next}while(u!==e)}}catch(i){x(t,t.return,i)}}function Zt(l,t,u){try{var a=t.updateQueue,e=a!==null?a.lastEffect:null;if(e!==null){var n=e.next;a=n;do{if((a.tag&l)===l){var c=a.inst,i=c.destroy;if(i!==void 0){c.destroy=void 0,e=t;var f=u;try{i()}catch(s){x(e,f,s)}}}a=a.next}while(a!==n)}}catch(s){x(t,t.return,s)}}function M1(l){var t=l.updateQueue;if(t!==null){var u=l.stateNode;try{D1(t,u)}catch(a){x(l,l.return,a)}}}function U1(l,t,u){u.props=fu(l.type,l.memoizedProps),u.state=l.memoizedState;try{u.componentWillUnmount()}catch(a){x(l,t,a)}}function Pt(l,t){try{var u=l.ref;if(u!==null){var a=l.stateNode;switch(l.tag){case 26:case 27:case 5:var e=a;break;default:e=a}typeof u=="function"?l.refCleanup=u(e):u.current=e}}catch(n){x(l,t,n)}}function Ml(l,t){var u=l.ref,a=l.refCleanup;if(u!==null)if(typeof a=="function")try{a()}catch(e){x(l,t,e)}finally{l.refCleanup=null,l=l.alternate,l!=null&&(l.refCleanup=null)}else if(typeof u=="function")try{u(null)}catch(e){x(l,t,e)}else u.current=null}function H1(l){var t=l.type,u=l.memoizedProps,a=l.stateNode;try{l:switch(t){case"button":case"input":case"select":case"textarea":u.autoFocus&&a.focus();break l;case"img":u.src?a.src=u.src:u.srcSet&&(a.srcset=u.srcSet)}}catch(e){x(l,l.return,e)}}function If(l,t,u){try{var a=l.stateNode;Zh(a,l.type,u,t),a[zl]=t}catch(e){x(l,l.return,e)}}function R1(l){return l.tag===5||l.tag===3||l.tag===26||l.tag===27||l.tag===4}function Pn(l){l:for(;;){for(;l.sibling===null;){if(l.return===null||R1(l.return))return null;l=l.return}for(l.sibling.return=l.return,l=l.sibling;l.tag!==5&&l.tag!==6&&l.tag!==27&&l.tag!==18;){if(l.flags&2||l.child===null||l.tag===4)continue l;l.child.return=l,l=l.child}if(!(l.flags&2))return l.stateNode}}function Vc(l,t,u){var a=l.tag;if(a===5||a===6)l=l.stateNode,t?u.nodeType===8?u.parentNode.insertBefore(l,t):u.insertBefore(l,t):(u.nodeType===8?(t=u.parentNode,t.insertBefore(l,u)):(t=u,t.appendChild(l)),u=u._reactRootContainer,u!=null||t.onclick!==null||(t.onclick=Dn));else if(a!==4&&a!==27&&(l=l.child,l!==null))for(Vc(l,t,u),l=l.sibling;l!==null;)Vc(l,t,u),l=l.sibling}function Pe(l,t,u){var a=l.tag;if(a===5||a===6)l=l.stateNode,t?u.insertBefore(l,t):u.appendChild(l);else if(a!==4&&a!==27&&(l=l.child,l!==null))for(Pe(l,t,u),l=l.sibling;l!==null;)Pe(l,t,u),l=l.sibling}var ct=!1,k=!1,In=!1,l0=typeof WeakSet=="function"?WeakSet:Set,fl=null,t0=!1;function Sh(l,t){if(l=l.containerInfo,Pc=sn,l=Es(l),bi(l)){if("selectionStart"in l)var u={start:l.selectionStart,end:l.selectionEnd};else l:{u=(u=l.ownerDocument)&&u.defaultView||window;var a=u.getSelection&&u.getSelection();if(a&&a.rangeCount!==0){u=a.anchorNode;var e=a.anchorOffset,n=a.focusNode;a=a.focusOffset;try{u.nodeType,n.nodeType}catch{u=null;break l}var c=0,i=-1,f=-1,s=0,m=0,g=l,h=null;t:for(;;){for(var r;g!==u||e!==0&&g.nodeType!==3||(i=c+e),g!==n||a!==0&&g.nodeType!==3||(f=c+a),g.nodeType===3&&(c+=g.nodeValue.length),(r=g.firstChild)!==null;)h=g,g=r;for(;;){if(g===l)break t;if(h===u&&++s===e&&(i=c),h===n&&++m===a&&(f=c),(r=g.nextSibling)!==null)break;g=h,h=g.parentNode}g=r}u=i===-1||f===-1?null:{start:i,end:f}}else u=null}u=u||{start:0,end:0}}else u=null;for(Ic={focusedElem:l,selectionRange:u},sn=!1,fl=t;fl!==null;)if(t=fl,l=t.child,(t.subtreeFlags&1028)!==0&&l!==null)l.return=t,fl=l;else for(;fl!==null;){switch(t=fl,n=t.alternate,l=t.flags,t.tag){case 0:break;case 11:case 15:break;case 1:if(l&1024&&n!==null){l=void 0,u=t,e=n.memoizedProps,n=n.memoizedState,a=u.stateNode;try{var T=fu(u.type,e,u.elementType===u.type);l=a.getSnapshotBeforeUpdate(T,n),a.__reactInternalSnapshotBeforeUpdate=l}catch(E){x(u,u.return,E)}}break;case 3:if(l&1024){if(l=t.stateNode.containerInfo,u=l.nodeType,u===9)ti(l);else if(u===1)switch(l.nodeName){case"HEAD":case"HTML":case"BODY":ti(l);break;default:l.textContent=""}}break;case 5:case 26:case 27:case 6:case 4:case 17:break;default:if(l&1024)throw Error(S(163))}if(l=t.sibling,l!==null){l.return=t.return,fl=l;break}fl=t.return}return T=t0,t0=!1,T}function N1(l,t,u){var a=u.flags;switch(u.tag){case 0:case 11:case 15:et(l,u),a&4&&te(5,u);break;case 1:if(et(l,u),a&4)if(l=u.stateNode,t===null)try{l.componentDidMount()}catch(i){x(u,u.return,i)}else{var e=fu(u.type,t.memoizedProps);t=t.memoizedState;try{l.componentDidUpdate(e,t,l.__reactInternalSnapshotBeforeUpdate)}catch(i){x(u,u.return,i)}}a&64&&M1(u),a&512&&Pt(u,u.return);break;case 3:if(et(l,u),a&64&&(a=u.updateQueue,a!==null)){if(l=null,u.child!==null)switch(u.child.tag){case 27:case 5:l=u.child.stateNode;break;case 1:l=u.child.stateNode}try{D1(a,l)}catch(i){x(u,u.return,i)}}break;case 26:et(l,u),a&512&&Pt(u,u.return);break;case 27:case 5:et(l,u),t===null&&a&4&&H1(u),a&512&&Pt(u,u.return);break;case 12:et(l,u);break;case 13:et(l,u),a&4&&Y1(l,u);break;case 22:if(e=u.memoizedState!==null||ct,!e){t=t!==null&&t.memoizedState!==null||k;var n=ct,c=k;ct=e,(k=t)&&!c?zt(l,u,(u.subtreeFlags&8772)!==0):et(l,u),ct=n,k=c}a&512&&(u.memoizedProps.mode==="manual"?Pt(u,u.return):Ml(u,u.return));break;default:et(l,u)}}function p1(l){var t=l.alternate;t!==null&&(l.alternate=null,p1(t)),l.child=null,l.deletions=null,l.sibling=null,l.tag===5&&(t=l.stateNode,t!==null&&oi(t)),l.stateNode=null,l.return=null,l.dependencies=null,l.memoizedProps=null,l.memoizedState=null,l.pendingProps=null,l.stateNode=null,l.updateQueue=null}var ll=null,_l=!1;function at(l,t,u){for(u=u.child;u!==null;)q1(l,t,u),u=u.sibling}function q1(l,t,u){if(Ul&&typeof Ul.onCommitFiberUnmount=="function")try{Ul.onCommitFiberUnmount(wa,u)}catch{}switch(u.tag){case 26:k||Ml(u,t),at(l,t,u),u.memoizedState?u.memoizedState.count--:u.stateNode&&(u=u.stateNode,u.parentNode.removeChild(u));break;case 27:k||Ml(u,t);var a=ll,e=_l;for(ll=u.stateNode,at(l,t,u),u=u.stateNode,t=u.attributes;t.length;)u.removeAttributeNode(t[0]);oi(u),ll=a,_l=e;break;case 5:k||Ml(u,t);case 6:e=ll;var n=_l;if(ll=null,at(l,t,u),ll=e,_l=n,ll!==null)if(_l)try{l=ll,a=u.stateNode,l.nodeType===8?l.parentNode.removeChild(a):l.removeChild(a)}catch(c){x(u,t,c)}else try{ll.removeChild(u.stateNode)}catch(c){x(u,t,c)}break;case 18:ll!==null&&(_l?(t=ll,u=u.stateNode,t.nodeType===8?vc(t.parentNode,u):t.nodeType===1&&vc(t,u),Ja(t)):vc(ll,u.stateNode));break;case 4:a=ll,e=_l,ll=u.stateNode.containerInfo,_l=!0,at(l,t,u),ll=a,_l=e;break;case 0:case 11:case 14:case 15:k||Zt(2,u,t),k||Zt(4,u,t),at(l,t,u);break;case 1:k||(Ml(u,t),a=u.stateNode,typeof a.componentWillUnmount=="function"&&U1(u,t,a)),at(l,t,u);break;case 21:at(l,t,u);break;case 22:k||Ml(u,t),k=(a=k)||u.memoizedState!==null,at(l,t,u),k=a;break;default:at(l,t,u)}}function Y1(l,t){if(t.memoizedState===null&&(l=t.alternate,l!==null&&(l=l.memoizedState,l!==null&&(l=l.dehydrated,l!==null))))try{Ja(l)}catch(u){x(t,t.return,u)}}function bh(l){switch(l.tag){case 13:case 19:var t=l.stateNode;return t===null&&(t=l.stateNode=new l0),t;case 22:return l=l.stateNode,t=l._retryCache,t===null&&(t=l._retryCache=new l0),t;default:throw Error(S(435,l.tag))}}function lc(l,t){var u=bh(l);t.forEach(function(a){var e=ph.bind(null,l,a);u.has(a)||(u.add(a),a.then(e,e))})}function pl(l,t){var u=t.deletions;if(u!==null)for(var a=0;a<u.length;a++){var e=u[a],n=l,c=t,i=c;l:for(;i!==null;){switch(i.tag){case 27:case 5:ll=i.stateNode,_l=!1;break l;case 3:ll=i.stateNode.containerInfo,_l=!0;break l;case 4:ll=i.stateNode.containerInfo,_l=!0;break l}i=i.return}if(ll===null)throw Error(S(160));q1(n,c,e),ll=null,_l=!1,n=e.alternate,n!==null&&(n.return=null),e.return=null}if(t.subtreeFlags&13878)for(t=t.child;t!==null;)j1(t,l),t=t.sibling}var Ll=null;function j1(l,t){var u=l.alternate,a=l.flags;switch(l.tag){case 0:case 11:case 14:case 15:pl(t,l),ql(l),a&4&&(Zt(3,l,l.return),te(3,l),Zt(5,l,l.return));break;case 1:pl(t,l),ql(l),a&512&&(k||u===null||Ml(u,u.return)),a&64&&ct&&(l=l.updateQueue,l!==null&&(a=l.callbacks,a!==null&&(u=l.shared.hiddenCallbacks,l.shared.hiddenCallbacks=u===null?a:u.concat(a))));break;case 26:var e=Ll;if(pl(t,l),ql(l),a&512&&(k||u===null||Ml(u,u.return)),a&4){var n=u!==null?u.memoizedState:null;if(a=l.memoizedState,u===null)if(a===null)if(l.stateNode===null){l:{a=l.type,u=l.memoizedProps,e=e.ownerDocument||e;t:switch(a){case"title":n=e.getElementsByTagName("title")[0],(!n||n[qa]||n[rl]||n.namespaceURI==="http://www.w3.org/2000/svg"||n.hasAttribute("itemprop"))&&(n=e.createElement(a),e.head.insertBefore(n,e.querySelector("head > title"))),yl(n,a,u),n[rl]=l,sl(n),a=n;break l;case"link":var c=S0("link","href",e).get(a+(u.href||""));if(c){for(var i=0;i<c.length;i++)if(n=c[i],n.getAttribute("href")===(u.href==null?null:u.href)&&n.getAttribute("rel")===(u.rel==null?null:u.rel)&&n.getAttribute("title")===(u.title==null?null:u.title)&&n.getAttribute("crossorigin")===(u.crossOrigin==null?null:u.crossOrigin)){c.splice(i,1);break t}}n=e.createElement(a),yl(n,a,u),e.head.appendChild(n);break;case"meta":if(c=S0("meta","content",e).get(a+(u.content||""))){for(i=0;i<c.length;i++)if(n=c[i],n.getAttribute("content")===(u.content==null?null:""+u.content)&&n.getAttribute("name")===(u.name==null?null:u.name)&&n.getAttribute("property")===(u.property==null?null:u.property)&&n.getAttribute("http-equiv")===(u.httpEquiv==null?null:u.httpEquiv)&&n.getAttribute("charset")===(u.charSet==null?null:u.charSet)){c.splice(i,1);break t}}n=e.createElement(a),yl(n,a,u),e.head.appendChild(n);break;default:throw Error(S(468,a))}n[rl]=l,sl(n),a=n}l.stateNode=a}else b0(e,l.type,l.stateNode);else l.stateNode=g0(e,a,l.memoizedProps);else n!==a?(n===null?u.stateNode!==null&&(u=u.stateNode,u.parentNode.removeChild(u)):n.count--,a===null?b0(e,l.type,l.stateNode):g0(e,a,l.memoizedProps)):a===null&&l.stateNode!==null&&If(l,l.memoizedProps,u.memoizedProps)}break;case 27:if(a&4&&l.alternate===null){e=l.stateNode,n=l.memoizedProps;try{for(var f=e.firstChild;f;){var s=f.nextSibling,m=f.nodeName;f[qa]||m==="HEAD"||m==="BODY"||m==="SCRIPT"||m==="STYLE"||m==="LINK"&&f.rel.toLowerCase()==="stylesheet"||e.removeChild(f),f=s}for(var g=l.type,h=e.attributes;h.length;)e.removeAttributeNode(h[0]);yl(e,g,n),e[rl]=l,e[zl]=n}catch(T){x(l,l.return,T)}}case 5:if(pl(t,l),ql(l),a&512&&(k||u===null||Ml(u,u.return)),l.flags&32){e=l.stateNode;try{Vu(e,"")}catch(T){x(l,l.return,T)}}a&4&&l.stateNode!=null&&(e=l.memoizedProps,If(l,e,u!==null?u.memoizedProps:e)),a&1024&&(In=!0);break;case 6:if(pl(t,l),ql(l),a&4){if(l.stateNode===null)throw Error(S(162));a=l.memoizedProps,u=l.stateNode;try{u.nodeValue=a}catch(T){x(l,l.return,T)}}break;case 3:if(Ye=null,e=Ll,Ll=nn(t.containerInfo),pl(t,l),Ll=e,ql(l),a&4&&u!==null&&u.memoizedState.isDehydrated)try{Ja(t.containerInfo)}catch(T){x(l,l.return,T)}In&&(In=!1,B1(l));break;case 4:a=Ll,Ll=nn(l.stateNode.containerInfo),pl(t,l),ql(l),Ll=a;break;case 12:pl(t,l),ql(l);break;case 13:pl(t,l),ql(l),l.child.flags&8192&&l.memoizedState!==null!=(u!==null&&u.memoizedState!==null)&&(Ji=Fl()),a&4&&(a=l.updateQueue,a!==null&&(l.updateQueue=null,lc(l,a)));break;case 22:if(a&512&&(k||u===null||Ml(u,u.return)),f=l.memoizedState!==null,s=u!==null&&u.memoizedState!==null,m=ct,g=k,ct=m||f,k=g||s,pl(t,l),k=g,ct=m,ql(l),t=l.stateNode,t._current=l,t._visibility&=-3,t._visibility|=t._pendingVisibility&2,a&8192&&(t._visibility=f?t._visibility&-2:t._visibility|1,f&&(t=ct||k,u===null||s||t||bu(l)),l.memoizedProps===null||l.memoizedProps.mode!=="manual"))l:for(u=null,t=l;;){if(t.tag===5||t.tag===26||t.tag===27){if(u===null){s=u=t;try{if(e=s.stateNode,f)n=e.style,typeof n.setProperty=="function"?n.setProperty("display","none","important"):n.display="none";else{c=s.stateNode,i=s.memoizedProps.style;var r=i!=null&&i.hasOwnProperty("display")?i.display:null;c.style.display=r==null||typeof r=="boolean"?"":(""+r).trim()}}catch(T){x(s,s.return,T)}}}else if(t.tag===6){if(u===null){s=t;try{s.stateNode.nodeValue=f?"":s.memoizedProps}catch(T){x(s,s.return,T)}}}else if((t.tag!==22&&t.tag!==23||t.memoizedState===null||t===l)&&t.child!==null){t.child.return=t,t=t.child;continue}if(t===l)break l;for(;t.sibling===null;){if(t.return===null||t.return===l)break l;u===t&&(u=null),t=t.return}u===t&&(u=null),t.sibling.return=t.return,t=t.sibling}a&4&&(a=l.updateQueue,a!==null&&(u=a.retryQueue,u!==null&&(a.retryQueue=null,lc(l,u))));break;case 19:pl(t,l),ql(l),a&4&&(a=l.updateQueue,a!==null&&(l.updateQueue=null,lc(l,a)));break;case 21:break;default:pl(t,l),ql(l)}}function ql(l){var t=l.flags;if(t&2){try{if(l.tag!==27){l:{for(var u=l.return;u!==null;){if(R1(u)){var a=u;break l}u=u.return}throw Error(S(160))}switch(a.tag){case 27:var e=a.stateNode,n=Pn(l);Pe(l,n,e);break;case 5:var c=a.stateNode;a.flags&32&&(Vu(c,""),a.flags&=-33);var i=Pn(l);Pe(l,i,c);break;case 3:case 4:var f=a.stateNode.containerInfo,s=Pn(l);Vc(l,s,f);break;default:throw Error(S(161))}}}catch(m){x(l,l.return,m)}l.flags&=-3}t&4096&&(l.flags&=-4097)}function B1(l){if(l.subtreeFlags&1024)for(l=l.child;l!==null;){var t=l;B1(t),t.tag===5&&t.flags&1024&&t.stateNode.reset(),l=l.sibling}}function et(l,t){if(t.subtreeFlags&8772)for(t=t.child;t!==null;)N1(l,t.alternate,t),t=t.sibling}function bu(l){for(l=l.child;l!==null;){var t=l;switch(t.tag){case 0:case 11:case 14:case 15:Zt(4,t,t.return),bu(t);break;case 1:Ml(t,t.return);var u=t.stateNode;typeof u.componentWillUnmount=="function"&&U1(t,t.return,u),bu(t);break;case 26:case 27:case 5:Ml(t,t.return),bu(t);break;case 22:Ml(t,t.return),t.memoizedState===null&&bu(t);break;default:bu(t)}l=l.sibling}}function zt(l,t,u){for(u=u&&(t.subtreeFlags&8772)!==0,t=t.child;t!==null;){var a=t.alternate,e=l,n=t,c=n.flags;switch(n.tag){case 0:case 11:case 15:zt(e,n,u),te(4,n);break;case 1:if(zt(e,n,u),a=n,e=a.stateNode,typeof e.componentDidMount=="function")try{e.componentDidMount()}catch(s){x(a,a.return,s)}if(a=n,e=a.updateQueue,e!==null){var i=a.stateNode;try{var f=e.shared.hiddenCallbacks;if(f!==null)for(e.shared.hiddenCallbacks=null,e=0;e<f.length;e++)_1(f[e],i)}catch(s){x(a,a.return,s)}}u&&c&64&&M1(n),Pt(n,n.return);break;case 26:case 27:case 5:zt(e,n,u),u&&a===null&&c&4&&H1(n),Pt(n,n.return);break;case 12:zt(e,n,u);break;case 13:zt(e,n,u),u&&c&4&&Y1(e,n);break;case 22:n.memoizedState===null&&zt(e,n,u),Pt(n,n.return);break;default:zt(e,n,u)}t=t.sibling}}function xi(l,t){var u=null;l!==null&&l.memoizedState!==null&&l.memoizedState.cachePool!==null&&(u=l.memoizedState.cachePool.pool),l=null,t.memoizedState!==null&&t.memoizedState.cachePool!==null&&(l=t.memoizedState.cachePool.pool),l!==u&&(l!=null&&l.refCount++,u!=null&&Pa(u))}function Zi(l,t){l=null,t.alternate!==null&&(l=t.alternate.memoizedState.cache),t=t.memoizedState.cache,t!==l&&(t.refCount++,l!=null&&Pa(l))}function At(l,t,u,a){if(t.subtreeFlags&10256)for(t=t.child;t!==null;)C1(l,t,u,a),t=t.sibling}function C1(l,t,u,a){var e=t.flags;switch(t.tag){case 0:case 11:case 15:At(l,t,u,a),e&2048&&te(9,t);break;case 3:At(l,t,u,a),e&2048&&(l=null,t.alternate!==null&&(l=t.alternate.memoizedState.cache),t=t.memoizedState.cache,t!==l&&(t.refCount++,l!=null&&Pa(l)));break;case 12:if(e&2048){At(l,t,u,a),l=t.stateNode;try{var n=t.memoizedProps,c=n.id,i=n.onPostCommit;typeof i=="function"&&i(c,t.alternate===null?"mount":"update",l.passiveEffectDuration,-0)}catch(f){x(t,t.return,f)}}else At(l,t,u,a);break;case 23:break;case 22:n=t.stateNode,t.memoizedState!==null?n._visibility&4?At(l,t,u,a):Ua(l,t):n._visibility&4?At(l,t,u,a):(n._visibility|=4,Eu(l,t,u,a,(t.subtreeFlags&10256)!==0)),e&2048&&xi(t.alternate,t);break;case 24:At(l,t,u,a),e&2048&&Zi(t.alternate,t);break;default:At(l,t,u,a)}}function Eu(l,t,u,a,e){for(e=e&&(t.subtreeFlags&10256)!==0,t=t.child;t!==null;){var n=l,c=t,i=u,f=a,s=c.flags;switch(c.tag){case 0:case 11:case 15:Eu(n,c,i,f,e),te(8,c);break;case 23:break;case 22:var m=c.stateNode;c.memoizedState!==null?m._visibility&4?Eu(n,c,i,f,e):Ua(n,c):(m._visibility|=4,Eu(n,c,i,f,e)),e&&s&2048&&xi(c.alternate,c);break;case 24:Eu(n,c,i,f,e),e&&s&2048&&Zi(c.alternate,c);break;default:Eu(n,c,i,f,e)}t=t.sibling}}function Ua(l,t){if(t.subtreeFlags&10256)for(t=t.child;t!==null;){var u=l,a=t,e=a.flags;switch(a.tag){case 22:Ua(u,a),e&2048&&xi(a.alternate,a);break;case 24:Ua(u,a),e&2048&&Zi(a.alternate,a);break;default:Ua(u,a)}t=t.sibling}}var ma=8192;function ru(l){if(l.subtreeFlags&ma)for(l=l.child;l!==null;)G1(l),l=l.sibling}function G1(l){switch(l.tag){case 26:ru(l),l.flags&ma&&l.memoizedState!==null&&io(Ll,l.memoizedState,l.memoizedProps);break;case 5:ru(l);break;case 3:case 4:var t=Ll;Ll=nn(l.stateNode.containerInfo),ru(l),Ll=t;break;case 22:l.memoizedState===null&&(t=l.alternate,t!==null&&t.memoizedState!==null?(t=ma,ma=16777216,ru(l),ma=t):ru(l));break;default:ru(l)}}function X1(l){var t=l.alternate;if(t!==null&&(l=t.child,l!==null)){t.child=null;do t=l.sibling,l.sibling=null,l=t;while(l!==null)}}function fa(l){var t=l.deletions;if(l.flags&16){if(t!==null)for(var u=0;u<t.length;u++){var a=t[u];fl=a,x1(a,l)}X1(l)}if(l.subtreeFlags&10256)for(l=l.child;l!==null;)Q1(l),l=l.sibling}function Q1(l){switch(l.tag){case 0:case 11:case 15:fa(l),l.flags&2048&&Zt(9,l,l.return);break;case 3:fa(l);break;case 12:fa(l);break;case 22:var t=l.stateNode;l.memoizedState!==null&&t._visibility&4&&(l.return===null||l.return.tag!==13)?(t._visibility&=-5,Ne(l)):fa(l);break;default:fa(l)}}function Ne(l){var t=l.deletions;if(l.flags&16){if(t!==null)for(var u=0;u<t.length;u++){var a=t[u];fl=a,x1(a,l)}X1(l)}for(l=l.child;l!==null;){switch(t=l,t.tag){case 0:case 11:case 15:Zt(8,t,t.return),Ne(t);break;case 22:u=t.stateNode,u._visibility&4&&(u._visibility&=-5,Ne(t));break;default:Ne(t)}l=l.sibling}}function x1(l,t){for(;fl!==null;){var u=fl;switch(u.tag){case 0:case 11:case 15:Zt(8,u,t);break;case 23:case 22:if(u.memoizedState!==null&&u.memoizedState.cachePool!==null){var a=u.memoizedState.cachePool.pool;a!=null&&a.refCount++}break;case 24:Pa(u.memoizedState.cache)}if(a=u.child,a!==null)a.return=u,fl=a;else l:for(u=l;fl!==null;){a=fl;var e=a.sibling,n=a.return;if(p1(a),a===u){fl=null;break l}if(e!==null){e.return=n,fl=e;break l}fl=n}}}function Eh(l,t,u,a){this.tag=l,this.key=u,this.sibling=this.child=this.return=this.stateNode=this.type=this.elementType=null,this.index=0,this.refCleanup=this.ref=null,this.pendingProps=t,this.dependencies=this.memoizedState=this.updateQueue=this.memoizedProps=null,this.mode=a,this.subtreeFlags=this.flags=0,this.deletions=null,this.childLanes=this.lanes=0,this.alternate=null}function Ql(l,t,u,a){return new Eh(l,t,u,a)}function Vi(l){return l=l.prototype,!(!l||!l.isReactComponent)}function jt(l,t){var u=l.alternate;return u===null?(u=Ql(l.tag,t,l.key,l.mode),u.elementType=l.elementType,u.type=l.type,u.stateNode=l.stateNode,u.alternate=l,l.alternate=u):(u.pendingProps=t,u.type=l.type,u.flags=0,u.subtreeFlags=0,u.deletions=null),u.flags=l.flags&31457280,u.childLanes=l.childLanes,u.lanes=l.lanes,u.child=l.child,u.memoizedProps=l.memoizedProps,u.memoizedState=l.memoizedState,u.updateQueue=l.updateQueue,t=l.dependencies,u.dependencies=t===null?null:{lanes:t.lanes,firstContext:t.firstContext},u.sibling=l.sibling,u.index=l.index,u.ref=l.ref,u.refCleanup=l.refCleanup,u}function Z1(l,t){l.flags&=31457282;var u=l.alternate;return u===null?(l.childLanes=0,l.lanes=t,l.child=null,l.subtreeFlags=0,l.memoizedProps=null,l.memoizedState=null,l.updateQueue=null,l.dependencies=null,l.stateNode=null):(l.childLanes=u.childLanes,l.lanes=u.lanes,l.child=u.child,l.subtreeFlags=0,l.deletions=null,l.memoizedProps=u.memoizedProps,l.memoizedState=u.memoizedState,l.updateQueue=u.updateQueue,l.type=u.type,t=u.dependencies,l.dependencies=t===null?null:{lanes:t.lanes,firstContext:t.firstContext}),l}function pe(l,t,u,a,e,n){var c=0;if(a=l,typeof l=="function")Vi(l)&&(c=1);else if(typeof l=="string")c=no(l,u,kl.current)?26:l==="html"||l==="head"||l==="body"?27:5;else l:switch(l){case Au:return uu(u.children,e,n,t);case V0:c=8,e|=24;break;case hc:return l=Ql(12,u,t,e|2),l.elementType=hc,l.lanes=n,l;case oc:return l=Ql(13,u,t,e),l.elementType=oc,l.lanes=n,l;case yc:return l=Ql(19,u,t,e),l.elementType=yc,l.lanes=n,l;case K0:return V1(u,e,n,t);default:if(typeof l=="object"&&l!==null)switch(l.$$typeof){case Kd:case st:c=10;break l;case L0:c=9;break l;case di:c=11;break l;case vi:c=14;break l;case Ot:c=16,a=null;break l}c=29,u=Error(S(130,l===null?"null":typeof l,"")),a=null}return t=Ql(c,u,t,e),t.elementType=l,t.type=a,t.lanes=n,t}function uu(l,t,u,a){return l=Ql(7,l,a,t),l.lanes=u,l}function V1(l,t,u,a){l=Ql(22,l,a,t),l.elementType=K0,l.lanes=u;var e={_visibility:1,_pendingVisibility:1,_pendingMarkers:null,_retryCache:null,_transitions:null,_current:null,detach:function(){var n=e._current;if(n===null)throw Error(S(456));if(!(e._pendingVisibility&2)){var c=Qt(n,2);c!==null&&(e._pendingVisibility|=2,El(c,n,2))}},attach:function(){var n=e._current;if(n===null)throw Error(S(456));if(e._pendingVisibility&2){var c=Qt(n,2);c!==null&&(e._pendingVisibility&=-3,El(c,n,2))}}};return l.stateNode=e,l}function tc(l,t,u){return l=Ql(6,l,null,t),l.lanes=u,l}function uc(l,t,u){return t=Ql(4,l.children!==null?l.children:[],l.key,t),t.lanes=u,t.stateNode={containerInfo:l.containerInfo,pendingChildren:null,implementation:l.implementation},t}function nt(l){l.flags|=4}function u0(l,t){if(t.type!=="stylesheet"||t.state.loading&4)l.flags&=-16777217;else if(l.flags|=16777216,!dd(t)){if(t=Zl.current,t!==null&&((j&4194176)===j?Pl!==null:(j&62914560)!==j&&!(j&536870912)||t!==Pl))throw Ta=Mc,Hs;l.flags|=8192}}function Se(l,t){t!==null&&(l.flags|=4),l.flags&16384&&(t=l.tag!==22?P0():536870912,l.lanes|=t,wu|=t)}function sa(l,t){if(!B)switch(l.tailMode){case"hidden":t=l.tail;for(var u=null;t!==null;)t.alternate!==null&&(u=t),t=t.sibling;u===null?l.tail=null:u.sibling=null;break;case"collapsed":u=l.tail;for(var a=null;u!==null;)u.alternate!==null&&(a=u),u=u.sibling;a===null?t||l.tail===null?l.tail=null:l.tail.sibling=null:a.sibling=null}}function w(l){var t=l.alternate!==null&&l.alternate.child===l.child,u=0,a=0;if(t)for(var e=l.child;e!==null;)u|=e.lanes|e.childLanes,a|=e.subtreeFlags&31457280,a|=e.flags&31457280,e.return=l,e=e.sibling;else for(e=l.child;e!==null;)u|=e.lanes|e.childLanes,a|=e.subtreeFlags,a|=e.flags,e.return=l,e=e.sibling;return l.subtreeFlags|=a,l.childLanes=u,t}function Th(l,t,u){var a=t.pendingProps;switch(zi(t),t.tag){case 16:case 15:case 0:case 11:case 7:case 8:case 12:case 9:case 14:return w(t),null;case 1:return w(t),null;case 3:return u=t.stateNode,a=null,l!==null&&(a=l.memoizedState.cache),t.memoizedState.cache!==a&&(t.flags|=2048),yt(el),xu(),u.pendingContext&&(u.context=u.pendingContext,u.pendingContext=null),(l===null||l.child===null)&&(ca(t)?nt(t):l===null||l.memoizedState.isDehydrated&&!(t.flags&256)||(t.flags|=1024,Kl!==null&&($c(Kl),Kl=null))),w(t),null;case 26:return u=t.memoizedState,l===null?(nt(t),u!==null?(w(t),u0(t,u)):(w(t),t.flags&=-16777217)):u?u!==l.memoizedState?(nt(t),w(t),u0(t,u)):(w(t),t.flags&=-16777217):(l.memoizedProps!==a&&nt(t),w(t),t.flags&=-16777217),null;case 27:Ge(t),u=pt.current;var e=t.type;if(l!==null&&t.stateNode!=null)l.memoizedProps!==a&&nt(t);else{if(!a){if(t.stateNode===null)throw Error(S(166));return w(t),null}l=kl.current,ca(t)?pf(t):(l=id(e,a,u),t.stateNode=l,nt(t))}return w(t),null;case 5:if(Ge(t),u=t.type,l!==null&&t.stateNode!=null)l.memoizedProps!==a&&nt(t);else{if(!a){if(t.stateNode===null)throw Error(S(166));return w(t),null}if(l=kl.current,ca(t))pf(t);else{switch(e=en(pt.current),l){case 1:l=e.createElementNS("http://www.w3.org/2000/svg",u);break;case 2:l=e.createElementNS("http://www.w3.org/1998/Math/MathML",u);break;default:switch(u){case"svg":l=e.createElementNS("http://www.w3.org/2000/svg",u);break;case"math":l=e.createElementNS("http://www.w3.org/1998/Math/MathML",u);break;case"script":l=e.createElement("div"),l.innerHTML="<script><\/script>",l=l.removeChild(l.firstChild);break;case"select":l=typeof a.is=="string"?e.createElement("select",{is:a.is}):e.createElement("select"),a.multiple?l.multiple=!0:a.size&&(l.size=a.size);break;default:l=typeof a.is=="string"?e.createElement(u,{is:a.is}):e.createElement(u)}}l[rl]=t,l[zl]=a;l:for(e=t.child;e!==null;){if(e.tag===5||e.tag===6)l.appendChild(e.stateNode);else if(e.tag!==4&&e.tag!==27&&e.child!==null){e.child.return=e,e=e.child;continue}if(e===t)break l;for(;e.sibling===null;){if(e.return===null||e.return===t)break l;e=e.return}e.sibling.return=e.return,e=e.sibling}t.stateNode=l;l:switch(yl(l,u,a),u){case"button":case"input":case"select":case"textarea":l=!!a.autoFocus;break l;case"img":l=!0;break l;default:l=!1}l&&nt(t)}}return w(t),t.flags&=-16777217,null;case 6:if(l&&t.stateNode!=null)l.memoizedProps!==a&&nt(t);else{if(typeof a!="string"&&t.stateNode===null)throw Error(S(166));if(l=pt.current,ca(t)){if(l=t.stateNode,u=t.memoizedProps,a=null,e=Sl,e!==null)switch(e.tag){case 27:case 5:a=e.memoizedProps}l[rl]=t,l=!!(l.nodeValue===u||a!==null&&a.suppressHydrationWarning===!0||ed(l.nodeValue,u)),l||nu(t)}else l=en(l).createTextNode(a),l[rl]=t,t.stateNode=l}return w(t),null;case 13:if(a=t.memoizedState,l===null||l.memoizedState!==null&&l.memoizedState.dehydrated!==null){if(e=ca(t),a!==null&&a.dehydrated!==null){if(l===null){if(!e)throw Error(S(318));if(e=t.memoizedState,e=e!==null?e.dehydrated:null,!e)throw Error(S(317));e[rl]=t}else Fa(),!(t.flags&128)&&(t.memoizedState=null),t.flags|=4;w(t),e=!1}else Kl!==null&&($c(Kl),Kl=null),e=!0;if(!e)return t.flags&256?(ht(t),t):(ht(t),null)}if(ht(t),t.flags&128)return t.lanes=u,t;if(u=a!==null,l=l!==null&&l.memoizedState!==null,u){a=t.child,e=null,a.alternate!==null&&a.alternate.memoizedState!==null&&a.alternate.memoizedState.cachePool!==null&&(e=a.alternate.memoizedState.cachePool.pool);var n=null;a.memoizedState!==null&&a.memoizedState.cachePool!==null&&(n=a.memoizedState.cachePool.pool),n!==e&&(a.flags|=2048)}return u!==l&&u&&(t.child.flags|=8192),Se(t,t.updateQueue),w(t),null;case 4:return xu(),l===null&&Wi(t.stateNode.containerInfo),w(t),null;case 10:return yt(t.type),w(t),null;case 19:if(vl(nl),e=t.memoizedState,e===null)return w(t),null;if(a=(t.flags&128)!==0,n=e.rendering,n===null)if(a)sa(e,!1);else{if(F!==0||l!==null&&l.flags&128)for(l=t.child;l!==null;){if(n=Je(l),n!==null){for(t.flags|=128,sa(e,!1),l=n.updateQueue,t.updateQueue=l,Se(t,l),t.subtreeFlags=0,l=u,u=t.child;u!==null;)Z1(u,l),u=u.sibling;return J(nl,nl.current&1|2),t.child}l=l.sibling}e.tail!==null&&Fl()>Ie&&(t.flags|=128,a=!0,sa(e,!1),t.lanes=4194304)}else{if(!a)if(l=Je(n),l!==null){if(t.flags|=128,a=!0,l=l.updateQueue,t.updateQueue=l,Se(t,l),sa(e,!0),e.tail===null&&e.tailMode==="hidden"&&!n.alternate&&!B)return w(t),null}else 2*Fl()-e.renderingStartTime>Ie&&u!==536870912&&(t.flags|=128,a=!0,sa(e,!1),t.lanes=4194304);e.isBackwards?(n.sibling=t.child,t.child=n):(l=e.last,l!==null?l.sibling=n:t.child=n,e.last=n)}return e.tail!==null?(t=e.tail,e.rendering=t,e.tail=t.sibling,e.renderingStartTime=Fl(),t.sibling=null,l=nl.current,J(nl,a?l&1|2:l&1),t):(w(t),null);case 22:case 23:return ht(t),Oi(),a=t.memoizedState!==null,l!==null?l.memoizedState!==null!==a&&(t.flags|=8192):a&&(t.flags|=8192),a?u&536870912&&!(t.flags&128)&&(w(t),t.subtreeFlags&6&&(t.flags|=8192)):w(t),u=t.updateQueue,u!==null&&Se(t,u.retryQueue),u=null,l!==null&&l.memoizedState!==null&&l.memoizedState.cachePool!==null&&(u=l.memoizedState.cachePool.pool),a=null,t.memoizedState!==null&&t.memoizedState.cachePool!==null&&(a=t.memoizedState.cachePool.pool),a!==u&&(t.flags|=2048),l!==null&&vl(tu),null;case 24:return u=null,l!==null&&(u=l.memoizedState.cache),t.memoizedState.cache!==u&&(t.flags|=2048),yt(el),w(t),null;case 25:return null}throw Error(S(156,t.tag))}function Ah(l,t){switch(zi(t),t.tag){case 1:return l=t.flags,l&65536?(t.flags=l&-65537|128,t):null;case 3:return yt(el),xu(),l=t.flags,l&65536&&!(l&128)?(t.flags=l&-65537|128,t):null;case 26:case 27:case 5:return Ge(t),null;case 13:if(ht(t),l=t.memoizedState,l!==null&&l.dehydrated!==null){if(t.alternate===null)throw Error(S(340));Fa()}return l=t.flags,l&65536?(t.flags=l&-65537|128,t):null;case 19:return vl(nl),null;case 4:return xu(),null;case 10:return yt(t.type),null;case 22:case 23:return ht(t),Oi(),l!==null&&vl(tu),l=t.flags,l&65536?(t.flags=l&-65537|128,t):null;case 24:return yt(el),null;case 25:return null;default:return null}}function L1(l,t){switch(zi(t),t.tag){case 3:yt(el),xu();break;case 26:case 27:case 5:Ge(t);break;case 4:xu();break;case 13:ht(t);break;case 19:vl(nl);break;case 10:yt(t.type);break;case 22:case 23:ht(t),Oi(),l!==null&&vl(tu);break;case 24:yt(el)}}var zh={getCacheForType:function(l){var t=gl(el),u=t.data.get(l);return u===void 0&&(u=l(),t.data.set(l,u)),u}},Oh=typeof WeakMap=="function"?WeakMap:Map,W=0,V=null,q=null,j=0,Z=0,Dl=null,it=!1,la=!1,Li=!1,St=0,F=0,Vt=0,au=0,Ki=0,xl=0,wu=0,Ha=null,Wl=null,Lc=!1,Ji=0,Ie=1/0,ln=null,Bt=null,be=!1,kt=null,Ra=0,Kc=0,Jc=null,Na=0,wc=null;function Rl(){if(W&2&&j!==0)return j&-j;if(M.T!==null){var l=Ku;return l!==0?l:$i()}return us()}function K1(){xl===0&&(xl=!(j&536870912)||B?F0():536870912);var l=Zl.current;return l!==null&&(l.flags|=32),xl}function El(l,t,u){(l===V&&Z===2||l.cancelPendingCommit!==null)&&($u(l,0),ft(l,j,xl,!1)),Wa(l,u),(!(W&2)||l!==V)&&(l===V&&(!(W&2)&&(au|=u),F===4&&ft(l,j,xl,!1)),tt(l))}function J1(l,t,u){if(W&6)throw Error(S(327));var a=!u&&(t&60)===0&&(t&l.expiredLanes)===0||$a(l,t),e=a?Mh(l,t):ac(l,t,!0),n=a;do{if(e===0){la&&!a&&ft(l,t,0,!1);break}else if(e===6)ft(l,t,0,!it);else{if(u=l.current.alternate,n&&!_h(u)){e=ac(l,t,!1),n=!1;continue}if(e===2){if(n=t,l.errorRecoveryDisabledLanes&n)var c=0;else c=l.pendingLanes&-536870913,c=c!==0?c:c&536870912?536870912:0;if(c!==0){t=c;l:{var i=l;e=Ha;var f=i.current.memoizedState.isDehydrated;if(f&&($u(i,c).flags|=256),c=ac(i,c,!1),c!==2){if(Li&&!f){i.errorRecoveryDisabledLanes|=n,au|=n,e=4;break l}n=Wl,Wl=e,n!==null&&$c(n)}e=c}if(n=!1,e!==2)continue}}if(e===1){$u(l,0),ft(l,t,0,!0);break}l:{switch(a=l,e){case 0:case 1:throw Error(S(345));case 4:if((t&4194176)===t){ft(a,t,xl,!it);break l}break;case 2:Wl=null;break;case 3:case 5:break;default:throw Error(S(329))}if(a.finishedWork=u,a.finishedLanes=t,(t&62914560)===t&&(n=Ji+300-Fl(),10<n)){if(ft(a,t,xl,!it),yn(a,0)!==0)break l;a.timeoutHandle=cd(a0.bind(null,a,u,Wl,ln,Lc,t,xl,au,wu,it,2,-0,0),n);break l}a0(a,u,Wl,ln,Lc,t,xl,au,wu,it,0,-0,0)}}break}while(!0);tt(l)}function $c(l){Wl===null?Wl=l:Wl.push.apply(Wl,l)}function a0(l,t,u,a,e,n,c,i,f,s,m,g,h){var r=t.subtreeFlags;if((r&8192||(r&16785408)===16785408)&&(Za={stylesheets:null,count:0,unsuspend:co},G1(t),t=fo(),t!==null)){l.cancelPendingCommit=t(n0.bind(null,l,u,a,e,c,i,f,1,g,h)),ft(l,n,c,!s);return}n0(l,u,a,e,c,i,f,m,g,h)}function _h(l){for(var t=l;;){var u=t.tag;if((u===0||u===11||u===15)&&t.flags&16384&&(u=t.updateQueue,u!==null&&(u=u.stores,u!==null)))for(var a=0;a<u.length;a++){var e=u[a],n=e.getSnapshot;e=e.value;try{if(!Nl(n(),e))return!1}catch{return!1}}if(u=t.child,t.subtreeFlags&16384&&u!==null)u.return=t,t=u;else{if(t===l)break;for(;t.sibling===null;){if(t.return===null||t.return===l)return!0;t=t.return}t.sibling.return=t.return,t=t.sibling}}return!0}function ft(l,t,u,a){t&=~Ki,t&=~au,l.suspendedLanes|=t,l.pingedLanes&=~t,a&&(l.warmLanes|=t),a=l.expirationTimes;for(var e=t;0<e;){var n=31-Hl(e),c=1<<n;a[n]=-1,e&=~c}u!==0&&I0(l,u,t)}function On(){return W&6?!0:(ue(0,!1),!1)}function wi(){if(q!==null){if(Z===0)var l=q.return;else l=q,ot=mu=null,Ni(l),Bu=null,Ga=0,l=q;for(;l!==null;)L1(l.alternate,l),l=l.return;q=null}}function $u(l,t){l.finishedWork=null,l.finishedLanes=0;var u=l.timeoutHandle;u!==-1&&(l.timeoutHandle=-1,Lh(u)),u=l.cancelPendingCommit,u!==null&&(l.cancelPendingCommit=null,u()),wi(),V=l,q=u=jt(l.current,null),j=t,Z=0,Dl=null,it=!1,la=$a(l,t),Li=!1,wu=xl=Ki=au=Vt=F=0,Wl=Ha=null,Lc=!1,t&8&&(t|=t&32);var a=l.entangledLanes;if(a!==0)for(l=l.entanglements,a&=t;0<a;){var e=31-Hl(a),n=1<<e;t|=l[e],a&=~n}return St=t,Sn(),u}function w1(l,t){R=null,M.H=Il,t===Ea?(t=jf(),Z=3):t===Hs?(t=jf(),Z=4):Z=t===S1?8:t!==null&&typeof t=="object"&&typeof t.then=="function"?6:1,Dl=t,q===null&&(F=1,ke(l,Xl(t,l.current)))}function $1(){var l=M.H;return M.H=Il,l===null?Il:l}function W1(){var l=M.A;return M.A=zh,l}function Wc(){F=4,it||(j&4194176)!==j&&Zl.current!==null||(la=!0),!(Vt&134217727)&&!(au&134217727)||V===null||ft(V,j,xl,!1)}function ac(l,t,u){var a=W;W|=2;var e=$1(),n=W1();(V!==l||j!==t)&&(ln=null,$u(l,t)),t=!1;var c=F;l:do try{if(Z!==0&&q!==null){var i=q,f=Dl;switch(Z){case 8:wi(),c=6;break l;case 3:case 2:case 6:Zl.current===null&&(t=!0);var s=Z;if(Z=0,Dl=null,pu(l,i,f,s),u&&la){c=0;break l}break;default:s=Z,Z=0,Dl=null,pu(l,i,f,s)}}Dh(),c=F;break}catch(m){w1(l,m)}while(!0);return t&&l.shellSuspendCounter++,ot=mu=null,W=a,M.H=e,M.A=n,q===null&&(V=null,j=0,Sn()),c}function Dh(){for(;q!==null;)k1(q)}function Mh(l,t){var u=W;W|=2;var a=$1(),e=W1();V!==l||j!==t?(ln=null,Ie=Fl()+500,$u(l,t)):la=$a(l,t);l:do try{if(Z!==0&&q!==null){t=q;var n=Dl;t:switch(Z){case 1:Z=0,Dl=null,pu(l,t,n,1);break;case 2:if(Yf(n)){Z=0,Dl=null,e0(t);break}t=function(){Z===2&&V===l&&(Z=7),tt(l)},n.then(t,t);break l;case 3:Z=7;break l;case 4:Z=5;break l;case 7:Yf(n)?(Z=0,Dl=null,e0(t)):(Z=0,Dl=null,pu(l,t,n,7));break;case 5:var c=null;switch(q.tag){case 26:c=q.memoizedState;case 5:case 27:var i=q;if(!c||dd(c)){Z=0,Dl=null;var f=i.sibling;if(f!==null)q=f;else{var s=i.return;s!==null?(q=s,_n(s)):q=null}break t}}Z=0,Dl=null,pu(l,t,n,5);break;case 6:Z=0,Dl=null,pu(l,t,n,6);break;case 8:wi(),F=6;break l;default:throw Error(S(462))}}Uh();break}catch(m){w1(l,m)}while(!0);return ot=mu=null,M.H=a,M.A=e,W=u,q!==null?0:(V=null,j=0,Sn(),F)}function Uh(){for(;q!==null&&!kd();)k1(q)}function k1(l){var t=z1(l.alternate,l,St);l.memoizedProps=l.pendingProps,t===null?_n(l):q=t}function e0(l){var t=l,u=t.alternate;switch(t.tag){case 15:case 0:t=Wf(u,t,t.pendingProps,t.type,void 0,j);break;case 11:t=Wf(u,t,t.pendingProps,t.type.render,t.ref,j);break;case 5:Ni(t);default:L1(u,t),t=q=Z1(t,St),t=z1(u,t,St)}l.memoizedProps=l.pendingProps,t===null?_n(l):q=t}function pu(l,t,u,a){ot=mu=null,Ni(t),Bu=null,Ga=0;var e=t.return;try{if(rh(l,e,t,u,j)){F=1,ke(l,Xl(u,l.current)),q=null;return}}catch(n){if(e!==null)throw q=e,n;F=1,ke(l,Xl(u,l.current)),q=null;return}t.flags&32768?(B||a===1?l=!0:la||j&536870912?l=!1:(it=l=!0,(a===2||a===3||a===6)&&(a=Zl.current,a!==null&&a.tag===13&&(a.flags|=16384))),F1(t,l)):_n(t)}function _n(l){var t=l;do{if(t.flags&32768){F1(t,it);return}l=t.return;var u=Th(t.alternate,t,St);if(u!==null){q=u;return}if(t=t.sibling,t!==null){q=t;return}q=t=l}while(t!==null);F===0&&(F=5)}function F1(l,t){do{var u=Ah(l.alternate,l);if(u!==null){u.flags&=32767,q=u;return}if(u=l.return,u!==null&&(u.flags|=32768,u.subtreeFlags=0,u.deletions=null),!t&&(l=l.sibling,l!==null)){q=l;return}q=l=u}while(l!==null);F=6,q=null}function n0(l,t,u,a,e,n,c,i,f,s){var m=M.T,g=L.p;try{L.p=2,M.T=null,Hh(l,t,u,a,g,e,n,c,i,f,s)}finally{M.T=m,L.p=g}}function Hh(l,t,u,a,e,n,c,i){do Qu();while(kt!==null);if(W&6)throw Error(S(327));var f=l.finishedWork;if(a=l.finishedLanes,f===null)return null;if(l.finishedWork=null,l.finishedLanes=0,f===l.current)throw Error(S(177));l.callbackNode=null,l.callbackPriority=0,l.cancelPendingCommit=null;var s=f.lanes|f.childLanes;if(s|=Ei,iv(l,a,s,n,c,i),l===V&&(q=V=null,j=0),!(f.subtreeFlags&10256)&&!(f.flags&10256)||be||(be=!0,Kc=s,Jc=u,qh(Xe,function(){return Qu(),null})),u=(f.flags&15990)!==0,f.subtreeFlags&15990||u?(u=M.T,M.T=null,n=L.p,L.p=2,c=W,W|=4,Sh(l,f),j1(f,l),Iv(Ic,l.containerInfo),sn=!!Pc,Ic=Pc=null,l.current=f,N1(l,f.alternate,f),Fd(),W=c,L.p=n,M.T=u):l.current=f,be?(be=!1,kt=l,Ra=a):P1(l,s),s=l.pendingLanes,s===0&&(Bt=null),uv(f.stateNode),tt(l),t!==null)for(e=l.onRecoverableError,f=0;f<t.length;f++)s=t[f],e(s.value,{componentStack:s.stack});return Ra&3&&Qu(),s=l.pendingLanes,a&4194218&&s&42?l===wc?Na++:(Na=0,wc=l):Na=0,ue(0,!1),null}function P1(l,t){(l.pooledCacheLanes&=t)===0&&(t=l.pooledCache,t!=null&&(l.pooledCache=null,Pa(t)))}function Qu(){if(kt!==null){var l=kt,t=Kc;Kc=0;var u=ts(Ra),a=M.T,e=L.p;try{if(L.p=32>u?32:u,M.T=null,kt===null)var n=!1;else{u=Jc,Jc=null;var c=kt,i=Ra;if(kt=null,Ra=0,W&6)throw Error(S(331));var f=W;if(W|=4,Q1(c.current),C1(c,c.current,i,u),W=f,ue(0,!1),Ul&&typeof Ul.onPostCommitFiberRoot=="function")try{Ul.onPostCommitFiberRoot(wa,c)}catch{}n=!0}return n}finally{L.p=e,M.T=a,P1(l,t)}}return!1}function c0(l,t,u){t=Xl(u,t),t=Yc(l.stateNode,t,2),l=Yt(l,t,2),l!==null&&(Wa(l,2),tt(l))}function x(l,t,u){if(l.tag===3)c0(l,l,u);else for(;t!==null;){if(t.tag===3){c0(t,l,u);break}else if(t.tag===1){var a=t.stateNode;if(typeof t.type.getDerivedStateFromError=="function"||typeof a.componentDidCatch=="function"&&(Bt===null||!Bt.has(a))){l=Xl(u,l),u=r1(2),a=Yt(t,u,2),a!==null&&(g1(u,a,t,l),Wa(a,2),tt(a));break}}t=t.return}}function ec(l,t,u){var a=l.pingCache;if(a===null){a=l.pingCache=new Oh;var e=new Set;a.set(t,e)}else e=a.get(t),e===void 0&&(e=new Set,a.set(t,e));e.has(u)||(Li=!0,e.add(u),l=Rh.bind(null,l,t,u),t.then(l,l))}function Rh(l,t,u){var a=l.pingCache;a!==null&&a.delete(t),l.pingedLanes|=l.suspendedLanes&u,l.warmLanes&=~u,V===l&&(j&u)===u&&(F===4||F===3&&(j&62914560)===j&&300>Fl()-Ji?!(W&2)&&$u(l,0):Ki|=u,wu===j&&(wu=0)),tt(l)}function I1(l,t){t===0&&(t=P0()),l=Qt(l,t),l!==null&&(Wa(l,t),tt(l))}function Nh(l){var t=l.memoizedState,u=0;t!==null&&(u=t.retryLane),I1(l,u)}function ph(l,t){var u=0;switch(l.tag){case 13:var a=l.stateNode,e=l.memoizedState;e!==null&&(u=e.retryLane);break;case 19:a=l.stateNode;break;case 22:a=l.stateNode._retryCache;break;default:throw Error(S(314))}a!==null&&a.delete(t),I1(l,u)}function qh(l,t){return hi(l,t)}var tn=null,Tu=null,kc=!1,un=!1,nc=!1,eu=0;function tt(l){l!==Tu&&l.next===null&&(Tu===null?tn=Tu=l:Tu=Tu.next=l),un=!0,kc||(kc=!0,jh(Yh))}function ue(l,t){if(!nc&&un){nc=!0;do for(var u=!1,a=tn;a!==null;){if(!t)if(l!==0){var e=a.pendingLanes;if(e===0)var n=0;else{var c=a.suspendedLanes,i=a.pingedLanes;n=(1<<31-Hl(42|l)+1)-1,n&=e&~(c&~i),n=n&201326677?n&201326677|1:n?n|2:0}n!==0&&(u=!0,i0(a,n))}else n=j,n=yn(a,a===V?n:0),!(n&3)||$a(a,n)||(u=!0,i0(a,n));a=a.next}while(u);nc=!1}}function Yh(){un=kc=!1;var l=0;eu!==0&&(Vh()&&(l=eu),eu=0);for(var t=Fl(),u=null,a=tn;a!==null;){var e=a.next,n=ld(a,t);n===0?(a.next=null,u===null?tn=e:u.next=e,e===null&&(Tu=u)):(u=a,(l!==0||n&3)&&(un=!0)),a=e}ue(l,!1)}function ld(l,t){for(var u=l.suspendedLanes,a=l.pingedLanes,e=l.expirationTimes,n=l.pendingLanes&-62914561;0<n;){var c=31-Hl(n),i=1<<c,f=e[c];f===-1?(!(i&u)||i&a)&&(e[c]=cv(i,t)):f<=t&&(l.expiredLanes|=i),n&=~i}if(t=V,u=j,u=yn(l,l===t?u:0),a=l.callbackNode,u===0||l===t&&Z===2||l.cancelPendingCommit!==null)return a!==null&&a!==null&&jn(a),l.callbackNode=null,l.callbackPriority=0;if(!(u&3)||$a(l,u)){if(t=u&-u,t===l.callbackPriority)return t;switch(a!==null&&jn(a),ts(u)){case 2:case 8:u=W0;break;case 32:u=Xe;break;case 268435456:u=k0;break;default:u=Xe}return a=td.bind(null,l),u=hi(u,a),l.callbackPriority=t,l.callbackNode=u,t}return a!==null&&a!==null&&jn(a),l.callbackPriority=2,l.callbackNode=null,2}function td(l,t){var u=l.callbackNode;if(Qu()&&l.callbackNode!==u)return null;var a=j;return a=yn(l,l===V?a:0),a===0?null:(J1(l,a,t),ld(l,Fl()),l.callbackNode!=null&&l.callbackNode===u?td.bind(null,l):null)}function i0(l,t){if(Qu())return null;J1(l,t,!0)}function jh(l){Kh(function(){W&6?hi($0,l):l()})}function $i(){return eu===0&&(eu=F0()),eu}function f0(l){return l==null||typeof l=="symbol"||typeof l=="boolean"?null:typeof l=="function"?l:_e(""+l)}function s0(l,t){var u=t.ownerDocument.createElement("input");return u.name=t.name,u.value=t.value,l.id&&u.setAttribute("form",l.id),t.parentNode.insertBefore(u,t),l=new FormData(l),u.parentNode.removeChild(u),l}function Bh(l,t,u,a,e){if(t==="submit"&&u&&u.stateNode===e){var n=f0((e[zl]||null).action),c=a.submitter;c&&(t=(t=c[zl]||null)?f0(t.formAction):c.getAttribute("formAction"),t!==null&&(n=t,c=null));var i=new mn("action","action",null,a,e);l.push({event:i,listeners:[{instance:null,listener:function(){if(a.defaultPrevented){if(eu!==0){var f=c?s0(e,c):new FormData(e);pc(u,{pending:!0,data:f,method:e.method,action:n},null,f)}}else typeof n=="function"&&(i.preventDefault(),f=c?s0(e,c):new FormData(e),pc(u,{pending:!0,data:f,method:e.method,action:n},n,f))},currentTarget:e}]})}}for(var cc=0;cc<Rf.length;cc++){var ic=Rf[cc],Ch=ic.toLowerCase(),Gh=ic[0].toUpperCase()+ic.slice(1);wl(Ch,"on"+Gh)}wl(As,"onAnimationEnd");wl(zs,"onAnimationIteration");wl(Os,"onAnimationStart");wl("dblclick","onDoubleClick");wl("focusin","onFocus");wl("focusout","onBlur");wl(th,"onTransitionRun");wl(uh,"onTransitionStart");wl(ah,"onTransitionCancel");wl(_s,"onTransitionEnd");Zu("onMouseEnter",["mouseout","mouseover"]);Zu("onMouseLeave",["mouseout","mouseover"]);Zu("onPointerEnter",["pointerout","pointerover"]);Zu("onPointerLeave",["pointerout","pointerover"]);du("onChange","change click focusin focusout input keydown keyup selectionchange".split(" "));du("onSelect","focusout contextmenu dragend focusin keydown keyup mousedown mouseup selectionchange".split(" "));du("onBeforeInput",["compositionend","keypress","textInput","paste"]);du("onCompositionEnd","compositionend focusout keydown keypress keyup mousedown".split(" "));du("onCompositionStart","compositionstart focusout keydown keypress keyup mousedown".split(" "));du("onCompositionUpdate","compositionupdate focusout keydown keypress keyup mousedown".split(" "));var Qa="abort canplay canplaythrough durationchange emptied encrypted ended error loadeddata loadedmetadata loadstart pause play playing progress ratechange resize seeked seeking stalled suspend timeupdate volumechange waiting".split(" "),Xh=new Set("beforetoggle cancel close invalid load scroll scrollend toggle".split(" ").concat(Qa));function ud(l,t){t=(t&4)!==0;for(var u=0;u<l.length;u++){var a=l[u],e=a.event;a=a.listeners;l:{var n=void 0;if(t)for(var c=a.length-1;0<=c;c--){var i=a[c],f=i.instance,s=i.currentTarget;if(i=i.listener,f!==n&&e.isPropagationStopped())break l;n=i,e.currentTarget=s;try{n(e)}catch(m){We(m)}e.currentTarget=null,n=f}else for(c=0;c<a.length;c++){if(i=a[c],f=i.instance,s=i.currentTarget,i=i.listener,f!==n&&e.isPropagationStopped())break l;n=i,e.currentTarget=s;try{n(e)}catch(m){We(m)}e.currentTarget=null,n=f}}}}function Y(l,t){var u=t[bc];u===void 0&&(u=t[bc]=new Set);var a=l+"__bubble";u.has(a)||(ad(t,l,2,!1),u.add(a))}function fc(l,t,u){var a=0;t&&(a|=4),ad(u,l,a,t)}var Ee="_reactListening"+Math.random().toString(36).slice(2);function Wi(l){if(!l[Ee]){l[Ee]=!0,as.forEach(function(u){u!=="selectionchange"&&(Xh.has(u)||fc(u,!1,l),fc(u,!0,l))});var t=l.nodeType===9?l:l.ownerDocument;t===null||t[Ee]||(t[Ee]=!0,fc("selectionchange",!1,t))}}function ad(l,t,u,a){switch(md(t)){case 2:var e=ho;break;case 8:e=oo;break;default:e=Ii}u=e.bind(null,t,u,l),e=void 0,!zc||t!=="touchstart"&&t!=="touchmove"&&t!=="wheel"||(e=!0),a?e!==void 0?l.addEventListener(t,u,{capture:!0,passive:e}):l.addEventListener(t,u,!0):e!==void 0?l.addEventListener(t,u,{passive:e}):l.addEventListener(t,u,!1)}function sc(l,t,u,a,e){var n=a;if(!(t&1)&&!(t&2)&&a!==null)l:for(;;){if(a===null)return;var c=a.tag;if(c===3||c===4){var i=a.stateNode.containerInfo;if(i===e||i.nodeType===8&&i.parentNode===e)break;if(c===4)for(c=a.return;c!==null;){var f=c.tag;if((f===3||f===4)&&(f=c.stateNode.containerInfo,f===e||f.nodeType===8&&f.parentNode===e))return;c=c.return}for(;i!==null;){if(c=Ft(i),c===null)return;if(f=c.tag,f===5||f===6||f===26||f===27){a=n=c;continue l}i=i.parentNode}}a=a.return}vs(function(){var s=n,m=mi(u),g=[];l:{var h=Ds.get(l);if(h!==void 0){var r=mn,T=l;switch(l){case"keypress":if(Me(u)===0)break l;case"keydown":case"keyup":r=pv;break;case"focusin":T="focus",r=Qn;break;case"focusout":T="blur",r=Qn;break;case"beforeblur":case"afterblur":r=Qn;break;case"click":if(u.button===2)break l;case"auxclick":case"dblclick":case"mousedown":case"mousemove":case"mouseup":case"mouseout":case"mouseover":case"contextmenu":r=Sf;break;case"drag":case"dragend":case"dragenter":case"dragexit":case"dragleave":case"dragover":case"dragstart":case"drop":r=Ev;break;case"touchcancel":case"touchend":case"touchmove":case"touchstart":r=jv;break;case As:case zs:case Os:r=zv;break;case _s:r=Cv;break;case"scroll":case"scrollend":r=Sv;break;case"wheel":r=Xv;break;case"copy":case"cut":case"paste":r=_v;break;case"gotpointercapture":case"lostpointercapture":case"pointercancel":case"pointerdown":case"pointermove":case"pointerout":case"pointerover":case"pointerup":r=Ef;break;case"toggle":case"beforetoggle":r=xv}var E=(t&4)!==0,Q=!E&&(l==="scroll"||l==="scrollend"),v=E?h!==null?h+"Capture":null:h;E=[];for(var d=s,o;d!==null;){var y=d;if(o=y.stateNode,y=y.tag,y!==5&&y!==26&&y!==27||o===null||v===null||(y=Ya(d,v),y!=null&&E.push(xa(d,y,o))),Q)break;d=d.return}0<E.length&&(h=new r(h,T,null,u,m),g.push({event:h,listeners:E}))}}if(!(t&7)){l:{if(h=l==="mouseover"||l==="pointerover",r=l==="mouseout"||l==="pointerout",h&&u!==Ac&&(T=u.relatedTarget||u.fromElement)&&(Ft(T)||T[Pu]))break l;if((r||h)&&(h=m.window===m?m:(h=m.ownerDocument)?h.defaultView||h.parentWindow:window,r?(T=u.relatedTarget||u.toElement,r=s,T=T?Ft(T):null,T!==null&&(Q=Fu(T),E=T.tag,T!==Q||E!==5&&E!==27&&E!==6)&&(T=null)):(r=null,T=s),r!==T)){if(E=Sf,y="onMouseLeave",v="onMouseEnter",d="mouse",(l==="pointerout"||l==="pointerover")&&(E=Ef,y="onPointerLeave",v="onPointerEnter",d="pointer"),Q=r==null?h:ya(r),o=T==null?h:ya(T),h=new E(y,d+"leave",r,u,m),h.target=Q,h.relatedTarget=o,y=null,Ft(m)===s&&(E=new E(v,d+"enter",T,u,m),E.target=o,E.relatedTarget=Q,y=E),Q=y,r&&T)t:{for(E=r,v=T,d=0,o=E;o;o=gu(o))d++;for(o=0,y=v;y;y=gu(y))o++;for(;0<d-o;)E=gu(E),d--;for(;0<o-d;)v=gu(v),o--;for(;d--;){if(E===v||v!==null&&E===v.alternate)break t;E=gu(E),v=gu(v)}E=null}else E=null;r!==null&&d0(g,h,r,E,!1),T!==null&&Q!==null&&d0(g,Q,T,E,!0)}}l:{if(h=s?ya(s):window,r=h.nodeName&&h.nodeName.toLowerCase(),r==="select"||r==="input"&&h.type==="file")var b=Of;else if(zf(h))if(gs)b=Fv;else{b=Wv;var D=$v}else r=h.nodeName,!r||r.toLowerCase()!=="input"||h.type!=="checkbox"&&h.type!=="radio"?s&&yi(s.elementType)&&(b=Of):b=kv;if(b&&(b=b(l,s))){rs(g,b,u,m);break l}D&&D(l,h,s),l==="focusout"&&s&&h.type==="number"&&s.memoizedProps.value!=null&&Tc(h,"number",h.value)}switch(D=s?ya(s):window,l){case"focusin":(zf(D)||D.contentEditable==="true")&&(Du=D,Oc=s,ba=null);break;case"focusout":ba=Oc=Du=null;break;case"mousedown":_c=!0;break;case"contextmenu":case"mouseup":case"dragend":_c=!1,Hf(g,u,m);break;case"selectionchange":if(lh)break;case"keydown":case"keyup":Hf(g,u,m)}var A;if(Si)l:{switch(l){case"compositionstart":var O="onCompositionStart";break l;case"compositionend":O="onCompositionEnd";break l;case"compositionupdate":O="onCompositionUpdate";break l}O=void 0}else _u?ys(l,u)&&(O="onCompositionEnd"):l==="keydown"&&u.keyCode===229&&(O="onCompositionStart");O&&(os&&u.locale!=="ko"&&(_u||O!=="onCompositionStart"?O==="onCompositionEnd"&&_u&&(A=hs()):(Nt=m,ri="value"in Nt?Nt.value:Nt.textContent,_u=!0)),D=an(s,O),0<D.length&&(O=new bf(O,l,null,u,m),g.push({event:O,listeners:D}),A?O.data=A:(A=ms(u),A!==null&&(O.data=A)))),(A=Vv?Lv(l,u):Kv(l,u))&&(O=an(s,"onBeforeInput"),0<O.length&&(D=new bf("onBeforeInput","beforeinput",null,u,m),g.push({event:D,listeners:O}),D.data=A)),Bh(g,l,s,u,m)}ud(g,t)})}function xa(l,t,u){return{instance:l,listener:t,currentTarget:u}}function an(l,t){for(var u=t+"Capture",a=[];l!==null;){var e=l,n=e.stateNode;e=e.tag,e!==5&&e!==26&&e!==27||n===null||(e=Ya(l,u),e!=null&&a.unshift(xa(l,e,n)),e=Ya(l,t),e!=null&&a.push(xa(l,e,n))),l=l.return}return a}function gu(l){if(l===null)return null;do l=l.return;while(l&&l.tag!==5&&l.tag!==27);return l||null}function d0(l,t,u,a,e){for(var n=t._reactName,c=[];u!==null&&u!==a;){var i=u,f=i.alternate,s=i.stateNode;if(i=i.tag,f!==null&&f===a)break;i!==5&&i!==26&&i!==27||s===null||(f=s,e?(s=Ya(u,n),s!=null&&c.unshift(xa(u,s,f))):e||(s=Ya(u,n),s!=null&&c.push(xa(u,s,f)))),u=u.return}c.length!==0&&l.push({event:t,listeners:c})}var Qh=/\r\n?/g,xh=/\u0000|\uFFFD/g;function v0(l){return(typeof l=="string"?l:""+l).replace(Qh,`
-`).replace(xh,"")}function ed(l,t){return t=v0(t),v0(l)===t}function Dn(){}function G(l,t,u,a,e,n){switch(u){case"children":typeof a=="string"?t==="body"||t==="textarea"&&a===""||Vu(l,a):(typeof a=="number"||typeof a=="bigint")&&t!=="body"&&Vu(l,""+a);break;case"className":he(l,"class",a);break;case"tabIndex":he(l,"tabindex",a);break;case"dir":case"role":case"viewBox":case"width":case"height":he(l,u,a);break;case"style":ds(l,a,n);break;case"data":if(t!=="object"){he(l,"data",a);break}case"src":case"href":if(a===""&&(t!=="a"||u!=="href")){l.removeAttribute(u);break}if(a==null||typeof a=="function"||typeof a=="symbol"||typeof a=="boolean"){l.removeAttribute(u);break}a=_e(""+a),l.setAttribute(u,a);break;case"action":case"formAction":if(typeof a=="function"){l.setAttribute(u,"javascript:throw new Error('A React form was unexpectedly submitted. If you called form.submit() manually, consider using form.requestSubmit() instead. If you\\'re trying to use event.stopPropagation() in a submit event handler, consider also calling event.preventDefault().')");break}else typeof n=="function"&&(u==="formAction"?(t!=="input"&&G(l,t,"name",e.name,e,null),G(l,t,"formEncType",e.formEncType,e,null),G(l,t,"formMethod",e.formMethod,e,null),G(l,t,"formTarget",e.formTarget,e,null)):(G(l,t,"encType",e.encType,e,null),G(l,t,"method",e.method,e,null),G(l,t,"target",e.target,e,null)));if(a==null||typeof a=="symbol"||typeof a=="boolean"){l.removeAttribute(u);break}a=_e(""+a),l.setAttribute(u,a);break;case"onClick":a!=null&&(l.onclick=Dn);break;case"onScroll":a!=null&&Y("scroll",l);break;case"onScrollEnd":a!=null&&Y("scrollend",l);break;case"dangerouslySetInnerHTML":if(a!=null){if(typeof a!="object"||!("__html"in a))throw Error(S(61));if(u=a.__html,u!=null){if(e.children!=null)throw Error(S(60));l.innerHTML=u}}break;case"multiple":l.multiple=a&&typeof a!="function"&&typeof a!="symbol";break;case"muted":l.muted=a&&typeof a!="function"&&typeof a!="symbol";break;case"suppressContentEditableWarning":case"suppressHydrationWarning":case"defaultValue":case"defaultChecked":case"innerHTML":case"ref":break;case"autoFocus":break;case"xlinkHref":if(a==null||typeof a=="function"||typeof a=="boolean"||typeof a=="symbol"){l.removeAttribute("xlink:href");break}u=_e(""+a),l.setAttributeNS("http://www.w3.org/1999/xlink","xlink:href",u);break;case"contentEditable":case"spellCheck":case"draggable":case"value":case"autoReverse":case"externalResourcesRequired":case"focusable":case"preserveAlpha":a!=null&&typeof a!="function"&&typeof a!="symbol"?l.setAttribute(u,""+a):l.removeAttribute(u);break;case"inert":case"allowFullScreen":case"async":case"autoPlay":case"controls":case"default":case"defer":case"disabled":case"disablePictureInPicture":case"disableRemotePlayback":case"formNoValidate":case"hidden":case"loop":case"noModule":case"noValidate":case"open":case"playsInline":case"readOnly":case"required":case"reversed":case"scoped":case"seamless":case"itemScope":a&&typeof a!="function"&&typeof a!="symbol"?l.setAttribute(u,""):l.removeAttribute(u);break;case"capture":case"download":a===!0?l.setAttribute(u,""):a!==!1&&a!=null&&typeof a!="function"&&typeof a!="symbol"?l.setAttribute(u,a):l.removeAttribute(u);break;case"cols":case"rows":case"size":case"span":a!=null&&typeof a!="function"&&typeof a!="symbol"&&!isNaN(a)&&1<=a?l.setAttribute(u,a):l.removeAttribute(u);break;case"rowSpan":case"start":a==null||typeof a=="function"||typeof a=="symbol"||isNaN(a)?l.removeAttribute(u):l.setAttribute(u,a);break;case"popover":Y("beforetoggle",l),Y("toggle",l),Oe(l,"popover",a);break;case"xlinkActuate":ut(l,"http://www.w3.org/1999/xlink","xlink:actuate",a);break;case"xlinkArcrole":ut(l,"http://www.w3.org/1999/xlink","xlink:arcrole",a);break;case"xlinkRole":ut(l,"http://www.w3.org/1999/xlink","xlink:role",a);break;case"xlinkShow":ut(l,"http://www.w3.org/1999/xlink","xlink:show",a);break;case"xlinkTitle":ut(l,"http://www.w3.org/1999/xlink","xlink:title",a);break;case"xlinkType":ut(l,"http://www.w3.org/1999/xlink","xlink:type",a);break;case"xmlBase":ut(l,"http://www.w3.org/XML/1998/namespace","xml:base",a);break;case"xmlLang":ut(l,"http://www.w3.org/XML/1998/namespace","xml:lang",a);break;case"xmlSpace":ut(l,"http://www.w3.org/XML/1998/namespace","xml:space",a);break;case"is":Oe(l,"is",a);break;case"innerText":case"textContent":break;default:(!(2<u.length)||u[0]!=="o"&&u[0]!=="O"||u[1]!=="n"&&u[1]!=="N")&&(u=rv.get(u)||u,Oe(l,u,a))}}function Fc(l,t,u,a,e,n){switch(u){case"style":ds(l,a,n);break;case"dangerouslySetInnerHTML":if(a!=null){if(typeof a!="object"||!("__html"in a))throw Error(S(61));if(u=a.__html,u!=null){if(e.children!=null)throw Error(S(60));l.innerHTML=u}}break;case"children":typeof a=="string"?Vu(l,a):(typeof a=="number"||typeof a=="bigint")&&Vu(l,""+a);break;case"onScroll":a!=null&&Y("scroll",l);break;case"onScrollEnd":a!=null&&Y("scrollend",l);break;case"onClick":a!=null&&(l.onclick=Dn);break;case"suppressContentEditableWarning":case"suppressHydrationWarning":case"innerHTML":case"ref":break;case"innerText":case"textContent":break;default:if(!es.hasOwnProperty(u))l:{if(u[0]==="o"&&u[1]==="n"&&(e=u.endsWith("Capture"),t=u.slice(2,e?u.length-7:void 0),n=l[zl]||null,n=n!=null?n[u]:null,typeof n=="function"&&l.removeEventListener(t,n,e),typeof a=="function")){typeof n!="function"&&n!==null&&(u in l?l[u]=null:l.hasAttribute(u)&&l.removeAttribute(u)),l.addEventListener(t,a,e);break l}u in l?l[u]=a:a===!0?l.setAttribute(u,""):Oe(l,u,a)}}}function yl(l,t,u){switch(t){case"div":case"span":case"svg":case"path":case"a":case"g":case"p":case"li":break;case"img":Y("error",l),Y("load",l);var a=!1,e=!1,n;for(n in u)if(u.hasOwnProperty(n)){var c=u[n];if(c!=null)switch(n){case"src":a=!0;break;case"srcSet":e=!0;break;case"children":case"dangerouslySetInnerHTML":throw Error(S(137,t));default:G(l,t,n,c,u,null)}}e&&G(l,t,"srcSet",u.srcSet,u,null),a&&G(l,t,"src",u.src,u,null);return;case"input":Y("invalid",l);var i=n=c=e=null,f=null,s=null;for(a in u)if(u.hasOwnProperty(a)){var m=u[a];if(m!=null)switch(a){case"name":e=m;break;case"type":c=m;break;case"checked":f=m;break;case"defaultChecked":s=m;break;case"value":n=m;break;case"defaultValue":i=m;break;case"children":case"dangerouslySetInnerHTML":if(m!=null)throw Error(S(137,t));break;default:G(l,t,a,m,u,null)}}is(l,n,i,f,s,c,e,!1),Qe(l);return;case"select":Y("invalid",l),a=c=n=null;for(e in u)if(u.hasOwnProperty(e)&&(i=u[e],i!=null))switch(e){case"value":n=i;break;case"defaultValue":c=i;break;case"multiple":a=i;default:G(l,t,e,i,u,null)}t=n,u=c,l.multiple=!!a,t!=null?Yu(l,!!a,t,!1):u!=null&&Yu(l,!!a,u,!0);return;case"textarea":Y("invalid",l),n=e=a=null;for(c in u)if(u.hasOwnProperty(c)&&(i=u[c],i!=null))switch(c){case"value":a=i;break;case"defaultValue":e=i;break;case"children":n=i;break;case"dangerouslySetInnerHTML":if(i!=null)throw Error(S(91));break;default:G(l,t,c,i,u,null)}ss(l,a,e,n),Qe(l);return;case"option":for(f in u)if(u.hasOwnProperty(f)&&(a=u[f],a!=null))switch(f){case"selected":l.selected=a&&typeof a!="function"&&typeof a!="symbol";break;default:G(l,t,f,a,u,null)}return;case"dialog":Y("cancel",l),Y("close",l);break;case"iframe":case"object":Y("load",l);break;case"video":case"audio":for(a=0;a<Qa.length;a++)Y(Qa[a],l);break;case"image":Y("error",l),Y("load",l);break;case"details":Y("toggle",l);break;case"embed":case"source":case"link":Y("error",l),Y("load",l);case"area":case"base":case"br":case"col":case"hr":case"keygen":case"meta":case"param":case"track":case"wbr":case"menuitem":for(s in u)if(u.hasOwnProperty(s)&&(a=u[s],a!=null))switch(s){case"children":case"dangerouslySetInnerHTML":throw Error(S(137,t));default:G(l,t,s,a,u,null)}return;default:if(yi(t)){for(m in u)u.hasOwnProperty(m)&&(a=u[m],a!==void 0&&Fc(l,t,m,a,u,void 0));return}}for(i in u)u.hasOwnProperty(i)&&(a=u[i],a!=null&&G(l,t,i,a,u,null))}function Zh(l,t,u,a){switch(t){case"div":case"span":case"svg":case"path":case"a":case"g":case"p":case"li":break;case"input":var e=null,n=null,c=null,i=null,f=null,s=null,m=null;for(r in u){var g=u[r];if(u.hasOwnProperty(r)&&g!=null)switch(r){case"checked":break;case"value":break;case"defaultValue":f=g;default:a.hasOwnProperty(r)||G(l,t,r,null,a,g)}}for(var h in a){var r=a[h];if(g=u[h],a.hasOwnProperty(h)&&(r!=null||g!=null))switch(h){case"type":n=r;break;case"name":e=r;break;case"checked":s=r;break;case"defaultChecked":m=r;break;case"value":c=r;break;case"defaultValue":i=r;break;case"children":case"dangerouslySetInnerHTML":if(r!=null)throw Error(S(137,t));break;default:r!==g&&G(l,t,h,r,a,g)}}Ec(l,c,i,f,s,m,n,e);return;case"select":r=c=i=h=null;for(n in u)if(f=u[n],u.hasOwnProperty(n)&&f!=null)switch(n){case"value":break;case"multiple":r=f;default:a.hasOwnProperty(n)||G(l,t,n,null,a,f)}for(e in a)if(n=a[e],f=u[e],a.hasOwnProperty(e)&&(n!=null||f!=null))switch(e){case"value":h=n;break;case"defaultValue":i=n;break;case"multiple":c=n;default:n!==f&&G(l,t,e,n,a,f)}t=i,u=c,a=r,h!=null?Yu(l,!!u,h,!1):!!a!=!!u&&(t!=null?Yu(l,!!u,t,!0):Yu(l,!!u,u?[]:"",!1));return;case"textarea":r=h=null;for(i in u)if(e=u[i],u.hasOwnProperty(i)&&e!=null&&!a.hasOwnProperty(i))switch(i){case"value":break;case"children":break;default:G(l,t,i,null,a,e)}for(c in a)if(e=a[c],n=u[c],a.hasOwnProperty(c)&&(e!=null||n!=null))switch(c){case"value":h=e;break;case"defaultValue":r=e;break;case"children":break;case"dangerouslySetInnerHTML":if(e!=null)throw Error(S(91));break;default:e!==n&&G(l,t,c,e,a,n)}fs(l,h,r);return;case"option":for(var T in u)if(h=u[T],u.hasOwnProperty(T)&&h!=null&&!a.hasOwnProperty(T))switch(T){case"selected":l.selected=!1;break;default:G(l,t,T,null,a,h)}for(f in a)if(h=a[f],r=u[f],a.hasOwnProperty(f)&&h!==r&&(h!=null||r!=null))switch(f){case"selected":l.selected=h&&typeof h!="function"&&typeof h!="symbol";break;default:G(l,t,f,h,a,r)}return;case"img":case"link":case"area":case"base":case"br":case"col":case"embed":case"hr":case"keygen":case"meta":case"param":case"source":case"track":case"wbr":case"menuitem":for(var E in u)h=u[E],u.hasOwnProperty(E)&&h!=null&&!a.hasOwnProperty(E)&&G(l,t,E,null,a,h);for(s in a)if(h=a[s],r=u[s],a.hasOwnProperty(s)&&h!==r&&(h!=null||r!=null))switch(s){case"children":case"dangerouslySetInnerHTML":if(h!=null)throw Error(S(137,t));break;default:G(l,t,s,h,a,r)}return;default:if(yi(t)){for(var Q in u)h=u[Q],u.hasOwnProperty(Q)&&h!==void 0&&!a.hasOwnProperty(Q)&&Fc(l,t,Q,void 0,a,h);for(m in a)h=a[m],r=u[m],!a.hasOwnProperty(m)||h===r||h===void 0&&r===void 0||Fc(l,t,m,h,a,r);return}}for(var v in u)h=u[v],u.hasOwnProperty(v)&&h!=null&&!a.hasOwnProperty(v)&&G(l,t,v,null,a,h);for(g in a)h=a[g],r=u[g],!a.hasOwnProperty(g)||h===r||h==null&&r==null||G(l,t,g,h,a,r)}var Pc=null,Ic=null;function en(l){return l.nodeType===9?l:l.ownerDocument}function h0(l){switch(l){case"http://www.w3.org/2000/svg":return 1;case"http://www.w3.org/1998/Math/MathML":return 2;default:return 0}}function nd(l,t){if(l===0)switch(t){case"svg":return 1;case"math":return 2;default:return 0}return l===1&&t==="foreignObject"?0:l}function li(l,t){return l==="textarea"||l==="noscript"||typeof t.children=="string"||typeof t.children=="number"||typeof t.children=="bigint"||typeof t.dangerouslySetInnerHTML=="object"&&t.dangerouslySetInnerHTML!==null&&t.dangerouslySetInnerHTML.__html!=null}var dc=null;function Vh(){var l=window.event;return l&&l.type==="popstate"?l===dc?!1:(dc=l,!0):(dc=null,!1)}var cd=typeof setTimeout=="function"?setTimeout:void 0,Lh=typeof clearTimeout=="function"?clearTimeout:void 0,o0=typeof Promise=="function"?Promise:void 0,Kh=typeof queueMicrotask=="function"?queueMicrotask:typeof o0<"u"?function(l){return o0.resolve(null).then(l).catch(Jh)}:cd;function Jh(l){setTimeout(function(){throw l})}function vc(l,t){var u=t,a=0;do{var e=u.nextSibling;if(l.removeChild(u),e&&e.nodeType===8)if(u=e.data,u==="/$"){if(a===0){l.removeChild(e),Ja(t);return}a--}else u!=="$"&&u!=="$?"&&u!=="$!"||a++;u=e}while(u);Ja(t)}function ti(l){var t=l.firstChild;for(t&&t.nodeType===10&&(t=t.nextSibling);t;){var u=t;switch(t=t.nextSibling,u.nodeName){case"HTML":case"HEAD":case"BODY":ti(u),oi(u);continue;case"SCRIPT":case"STYLE":continue;case"LINK":if(u.rel.toLowerCase()==="stylesheet")continue}l.removeChild(u)}}function wh(l,t,u,a){for(;l.nodeType===1;){var e=u;if(l.nodeName.toLowerCase()!==t.toLowerCase()){if(!a&&(l.nodeName!=="INPUT"||l.type!=="hidden"))break}else if(a){if(!l[qa])switch(t){case"meta":if(!l.hasAttribute("itemprop"))break;return l;case"link":if(n=l.getAttribute("rel"),n==="stylesheet"&&l.hasAttribute("data-precedence"))break;if(n!==e.rel||l.getAttribute("href")!==(e.href==null?null:e.href)||l.getAttribute("crossorigin")!==(e.crossOrigin==null?null:e.crossOrigin)||l.getAttribute("title")!==(e.title==null?null:e.title))break;return l;case"style":if(l.hasAttribute("data-precedence"))break;return l;case"script":if(n=l.getAttribute("src"),(n!==(e.src==null?null:e.src)||l.getAttribute("type")!==(e.type==null?null:e.type)||l.getAttribute("crossorigin")!==(e.crossOrigin==null?null:e.crossOrigin))&&n&&l.hasAttribute("async")&&!l.hasAttribute("itemprop"))break;return l;default:return l}}else if(t==="input"&&l.type==="hidden"){var n=e.name==null?null:""+e.name;if(e.type==="hidden"&&l.getAttribute("name")===n)return l}else return l;if(l=Jl(l.nextSibling),l===null)break}return null}function $h(l,t,u){if(t==="")return null;for(;l.nodeType!==3;)if((l.nodeType!==1||l.nodeName!=="INPUT"||l.type!=="hidden")&&!u||(l=Jl(l.nextSibling),l===null))return null;return l}function Jl(l){for(;l!=null;l=l.nextSibling){var t=l.nodeType;if(t===1||t===3)break;if(t===8){if(t=l.data,t==="$"||t==="$!"||t==="$?"||t==="F!"||t==="F")break;if(t==="/$")return null}}return l}function y0(l){l=l.previousSibling;for(var t=0;l;){if(l.nodeType===8){var u=l.data;if(u==="$"||u==="$!"||u==="$?"){if(t===0)return l;t--}else u==="/$"&&t++}l=l.previousSibling}return null}function id(l,t,u){switch(t=en(u),l){case"html":if(l=t.documentElement,!l)throw Error(S(452));return l;case"head":if(l=t.head,!l)throw Error(S(453));return l;case"body":if(l=t.body,!l)throw Error(S(454));return l;default:throw Error(S(451))}}var Vl=new Map,m0=new Set;function nn(l){return typeof l.getRootNode=="function"?l.getRootNode():l.ownerDocument}var bt=L.d;L.d={f:Wh,r:kh,D:Fh,C:Ph,L:Ih,m:lo,X:uo,S:to,M:ao};function Wh(){var l=bt.f(),t=On();return l||t}function kh(l){var t=Iu(l);t!==null&&t.tag===5&&t.type==="form"?i1(t):bt.r(l)}var ta=typeof document>"u"?null:document;function fd(l,t,u){var a=ta;if(a&&typeof t=="string"&&t){var e=Gl(t);e='link[rel="'+l+'"][href="'+e+'"]',typeof u=="string"&&(e+='[crossorigin="'+u+'"]'),m0.has(e)||(m0.add(e),l={rel:l,crossOrigin:u,href:t},a.querySelector(e)===null&&(t=a.createElement("link"),yl(t,"link",l),sl(t),a.head.appendChild(t)))}}function Fh(l){bt.D(l),fd("dns-prefetch",l,null)}function Ph(l,t){bt.C(l,t),fd("preconnect",l,t)}function Ih(l,t,u){bt.L(l,t,u);var a=ta;if(a&&l&&t){var e='link[rel="preload"][as="'+Gl(t)+'"]';t==="image"&&u&&u.imageSrcSet?(e+='[imagesrcset="'+Gl(u.imageSrcSet)+'"]',typeof u.imageSizes=="string"&&(e+='[imagesizes="'+Gl(u.imageSizes)+'"]')):e+='[href="'+Gl(l)+'"]';var n=e;switch(t){case"style":n=Wu(l);break;case"script":n=ua(l)}Vl.has(n)||(l=K({rel:"preload",href:t==="image"&&u&&u.imageSrcSet?void 0:l,as:t},u),Vl.set(n,l),a.querySelector(e)!==null||t==="style"&&a.querySelector(ae(n))||t==="script"&&a.querySelector(ee(n))||(t=a.createElement("link"),yl(t,"link",l),sl(t),a.head.appendChild(t)))}}function lo(l,t){bt.m(l,t);var u=ta;if(u&&l){var a=t&&typeof t.as=="string"?t.as:"script",e='link[rel="modulepreload"][as="'+Gl(a)+'"][href="'+Gl(l)+'"]',n=e;switch(a){case"audioworklet":case"paintworklet":case"serviceworker":case"sharedworker":case"worker":case"script":n=ua(l)}if(!Vl.has(n)&&(l=K({rel:"modulepreload",href:l},t),Vl.set(n,l),u.querySelector(e)===null)){switch(a){case"audioworklet":case"paintworklet":case"serviceworker":case"sharedworker":case"worker":case"script":if(u.querySelector(ee(n)))return}a=u.createElement("link"),yl(a,"link",l),sl(a),u.head.appendChild(a)}}}function to(l,t,u){bt.S(l,t,u);var a=ta;if(a&&l){var e=qu(a).hoistableStyles,n=Wu(l);t=t||"default";var c=e.get(n);if(!c){var i={loading:0,preload:null};if(c=a.querySelector(ae(n)))i.loading=5;else{l=K({rel:"stylesheet",href:l,"data-precedence":t},u),(u=Vl.get(n))&&ki(l,u);var f=c=a.createElement("link");sl(f),yl(f,"link",l),f._p=new Promise(function(s,m){f.onload=s,f.onerror=m}),f.addEventListener("load",function(){i.loading|=1}),f.addEventListener("error",function(){i.loading|=2}),i.loading|=4,qe(c,t,a)}c={type:"stylesheet",instance:c,count:1,state:i},e.set(n,c)}}}function uo(l,t){bt.X(l,t);var u=ta;if(u&&l){var a=qu(u).hoistableScripts,e=ua(l),n=a.get(e);n||(n=u.querySelector(ee(e)),n||(l=K({src:l,async:!0},t),(t=Vl.get(e))&&Fi(l,t),n=u.createElement("script"),sl(n),yl(n,"link",l),u.head.appendChild(n)),n={type:"script",instance:n,count:1,state:null},a.set(e,n))}}function ao(l,t){bt.M(l,t);var u=ta;if(u&&l){var a=qu(u).hoistableScripts,e=ua(l),n=a.get(e);n||(n=u.querySelector(ee(e)),n||(l=K({src:l,async:!0,type:"module"},t),(t=Vl.get(e))&&Fi(l,t),n=u.createElement("script"),sl(n),yl(n,"link",l),u.head.appendChild(n)),n={type:"script",instance:n,count:1,state:null},a.set(e,n))}}function r0(l,t,u,a){var e=(e=pt.current)?nn(e):null;if(!e)throw Error(S(446));switch(l){case"meta":case"title":return null;case"style":return typeof u.precedence=="string"&&typeof u.href=="string"?(t=Wu(u.href),u=qu(e).hoistableStyles,a=u.get(t),a||(a={type:"style",instance:null,count:0,state:null},u.set(t,a)),a):{type:"void",instance:null,count:0,state:null};case"link":if(u.rel==="stylesheet"&&typeof u.href=="string"&&typeof u.precedence=="string"){l=Wu(u.href);var n=qu(e).hoistableStyles,c=n.get(l);if(c||(e=e.ownerDocument||e,c={type:"stylesheet",instance:null,count:0,state:{loading:0,preload:null}},n.set(l,c),(n=e.querySelector(ae(l)))&&!n._p&&(c.instance=n,c.state.loading=5),Vl.has(l)||(u={rel:"preload",as:"style",href:u.href,crossOrigin:u.crossOrigin,integrity:u.integrity,media:u.media,hrefLang:u.hrefLang,referrerPolicy:u.referrerPolicy},Vl.set(l,u),n||eo(e,l,u,c.state))),t&&a===null)throw Error(S(528,""));return c}if(t&&a!==null)throw Error(S(529,""));return null;case"script":return t=u.async,u=u.src,typeof u=="string"&&t&&typeof t!="function"&&typeof t!="symbol"?(t=ua(u),u=qu(e).hoistableScripts,a=u.get(t),a||(a={type:"script",instance:null,count:0,state:null},u.set(t,a)),a):{type:"void",instance:null,count:0,state:null};default:throw Error(S(444,l))}}function Wu(l){return'href="'+Gl(l)+'"'}function ae(l){return'link[rel="stylesheet"]['+l+"]"}function sd(l){return K({},l,{"data-precedence":l.precedence,precedence:null})}function eo(l,t,u,a){l.querySelector('link[rel="preload"][as="style"]['+t+"]")?a.loading=1:(t=l.createElement("link"),a.preload=t,t.addEventListener("load",function(){return a.loading|=1}),t.addEventListener("error",function(){return a.loading|=2}),yl(t,"link",u),sl(t),l.head.appendChild(t))}function ua(l){return'[src="'+Gl(l)+'"]'}function ee(l){return"script[async]"+l}function g0(l,t,u){if(t.count++,t.instance===null)switch(t.type){case"style":var a=l.querySelector('style[data-href~="'+Gl(u.href)+'"]');if(a)return t.instance=a,sl(a),a;var e=K({},u,{"data-href":u.href,"data-precedence":u.precedence,href:null,precedence:null});return a=(l.ownerDocument||l).createElement("style"),sl(a),yl(a,"style",e),qe(a,u.precedence,l),t.instance=a;case"stylesheet":e=Wu(u.href);var n=l.querySelector(ae(e));if(n)return t.state.loading|=4,t.instance=n,sl(n),n;a=sd(u),(e=Vl.get(e))&&ki(a,e),n=(l.ownerDocument||l).createElement("link"),sl(n);var c=n;return c._p=new Promise(function(i,f){c.onload=i,c.onerror=f}),yl(n,"link",a),t.state.loading|=4,qe(n,u.precedence,l),t.instance=n;case"script":return n=ua(u.src),(e=l.querySelector(ee(n)))?(t.instance=e,sl(e),e):(a=u,(e=Vl.get(n))&&(a=K({},u),Fi(a,e)),l=l.ownerDocument||l,e=l.createElement("script"),sl(e),yl(e,"link",a),l.head.appendChild(e),t.instance=e);case"void":return null;default:throw Error(S(443,t.type))}else t.type==="stylesheet"&&!(t.state.loading&4)&&(a=t.instance,t.state.loading|=4,qe(a,u.precedence,l));return t.instance}function qe(l,t,u){for(var a=u.querySelectorAll('link[rel="stylesheet"][data-precedence],style[data-precedence]'),e=a.length?a[a.length-1]:null,n=e,c=0;c<a.length;c++){var i=a[c];if(i.dataset.precedence===t)n=i;else if(n!==e)break}n?n.parentNode.insertBefore(l,n.nextSibling):(t=u.nodeType===9?u.head:u,t.insertBefore(l,t.firstChild))}function ki(l,t){l.crossOrigin==null&&(l.crossOrigin=t.crossOrigin),l.referrerPolicy==null&&(l.referrerPolicy=t.referrerPolicy),l.title==null&&(l.title=t.title)}function Fi(l,t){l.crossOrigin==null&&(l.crossOrigin=t.crossOrigin),l.referrerPolicy==null&&(l.referrerPolicy=t.referrerPolicy),l.integrity==null&&(l.integrity=t.integrity)}var Ye=null;function S0(l,t,u){if(Ye===null){var a=new Map,e=Ye=new Map;e.set(u,a)}else e=Ye,a=e.get(u),a||(a=new Map,e.set(u,a));if(a.has(l))return a;for(a.set(l,null),u=u.getElementsByTagName(l),e=0;e<u.length;e++){var n=u[e];if(!(n[qa]||n[rl]||l==="link"&&n.getAttribute("rel")==="stylesheet")&&n.namespaceURI!=="http://www.w3.org/2000/svg"){var c=n.getAttribute(t)||"";c=l+c;var i=a.get(c);i?i.push(n):a.set(c,[n])}}return a}function b0(l,t,u){l=l.ownerDocument||l,l.head.insertBefore(u,t==="title"?l.querySelector("head > title"):null)}function no(l,t,u){if(u===1||t.itemProp!=null)return!1;switch(l){case"meta":case"title":return!0;case"style":if(typeof t.precedence!="string"||typeof t.href!="string"||t.href==="")break;return!0;case"link":if(typeof t.rel!="string"||typeof t.href!="string"||t.href===""||t.onLoad||t.onError)break;switch(t.rel){case"stylesheet":return l=t.disabled,typeof t.precedence=="string"&&l==null;default:return!0}case"script":if(t.async&&typeof t.async!="function"&&typeof t.async!="symbol"&&!t.onLoad&&!t.onError&&t.src&&typeof t.src=="string")return!0}return!1}function dd(l){return!(l.type==="stylesheet"&&!(l.state.loading&3))}var Za=null;function co(){}function io(l,t,u){if(Za===null)throw Error(S(475));var a=Za;if(t.type==="stylesheet"&&(typeof u.media!="string"||matchMedia(u.media).matches!==!1)&&!(t.state.loading&4)){if(t.instance===null){var e=Wu(u.href),n=l.querySelector(ae(e));if(n){l=n._p,l!==null&&typeof l=="object"&&typeof l.then=="function"&&(a.count++,a=cn.bind(a),l.then(a,a)),t.state.loading|=4,t.instance=n,sl(n);return}n=l.ownerDocument||l,u=sd(u),(e=Vl.get(e))&&ki(u,e),n=n.createElement("link"),sl(n);var c=n;c._p=new Promise(function(i,f){c.onload=i,c.onerror=f}),yl(n,"link",u),t.instance=n}a.stylesheets===null&&(a.stylesheets=new Map),a.stylesheets.set(t,l),(l=t.state.preload)&&!(t.state.loading&3)&&(a.count++,t=cn.bind(a),l.addEventListener("load",t),l.addEventListener("error",t))}}function fo(){if(Za===null)throw Error(S(475));var l=Za;return l.stylesheets&&l.count===0&&ui(l,l.stylesheets),0<l.count?function(t){var u=setTimeout(function(){if(l.stylesheets&&ui(l,l.stylesheets),l.unsuspend){var a=l.unsuspend;l.unsuspend=null,a()}},6e4);return l.unsuspend=t,function(){l.unsuspend=null,clearTimeout(u)}}:null}function cn(){if(this.count--,this.count===0){if(this.stylesheets)ui(this,this.stylesheets);else if(this.unsuspend){var l=this.unsuspend;this.unsuspend=null,l()}}}var fn=null;function ui(l,t){l.stylesheets=null,l.unsuspend!==null&&(l.count++,fn=new Map,t.forEach(so,l),fn=null,cn.call(l))}function so(l,t){if(!(t.state.loading&4)){var u=fn.get(l);if(u)var a=u.get(null);else{u=new Map,fn.set(l,u);for(var e=l.querySelectorAll("link[data-precedence],style[data-precedence]"),n=0;n<e.length;n++){var c=e[n];(c.nodeName==="LINK"||c.getAttribute("media")!=="not all")&&(u.set(c.dataset.precedence,c),a=c)}a&&u.set(null,a)}e=t.instance,c=e.getAttribute("data-precedence"),n=u.get(c)||a,n===a&&u.set(null,e),u.set(c,e),this.count++,a=cn.bind(this),e.addEventListener("load",a),e.addEventListener("error",a),n?n.parentNode.insertBefore(e,n.nextSibling):(l=l.nodeType===9?l.head:l,l.insertBefore(e,l.firstChild)),t.state.loading|=4}}var Va={$$typeof:st,Provider:null,Consumer:null,_currentValue:It,_currentValue2:It,_threadCount:0};function vo(l,t,u,a,e,n,c,i){this.tag=1,this.containerInfo=l,this.finishedWork=this.pingCache=this.current=this.pendingChildren=null,this.timeoutHandle=-1,this.callbackNode=this.next=this.pendingContext=this.context=this.cancelPendingCommit=null,this.callbackPriority=0,this.expirationTimes=Bn(-1),this.entangledLanes=this.shellSuspendCounter=this.errorRecoveryDisabledLanes=this.finishedLanes=this.expiredLanes=this.warmLanes=this.pingedLanes=this.suspendedLanes=this.pendingLanes=0,this.entanglements=Bn(0),this.hiddenUpdates=Bn(null),this.identifierPrefix=a,this.onUncaughtError=e,this.onCaughtError=n,this.onRecoverableError=c,this.pooledCache=null,this.pooledCacheLanes=0,this.formState=i,this.incompleteTransitions=new Map}function vd(l,t,u,a,e,n,c,i,f,s,m,g){return l=new vo(l,t,u,c,i,f,s,g),t=1,n===!0&&(t|=24),n=Ql(3,null,null,t),l.current=n,n.stateNode=l,t=_i(),t.refCount++,l.pooledCache=t,t.refCount++,n.memoizedState={element:a,isDehydrated:u,cache:t},Qi(n),l}function hd(l){return l?(l=Hu,l):Hu}function od(l,t,u,a,e,n){e=hd(e),a.context===null?a.context=e:a.pendingContext=e,a=qt(t),a.payload={element:u},n=n===void 0?null:n,n!==null&&(a.callback=n),u=Yt(l,a,t),u!==null&&(El(u,l,t),_a(u,l,t))}function E0(l,t){if(l=l.memoizedState,l!==null&&l.dehydrated!==null){var u=l.retryLane;l.retryLane=u!==0&&u<t?u:t}}function Pi(l,t){E0(l,t),(l=l.alternate)&&E0(l,t)}function yd(l){if(l.tag===13){var t=Qt(l,67108864);t!==null&&El(t,l,67108864),Pi(l,67108864)}}var sn=!0;function ho(l,t,u,a){var e=M.T;M.T=null;var n=L.p;try{L.p=2,Ii(l,t,u,a)}finally{L.p=n,M.T=e}}function oo(l,t,u,a){var e=M.T;M.T=null;var n=L.p;try{L.p=8,Ii(l,t,u,a)}finally{L.p=n,M.T=e}}function Ii(l,t,u,a){if(sn){var e=ai(a);if(e===null)sc(l,t,a,dn,u),T0(l,a);else if(mo(e,l,t,u,a))a.stopPropagation();else if(T0(l,a),t&4&&-1<yo.indexOf(l)){for(;e!==null;){var n=Iu(e);if(n!==null)switch(n.tag){case 3:if(n=n.stateNode,n.current.memoizedState.isDehydrated){var c=$t(n.pendingLanes);if(c!==0){var i=n;for(i.pendingLanes|=2,i.entangledLanes|=2;c;){var f=1<<31-Hl(c);i.entanglements[1]|=f,c&=~f}tt(n),!(W&6)&&(Ie=Fl()+500,ue(0,!1))}}break;case 13:i=Qt(n,2),i!==null&&El(i,n,2),On(),Pi(n,2)}if(n=ai(a),n===null&&sc(l,t,a,dn,u),n===e)break;e=n}e!==null&&a.stopPropagation()}else sc(l,t,a,null,u)}}function ai(l){return l=mi(l),lf(l)}var dn=null;function lf(l){if(dn=null,l=Ft(l),l!==null){var t=Fu(l);if(t===null)l=null;else{var u=t.tag;if(u===13){if(l=J0(t),l!==null)return l;l=null}else if(u===3){if(t.stateNode.current.memoizedState.isDehydrated)return t.tag===3?t.stateNode.containerInfo:null;l=null}else t!==l&&(l=null)}}return dn=l,null}function md(l){switch(l){case"beforetoggle":case"cancel":case"click":case"close":case"contextmenu":case"copy":case"cut":case"auxclick":case"dblclick":case"dragend":case"dragstart":case"drop":case"focusin":case"focusout":case"input":case"invalid":case"keydown":case"keypress":case"keyup":case"mousedown":case"mouseup":case"paste":case"pause":case"play":case"pointercancel":case"pointerdown":case"pointerup":case"ratechange":case"reset":case"resize":case"seeked":case"submit":case"toggle":case"touchcancel":case"touchend":case"touchstart":case"volumechange":case"change":case"selectionchange":case"textInput":case"compositionstart":case"compositionend":case"compositionupdate":case"beforeblur":case"afterblur":case"beforeinput":case"blur":case"fullscreenchange":case"focus":case"hashchange":case"popstate":case"select":case"selectstart":return 2;case"drag":case"dragenter":case"dragexit":case"dragleave":case"dragover":case"mousemove":case"mouseout":case"mouseover":case"pointermove":case"pointerout":case"pointerover":case"scroll":case"touchmove":case"wheel":case"mouseenter":case"mouseleave":case"pointerenter":case"pointerleave":return 8;case"message":switch(Pd()){case $0:return 2;case W0:return 8;case Xe:case Id:return 32;case k0:return 268435456;default:return 32}default:return 32}}var ei=!1,Ct=null,Gt=null,Xt=null,La=new Map,Ka=new Map,Ht=[],yo="mousedown mouseup touchcancel touchend touchstart auxclick dblclick pointercancel pointerdown pointerup dragend dragstart drop compositionend compositionstart keydown keypress keyup input textInput copy cut paste click change contextmenu reset".split(" ");function T0(l,t){switch(l){case"focusin":case"focusout":Ct=null;break;case"dragenter":case"dragleave":Gt=null;break;case"mouseover":case"mouseout":Xt=null;break;case"pointerover":case"pointerout":La.delete(t.pointerId);break;case"gotpointercapture":case"lostpointercapture":Ka.delete(t.pointerId)}}function da(l,t,u,a,e,n){return l===null||l.nativeEvent!==n?(l={blockedOn:t,domEventName:u,eventSystemFlags:a,nativeEvent:n,targetContainers:[e]},t!==null&&(t=Iu(t),t!==null&&yd(t)),l):(l.eventSystemFlags|=a,t=l.targetContainers,e!==null&&t.indexOf(e)===-1&&t.push(e),l)}function mo(l,t,u,a,e){switch(t){case"focusin":return Ct=da(Ct,l,t,u,a,e),!0;case"dragenter":return Gt=da(Gt,l,t,u,a,e),!0;case"mouseover":return Xt=da(Xt,l,t,u,a,e),!0;case"pointerover":var n=e.pointerId;return La.set(n,da(La.get(n)||null,l,t,u,a,e)),!0;case"gotpointercapture":return n=e.pointerId,Ka.set(n,da(Ka.get(n)||null,l,t,u,a,e)),!0}return!1}function rd(l){var t=Ft(l.target);if(t!==null){var u=Fu(t);if(u!==null){if(t=u.tag,t===13){if(t=J0(u),t!==null){l.blockedOn=t,fv(l.priority,function(){if(u.tag===13){var a=Rl(),e=Qt(u,a);e!==null&&El(e,u,a),Pi(u,a)}});return}}else if(t===3&&u.stateNode.current.memoizedState.isDehydrated){l.blockedOn=u.tag===3?u.stateNode.containerInfo:null;return}}}l.blockedOn=null}function je(l){if(l.blockedOn!==null)return!1;for(var t=l.targetContainers;0<t.length;){var u=ai(l.nativeEvent);if(u===null){u=l.nativeEvent;var a=new u.constructor(u.type,u);Ac=a,u.target.dispatchEvent(a),Ac=null}else return t=Iu(u),t!==null&&yd(t),l.blockedOn=u,!1;t.shift()}return!0}function A0(l,t,u){je(l)&&u.delete(t)}function ro(){ei=!1,Ct!==null&&je(Ct)&&(Ct=null),Gt!==null&&je(Gt)&&(Gt=null),Xt!==null&&je(Xt)&&(Xt=null),La.forEach(A0),Ka.forEach(A0)}function Te(l,t){l.blockedOn===t&&(l.blockedOn=null,ei||(ei=!0,cl.unstable_scheduleCallback(cl.unstable_NormalPriority,ro)))}var Ae=null;function z0(l){Ae!==l&&(Ae=l,cl.unstable_scheduleCallback(cl.unstable_NormalPriority,function(){Ae===l&&(Ae=null);for(var t=0;t<l.length;t+=3){var u=l[t],a=l[t+1],e=l[t+2];if(typeof a!="function"){if(lf(a||u)===null)continue;break}var n=Iu(u);n!==null&&(l.splice(t,3),t-=3,pc(n,{pending:!0,data:e,method:u.method,action:a},a,e))}}))}function Ja(l){function t(f){return Te(f,l)}Ct!==null&&Te(Ct,l),Gt!==null&&Te(Gt,l),Xt!==null&&Te(Xt,l),La.forEach(t),Ka.forEach(t);for(var u=0;u<Ht.length;u++){var a=Ht[u];a.blockedOn===l&&(a.blockedOn=null)}for(;0<Ht.length&&(u=Ht[0],u.blockedOn===null);)rd(u),u.blockedOn===null&&Ht.shift();if(u=(l.ownerDocument||l).$$reactFormReplay,u!=null)for(a=0;a<u.length;a+=3){var e=u[a],n=u[a+1],c=e[zl]||null;if(typeof n=="function")c||z0(u);else if(c){var i=null;if(n&&n.hasAttribute("formAction")){if(e=n,c=n[zl]||null)i=c.formAction;else if(lf(e)!==null)continue}else i=c.action;typeof i=="function"?u[a+1]=i:(u.splice(a,3),a-=3),z0(u)}}}function tf(l){this._internalRoot=l}Mn.prototype.render=tf.prototype.render=function(l){var t=this._internalRoot;if(t===null)throw Error(S(409));var u=t.current,a=Rl();od(u,a,l,t,null,null)};Mn.prototype.unmount=tf.prototype.unmount=function(){var l=this._internalRoot;if(l!==null){this._internalRoot=null;var t=l.containerInfo;l.tag===0&&Qu(),od(l.current,2,null,l,null,null),On(),t[Pu]=null}};function Mn(l){this._internalRoot=l}Mn.prototype.unstable_scheduleHydration=function(l){if(l){var t=us();l={blockedOn:null,target:l,priority:t};for(var u=0;u<Ht.length&&t!==0&&t<Ht[u].priority;u++);Ht.splice(u,0,l),u===0&&rd(l)}};var O0=x0.version;if(O0!=="19.0.0")throw Error(S(527,O0,"19.0.0"));L.findDOMNode=function(l){var t=l._reactInternals;if(t===void 0)throw typeof l.render=="function"?Error(S(188)):(l=Object.keys(l).join(","),Error(S(268,l)));return l=Wd(t),l=l!==null?w0(l):null,l=l===null?null:l.stateNode,l};var go={bundleType:0,version:"19.0.0",rendererPackageName:"react-dom",currentDispatcherRef:M,findFiberByHostInstance:Ft,reconcilerVersion:"19.0.0"};if(typeof __REACT_DEVTOOLS_GLOBAL_HOOK__<"u"){var ze=__REACT_DEVTOOLS_GLOBAL_HOOK__;if(!ze.isDisabled&&ze.supportsFiber)try{wa=ze.inject(go),Ul=ze}catch{}}hn.createRoot=function(l,t){if(!Z0(l))throw Error(S(299));var u=!1,a="",e=o1,n=y1,c=m1,i=null;return t!=null&&(t.unstable_strictMode===!0&&(u=!0),t.identifierPrefix!==void 0&&(a=t.identifierPrefix),t.onUncaughtError!==void 0&&(e=t.onUncaughtError),t.onCaughtError!==void 0&&(n=t.onCaughtError),t.onRecoverableError!==void 0&&(c=t.onRecoverableError),t.unstable_transitionCallbacks!==void 0&&(i=t.unstable_transitionCallbacks)),t=vd(l,1,!1,null,null,u,a,e,n,c,i,null),l[Pu]=t.current,Wi(l.nodeType===8?l.parentNode:l),new tf(t)};hn.hydrateRoot=function(l,t,u){if(!Z0(l))throw Error(S(299));var a=!1,e="",n=o1,c=y1,i=m1,f=null,s=null;return u!=null&&(u.unstable_strictMode===!0&&(a=!0),u.identifierPrefix!==void 0&&(e=u.identifierPrefix),u.onUncaughtError!==void 0&&(n=u.onUncaughtError),u.onCaughtError!==void 0&&(c=u.onCaughtError),u.onRecoverableError!==void 0&&(i=u.onRecoverableError),u.unstable_transitionCallbacks!==void 0&&(f=u.unstable_transitionCallbacks),u.formState!==void 0&&(s=u.formState)),t=vd(l,1,!0,t,u??null,a,e,n,c,i,f,s),t.context=hd(null),u=t.current,a=Rl(),e=qt(a),e.callback=null,Yt(u,e,a),t.current.lanes=a,Wa(t,a),tt(t),l[Pu]=t.current,Wi(l),new Mn(t)};hn.version="19.0.0";function gd(){if(!(typeof __REACT_DEVTOOLS_GLOBAL_HOOK__>"u"||typeof __REACT_DEVTOOLS_GLOBAL_HOOK__.checkDCE!="function"))try{__REACT_DEVTOOLS_GLOBAL_HOOK__.checkDCE(gd)}catch(l){console.error(l)}}gd(),j0.exports=hn;var So=j0.exports;const bo=_0(So),Sd=al.createContext({theme:!0,themeIsAuto:!0,changeTheme:l=>{},themeToDefault:()=>{}}),Eo=()=>al.useContext(Sd),To=({children:l})=>{const[t,u]=al.useState(()=>{try{const f=localStorage.getItem("themeIsAuto");return f===null?!0:f==="true"}catch(f){return console.error("Error reading themeIsAuto from localStorage:",f),!0}}),[a,e]=al.useState(()=>{try{const f=localStorage.getItem("themeIsDark"),s=window.matchMedia("(prefers-color-scheme: dark)").matches;return f===null?s:f==="true"}catch(f){return console.error("Error reading themeIsDark from localStorage:",f),window.matchMedia("(prefers-color-scheme: dark)").matches}});al.useLayoutEffect(()=>{try{localStorage.setItem("themeIsDark",String(a))}catch(s){console.error("Error setting themeIsDark to localStorage:",s)}document.documentElement.classList.toggle("dark",a);const f=document.querySelector("meta[name='theme-color']");f&&(f.content=a?"#18181b":"#fcfcff")},[a]);const n=al.useCallback(f=>{e(f)},[]);al.useLayoutEffect(()=>{const f=m=>{t&&n(m.matches)},s=window.matchMedia("(prefers-color-scheme: dark)");try{localStorage.getItem("themeIsAuto")==="true"&&n(s.matches)}catch(m){console.log(m)}return s.addEventListener("change",f),()=>{s.removeEventListener("change",f)}},[n,t]);const c=al.useCallback(f=>{u(!1);try{localStorage.setItem("themeIsAuto",String(!1))}catch(s){console.error("Error setting themeIsAuto to localStorage:",s)}n(f)},[n]),i=al.useCallback(()=>{u(!0);try{localStorage.setItem("themeIsAuto",String(!0))}catch(f){console.error("Error setting themeIsAuto to localStorage:",f)}n(window.matchMedia("(prefers-color-scheme: dark)").matches)},[n]);return _.jsx(Sd,{value:{theme:a,themeIsAuto:t,changeTheme:c,themeToDefault:i},children:l})};function Ao(){return _.jsx("svg",{xmlns:"http://www.w3.org/2000/svg",height:"24",viewBox:"0 -960 960 960",width:"24",children:_.jsx("path",{d:"M480-80q-83 0-156-31.5T197-197q-54-54-85.5-127T80-480q0-83 31.5-156T197-763q54-54 127-85.5T480-880q83 0 156 31.5T763-763q54 54 85.5 127T880-480q0 83-31.5 156T763-197q-54 54-127 85.5T480-80Zm40-83q119-15 199.5-104.5T800-480q0-123-80.5-212.5T520-797v634Z"})})}function zo(){const{theme:l,changeTheme:t}=Eo(),[u,a]=al.useState(""),[e,n]=al.useState([]),[c,i]=al.useState(!1),[f,s]=al.useState(8080),[m,g]=al.useState([]),[h,r]=al.useState([]);async function T(){let y=`${window.location.origin}/api`;fetch(y).then(b=>{if(!b.ok)throw new Error(`Ошибка HTTP: ${b.status}`);return b.text()}).then(b=>{console.log("Ответ от API:",b),s(b)}).catch(b=>{console.error("Ошибка при выполнении запроса:",b)})}al.useEffect(()=>{async function y(){i(null);try{const b=await T();s(b)}catch(b){n([...e,b])}}y()},[]);const E=y=>{c?(a(y),i(!1)):a(`${u}${y}`)},Q=()=>{c&&(a(""),i(!1)),a(u.slice(0,-1))},v=()=>{i(!1),a("")};async function d(y,b,D){const A=`http://${f}/api/v1/expressions/${y}`;try{const O=await fetch(A);if(!O.ok)throw new Error("Response status: ");const C=await O.json();if(C.expression&&C.expression.id)if(C.expression.status=="processing"||C.expression.status=="pending"){let U=m.filter((ml,Et)=>Et!==D);g([{id:y,expr:b,status:C.expression.status=="processing"?"Выполняется":"В ожидании"},...U])}else{C.expression.expr=b,r([C.expression,...h]);let U=m.filter((ml,Et)=>Et!==D);g(U)}}catch(O){n([...e,O.message])}}async function o(){a("");let y=u;try{const b=`http://${f}/api/v1/calculate`,A=await(await fetch(b,{method:"POST",headers:{"Content-Type":"application/json"},body:JSON.stringify({expression:y})})).json();A.error?(i(!0),n([...e,A.error])):g([{id:A.id,expr:y},...m])}catch(b){i(!0),n([...e,b.message])}}return _.jsxs("div",{className:"page",children:[_.jsx("div",{className:"theme",onClick:()=>{t(!l)},children:_.jsx(Ao,{})}),_.jsxs("div",{className:"page_container",children:[_.jsx("div",{className:`results ${m.length==0?"trans":""}`,children:m.map((y,b)=>y.status?_.jsxs("div",{className:"result waiting pro",children:[_.jsx("div",{children:y.expr}),_.jsx("div",{children:y.status}),_.jsx("button",{onClick:()=>{d(y.id,y.expr,b)},children:"Проверить"})]},y.id):_.jsxs("div",{className:"result waiting",children:[_.jsx("div",{children:y.expr}),_.jsx("button",{onClick:()=>{d(y.id,y.expr,b)},children:"Проверить"})]},y.id))}),_.jsxs("div",{className:"calculator",children:[_.jsx("div",{className:"errors",children:e.map((y,b)=>_.jsx("div",{className:"error",onClick:()=>{let D=e.filter((A,O)=>O!==b);n(D)},children:y},b))}),_.jsx("input",{className:`display${c?" err":""}`,onChange:y=>{i(!1),a(c?"":y.target.value.replace(/[^0-9()\^\+\-\*\/]/g,""))},value:u}),_.jsxs("div",{className:"buttons",children:[_.jsx("button",{onClick:()=>E("("),children:"("}),_.jsx("button",{onClick:()=>E(")"),children:")"}),_.jsx("button",{onClick:()=>E("^"),children:"^"}),_.jsx("button",{onClick:v,children:"CE"}),_.jsx("button",{onClick:()=>E("7"),children:"7"}),_.jsx("button",{onClick:()=>E("8"),children:"8"}),_.jsx("button",{onClick:()=>E("9"),children:"9"}),_.jsx("button",{onClick:()=>E("/"),children:"/"}),_.jsx("button",{onClick:()=>E("4"),children:"4"}),_.jsx("button",{onClick:()=>E("5"),children:"5"}),_.jsx("button",{onClick:()=>E("6"),children:"6"}),_.jsx("button",{onClick:()=>E("*"),children:"*"}),_.jsx("button",{onClick:()=>E("1"),children:"1"}),_.jsx("button",{onClick:()=>E("2"),children:"2"}),_.jsx("button",{onClick:()=>E("3"),children:"3"}),_.jsx("button",{onClick:()=>E("-"),children:"-"}),_.jsx("button",{onClick:()=>E("00"),children:"00"}),_.jsx("button",{onClick:()=>E("."),children:"."}),_.jsx("button",{onClick:()=>E("0"),children:"0"}),_.jsx("button",{onClick:()=>E("+"),children:"+"}),_.jsx("button",{className:"col-span-2",onClick:Q,children:"←"}),_.jsx("button",{className:"col-span-2",onClick:o,children:"="})]})]}),_.jsx("div",{className:`results ${h.length==0?"trans":""}`,children:h.map((y,b)=>y.error?_.jsxs("div",{className:"result err",children:[_.jsx("div",{children:y.expr}),_.jsxs("div",{children:[y.error," "]})]},b):_.jsxs("div",{className:"result",children:[_.jsx("div",{children:y.expr}),_.jsxs("div",{children:["= ",y.result," "]})]},b))})]})]})}function Oo(){return _.jsx(To,{children:_.jsx(zo,{})})}bo.createRoot(document.getElementById("root")).render(_.jsx(Cd.StrictMode,{children:_.jsx(Oo,{})}));
+`).replace(xh,"")}function ed(l,t){return t=v0(t),v0(l)===t}function Dn(){}function G(l,t,u,a,e,n){switch(u){case"children":typeof a=="string"?t==="body"||t==="textarea"&&a===""||Vu(l,a):(typeof a=="number"||typeof a=="bigint")&&t!=="body"&&Vu(l,""+a);break;case"className":he(l,"class",a);break;case"tabIndex":he(l,"tabindex",a);break;case"dir":case"role":case"viewBox":case"width":case"height":he(l,u,a);break;case"style":ds(l,a,n);break;case"data":if(t!=="object"){he(l,"data",a);break}case"src":case"href":if(a===""&&(t!=="a"||u!=="href")){l.removeAttribute(u);break}if(a==null||typeof a=="function"||typeof a=="symbol"||typeof a=="boolean"){l.removeAttribute(u);break}a=_e(""+a),l.setAttribute(u,a);break;case"action":case"formAction":if(typeof a=="function"){l.setAttribute(u,"javascript:throw new Error('A React form was unexpectedly submitted. If you called form.submit() manually, consider using form.requestSubmit() instead. If you\\'re trying to use event.stopPropagation() in a submit event handler, consider also calling event.preventDefault().')");break}else typeof n=="function"&&(u==="formAction"?(t!=="input"&&G(l,t,"name",e.name,e,null),G(l,t,"formEncType",e.formEncType,e,null),G(l,t,"formMethod",e.formMethod,e,null),G(l,t,"formTarget",e.formTarget,e,null)):(G(l,t,"encType",e.encType,e,null),G(l,t,"method",e.method,e,null),G(l,t,"target",e.target,e,null)));if(a==null||typeof a=="symbol"||typeof a=="boolean"){l.removeAttribute(u);break}a=_e(""+a),l.setAttribute(u,a);break;case"onClick":a!=null&&(l.onclick=Dn);break;case"onScroll":a!=null&&Y("scroll",l);break;case"onScrollEnd":a!=null&&Y("scrollend",l);break;case"dangerouslySetInnerHTML":if(a!=null){if(typeof a!="object"||!("__html"in a))throw Error(S(61));if(u=a.__html,u!=null){if(e.children!=null)throw Error(S(60));l.innerHTML=u}}break;case"multiple":l.multiple=a&&typeof a!="function"&&typeof a!="symbol";break;case"muted":l.muted=a&&typeof a!="function"&&typeof a!="symbol";break;case"suppressContentEditableWarning":case"suppressHydrationWarning":case"defaultValue":case"defaultChecked":case"innerHTML":case"ref":break;case"autoFocus":break;case"xlinkHref":if(a==null||typeof a=="function"||typeof a=="boolean"||typeof a=="symbol"){l.removeAttribute("xlink:href");break}u=_e(""+a),l.setAttributeNS("http://www.w3.org/1999/xlink","xlink:href",u);break;case"contentEditable":case"spellCheck":case"draggable":case"value":case"autoReverse":case"externalResourcesRequired":case"focusable":case"preserveAlpha":a!=null&&typeof a!="function"&&typeof a!="symbol"?l.setAttribute(u,""+a):l.removeAttribute(u);break;case"inert":case"allowFullScreen":case"async":case"autoPlay":case"controls":case"default":case"defer":case"disabled":case"disablePictureInPicture":case"disableRemotePlayback":case"formNoValidate":case"hidden":case"loop":case"noModule":case"noValidate":case"open":case"playsInline":case"readOnly":case"required":case"reversed":case"scoped":case"seamless":case"itemScope":a&&typeof a!="function"&&typeof a!="symbol"?l.setAttribute(u,""):l.removeAttribute(u);break;case"capture":case"download":a===!0?l.setAttribute(u,""):a!==!1&&a!=null&&typeof a!="function"&&typeof a!="symbol"?l.setAttribute(u,a):l.removeAttribute(u);break;case"cols":case"rows":case"size":case"span":a!=null&&typeof a!="function"&&typeof a!="symbol"&&!isNaN(a)&&1<=a?l.setAttribute(u,a):l.removeAttribute(u);break;case"rowSpan":case"start":a==null||typeof a=="function"||typeof a=="symbol"||isNaN(a)?l.removeAttribute(u):l.setAttribute(u,a);break;case"popover":Y("beforetoggle",l),Y("toggle",l),Oe(l,"popover",a);break;case"xlinkActuate":ut(l,"http://www.w3.org/1999/xlink","xlink:actuate",a);break;case"xlinkArcrole":ut(l,"http://www.w3.org/1999/xlink","xlink:arcrole",a);break;case"xlinkRole":ut(l,"http://www.w3.org/1999/xlink","xlink:role",a);break;case"xlinkShow":ut(l,"http://www.w3.org/1999/xlink","xlink:show",a);break;case"xlinkTitle":ut(l,"http://www.w3.org/1999/xlink","xlink:title",a);break;case"xlinkType":ut(l,"http://www.w3.org/1999/xlink","xlink:type",a);break;case"xmlBase":ut(l,"http://www.w3.org/XML/1998/namespace","xml:base",a);break;case"xmlLang":ut(l,"http://www.w3.org/XML/1998/namespace","xml:lang",a);break;case"xmlSpace":ut(l,"http://www.w3.org/XML/1998/namespace","xml:space",a);break;case"is":Oe(l,"is",a);break;case"innerText":case"textContent":break;default:(!(2<u.length)||u[0]!=="o"&&u[0]!=="O"||u[1]!=="n"&&u[1]!=="N")&&(u=rv.get(u)||u,Oe(l,u,a))}}function Fc(l,t,u,a,e,n){switch(u){case"style":ds(l,a,n);break;case"dangerouslySetInnerHTML":if(a!=null){if(typeof a!="object"||!("__html"in a))throw Error(S(61));if(u=a.__html,u!=null){if(e.children!=null)throw Error(S(60));l.innerHTML=u}}break;case"children":typeof a=="string"?Vu(l,a):(typeof a=="number"||typeof a=="bigint")&&Vu(l,""+a);break;case"onScroll":a!=null&&Y("scroll",l);break;case"onScrollEnd":a!=null&&Y("scrollend",l);break;case"onClick":a!=null&&(l.onclick=Dn);break;case"suppressContentEditableWarning":case"suppressHydrationWarning":case"innerHTML":case"ref":break;case"innerText":case"textContent":break;default:if(!es.hasOwnProperty(u))l:{if(u[0]==="o"&&u[1]==="n"&&(e=u.endsWith("Capture"),t=u.slice(2,e?u.length-7:void 0),n=l[zl]||null,n=n!=null?n[u]:null,typeof n=="function"&&l.removeEventListener(t,n,e),typeof a=="function")){typeof n!="function"&&n!==null&&(u in l?l[u]=null:l.hasAttribute(u)&&l.removeAttribute(u)),l.addEventListener(t,a,e);break l}u in l?l[u]=a:a===!0?l.setAttribute(u,""):Oe(l,u,a)}}}function yl(l,t,u){switch(t){case"div":case"span":case"svg":case"path":case"a":case"g":case"p":case"li":break;case"img":Y("error",l),Y("load",l);var a=!1,e=!1,n;for(n in u)if(u.hasOwnProperty(n)){var c=u[n];if(c!=null)switch(n){case"src":a=!0;break;case"srcSet":e=!0;break;case"children":case"dangerouslySetInnerHTML":throw Error(S(137,t));default:G(l,t,n,c,u,null)}}e&&G(l,t,"srcSet",u.srcSet,u,null),a&&G(l,t,"src",u.src,u,null);return;case"input":Y("invalid",l);var i=n=c=e=null,f=null,s=null;for(a in u)if(u.hasOwnProperty(a)){var m=u[a];if(m!=null)switch(a){case"name":e=m;break;case"type":c=m;break;case"checked":f=m;break;case"defaultChecked":s=m;break;case"value":n=m;break;case"defaultValue":i=m;break;case"children":case"dangerouslySetInnerHTML":if(m!=null)throw Error(S(137,t));break;default:G(l,t,a,m,u,null)}}is(l,n,i,f,s,c,e,!1),Qe(l);return;case"select":Y("invalid",l),a=c=n=null;for(e in u)if(u.hasOwnProperty(e)&&(i=u[e],i!=null))switch(e){case"value":n=i;break;case"defaultValue":c=i;break;case"multiple":a=i;default:G(l,t,e,i,u,null)}t=n,u=c,l.multiple=!!a,t!=null?Yu(l,!!a,t,!1):u!=null&&Yu(l,!!a,u,!0);return;case"textarea":Y("invalid",l),n=e=a=null;for(c in u)if(u.hasOwnProperty(c)&&(i=u[c],i!=null))switch(c){case"value":a=i;break;case"defaultValue":e=i;break;case"children":n=i;break;case"dangerouslySetInnerHTML":if(i!=null)throw Error(S(91));break;default:G(l,t,c,i,u,null)}ss(l,a,e,n),Qe(l);return;case"option":for(f in u)if(u.hasOwnProperty(f)&&(a=u[f],a!=null))switch(f){case"selected":l.selected=a&&typeof a!="function"&&typeof a!="symbol";break;default:G(l,t,f,a,u,null)}return;case"dialog":Y("cancel",l),Y("close",l);break;case"iframe":case"object":Y("load",l);break;case"video":case"audio":for(a=0;a<Qa.length;a++)Y(Qa[a],l);break;case"image":Y("error",l),Y("load",l);break;case"details":Y("toggle",l);break;case"embed":case"source":case"link":Y("error",l),Y("load",l);case"area":case"base":case"br":case"col":case"hr":case"keygen":case"meta":case"param":case"track":case"wbr":case"menuitem":for(s in u)if(u.hasOwnProperty(s)&&(a=u[s],a!=null))switch(s){case"children":case"dangerouslySetInnerHTML":throw Error(S(137,t));default:G(l,t,s,a,u,null)}return;default:if(yi(t)){for(m in u)u.hasOwnProperty(m)&&(a=u[m],a!==void 0&&Fc(l,t,m,a,u,void 0));return}}for(i in u)u.hasOwnProperty(i)&&(a=u[i],a!=null&&G(l,t,i,a,u,null))}function Zh(l,t,u,a){switch(t){case"div":case"span":case"svg":case"path":case"a":case"g":case"p":case"li":break;case"input":var e=null,n=null,c=null,i=null,f=null,s=null,m=null;for(r in u){var g=u[r];if(u.hasOwnProperty(r)&&g!=null)switch(r){case"checked":break;case"value":break;case"defaultValue":f=g;default:a.hasOwnProperty(r)||G(l,t,r,null,a,g)}}for(var h in a){var r=a[h];if(g=u[h],a.hasOwnProperty(h)&&(r!=null||g!=null))switch(h){case"type":n=r;break;case"name":e=r;break;case"checked":s=r;break;case"defaultChecked":m=r;break;case"value":c=r;break;case"defaultValue":i=r;break;case"children":case"dangerouslySetInnerHTML":if(r!=null)throw Error(S(137,t));break;default:r!==g&&G(l,t,h,r,a,g)}}Ec(l,c,i,f,s,m,n,e);return;case"select":r=c=i=h=null;for(n in u)if(f=u[n],u.hasOwnProperty(n)&&f!=null)switch(n){case"value":break;case"multiple":r=f;default:a.hasOwnProperty(n)||G(l,t,n,null,a,f)}for(e in a)if(n=a[e],f=u[e],a.hasOwnProperty(e)&&(n!=null||f!=null))switch(e){case"value":h=n;break;case"defaultValue":i=n;break;case"multiple":c=n;default:n!==f&&G(l,t,e,n,a,f)}t=i,u=c,a=r,h!=null?Yu(l,!!u,h,!1):!!a!=!!u&&(t!=null?Yu(l,!!u,t,!0):Yu(l,!!u,u?[]:"",!1));return;case"textarea":r=h=null;for(i in u)if(e=u[i],u.hasOwnProperty(i)&&e!=null&&!a.hasOwnProperty(i))switch(i){case"value":break;case"children":break;default:G(l,t,i,null,a,e)}for(c in a)if(e=a[c],n=u[c],a.hasOwnProperty(c)&&(e!=null||n!=null))switch(c){case"value":h=e;break;case"defaultValue":r=e;break;case"children":break;case"dangerouslySetInnerHTML":if(e!=null)throw Error(S(91));break;default:e!==n&&G(l,t,c,e,a,n)}fs(l,h,r);return;case"option":for(var T in u)if(h=u[T],u.hasOwnProperty(T)&&h!=null&&!a.hasOwnProperty(T))switch(T){case"selected":l.selected=!1;break;default:G(l,t,T,null,a,h)}for(f in a)if(h=a[f],r=u[f],a.hasOwnProperty(f)&&h!==r&&(h!=null||r!=null))switch(f){case"selected":l.selected=h&&typeof h!="function"&&typeof h!="symbol";break;default:G(l,t,f,h,a,r)}return;case"img":case"link":case"area":case"base":case"br":case"col":case"embed":case"hr":case"keygen":case"meta":case"param":case"source":case"track":case"wbr":case"menuitem":for(var E in u)h=u[E],u.hasOwnProperty(E)&&h!=null&&!a.hasOwnProperty(E)&&G(l,t,E,null,a,h);for(s in a)if(h=a[s],r=u[s],a.hasOwnProperty(s)&&h!==r&&(h!=null||r!=null))switch(s){case"children":case"dangerouslySetInnerHTML":if(h!=null)throw Error(S(137,t));break;default:G(l,t,s,h,a,r)}return;default:if(yi(t)){for(var Q in u)h=u[Q],u.hasOwnProperty(Q)&&h!==void 0&&!a.hasOwnProperty(Q)&&Fc(l,t,Q,void 0,a,h);for(m in a)h=a[m],r=u[m],!a.hasOwnProperty(m)||h===r||h===void 0&&r===void 0||Fc(l,t,m,h,a,r);return}}for(var v in u)h=u[v],u.hasOwnProperty(v)&&h!=null&&!a.hasOwnProperty(v)&&G(l,t,v,null,a,h);for(g in a)h=a[g],r=u[g],!a.hasOwnProperty(g)||h===r||h==null&&r==null||G(l,t,g,h,a,r)}var Pc=null,Ic=null;function en(l){return l.nodeType===9?l:l.ownerDocument}function h0(l){switch(l){case"http://www.w3.org/2000/svg":return 1;case"http://www.w3.org/1998/Math/MathML":return 2;default:return 0}}function nd(l,t){if(l===0)switch(t){case"svg":return 1;case"math":return 2;default:return 0}return l===1&&t==="foreignObject"?0:l}function li(l,t){return l==="textarea"||l==="noscript"||typeof t.children=="string"||typeof t.children=="number"||typeof t.children=="bigint"||typeof t.dangerouslySetInnerHTML=="object"&&t.dangerouslySetInnerHTML!==null&&t.dangerouslySetInnerHTML.__html!=null}var dc=null;function Vh(){var l=window.event;return l&&l.type==="popstate"?l===dc?!1:(dc=l,!0):(dc=null,!1)}var cd=typeof setTimeout=="function"?setTimeout:void 0,Lh=typeof clearTimeout=="function"?clearTimeout:void 0,o0=typeof Promise=="function"?Promise:void 0,Kh=typeof queueMicrotask=="function"?queueMicrotask:typeof o0<"u"?function(l){return o0.resolve(null).then(l).catch(Jh)}:cd;function Jh(l){setTimeout(function(){throw l})}function vc(l,t){var u=t,a=0;do{var e=u.nextSibling;if(l.removeChild(u),e&&e.nodeType===8)if(u=e.data,u==="/$"){if(a===0){l.removeChild(e),Ja(t);return}a--}else u!=="$"&&u!=="$?"&&u!=="$!"||a++;u=e}while(u);Ja(t)}function ti(l){var t=l.firstChild;for(t&&t.nodeType===10&&(t=t.nextSibling);t;){var u=t;switch(t=t.nextSibling,u.nodeName){case"HTML":case"HEAD":case"BODY":ti(u),oi(u);continue;case"SCRIPT":case"STYLE":continue;case"LINK":if(u.rel.toLowerCase()==="stylesheet")continue}l.removeChild(u)}}function wh(l,t,u,a){for(;l.nodeType===1;){var e=u;if(l.nodeName.toLowerCase()!==t.toLowerCase()){if(!a&&(l.nodeName!=="INPUT"||l.type!=="hidden"))break}else if(a){if(!l[qa])switch(t){case"meta":if(!l.hasAttribute("itemprop"))break;return l;case"link":if(n=l.getAttribute("rel"),n==="stylesheet"&&l.hasAttribute("data-precedence"))break;if(n!==e.rel||l.getAttribute("href")!==(e.href==null?null:e.href)||l.getAttribute("crossorigin")!==(e.crossOrigin==null?null:e.crossOrigin)||l.getAttribute("title")!==(e.title==null?null:e.title))break;return l;case"style":if(l.hasAttribute("data-precedence"))break;return l;case"script":if(n=l.getAttribute("src"),(n!==(e.src==null?null:e.src)||l.getAttribute("type")!==(e.type==null?null:e.type)||l.getAttribute("crossorigin")!==(e.crossOrigin==null?null:e.crossOrigin))&&n&&l.hasAttribute("async")&&!l.hasAttribute("itemprop"))break;return l;default:return l}}else if(t==="input"&&l.type==="hidden"){var n=e.name==null?null:""+e.name;if(e.type==="hidden"&&l.getAttribute("name")===n)return l}else return l;if(l=Jl(l.nextSibling),l===null)break}return null}function $h(l,t,u){if(t==="")return null;for(;l.nodeType!==3;)if((l.nodeType!==1||l.nodeName!=="INPUT"||l.type!=="hidden")&&!u||(l=Jl(l.nextSibling),l===null))return null;return l}function Jl(l){for(;l!=null;l=l.nextSibling){var t=l.nodeType;if(t===1||t===3)break;if(t===8){if(t=l.data,t==="$"||t==="$!"||t==="$?"||t==="F!"||t==="F")break;if(t==="/$")return null}}return l}function y0(l){l=l.previousSibling;for(var t=0;l;){if(l.nodeType===8){var u=l.data;if(u==="$"||u==="$!"||u==="$?"){if(t===0)return l;t--}else u==="/$"&&t++}l=l.previousSibling}return null}function id(l,t,u){switch(t=en(u),l){case"html":if(l=t.documentElement,!l)throw Error(S(452));return l;case"head":if(l=t.head,!l)throw Error(S(453));return l;case"body":if(l=t.body,!l)throw Error(S(454));return l;default:throw Error(S(451))}}var Vl=new Map,m0=new Set;function nn(l){return typeof l.getRootNode=="function"?l.getRootNode():l.ownerDocument}var bt=L.d;L.d={f:Wh,r:kh,D:Fh,C:Ph,L:Ih,m:lo,X:uo,S:to,M:ao};function Wh(){var l=bt.f(),t=On();return l||t}function kh(l){var t=Iu(l);t!==null&&t.tag===5&&t.type==="form"?i1(t):bt.r(l)}var ta=typeof document>"u"?null:document;function fd(l,t,u){var a=ta;if(a&&typeof t=="string"&&t){var e=Gl(t);e='link[rel="'+l+'"][href="'+e+'"]',typeof u=="string"&&(e+='[crossorigin="'+u+'"]'),m0.has(e)||(m0.add(e),l={rel:l,crossOrigin:u,href:t},a.querySelector(e)===null&&(t=a.createElement("link"),yl(t,"link",l),sl(t),a.head.appendChild(t)))}}function Fh(l){bt.D(l),fd("dns-prefetch",l,null)}function Ph(l,t){bt.C(l,t),fd("preconnect",l,t)}function Ih(l,t,u){bt.L(l,t,u);var a=ta;if(a&&l&&t){var e='link[rel="preload"][as="'+Gl(t)+'"]';t==="image"&&u&&u.imageSrcSet?(e+='[imagesrcset="'+Gl(u.imageSrcSet)+'"]',typeof u.imageSizes=="string"&&(e+='[imagesizes="'+Gl(u.imageSizes)+'"]')):e+='[href="'+Gl(l)+'"]';var n=e;switch(t){case"style":n=Wu(l);break;case"script":n=ua(l)}Vl.has(n)||(l=K({rel:"preload",href:t==="image"&&u&&u.imageSrcSet?void 0:l,as:t},u),Vl.set(n,l),a.querySelector(e)!==null||t==="style"&&a.querySelector(ae(n))||t==="script"&&a.querySelector(ee(n))||(t=a.createElement("link"),yl(t,"link",l),sl(t),a.head.appendChild(t)))}}function lo(l,t){bt.m(l,t);var u=ta;if(u&&l){var a=t&&typeof t.as=="string"?t.as:"script",e='link[rel="modulepreload"][as="'+Gl(a)+'"][href="'+Gl(l)+'"]',n=e;switch(a){case"audioworklet":case"paintworklet":case"serviceworker":case"sharedworker":case"worker":case"script":n=ua(l)}if(!Vl.has(n)&&(l=K({rel:"modulepreload",href:l},t),Vl.set(n,l),u.querySelector(e)===null)){switch(a){case"audioworklet":case"paintworklet":case"serviceworker":case"sharedworker":case"worker":case"script":if(u.querySelector(ee(n)))return}a=u.createElement("link"),yl(a,"link",l),sl(a),u.head.appendChild(a)}}}function to(l,t,u){bt.S(l,t,u);var a=ta;if(a&&l){var e=qu(a).hoistableStyles,n=Wu(l);t=t||"default";var c=e.get(n);if(!c){var i={loading:0,preload:null};if(c=a.querySelector(ae(n)))i.loading=5;else{l=K({rel:"stylesheet",href:l,"data-precedence":t},u),(u=Vl.get(n))&&ki(l,u);var f=c=a.createElement("link");sl(f),yl(f,"link",l),f._p=new Promise(function(s,m){f.onload=s,f.onerror=m}),f.addEventListener("load",function(){i.loading|=1}),f.addEventListener("error",function(){i.loading|=2}),i.loading|=4,qe(c,t,a)}c={type:"stylesheet",instance:c,count:1,state:i},e.set(n,c)}}}function uo(l,t){bt.X(l,t);var u=ta;if(u&&l){var a=qu(u).hoistableScripts,e=ua(l),n=a.get(e);n||(n=u.querySelector(ee(e)),n||(l=K({src:l,async:!0},t),(t=Vl.get(e))&&Fi(l,t),n=u.createElement("script"),sl(n),yl(n,"link",l),u.head.appendChild(n)),n={type:"script",instance:n,count:1,state:null},a.set(e,n))}}function ao(l,t){bt.M(l,t);var u=ta;if(u&&l){var a=qu(u).hoistableScripts,e=ua(l),n=a.get(e);n||(n=u.querySelector(ee(e)),n||(l=K({src:l,async:!0,type:"module"},t),(t=Vl.get(e))&&Fi(l,t),n=u.createElement("script"),sl(n),yl(n,"link",l),u.head.appendChild(n)),n={type:"script",instance:n,count:1,state:null},a.set(e,n))}}function r0(l,t,u,a){var e=(e=pt.current)?nn(e):null;if(!e)throw Error(S(446));switch(l){case"meta":case"title":return null;case"style":return typeof u.precedence=="string"&&typeof u.href=="string"?(t=Wu(u.href),u=qu(e).hoistableStyles,a=u.get(t),a||(a={type:"style",instance:null,count:0,state:null},u.set(t,a)),a):{type:"void",instance:null,count:0,state:null};case"link":if(u.rel==="stylesheet"&&typeof u.href=="string"&&typeof u.precedence=="string"){l=Wu(u.href);var n=qu(e).hoistableStyles,c=n.get(l);if(c||(e=e.ownerDocument||e,c={type:"stylesheet",instance:null,count:0,state:{loading:0,preload:null}},n.set(l,c),(n=e.querySelector(ae(l)))&&!n._p&&(c.instance=n,c.state.loading=5),Vl.has(l)||(u={rel:"preload",as:"style",href:u.href,crossOrigin:u.crossOrigin,integrity:u.integrity,media:u.media,hrefLang:u.hrefLang,referrerPolicy:u.referrerPolicy},Vl.set(l,u),n||eo(e,l,u,c.state))),t&&a===null)throw Error(S(528,""));return c}if(t&&a!==null)throw Error(S(529,""));return null;case"script":return t=u.async,u=u.src,typeof u=="string"&&t&&typeof t!="function"&&typeof t!="symbol"?(t=ua(u),u=qu(e).hoistableScripts,a=u.get(t),a||(a={type:"script",instance:null,count:0,state:null},u.set(t,a)),a):{type:"void",instance:null,count:0,state:null};default:throw Error(S(444,l))}}function Wu(l){return'href="'+Gl(l)+'"'}function ae(l){return'link[rel="stylesheet"]['+l+"]"}function sd(l){return K({},l,{"data-precedence":l.precedence,precedence:null})}function eo(l,t,u,a){l.querySelector('link[rel="preload"][as="style"]['+t+"]")?a.loading=1:(t=l.createElement("link"),a.preload=t,t.addEventListener("load",function(){return a.loading|=1}),t.addEventListener("error",function(){return a.loading|=2}),yl(t,"link",u),sl(t),l.head.appendChild(t))}function ua(l){return'[src="'+Gl(l)+'"]'}function ee(l){return"script[async]"+l}function g0(l,t,u){if(t.count++,t.instance===null)switch(t.type){case"style":var a=l.querySelector('style[data-href~="'+Gl(u.href)+'"]');if(a)return t.instance=a,sl(a),a;var e=K({},u,{"data-href":u.href,"data-precedence":u.precedence,href:null,precedence:null});return a=(l.ownerDocument||l).createElement("style"),sl(a),yl(a,"style",e),qe(a,u.precedence,l),t.instance=a;case"stylesheet":e=Wu(u.href);var n=l.querySelector(ae(e));if(n)return t.state.loading|=4,t.instance=n,sl(n),n;a=sd(u),(e=Vl.get(e))&&ki(a,e),n=(l.ownerDocument||l).createElement("link"),sl(n);var c=n;return c._p=new Promise(function(i,f){c.onload=i,c.onerror=f}),yl(n,"link",a),t.state.loading|=4,qe(n,u.precedence,l),t.instance=n;case"script":return n=ua(u.src),(e=l.querySelector(ee(n)))?(t.instance=e,sl(e),e):(a=u,(e=Vl.get(n))&&(a=K({},u),Fi(a,e)),l=l.ownerDocument||l,e=l.createElement("script"),sl(e),yl(e,"link",a),l.head.appendChild(e),t.instance=e);case"void":return null;default:throw Error(S(443,t.type))}else t.type==="stylesheet"&&!(t.state.loading&4)&&(a=t.instance,t.state.loading|=4,qe(a,u.precedence,l));return t.instance}function qe(l,t,u){for(var a=u.querySelectorAll('link[rel="stylesheet"][data-precedence],style[data-precedence]'),e=a.length?a[a.length-1]:null,n=e,c=0;c<a.length;c++){var i=a[c];if(i.dataset.precedence===t)n=i;else if(n!==e)break}n?n.parentNode.insertBefore(l,n.nextSibling):(t=u.nodeType===9?u.head:u,t.insertBefore(l,t.firstChild))}function ki(l,t){l.crossOrigin==null&&(l.crossOrigin=t.crossOrigin),l.referrerPolicy==null&&(l.referrerPolicy=t.referrerPolicy),l.title==null&&(l.title=t.title)}function Fi(l,t){l.crossOrigin==null&&(l.crossOrigin=t.crossOrigin),l.referrerPolicy==null&&(l.referrerPolicy=t.referrerPolicy),l.integrity==null&&(l.integrity=t.integrity)}var Ye=null;function S0(l,t,u){if(Ye===null){var a=new Map,e=Ye=new Map;e.set(u,a)}else e=Ye,a=e.get(u),a||(a=new Map,e.set(u,a));if(a.has(l))return a;for(a.set(l,null),u=u.getElementsByTagName(l),e=0;e<u.length;e++){var n=u[e];if(!(n[qa]||n[rl]||l==="link"&&n.getAttribute("rel")==="stylesheet")&&n.namespaceURI!=="http://www.w3.org/2000/svg"){var c=n.getAttribute(t)||"";c=l+c;var i=a.get(c);i?i.push(n):a.set(c,[n])}}return a}function b0(l,t,u){l=l.ownerDocument||l,l.head.insertBefore(u,t==="title"?l.querySelector("head > title"):null)}function no(l,t,u){if(u===1||t.itemProp!=null)return!1;switch(l){case"meta":case"title":return!0;case"style":if(typeof t.precedence!="string"||typeof t.href!="string"||t.href==="")break;return!0;case"link":if(typeof t.rel!="string"||typeof t.href!="string"||t.href===""||t.onLoad||t.onError)break;switch(t.rel){case"stylesheet":return l=t.disabled,typeof t.precedence=="string"&&l==null;default:return!0}case"script":if(t.async&&typeof t.async!="function"&&typeof t.async!="symbol"&&!t.onLoad&&!t.onError&&t.src&&typeof t.src=="string")return!0}return!1}function dd(l){return!(l.type==="stylesheet"&&!(l.state.loading&3))}var Za=null;function co(){}function io(l,t,u){if(Za===null)throw Error(S(475));var a=Za;if(t.type==="stylesheet"&&(typeof u.media!="string"||matchMedia(u.media).matches!==!1)&&!(t.state.loading&4)){if(t.instance===null){var e=Wu(u.href),n=l.querySelector(ae(e));if(n){l=n._p,l!==null&&typeof l=="object"&&typeof l.then=="function"&&(a.count++,a=cn.bind(a),l.then(a,a)),t.state.loading|=4,t.instance=n,sl(n);return}n=l.ownerDocument||l,u=sd(u),(e=Vl.get(e))&&ki(u,e),n=n.createElement("link"),sl(n);var c=n;c._p=new Promise(function(i,f){c.onload=i,c.onerror=f}),yl(n,"link",u),t.instance=n}a.stylesheets===null&&(a.stylesheets=new Map),a.stylesheets.set(t,l),(l=t.state.preload)&&!(t.state.loading&3)&&(a.count++,t=cn.bind(a),l.addEventListener("load",t),l.addEventListener("error",t))}}function fo(){if(Za===null)throw Error(S(475));var l=Za;return l.stylesheets&&l.count===0&&ui(l,l.stylesheets),0<l.count?function(t){var u=setTimeout(function(){if(l.stylesheets&&ui(l,l.stylesheets),l.unsuspend){var a=l.unsuspend;l.unsuspend=null,a()}},6e4);return l.unsuspend=t,function(){l.unsuspend=null,clearTimeout(u)}}:null}function cn(){if(this.count--,this.count===0){if(this.stylesheets)ui(this,this.stylesheets);else if(this.unsuspend){var l=this.unsuspend;this.unsuspend=null,l()}}}var fn=null;function ui(l,t){l.stylesheets=null,l.unsuspend!==null&&(l.count++,fn=new Map,t.forEach(so,l),fn=null,cn.call(l))}function so(l,t){if(!(t.state.loading&4)){var u=fn.get(l);if(u)var a=u.get(null);else{u=new Map,fn.set(l,u);for(var e=l.querySelectorAll("link[data-precedence],style[data-precedence]"),n=0;n<e.length;n++){var c=e[n];(c.nodeName==="LINK"||c.getAttribute("media")!=="not all")&&(u.set(c.dataset.precedence,c),a=c)}a&&u.set(null,a)}e=t.instance,c=e.getAttribute("data-precedence"),n=u.get(c)||a,n===a&&u.set(null,e),u.set(c,e),this.count++,a=cn.bind(this),e.addEventListener("load",a),e.addEventListener("error",a),n?n.parentNode.insertBefore(e,n.nextSibling):(l=l.nodeType===9?l.head:l,l.insertBefore(e,l.firstChild)),t.state.loading|=4}}var Va={$$typeof:st,Provider:null,Consumer:null,_currentValue:It,_currentValue2:It,_threadCount:0};function vo(l,t,u,a,e,n,c,i){this.tag=1,this.containerInfo=l,this.finishedWork=this.pingCache=this.current=this.pendingChildren=null,this.timeoutHandle=-1,this.callbackNode=this.next=this.pendingContext=this.context=this.cancelPendingCommit=null,this.callbackPriority=0,this.expirationTimes=Bn(-1),this.entangledLanes=this.shellSuspendCounter=this.errorRecoveryDisabledLanes=this.finishedLanes=this.expiredLanes=this.warmLanes=this.pingedLanes=this.suspendedLanes=this.pendingLanes=0,this.entanglements=Bn(0),this.hiddenUpdates=Bn(null),this.identifierPrefix=a,this.onUncaughtError=e,this.onCaughtError=n,this.onRecoverableError=c,this.pooledCache=null,this.pooledCacheLanes=0,this.formState=i,this.incompleteTransitions=new Map}function vd(l,t,u,a,e,n,c,i,f,s,m,g){return l=new vo(l,t,u,c,i,f,s,g),t=1,n===!0&&(t|=24),n=Ql(3,null,null,t),l.current=n,n.stateNode=l,t=_i(),t.refCount++,l.pooledCache=t,t.refCount++,n.memoizedState={element:a,isDehydrated:u,cache:t},Qi(n),l}function hd(l){return l?(l=Hu,l):Hu}function od(l,t,u,a,e,n){e=hd(e),a.context===null?a.context=e:a.pendingContext=e,a=qt(t),a.payload={element:u},n=n===void 0?null:n,n!==null&&(a.callback=n),u=Yt(l,a,t),u!==null&&(El(u,l,t),_a(u,l,t))}function E0(l,t){if(l=l.memoizedState,l!==null&&l.dehydrated!==null){var u=l.retryLane;l.retryLane=u!==0&&u<t?u:t}}function Pi(l,t){E0(l,t),(l=l.alternate)&&E0(l,t)}function yd(l){if(l.tag===13){var t=Qt(l,67108864);t!==null&&El(t,l,67108864),Pi(l,67108864)}}var sn=!0;function ho(l,t,u,a){var e=M.T;M.T=null;var n=L.p;try{L.p=2,Ii(l,t,u,a)}finally{L.p=n,M.T=e}}function oo(l,t,u,a){var e=M.T;M.T=null;var n=L.p;try{L.p=8,Ii(l,t,u,a)}finally{L.p=n,M.T=e}}function Ii(l,t,u,a){if(sn){var e=ai(a);if(e===null)sc(l,t,a,dn,u),T0(l,a);else if(mo(e,l,t,u,a))a.stopPropagation();else if(T0(l,a),t&4&&-1<yo.indexOf(l)){for(;e!==null;){var n=Iu(e);if(n!==null)switch(n.tag){case 3:if(n=n.stateNode,n.current.memoizedState.isDehydrated){var c=$t(n.pendingLanes);if(c!==0){var i=n;for(i.pendingLanes|=2,i.entangledLanes|=2;c;){var f=1<<31-Hl(c);i.entanglements[1]|=f,c&=~f}tt(n),!(W&6)&&(Ie=Fl()+500,ue(0,!1))}}break;case 13:i=Qt(n,2),i!==null&&El(i,n,2),On(),Pi(n,2)}if(n=ai(a),n===null&&sc(l,t,a,dn,u),n===e)break;e=n}e!==null&&a.stopPropagation()}else sc(l,t,a,null,u)}}function ai(l){return l=mi(l),lf(l)}var dn=null;function lf(l){if(dn=null,l=Ft(l),l!==null){var t=Fu(l);if(t===null)l=null;else{var u=t.tag;if(u===13){if(l=J0(t),l!==null)return l;l=null}else if(u===3){if(t.stateNode.current.memoizedState.isDehydrated)return t.tag===3?t.stateNode.containerInfo:null;l=null}else t!==l&&(l=null)}}return dn=l,null}function md(l){switch(l){case"beforetoggle":case"cancel":case"click":case"close":case"contextmenu":case"copy":case"cut":case"auxclick":case"dblclick":case"dragend":case"dragstart":case"drop":case"focusin":case"focusout":case"input":case"invalid":case"keydown":case"keypress":case"keyup":case"mousedown":case"mouseup":case"paste":case"pause":case"play":case"pointercancel":case"pointerdown":case"pointerup":case"ratechange":case"reset":case"resize":case"seeked":case"submit":case"toggle":case"touchcancel":case"touchend":case"touchstart":case"volumechange":case"change":case"selectionchange":case"textInput":case"compositionstart":case"compositionend":case"compositionupdate":case"beforeblur":case"afterblur":case"beforeinput":case"blur":case"fullscreenchange":case"focus":case"hashchange":case"popstate":case"select":case"selectstart":return 2;case"drag":case"dragenter":case"dragexit":case"dragleave":case"dragover":case"mousemove":case"mouseout":case"mouseover":case"pointermove":case"pointerout":case"pointerover":case"scroll":case"touchmove":case"wheel":case"mouseenter":case"mouseleave":case"pointerenter":case"pointerleave":return 8;case"message":switch(Pd()){case $0:return 2;case W0:return 8;case Xe:case Id:return 32;case k0:return 268435456;default:return 32}default:return 32}}var ei=!1,Ct=null,Gt=null,Xt=null,La=new Map,Ka=new Map,Ht=[],yo="mousedown mouseup touchcancel touchend touchstart auxclick dblclick pointercancel pointerdown pointerup dragend dragstart drop compositionend compositionstart keydown keypress keyup input textInput copy cut paste click change contextmenu reset".split(" ");function T0(l,t){switch(l){case"focusin":case"focusout":Ct=null;break;case"dragenter":case"dragleave":Gt=null;break;case"mouseover":case"mouseout":Xt=null;break;case"pointerover":case"pointerout":La.delete(t.pointerId);break;case"gotpointercapture":case"lostpointercapture":Ka.delete(t.pointerId)}}function da(l,t,u,a,e,n){return l===null||l.nativeEvent!==n?(l={blockedOn:t,domEventName:u,eventSystemFlags:a,nativeEvent:n,targetContainers:[e]},t!==null&&(t=Iu(t),t!==null&&yd(t)),l):(l.eventSystemFlags|=a,t=l.targetContainers,e!==null&&t.indexOf(e)===-1&&t.push(e),l)}function mo(l,t,u,a,e){switch(t){case"focusin":return Ct=da(Ct,l,t,u,a,e),!0;case"dragenter":return Gt=da(Gt,l,t,u,a,e),!0;case"mouseover":return Xt=da(Xt,l,t,u,a,e),!0;case"pointerover":var n=e.pointerId;return La.set(n,da(La.get(n)||null,l,t,u,a,e)),!0;case"gotpointercapture":return n=e.pointerId,Ka.set(n,da(Ka.get(n)||null,l,t,u,a,e)),!0}return!1}function rd(l){var t=Ft(l.target);if(t!==null){var u=Fu(t);if(u!==null){if(t=u.tag,t===13){if(t=J0(u),t!==null){l.blockedOn=t,fv(l.priority,function(){if(u.tag===13){var a=Rl(),e=Qt(u,a);e!==null&&El(e,u,a),Pi(u,a)}});return}}else if(t===3&&u.stateNode.current.memoizedState.isDehydrated){l.blockedOn=u.tag===3?u.stateNode.containerInfo:null;return}}}l.blockedOn=null}function je(l){if(l.blockedOn!==null)return!1;for(var t=l.targetContainers;0<t.length;){var u=ai(l.nativeEvent);if(u===null){u=l.nativeEvent;var a=new u.constructor(u.type,u);Ac=a,u.target.dispatchEvent(a),Ac=null}else return t=Iu(u),t!==null&&yd(t),l.blockedOn=u,!1;t.shift()}return!0}function A0(l,t,u){je(l)&&u.delete(t)}function ro(){ei=!1,Ct!==null&&je(Ct)&&(Ct=null),Gt!==null&&je(Gt)&&(Gt=null),Xt!==null&&je(Xt)&&(Xt=null),La.forEach(A0),Ka.forEach(A0)}function Te(l,t){l.blockedOn===t&&(l.blockedOn=null,ei||(ei=!0,cl.unstable_scheduleCallback(cl.unstable_NormalPriority,ro)))}var Ae=null;function z0(l){Ae!==l&&(Ae=l,cl.unstable_scheduleCallback(cl.unstable_NormalPriority,function(){Ae===l&&(Ae=null);for(var t=0;t<l.length;t+=3){var u=l[t],a=l[t+1],e=l[t+2];if(typeof a!="function"){if(lf(a||u)===null)continue;break}var n=Iu(u);n!==null&&(l.splice(t,3),t-=3,pc(n,{pending:!0,data:e,method:u.method,action:a},a,e))}}))}function Ja(l){function t(f){return Te(f,l)}Ct!==null&&Te(Ct,l),Gt!==null&&Te(Gt,l),Xt!==null&&Te(Xt,l),La.forEach(t),Ka.forEach(t);for(var u=0;u<Ht.length;u++){var a=Ht[u];a.blockedOn===l&&(a.blockedOn=null)}for(;0<Ht.length&&(u=Ht[0],u.blockedOn===null);)rd(u),u.blockedOn===null&&Ht.shift();if(u=(l.ownerDocument||l).$$reactFormReplay,u!=null)for(a=0;a<u.length;a+=3){var e=u[a],n=u[a+1],c=e[zl]||null;if(typeof n=="function")c||z0(u);else if(c){var i=null;if(n&&n.hasAttribute("formAction")){if(e=n,c=n[zl]||null)i=c.formAction;else if(lf(e)!==null)continue}else i=c.action;typeof i=="function"?u[a+1]=i:(u.splice(a,3),a-=3),z0(u)}}}function tf(l){this._internalRoot=l}Mn.prototype.render=tf.prototype.render=function(l){var t=this._internalRoot;if(t===null)throw Error(S(409));var u=t.current,a=Rl();od(u,a,l,t,null,null)};Mn.prototype.unmount=tf.prototype.unmount=function(){var l=this._internalRoot;if(l!==null){this._internalRoot=null;var t=l.containerInfo;l.tag===0&&Qu(),od(l.current,2,null,l,null,null),On(),t[Pu]=null}};function Mn(l){this._internalRoot=l}Mn.prototype.unstable_scheduleHydration=function(l){if(l){var t=us();l={blockedOn:null,target:l,priority:t};for(var u=0;u<Ht.length&&t!==0&&t<Ht[u].priority;u++);Ht.splice(u,0,l),u===0&&rd(l)}};var O0=x0.version;if(O0!=="19.0.0")throw Error(S(527,O0,"19.0.0"));L.findDOMNode=function(l){var t=l._reactInternals;if(t===void 0)throw typeof l.render=="function"?Error(S(188)):(l=Object.keys(l).join(","),Error(S(268,l)));return l=Wd(t),l=l!==null?w0(l):null,l=l===null?null:l.stateNode,l};var go={bundleType:0,version:"19.0.0",rendererPackageName:"react-dom",currentDispatcherRef:M,findFiberByHostInstance:Ft,reconcilerVersion:"19.0.0"};if(typeof __REACT_DEVTOOLS_GLOBAL_HOOK__<"u"){var ze=__REACT_DEVTOOLS_GLOBAL_HOOK__;if(!ze.isDisabled&&ze.supportsFiber)try{wa=ze.inject(go),Ul=ze}catch{}}hn.createRoot=function(l,t){if(!Z0(l))throw Error(S(299));var u=!1,a="",e=o1,n=y1,c=m1,i=null;return t!=null&&(t.unstable_strictMode===!0&&(u=!0),t.identifierPrefix!==void 0&&(a=t.identifierPrefix),t.onUncaughtError!==void 0&&(e=t.onUncaughtError),t.onCaughtError!==void 0&&(n=t.onCaughtError),t.onRecoverableError!==void 0&&(c=t.onRecoverableError),t.unstable_transitionCallbacks!==void 0&&(i=t.unstable_transitionCallbacks)),t=vd(l,1,!1,null,null,u,a,e,n,c,i,null),l[Pu]=t.current,Wi(l.nodeType===8?l.parentNode:l),new tf(t)};hn.hydrateRoot=function(l,t,u){if(!Z0(l))throw Error(S(299));var a=!1,e="",n=o1,c=y1,i=m1,f=null,s=null;return u!=null&&(u.unstable_strictMode===!0&&(a=!0),u.identifierPrefix!==void 0&&(e=u.identifierPrefix),u.onUncaughtError!==void 0&&(n=u.onUncaughtError),u.onCaughtError!==void 0&&(c=u.onCaughtError),u.onRecoverableError!==void 0&&(i=u.onRecoverableError),u.unstable_transitionCallbacks!==void 0&&(f=u.unstable_transitionCallbacks),u.formState!==void 0&&(s=u.formState)),t=vd(l,1,!0,t,u??null,a,e,n,c,i,f,s),t.context=hd(null),u=t.current,a=Rl(),e=qt(a),e.callback=null,Yt(u,e,a),t.current.lanes=a,Wa(t,a),tt(t),l[Pu]=t.current,Wi(l),new Mn(t)};hn.version="19.0.0";function gd(){if(!(typeof __REACT_DEVTOOLS_GLOBAL_HOOK__>"u"||typeof __REACT_DEVTOOLS_GLOBAL_HOOK__.checkDCE!="function"))try{__REACT_DEVTOOLS_GLOBAL_HOOK__.checkDCE(gd)}catch(l){console.error(l)}}gd(),j0.exports=hn;var So=j0.exports;const bo=_0(So),Sd=al.createContext({theme:!0,themeIsAuto:!0,changeTheme:l=>{},themeToDefault:()=>{}}),Eo=()=>al.useContext(Sd),To=({children:l})=>{const[t,u]=al.useState(()=>{try{const f=localStorage.getItem("themeIsAuto");return f===null?!0:f==="true"}catch(f){return console.error("Error reading themeIsAuto from localStorage:",f),!0}}),[a,e]=al.useState(()=>{try{const f=localStorage.getItem("themeIsDark"),s=window.matchMedia("(prefers-color-scheme: dark)").matches;return f===null?s:f==="true"}catch(f){return console.error("Error reading themeIsDark from localStorage:",f),window.matchMedia("(prefers-color-scheme: dark)").matches}});al.useLayoutEffect(()=>{try{localStorage.setItem("themeIsDark",String(a))}catch(s){console.error("Error setting themeIsDark to localStorage:",s)}document.documentElement.classList.toggle("dark",a);const f=document.querySelector("meta[name='theme-color']");f&&(f.content=a?"#18181b":"#fcfcff")},[a]);const n=al.useCallback(f=>{e(f)},[]);al.useLayoutEffect(()=>{const f=m=>{t&&n(m.matches)},s=window.matchMedia("(prefers-color-scheme: dark)");try{localStorage.getItem("themeIsAuto")==="true"&&n(s.matches)}catch(m){console.log(m)}return s.addEventListener("change",f),()=>{s.removeEventListener("change",f)}},[n,t]);const c=al.useCallback(f=>{u(!1);try{localStorage.setItem("themeIsAuto",String(!1))}catch(s){console.error("Error setting themeIsAuto to localStorage:",s)}n(f)},[n]),i=al.useCallback(()=>{u(!0);try{localStorage.setItem("themeIsAuto",String(!0))}catch(f){console.error("Error setting themeIsAuto to localStorage:",f)}n(window.matchMedia("(prefers-color-scheme: dark)").matches)},[n]);return _.jsx(Sd,{value:{theme:a,themeIsAuto:t,changeTheme:c,themeToDefault:i},children:l})};function Ao(){return _.jsx("svg",{xmlns:"http://www.w3.org/2000/svg",height:"24",viewBox:"0 -960 960 960",width:"24",children:_.jsx("path",{d:"M480-80q-83 0-156-31.5T197-197q-54-54-85.5-127T80-480q0-83 31.5-156T197-763q54-54 127-85.5T480-880q83 0 156 31.5T763-763q54 54 85.5 127T880-480q0 83-31.5 156T763-197q-54 54-127 85.5T480-80Zm40-83q119-15 199.5-104.5T800-480q0-123-80.5-212.5T520-797v634Z"})})}function errorRange(l,t){const u=new TextEncoder,a=new TextDecoder().decode(u.encode(t).slice(0,l.position)).length;return[a,Math.min(t.length,a+Math.max((l.token||"").length,1))]}function errorMark(l,t){const[u,a]=errorRange(l,t);return _.jsxs("span",{children:[l.error,_.jsxs("span",{className:"error_expr",children:[t.slice(0,u),_.jsx("mark",{children:t.slice(u,a)||" "}),t.slice(a)]})]})}function zo(){const{theme:l,changeTheme:t}=Eo(),[u,a]=al.useState(""),[e,n]=al.useState([]),[c,i]=al.useState(!1),[f,s]=al.useState(8080),[m,g]=al.useState([]),[h,r]=al.useState([]);async function T(){let y=`${window.location.origin}/api`;fetch(y).then(b=>{if(!b.ok)throw new Error(`Ошибка HTTP: ${b.status}`);return b.text()}).then(b=>{console.log("Ответ от API:",b),s(b)}).catch(b=>{console.error("Ошибка при выполнении запроса:",b)})}al.useEffect(()=>{async function y(){i(null);try{const b=await T();s(b)}catch(b){n([...e,b])}}y()},[]);const E=y=>{i(!1),a(`${u}${y}`)},Q=()=>{i(!1),a(u.slice(0,-1))},v=()=>{i(!1),a("")};async function d(y,b,D){const A=`http://${f}/api/v1/expressions/${y}`;try{const O=await fetch(A);if(!O.ok)throw new Error("Response status: ");const C=await O.json();if(C.expression&&C.expression.id)if(C.expression.status=="processing"||C.expression.status=="pending"){let U=m.filter((ml,Et)=>Et!==D);g([{id:y,expr:b,status:C.expression.status=="processing"?"Выполняется":"В ожидании"},...U])}else{C.expression.expr=b,r([C.expression,...h]);let U=m.filter((ml,Et)=>Et!==D);g(U)}}catch(O){n([...e,O.message])}}async function o(){a("");let y=u;try{const b=`http://${f}/api/v1/calculate`,A=await(await fetch(b,{method:"POST",headers:{"Content-Type":"application/json"},body:JSON.stringify({expression:y})})).json();A.error?(i(!0),typeof A.position=="number"?(a(y),n([...e,errorMark(A,y)]),requestAnimationFrame(()=>{const D=document.querySelector(".display"),[O,C]=errorRange(A,y);D&&(D.focus(),D.setSelectionRange(O,C))})):n([...e,A.error])):g([{id:A.id,expr:y},...m])}catch(b){i(!0),n([...e,b.message])}}return _.jsxs("div",{className:"page",children:[_.jsx("div",{className:"theme",onClick:()=>{t(!l)},children:_.jsx(Ao,{})}),_.jsxs("div",{className:"page_container",children:[_.jsx("div",{className:`results ${m.length==0?"trans":""}`,children:m.map((y,b)=>y.status?_.jsxs("div",{className:"result waiting pro",children:[_.jsx("div",{children:y.expr}),_.jsx("div",{children:y.status}),_.jsx("button",{onClick:()=>{d(y.id,y.expr,b)},children:"Проверить"})]},y.id):_.jsxs("div",{className:"result waiting",children:[_.jsx("div",{children:y.expr}),_.jsx("button",{onClick:()=>{d(y.id,y.expr,b)},children:"Проверить"})]},y.id))}),_.jsxs("div",{className:"calculator",children:[_.jsx("div",{className:"errors",children:e.map((y,b)=>_.jsx("div",{className:"error",onClick:()=>{let D=e.filter((A,O)=>O!==b);n(D)},children:y},b))}),_.jsx("input",{className:`display${c?" err":""}`,onChange:y=>{i(!1),a(y.target.value.replace(/[^0-9()\^\+\-\*\/]/g,""))},value:u}),_.jsxs("div",{className:"buttons",children:[_.jsx("button",{onClick:()=>E("("),children:"("}),_.jsx("button",{onClick:()=>E(")"),children:")"}),_.jsx("button",{onClick:()=>E("^"),children:"^"}),_.jsx("button",{onClick:v,children:"CE"}),_.jsx("button",{onClick:()=>E("7"),children:"7"}),_.jsx("button",{onClick:()=>E("8"),children:"8"}),_.jsx("button",{onClick:()=>E("9"),children:"9"}),_.jsx("button",{onClick:()=>E("/"),children:"/"}),_.jsx("button",{onClick:()=>E("4"),children:"4"}),_.jsx("button",{onClick:()=>E("5"),children:"5"}),_.jsx("button",{onClick:()=>E("6"),children:"6"}),_.jsx("button",{onClick:()=>E("*"),children:"*"}),_.jsx("button",{onClick:()=>E("1"),children:"1"}),_.jsx("button",{onClick:()=>E("2"),children:"2"}),_.jsx("button",{onClick:()=>E("3"),children:"3"}),_.jsx("button",{onClick:()=>E("-"),children:"-"}),_.jsx("button",{onClick:()=>E("00"),children:"00"}),_.jsx("button",{onClick:()=>E("."),children:"."}),_.jsx("button",{onClick:()=>E("0"),children:"0"}),_.jsx("button",{onClick:()=>E("+"),children:"+"}),_.jsx("button",{className:"col-span-2",onClick:Q,children:"←"}),_.jsx("button",{className:"col-span-2",onClick:o,children:"="})]})]}),_.jsx("div",{className:`results ${h.length==0?"trans":""}`,children:h.map((y,b)=>y.error?_.jsxs("div",{className:"result err",children:[_.jsx("div",{children:y.expr}),_.jsxs("div",{children:[y.error," "]})]},b):_.jsxs("div",{className:"result",children:[_.jsx("div",{children:y.expr}),_.jsxs("div",{children:["= ",y.result," "]})]},b))})]})]})}function Oo(){return _.jsx(To,{children:_.jsx(zo,{})})}bo.createRoot(document.getElementById("root")).render(_.jsx(Cd.StrictMode,{children:_.jsx(Oo,{})}));